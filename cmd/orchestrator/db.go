@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// dbCommand exports and imports database snapshots, so that a new node can start from a trusted snapshot instead of
+// verifying from genesis
+var dbCommand = &cli.Command{
+	Name:  "db",
+	Usage: "Exports, imports and inspects the orchestrator database",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "export",
+			Usage:  "Writes every database bucket with integrity checksums to a gzipped snapshot. The node must be stopped",
+			Action: exportDB,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.SnapshotOutFlag,
+			}),
+		},
+		{
+			Name:   "import",
+			Usage:  "Restores a snapshot written by db export into a new database after verifying its checksums",
+			Action: importDB,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.DBBackendFlag,
+				cmd.SnapshotInFlag,
+			}),
+		},
+		{
+			Name:   "analyze",
+			Usage:  "Reports key counts, value sizes, largest entries and growth since the last analysis of every bucket",
+			Action: analyzeDB,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.AnalyzeTopFlag,
+			}),
+		},
+		{
+			Name:   "inspect",
+			Usage:  "Opens the database read-only and prints the shard info, status, accumulator step and finalization of a slot given by --slot, --step or --pan-hash",
+			Action: inspectDB,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.InspectSlotFlag,
+				cmd.InspectStepFlag,
+				cmd.InspectPandoraHashFlag,
+			}),
+		},
+		{
+			Name:   "migrations",
+			Usage:  "Prints the schema version and the migration history of the database for support diagnostics",
+			Action: migrationsDB,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+			}),
+		},
+	},
+}
+
+// exportDB
+func exportDB(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	out := cliCtx.String(cmd.SnapshotOutFlag.Name)
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer closeDB(store)
+
+	// snapshot is written next to the target and renamed when it is complete
+	tmpFile := out + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.OrchestratorIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not create snapshot file")
+	}
+	if err := store.Export(file); err != nil {
+		file.Close()
+		os.Remove(tmpFile)
+		return errors.Wrap(err, "could not export database")
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	if err := os.Rename(tmpFile, out); err != nil {
+		return err
+	}
+	log.WithField("database-path", dbPath).WithField("snapshot", out).Info("Exported database")
+	return nil
+}
+
+// importDB
+func importDB(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	in := cliCtx.String(cmd.SnapshotInFlag.Name)
+	file, err := os.Open(in)
+	if err != nil {
+		return errors.Wrap(err, "could not open snapshot")
+	}
+	defer file.Close()
+
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{
+		Backend: kv.BackendType(cliCtx.String(cmd.DBBackendFlag.Name)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer closeDB(store)
+
+	if err := store.Import(file); err != nil {
+		return errors.Wrap(err, "could not import snapshot")
+	}
+	log.WithField("database-path", dbPath).WithField("snapshot", in).Info("Imported database")
+	return nil
+}
+
+// analyzeDB
+func analyzeDB(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer closeDB(store)
+
+	report, err := store.AnalyzeUsage(cliCtx.Int(cmd.AnalyzeTopFlag.Name))
+	if err != nil {
+		return errors.Wrap(err, "could not analyze database")
+	}
+	return printUsageReport(os.Stdout, report)
+}
+
+// printUsageReport writes the usage of the buckets as a table followed by their largest entries
+func printUsageReport(w io.Writer, report *kv.UsageReport) error {
+	fmt.Fprintf(w, "Database file size: %d bytes\n", report.FileSize)
+	if report.PreviousTime > 0 {
+		fmt.Fprintf(w, "Growth since: %s\n", time.Unix(report.PreviousTime, 0).Format(time.RFC3339))
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{"BUCKET", "KEYS", "KEY BYTES", "VALUE BYTES", "KEY GROWTH", "BYTE GROWTH"}
+	for _, bound := range kv.ValueSizeBounds {
+		header = append(header, fmt.Sprintf("<=%d", bound))
+	}
+	header = append(header, fmt.Sprintf(">%d", kv.ValueSizeBounds[len(kv.ValueSizeBounds)-1]))
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, usage := range report.Buckets {
+		keyGrowth, byteGrowth := "-", "-"
+		if usage.Growth != nil {
+			keyGrowth, byteGrowth = fmt.Sprintf("%+d", usage.Growth.Keys), fmt.Sprintf("%+d", usage.Growth.Bytes)
+		}
+		row := []string{usage.Name, fmt.Sprint(usage.Keys), fmt.Sprint(usage.KeyBytes), fmt.Sprint(usage.ValueBytes),
+			keyGrowth, byteGrowth}
+		for _, count := range usage.ValueSizes {
+			row = append(row, fmt.Sprint(count))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, usage := range report.Buckets {
+		if len(usage.Largest) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\nLargest entries of %s:\n", usage.Name)
+		for _, entry := range usage.Largest {
+			fmt.Fprintf(w, "  %s  %d bytes\n", entry.Key, entry.Size)
+		}
+	}
+	return nil
+}
+
+// migrationsDB
+func migrationsDB(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer closeDB(store)
+
+	history, err := store.MigrationHistory()
+	if err != nil {
+		return errors.Wrap(err, "could not read migration history")
+	}
+	fmt.Fprintf(os.Stdout, "Schema version: %d\n\n", store.SchemaVersion())
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "APPLIED AT\tVERSION\tNAME\tBACKUP")
+	for _, record := range history {
+		backup := record.Backup
+		if backup == "" {
+			backup = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", time.Unix(record.AppliedAt, 0).Format(time.RFC3339), record.Version,
+			record.Name, backup)
+	}
+	return tw.Flush()
+}
+
+// slotInspection is what the database knows about a slot
+type slotInspection struct {
+	Slot                uint64                   `json:"slot"`
+	Status              types.Status             `json:"status"`
+	Verified            *types.SlotInfo          `json:"verified,omitempty"`
+	Invalid             *types.SlotInfo          `json:"invalid,omitempty"`
+	Disagreement        *types.ShardDisagreement `json:"disagreement,omitempty"`
+	Step                *types.AccumulatorStep   `json:"step,omitempty"`
+	Finalized           bool                     `json:"finalized"`
+	LatestVerifiedSlot  uint64                   `json:"latestVerifiedSlot"`
+	LatestFinalizedSlot uint64                   `json:"latestFinalizedSlot"`
+}
+
+// inspectDB
+func inspectDB(cliCtx *cli.Context) error {
+	given := 0
+	for _, flag := range []string{cmd.InspectSlotFlag.Name, cmd.InspectStepFlag.Name, cmd.InspectPandoraHashFlag.Name} {
+		if cliCtx.IsSet(flag) {
+			given++
+		}
+	}
+	if given != 1 {
+		return errors.New("exactly one of --slot, --step and --pan-hash must be given")
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{ReadOnly: true})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer closeDB(store)
+
+	slot := cliCtx.Uint64(cmd.InspectSlotFlag.Name)
+	switch {
+	case cliCtx.IsSet(cmd.InspectStepFlag.Name):
+		step, err := store.AccumulatorStepByIndex(cliCtx.Uint64(cmd.InspectStepFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not read accumulator step")
+		}
+		if step == nil {
+			return errors.Errorf("no accumulator step %d", cliCtx.Uint64(cmd.InspectStepFlag.Name))
+		}
+		slot = step.Slot
+	case cliCtx.IsSet(cmd.InspectPandoraHashFlag.Name):
+		hashBytes, err := hexutil.Decode(cliCtx.String(cmd.InspectPandoraHashFlag.Name))
+		if err != nil || len(hashBytes) != common.HashLength {
+			return errors.Errorf("invalid pandora header hash %s", cliCtx.String(cmd.InspectPandoraHashFlag.Name))
+		}
+		var found bool
+		if slot, found, err = store.SlotOfPandoraHeaderHash(common.BytesToHash(hashBytes)); err != nil {
+			return errors.Wrap(err, "could not look up pandora header hash")
+		}
+		if !found {
+			return errors.Errorf("no verified or invalid slot has pandora header %s", common.BytesToHash(hashBytes))
+		}
+	}
+
+	inspection, err := inspectSlot(store, slot)
+	if err != nil {
+		return err
+	}
+	enc, err := json.MarshalIndent(inspection, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, string(enc))
+	return nil
+}
+
+// inspectSlot collects the shard infos, the accumulator step and the finalization of the slot
+func inspectSlot(store *kv.Store, slot uint64) (*slotInspection, error) {
+	inspection := &slotInspection{
+		Slot:                slot,
+		LatestVerifiedSlot:  store.LatestSavedVerifiedSlot(),
+		LatestFinalizedSlot: store.LatestLatestFinalizedSlot(),
+	}
+	inspection.Finalized = slot <= inspection.LatestFinalizedSlot
+
+	var err error
+	if inspection.Verified, err = store.VerifiedSlotInfo(slot); err != nil {
+		return nil, errors.Wrap(err, "could not read verified slot info")
+	}
+	if inspection.Invalid, err = store.InvalidSlotInfo(slot); err != nil {
+		return nil, errors.Wrap(err, "could not read invalid slot info")
+	}
+	if inspection.Disagreement, err = store.ShardDisagreement(slot); err != nil {
+		return nil, errors.Wrap(err, "could not read shard disagreement")
+	}
+	if inspection.Step, err = store.AccumulatorStep(slot); err != nil {
+		return nil, errors.Wrap(err, "could not read accumulator step")
+	}
+
+	switch {
+	case inspection.Verified != nil:
+		inspection.Status = types.Verified
+	case inspection.Invalid != nil:
+		inspection.Status = types.Invalid
+	case slot > inspection.LatestVerifiedSlot:
+		inspection.Status = types.Pending
+	default:
+		inspection.Status = types.Unknown
+	}
+	return inspection, nil
+}
+
+func closeDB(store *kv.Store) {
+	if err := store.Close(); err != nil {
+		log.WithError(err).Error("Failed to close database")
+	}
+}
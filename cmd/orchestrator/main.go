@@ -18,15 +18,86 @@ import (
 
 var appFlags = []cli.Flag{
 	cmd.VanguardGRPCEndpoint,
+	cmd.VanguardFanInEndpoints,
+	cmd.VanguardFallbackEndpointsFlag,
+	cmd.VanguardTLSCertFlag,
+	cmd.VanguardTLSCAFlag,
+	cmd.VanguardGRPCTokenFlag,
 	cmd.PandoraRPCEndpoint,
+	cmd.PandoraFallbackEndpointsFlag,
+	cmd.PandoraHeaderBufferFlag,
+	cmd.PandoraOverflowPolicyFlag,
+	cmd.PendingCacheSizeFlag,
+	cmd.EndpointProbeIntervalFlag,
+	cmd.EndpointSwitchMarginFlag,
+	cmd.ConfirmationAckFlag,
+	cmd.ConfirmationConsumersFlag,
+	cmd.ConfirmationJournalSizeFlag,
+	cmd.ConfirmationJournalMaxAgeFlag,
+	cmd.NetworkFlag,
+	cmd.GenesisPandoraHashFlag,
+	cmd.GenesisVanguardHashFlag,
+	cmd.CheckpointSlotFlag,
+	cmd.CheckpointShardRootFlag,
+	cmd.ReorderWindowFlag,
+	cmd.MaxFutureSlotsFlag,
+	cmd.VerificationBatchSizeFlag,
+	cmd.VerificationWorkersFlag,
+	cmd.VerificationLaneBufferFlag,
+	cmd.CatchUpDistanceFlag,
+	cmd.DBEncodingFlag,
+	cmd.ArchiveFlag,
+	cmd.DBRetentionEpochsFlag,
+	cmd.DBEpochInfoRetentionFlag,
+	cmd.DBPruneIntervalFlag,
+	cmd.TaskQueueIntervalFlag,
+	cmd.TaskRetryDelayFlag,
+	cmd.TaskMaxAttemptsFlag,
+	cmd.ReconcileIntervalFlag,
+	cmd.ReconcileSampleSizeFlag,
+	cmd.IdleAfterFlag,
+	cmd.DiskCheckIntervalFlag,
+	cmd.DiskFullWarningFlag,
+	cmd.DiskFreeFloorFlag,
+	cmd.DBCompressionFlag,
+	cmd.DBBackendFlag,
+	cmd.DBLockRetriesFlag,
+	cmd.DBInMemoryFlag,
+	cmd.IdentityKeyFlag,
+	cmd.RemoteSignerURLFlag,
+	cmd.RemoteSignerPublicKeyFlag,
+	cmd.CheckpointEndpointsFlag,
+	cmd.CheckpointIntervalFlag,
+	cmd.SQLSinkDSNFlag,
+	cmd.SQLSinkBatchSizeFlag,
+	cmd.SQLSinkFlushIntervalFlag,
+	cmd.HooksConfigFlag,
+	cmd.MaintenanceConfigFlag,
+	cmd.MyValidatorsFlag,
+	cmd.MetricsEnabledFlag,
+	cmd.MetricsListenAddrFlag,
+	cmd.MetricsPortFlag,
+	cmd.StatsEnabledFlag,
 	cmd.VerbosityFlag,
+	cmd.LogLevelFlag,
 	cmd.IPCPathFlag,
 	cmd.HTTPEnabledFlag,
 	cmd.HTTPListenAddrFlag,
 	cmd.HTTPPortFlag,
+	cmd.HTTPRESTFlag,
 	cmd.WSEnabledFlag,
 	cmd.WSListenAddrFlag,
 	cmd.WSPortFlag,
+	cmd.GRPCEnabledFlag,
+	cmd.GRPCListenAddrFlag,
+	cmd.GRPCPortFlag,
+	cmd.WSCompressionFlag,
+	cmd.WSCompressionLevelFlag,
+	cmd.RPCPortRetriesFlag,
+	cmd.RPCJWTSecretFlag,
+	cmd.RPCJWTScopeFlag,
+	cmd.RPCRateLimitFlag,
+	cmd.RPCRateBurstFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
@@ -46,6 +117,7 @@ func main() {
 	app.Version = version.Version()
 
 	app.Flags = appFlags
+	app.Commands = []*cli.Command{migrateDBCommand, dbCommand, resyncCommand, networksCommand}
 	app.Before = func(ctx *cli.Context) error {
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
@@ -64,7 +136,7 @@ func main() {
 			}
 			logrus.SetFormatter(f)
 		case "json":
-			logrus.SetFormatter(&logrus.JSONFormatter{})
+			logrus.SetFormatter(logutil.NewJSONFormatter())
 		case "journald":
 			if err := journald.Enable(); err != nil {
 				return err
@@ -103,7 +175,11 @@ func startNode(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+	levels, err := logutil.ParseModuleLevels(level, ctx.String(cmd.LogLevelFlag.Name))
+	if err != nil {
+		return err
+	}
+	logutil.ConfigureModuleLevels(logrus.StandardLogger(), levels)
 
 	orchestrator, err := node.New(ctx)
 	if err != nil {
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+	"path/filepath"
+)
+
+// migrateDBCommand re-encodes the values of an existing db with the given encoding and compression
+var migrateDBCommand = &cli.Command{
+	Name:   "migrate-db",
+	Usage:  "Re-encodes the orchestrator database values with the given --db-encoding and --db-compression",
+	Action: migrateDB,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		cmd.DBEncodingFlag,
+		cmd.DBCompressionFlag,
+	}),
+}
+
+// migrateDB
+func migrateDB(cliCtx *cli.Context) error {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	encoding := kv.Encoding(cliCtx.String(cmd.DBEncodingFlag.Name))
+	compression := kv.Compression(cliCtx.String(cmd.DBCompressionFlag.Name))
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{Encoding: encoding, Compression: compression})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	log.WithField("database-path", dbPath).WithField("from", store.Codec()).
+		WithField("encoding", encoding).WithField("compression", compression).Info("Migrating database")
+	return store.MigrateCodec(encoding, compression)
+}
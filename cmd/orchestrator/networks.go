@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/urfave/cli/v2"
+)
+
+// networksCommand prints the presets of the known networks which --network selects from
+var networksCommand = &cli.Command{
+	Name:   "networks",
+	Usage:  "Prints the genesis, pandora chain id and default endpoints of every network known to --network",
+	Action: printNetworks,
+}
+
+// printNetworks
+func printNetworks(cliCtx *cli.Context) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tPANDORA CHAIN ID\tPANDORA GENESIS\tVANGUARD GENESIS\tVANGUARD ENDPOINT\tPANDORA ENDPOINT")
+	for _, network := range params.Networks() {
+		chainID, pandoraGenesis, vanguardGenesis := "-", "-", "-"
+		if network.PandoraChainID != nil {
+			chainID = network.PandoraChainID.String()
+		}
+		if network.GenesisShardInfo != nil {
+			pandoraGenesis = network.GenesisShardInfo.PandoraHeaderHash.Hex()
+			vanguardGenesis = network.GenesisShardInfo.VanguardBlockHash.Hex()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", network.Name, chainID, pandoraGenesis, vanguardGenesis,
+			network.VanguardGRPCEndpoint, network.PandoraRPCEndpoint)
+	}
+	return tw.Flush()
+}
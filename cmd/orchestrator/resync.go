@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+// resyncCommand replaces deleting the db directory by hand with a guided reset to the finalized checkpoint. The db
+// is backed up before anything is removed and verification starts again at the next start of the node.
+var resyncCommand = &cli.Command{
+	Name: "resync",
+	Usage: "Wipes the non-finalized data, or the whole database with --full, so that verification starts again from " +
+		"the finalized checkpoint or a snapshot at next start. The node must be stopped",
+	Action: resync,
+	Flags: cmd.WrapFlags([]cli.Flag{
+		cmd.DataDirFlag,
+		cmd.ResyncFromFinalizedFlag,
+		cmd.ResyncFullFlag,
+		cmd.ResyncSnapshotFlag,
+		cmd.ResyncForceFlag,
+	}),
+}
+
+// resync
+func resync(cliCtx *cli.Context) error {
+	full := cliCtx.Bool(cmd.ResyncFullFlag.Name)
+	if full == cliCtx.Bool(cmd.ResyncFromFinalizedFlag.Name) {
+		return errors.New("exactly one of --from-finalized and --full must be given")
+	}
+	snapshot := cliCtx.String(cmd.ResyncSnapshotFlag.Name)
+	if snapshot != "" && !full {
+		return errors.New("--snapshot can only be restored after a --full wipe")
+	}
+	if snapshot != "" && !fileutil.FileExists(snapshot) {
+		return errors.Errorf("snapshot %s does not exist", snapshot)
+	}
+
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.OrchestratorNodeDbDirName)
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	plan, err := store.PlanResync()
+	if err != nil {
+		closeDB(store)
+		return errors.Wrap(err, "could not inspect database")
+	}
+	printResyncPlan(os.Stdout, plan, full, snapshot)
+
+	if !cliCtx.Bool(cmd.ResyncForceFlag.Name) {
+		confirmed, err := cmd.ConfirmAction("Do you want to proceed? A backup of the database is taken first (Y/N)",
+			"Database will not be resynced. No changes have been made.")
+		if err != nil || !confirmed {
+			closeDB(store)
+			return err
+		}
+	}
+
+	backup, err := store.Backup("resync")
+	if err != nil {
+		closeDB(store)
+		return err
+	}
+	log.WithField("backup", backup).Info("Backed up database before resync")
+
+	if !full {
+		defer closeDB(store)
+		if _, err := store.ResyncFromFinalized(); err != nil {
+			return err
+		}
+		log.WithField("finalizedSlot", plan.FinalizedSlot).WithField("verifiedSlots", plan.VerifiedSlots).
+			WithField("epochInfos", plan.EpochInfos).
+			Info("Removed non-finalized data, verification restarts from the finalized slot at next start")
+		return nil
+	}
+
+	if err := store.Close(); err != nil {
+		return errors.Wrap(err, "could not close database prior to clearing")
+	}
+	if err := store.ClearDB(); err != nil {
+		return errors.Wrap(err, "could not clear database")
+	}
+	if snapshot == "" {
+		log.Info("Removed database, verification restarts from genesis at next start")
+		return nil
+	}
+	return restoreSnapshot(dbPath, snapshot)
+}
+
+// restoreSnapshot imports the snapshot into a new database
+func restoreSnapshot(dbPath string, snapshot string) error {
+	file, err := os.Open(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not open snapshot")
+	}
+	defer file.Close()
+
+	store, err := kv.NewKVStore(context.Background(), dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not create new database")
+	}
+	defer closeDB(store)
+
+	if err := store.Import(file); err != nil {
+		return errors.Wrap(err, "could not import snapshot")
+	}
+	log.WithField("snapshot", snapshot).WithField("finalizedSlot", store.LatestLatestFinalizedSlot()).
+		Info("Restored database from snapshot, verification restarts from its finalized slot at next start")
+	return nil
+}
+
+// printResyncPlan writes what the resync removes
+func printResyncPlan(w io.Writer, plan *kv.ResyncPlan, full bool, snapshot string) {
+	fmt.Fprintf(w, "Finalized checkpoint: slot %d, epoch %d\n", plan.FinalizedSlot, plan.FinalizedEpoch)
+	fmt.Fprintf(w, "Latest verified slot: %d, latest epoch: %d\n\n", plan.LatestVerifiedSlot, plan.LatestEpoch)
+	if full {
+		fmt.Fprintln(w, "The whole database will be removed.")
+		if snapshot != "" {
+			fmt.Fprintf(w, "It will be restored from snapshot %s.\n", snapshot)
+		}
+		return
+	}
+	fmt.Fprintln(w, "Data after the finalized checkpoint which will be removed:")
+	fmt.Fprintf(w, "  verified slots:    %d\n", plan.VerifiedSlots)
+	fmt.Fprintf(w, "  invalid slots:     %d\n", plan.InvalidSlots)
+	fmt.Fprintf(w, "  epoch infos:       %d\n", plan.EpochInfos)
+	fmt.Fprintf(w, "  in-progress slots: %d\n", plan.InProgressSlots)
+}
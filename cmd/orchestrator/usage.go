@@ -43,9 +43,28 @@ var appHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
+			cmd.LogLevelFlag,
 			cmd.ForceClearDB,
 			cmd.ClearDB,
 			cmd.BoltMMapInitialSizeFlag,
+			cmd.DBEncodingFlag,
+			cmd.DBCompressionFlag,
+			cmd.DBBackendFlag,
+			cmd.DBLockRetriesFlag,
+			cmd.DBInMemoryFlag,
+			cmd.ArchiveFlag,
+			cmd.DBRetentionEpochsFlag,
+			cmd.DBEpochInfoRetentionFlag,
+			cmd.DBPruneIntervalFlag,
+			cmd.TaskQueueIntervalFlag,
+			cmd.TaskRetryDelayFlag,
+			cmd.TaskMaxAttemptsFlag,
+			cmd.ReconcileIntervalFlag,
+			cmd.ReconcileSampleSizeFlag,
+			cmd.IdleAfterFlag,
+			cmd.DiskCheckIntervalFlag,
+			cmd.DiskFullWarningFlag,
+			cmd.DiskFreeFloorFlag,
 		},
 	},
 	{
@@ -55,11 +74,63 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.HTTPEnabledFlag,
 			cmd.HTTPListenAddrFlag,
 			cmd.HTTPPortFlag,
+			cmd.HTTPRESTFlag,
 			cmd.WSEnabledFlag,
 			cmd.WSListenAddrFlag,
 			cmd.WSPortFlag,
+			cmd.GRPCEnabledFlag,
+			cmd.GRPCListenAddrFlag,
+			cmd.GRPCPortFlag,
+			cmd.WSCompressionFlag,
+			cmd.WSCompressionLevelFlag,
+			cmd.RPCPortRetriesFlag,
+			cmd.RPCJWTSecretFlag,
+			cmd.RPCJWTScopeFlag,
+			cmd.RPCRateLimitFlag,
+			cmd.RPCRateBurstFlag,
 			cmd.VanguardGRPCEndpoint,
+			cmd.VanguardFanInEndpoints,
+			cmd.VanguardFallbackEndpointsFlag,
+			cmd.VanguardTLSCertFlag,
+			cmd.VanguardTLSCAFlag,
+			cmd.VanguardGRPCTokenFlag,
 			cmd.PandoraRPCEndpoint,
+			cmd.PandoraFallbackEndpointsFlag,
+			cmd.PandoraHeaderBufferFlag,
+			cmd.PandoraOverflowPolicyFlag,
+			cmd.PendingCacheSizeFlag,
+			cmd.EndpointProbeIntervalFlag,
+			cmd.EndpointSwitchMarginFlag,
+			cmd.ConfirmationAckFlag,
+			cmd.ConfirmationConsumersFlag,
+			cmd.ConfirmationJournalSizeFlag,
+			cmd.ConfirmationJournalMaxAgeFlag,
+			cmd.NetworkFlag,
+			cmd.GenesisPandoraHashFlag,
+			cmd.GenesisVanguardHashFlag,
+			cmd.CheckpointSlotFlag,
+			cmd.CheckpointShardRootFlag,
+			cmd.ReorderWindowFlag,
+			cmd.MaxFutureSlotsFlag,
+			cmd.VerificationBatchSizeFlag,
+			cmd.VerificationWorkersFlag,
+			cmd.VerificationLaneBufferFlag,
+			cmd.CatchUpDistanceFlag,
+			cmd.IdentityKeyFlag,
+			cmd.RemoteSignerURLFlag,
+			cmd.RemoteSignerPublicKeyFlag,
+			cmd.CheckpointEndpointsFlag,
+			cmd.CheckpointIntervalFlag,
+			cmd.SQLSinkDSNFlag,
+			cmd.SQLSinkBatchSizeFlag,
+			cmd.SQLSinkFlushIntervalFlag,
+			cmd.HooksConfigFlag,
+			cmd.MaintenanceConfigFlag,
+			cmd.MyValidatorsFlag,
+			cmd.MetricsEnabledFlag,
+			cmd.MetricsListenAddrFlag,
+			cmd.MetricsPortFlag,
+			cmd.StatsEnabledFlag,
 		},
 	},
 	{
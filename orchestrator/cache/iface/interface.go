@@ -11,6 +11,7 @@ type PandoraHeaderCache interface {
 	Get(ctx context.Context, slot uint64) (*eth1Types.Header, error)
 	GetAll() ([]*eth1Types.Header, error)
 	Remove(ctx context.Context, slot uint64)
+	RemoveAbove(ctx context.Context, slot uint64)
 	Purge()
 }
 
@@ -19,5 +20,6 @@ type VanguardShardInfoCache interface {
 	Put(ctx context.Context, slot uint64, shardInfo *types.VanguardShardInfo) error
 	Get(ctx context.Context, slot uint64) (*types.VanguardShardInfo, error)
 	Remove(ctx context.Context, slot uint64)
+	RemoveAbove(ctx context.Context, slot uint64)
 	Purge()
 }
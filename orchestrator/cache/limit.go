@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"github.com/ethereum/go-ethereum/metrics"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// InProgressFn tells whether the slot is being verified. Such slots are never evicted from a full pending cache.
+type InProgressFn func(slot uint64) bool
+
+// slotLimit bounds the number of slots in a pending cache, zero max entries does not bound it. The underlying lru
+// cache is unbounded, so eviction can skip the slots which are in progress.
+type slotLimit struct {
+	maxEntries int
+	inProgress InProgressFn
+}
+
+// evict removes the least recently used slots which are not in progress until the cache is within the limit. The
+// cache stays above the limit when every remaining slot is in progress.
+func (l *slotLimit) evict(cache *lru.Cache, name string, evictions metrics.Counter) {
+	excess := cache.Len() - l.maxEntries
+	if l.maxEntries <= 0 || excess <= 0 {
+		return
+	}
+	evicted := make([]uint64, 0, excess)
+	// keys are ordered from the least recently used
+	for _, key := range cache.Keys() {
+		if len(evicted) == excess {
+			break
+		}
+		slot := key.(uint64)
+		if l.inProgress != nil && l.inProgress(slot) {
+			continue
+		}
+		cache.Remove(key)
+		evicted = append(evicted, slot)
+	}
+	if len(evicted) == 0 {
+		return
+	}
+	evictions.Inc(int64(len(evicted)))
+	log.WithField("cache", name).WithField("slots", evicted).WithField("maxEntries", l.maxEntries).
+		Warn("Evicted least recently used slots from full pending cache")
+}
@@ -0,0 +1,5 @@
+package cache
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "cache")
@@ -0,0 +1,15 @@
+package cache
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// pandoraCacheSizeGauge is the number of pending pandora headers
+	pandoraCacheSizeGauge = metrics.NewRegisteredGauge("orc_pending_cache_size_pandora", nil)
+	// vanguardCacheSizeGauge is the number of pending vanguard sharding infos
+	vanguardCacheSizeGauge = metrics.NewRegisteredGauge("orc_pending_cache_size_vanguard", nil)
+	// pandoraCacheEvictionsCounter is the number of pending pandora headers which are evicted from the full cache
+	pandoraCacheEvictionsCounter = metrics.NewRegisteredCounter("orc_pending_cache_evictions_pandora_total", nil)
+	// vanguardCacheEvictionsCounter is the number of pending vanguard sharding infos which are evicted from the full
+	// cache
+	vanguardCacheEvictionsCounter = metrics.NewRegisteredCounter("orc_pending_cache_evictions_vanguard_total", nil)
+)
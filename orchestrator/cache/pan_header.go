@@ -13,23 +13,39 @@ import (
 type PanHeaderCache struct {
 	cache *lru.Cache
 	lock  sync.RWMutex
+	limit slotLimit
 }
 
 // NewPanHeaderCache initializes the map and underlying cache.
 func NewPanHeaderCache() *PanHeaderCache {
-	cache, err := lru.New(maxCacheSize)
+	cache, err := lru.New(maxInt)
 	if err != nil {
 		panic(err)
 	}
 	return &PanHeaderCache{
 		cache: cache,
+		limit: slotLimit{maxEntries: maxCacheSize},
 	}
 }
 
+// SetLimit bounds the number of cached headers. Least recently used headers are evicted beyond it, except the
+// headers of the slots which are in progress.
+func (c *PanHeaderCache) SetLimit(maxEntries int, inProgress InProgressFn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.limit = slotLimit{maxEntries: maxEntries, inProgress: inProgress}
+	c.limit.evict(c.cache, "pandora", pandoraCacheEvictionsCounter)
+	pandoraCacheSizeGauge.Update(int64(c.cache.Len()))
+}
+
 // Put
 func (c *PanHeaderCache) Put(ctx context.Context, slot uint64, header *eth1Types.Header) error {
 	copyHeader := types.CopyHeader(header)
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.cache.Add(slot, copyHeader)
+	c.limit.evict(c.cache, "pandora", pandoraCacheEvictionsCounter)
+	pandoraCacheSizeGauge.Update(int64(c.cache.Len()))
 	return nil
 }
 
@@ -51,6 +67,20 @@ func (c *PanHeaderCache) Remove(ctx context.Context, slot uint64) {
 			c.cache.Remove(i)
 		}
 	}
+	pandoraCacheSizeGauge.Update(int64(c.cache.Len()))
+}
+
+// RemoveAbove removes the headers of every slot above the given slot. It is used when verified slots above the slot
+// are reverted, so that stale headers are not matched again.
+func (c *PanHeaderCache) RemoveAbove(ctx context.Context, slot uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, key := range c.cache.Keys() {
+		if key.(uint64) > slot {
+			c.cache.Remove(key)
+		}
+	}
+	pandoraCacheSizeGauge.Update(int64(c.cache.Len()))
 }
 
 func (c *PanHeaderCache) GetAll() ([]*eth1Types.Header, error) {
@@ -73,5 +103,6 @@ func (c *PanHeaderCache) GetAll() ([]*eth1Types.Header, error) {
 func (c *PanHeaderCache) Purge() {
 	c.lock.Lock()
 	c.cache.Purge()
+	pandoraCacheSizeGauge.Update(0)
 	c.lock.Unlock()
 }
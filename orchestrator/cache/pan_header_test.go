@@ -116,3 +116,56 @@ func Test_PandoraHeaderPurge(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 0, len(actualPanHeaders))
 }
+
+func Test_PandoraHeaderRemoveAbove(t *testing.T) {
+	maxCacheSize = 1 << 10
+	pc := NewPanHeaderCache()
+	ctx := context.Background()
+	setup(100)
+
+	for slot := 1; slot <= 100; slot++ {
+		slotUint64 := uint64(slot)
+		pc.Put(ctx, slotUint64, expectedPanHeaders[slotUint64])
+	}
+	pc.RemoveAbove(ctx, 60)
+
+	for i := uint64(1); i <= 60; i++ {
+		actualHeader, err := pc.Get(ctx, i)
+		require.NoError(t, err, "Should be found slot")
+		assert.DeepEqual(t, expectedPanHeaders[i], actualHeader)
+	}
+	for i := uint64(61); i <= 100; i++ {
+		_, err := pc.Get(ctx, i)
+		require.ErrorContains(t, "Invalid slot", err, "Should not be found because it is removed")
+	}
+	require.Equal(t, 60, pc.cache.Len())
+}
+
+func Test_PandoraHeaderCache_SetLimit(t *testing.T) {
+	pc := NewPanHeaderCache()
+	ctx := context.Background()
+	setup(20)
+
+	for slot := uint64(1); slot <= 20; slot++ {
+		require.NoError(t, pc.Put(ctx, slot, expectedPanHeaders[slot]))
+	}
+	// slots 1 and 2 wait for verification, so the least recently used slots after them are evicted
+	pc.SetLimit(10, func(slot uint64) bool { return slot <= 2 })
+	assert.Equal(t, 10, pc.cache.Len())
+	for _, slot := range []uint64{1, 2, 13, 20} {
+		_, err := pc.Get(ctx, slot)
+		require.NoError(t, err, "slot %d should stay in the cache", slot)
+	}
+	_, err := pc.Get(ctx, 3)
+	require.ErrorContains(t, "Invalid slot", err)
+
+	// the cache stays above the limit when every slot is in progress
+	pc.SetLimit(1, func(slot uint64) bool { return true })
+	require.NoError(t, pc.Put(ctx, 21, testutil.NewEth1Header(21)))
+	assert.Equal(t, 11, pc.cache.Len())
+
+	// zero max entries does not bound the cache
+	pc.SetLimit(0, nil)
+	require.NoError(t, pc.Put(ctx, 22, testutil.NewEth1Header(22)))
+	assert.Equal(t, 12, pc.cache.Len())
+}
@@ -11,22 +11,38 @@ import (
 type VanShardingInfoCache struct {
 	cache *lru.Cache
 	lock  sync.RWMutex
+	limit slotLimit
 }
 
 // NewVanShardInfoCache initializes the map and underlying cache.
 func NewVanShardInfoCache(cacheSize int) *VanShardingInfoCache {
-	cache, err := lru.New(cacheSize)
+	cache, err := lru.New(maxInt)
 	if err != nil {
 		panic(err)
 	}
 	return &VanShardingInfoCache{
 		cache: cache,
+		limit: slotLimit{maxEntries: cacheSize},
 	}
 }
 
+// SetLimit bounds the number of cached sharding infos. Least recently used sharding infos are evicted beyond it,
+// except the sharding infos of the slots which are in progress.
+func (vc *VanShardingInfoCache) SetLimit(maxEntries int, inProgress InProgressFn) {
+	vc.lock.Lock()
+	defer vc.lock.Unlock()
+	vc.limit = slotLimit{maxEntries: maxEntries, inProgress: inProgress}
+	vc.limit.evict(vc.cache, "vanguard", vanguardCacheEvictionsCounter)
+	vanguardCacheSizeGauge.Update(int64(vc.cache.Len()))
+}
+
 // Put puts sharding info into a lru cache. return error if fails.
 func (vc *VanShardingInfoCache) Put(ctx context.Context, slot uint64, shardInfo *types.VanguardShardInfo) error {
+	vc.lock.Lock()
+	defer vc.lock.Unlock()
 	vc.cache.Add(slot, shardInfo)
+	vc.limit.evict(vc.cache, "vanguard", vanguardCacheEvictionsCounter)
+	vanguardCacheSizeGauge.Update(int64(vc.cache.Len()))
 	return nil
 }
 
@@ -47,11 +63,26 @@ func (vc *VanShardingInfoCache) Remove(ctx context.Context, slot uint64) {
 			vc.cache.Remove(i)
 		}
 	}
+	vanguardCacheSizeGauge.Update(int64(vc.cache.Len()))
+}
+
+// RemoveAbove removes the sharding infos of every slot above the given slot. It is used when verified slots above
+// the slot are reverted, so that stale sharding infos are not matched again.
+func (vc *VanShardingInfoCache) RemoveAbove(ctx context.Context, slot uint64) {
+	vc.lock.Lock()
+	defer vc.lock.Unlock()
+	for _, key := range vc.cache.Keys() {
+		if key.(uint64) > slot {
+			vc.cache.Remove(key)
+		}
+	}
+	vanguardCacheSizeGauge.Update(int64(vc.cache.Len()))
 }
 
 // Clear the vanguard sharding cache.
 func (c *VanShardingInfoCache) Purge() {
 	c.lock.Lock()
 	c.cache.Purge()
+	vanguardCacheSizeGauge.Update(0)
 	c.lock.Unlock()
 }
@@ -154,3 +154,26 @@ func TestVanguardRemoveShardInfo(t *testing.T) {
 		assert.DeepEqual(t, generatedShardInfos[uint64(i)], actualHeader)
 	}
 }
+
+func TestVanguardRemoveShardInfoAbove(t *testing.T) {
+	vanguardCache := NewVanShardInfoCache(100)
+	ctx := context.Background()
+	generatedShardInfos, err := setupShardingCache(100)
+	require.NoError(t, err)
+
+	for slot := 1; slot <= 100; slot++ {
+		slotUint64 := uint64(slot)
+		vanguardCache.Put(ctx, slotUint64, generatedShardInfos[slotUint64])
+	}
+	vanguardCache.RemoveAbove(ctx, 60)
+
+	for i := uint64(1); i <= 60; i++ {
+		shardInfo, err := vanguardCache.Get(ctx, i)
+		require.NoError(t, err, "Should be found slot")
+		assert.DeepEqual(t, generatedShardInfos[i], shardInfo)
+	}
+	for i := uint64(61); i <= 100; i++ {
+		_, err := vanguardCache.Get(ctx, i)
+		require.ErrorContains(t, "Invalid slot", err, "Should not be found because it is removed")
+	}
+}
@@ -0,0 +1,115 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// publishTimeout bounds one upload to an endpoint
+const publishTimeout = 30 * time.Second
+
+// publisher uploads a named object to an external storage
+type publisher interface {
+	publish(ctx context.Context, name string, data []byte) error
+	String() string
+}
+
+// newPublisher returns the publisher of the endpoint. ipfs:// endpoints point to the http api of an ipfs node,
+// every other endpoint is an S3, GCS or plain http bucket url which accepts PUT of objects under it.
+func newPublisher(endpoint string) (publisher, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint endpoint %s", endpoint)
+	}
+	client := &http.Client{Timeout: publishTimeout}
+	switch u.Scheme {
+	case "ipfs":
+		u.Scheme = "http"
+		return &ipfsPublisher{apiURL: strings.TrimSuffix(u.String(), "/"), client: client}, nil
+	case "http", "https":
+		return &bucketPublisher{bucketURL: u, client: client}, nil
+	default:
+		return nil, errors.Errorf("unsupported checkpoint endpoint scheme %q", u.Scheme)
+	}
+}
+
+// bucketPublisher puts objects under the bucket url. Query of the url, e.g. a signed access token, is kept
+// for every object.
+type bucketPublisher struct {
+	bucketURL *url.URL
+	client    *http.Client
+}
+
+// publish
+func (p *bucketPublisher) publish(ctx context.Context, name string, data []byte) error {
+	objectURL := *p.bucketURL
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + name
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return do(p.client, req)
+}
+
+// String hides the query of bucket url which may carry credentials
+func (p *bucketPublisher) String() string {
+	return p.bucketURL.Scheme + "://" + p.bucketURL.Host + p.bucketURL.Path
+}
+
+// ipfsPublisher adds objects to an ipfs node and publishes them under the name in its mutable file system
+type ipfsPublisher struct {
+	apiURL string
+	client *http.Client
+}
+
+// publish writes the object into /<name> of the node's mutable file system, so it keeps a well-known path
+func (p *ipfsPublisher) publish(ctx context.Context, name string, data []byte) error {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(data); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v0/files/write?arg=/%s&create=true&truncate=true&parents=true", p.apiURL, url.QueryEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return do(p.client, req)
+}
+
+// String
+func (p *ipfsPublisher) String() string {
+	return p.apiURL
+}
+
+// do sends the request and fails on non 2xx response
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("endpoint responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/idle"
+	"github.com/lukso-network/lukso-orchestrator/shared/identity"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// LatestCheckpointName is the object which always holds the latest published checkpoint
+const LatestCheckpointName = "latest.json"
+
+type Config struct {
+	// Endpoints are the storage urls which checkpoints are uploaded to
+	Endpoints          []string
+	Interval           time.Duration
+	Identity           identity.Signer
+	VerifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
+	// Idle skips publication while the chains are idle. It is optional.
+	Idle idle.Detector
+}
+
+// Service periodically signs the latest finalized verified slot and uploads it to the configured endpoints
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	publishers         []publisher
+	interval           time.Duration
+	identity           identity.Signer
+	verifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
+	idle               idle.Detector
+
+	lock sync.Mutex
+	// lastSlot is the latest finalized slot which is published to every endpoint
+	lastSlot   uint64
+	publishErr error
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Identity == nil {
+		return nil, errors.New("checkpoint publication needs orchestrator identity")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.New("checkpoint publication interval must be positive")
+	}
+	publishers := make([]publisher, 0, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		p, err := newPublisher(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, p)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:                ctx,
+		cancel:             cancel,
+		publishers:         publishers,
+		interval:           cfg.Interval,
+		identity:           cfg.Identity,
+		verifiedSlotInfoDB: cfg.VerifiedSlotInfoDB,
+		idle:               cfg.Idle,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start checkpoint service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("endpoints", len(s.publishers)).WithField("interval", s.interval).Info("Started checkpoint publication")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status returns the error of the latest failed publication
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.publishErr
+}
+
+// run
+func (s *Service) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// failed publications are retried once the chains produce blocks again
+			if s.idle != nil && s.idle.Idle() {
+				continue
+			}
+			s.publishLatest()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing checkpoint service")
+			return
+		}
+	}
+}
+
+// publishLatest publishes the latest finalized slot when it is not published yet
+func (s *Service) publishLatest() {
+	slot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	if slot == 0 || slot == s.lastSlot {
+		return
+	}
+
+	checkpoint, err := s.signedCheckpoint(slot)
+	if err != nil {
+		s.setPublishErr(err)
+		log.WithError(err).WithField("slot", slot).Error("Failed to create signed checkpoint")
+		return
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		s.setPublishErr(err)
+		return
+	}
+
+	var failed error
+	for _, p := range s.publishers {
+		if err := s.publish(p, slot, data); err != nil {
+			failed = errors.Wrapf(err, "could not publish checkpoint to %s", p)
+			log.WithError(err).WithField("endpoint", p).WithField("slot", slot).Error("Failed to publish checkpoint")
+		}
+	}
+	s.setPublishErr(failed)
+	if failed != nil {
+		// retried on next tick
+		return
+	}
+	s.lastSlot = slot
+	log.WithField("slot", slot).WithField("epoch", checkpoint.Epoch).WithField("root", checkpoint.ShardInfoRoot).
+		Info("Published signed checkpoint")
+}
+
+// publish uploads the checkpoint under its slot and as the latest one
+func (s *Service) publish(p publisher, slot uint64, data []byte) error {
+	if err := p.publish(s.ctx, fmt.Sprintf("checkpoint-%d.json", slot), data); err != nil {
+		return err
+	}
+	return p.publish(s.ctx, LatestCheckpointName, data)
+}
+
+// signedCheckpoint
+func (s *Service) signedCheckpoint(slot uint64) (*types.SignedCheckpoint, error) {
+	slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, errors.Errorf("verified slot info of finalized slot %d is not found", slot)
+	}
+	checkpoint := &types.Checkpoint{
+		Slot:              slot,
+		Epoch:             s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch(),
+		VanguardBlockHash: slotInfo.VanguardBlockHash,
+		PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+		ShardInfoRoot:     slotInfo.Root(),
+	}
+	signature, err := s.identity.Sign(s.ctx, checkpoint.SigningData())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not sign checkpoint")
+	}
+	return &types.SignedCheckpoint{
+		Checkpoint: checkpoint,
+		Signer:     s.identity.Address(),
+		Signature:  signature,
+	}, nil
+}
+
+// setPublishErr
+func (s *Service) setPublishErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.publishErr = err
+}
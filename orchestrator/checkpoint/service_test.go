@@ -0,0 +1,81 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/identity"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_PublishLatest(t *testing.T) {
+	db := testDB.SetupDB(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := identity.NewFileSigner(key)
+
+	var lock sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "token=secret", r.URL.RawQuery)
+		data, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		lock.Lock()
+		objects[r.URL.Path] = data
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	s, err := NewService(context.Background(), &Config{
+		Endpoints:          []string{server.URL + "/checkpoints/?token=secret"},
+		Interval:           time.Second,
+		Identity:           signer,
+		VerifiedSlotInfoDB: db,
+	})
+	require.NoError(t, err)
+
+	// nothing is finalized yet
+	s.publishLatest()
+	assert.Equal(t, 0, len(objects))
+
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(64, slotInfo))
+	require.NoError(t, db.SaveLatestFinalizedSlot(64))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(2))
+
+	s.publishLatest()
+	require.NoError(t, s.Status())
+	assert.DeepEqual(t, objects["/checkpoints/checkpoint-64.json"], objects["/checkpoints/"+LatestCheckpointName])
+
+	var checkpoint *types.SignedCheckpoint
+	require.NoError(t, json.Unmarshal(objects["/checkpoints/"+LatestCheckpointName], &checkpoint))
+	assert.Equal(t, uint64(64), checkpoint.Slot)
+	assert.Equal(t, uint64(2), checkpoint.Epoch)
+	assert.Equal(t, slotInfo.Root(), checkpoint.ShardInfoRoot)
+	assert.NoError(t, identity.Verify(signer.Address(), checkpoint.SigningData(), checkpoint.Signature))
+}
+
+func TestNewPublisher(t *testing.T) {
+	p, err := newPublisher("https://bucket.s3.amazonaws.com/orchestrator?X-Amz-Signature=secret")
+	require.NoError(t, err)
+	assert.Equal(t, "https://bucket.s3.amazonaws.com/orchestrator", p.String())
+
+	p, err = newPublisher("ipfs://127.0.0.1:5001")
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:5001", p.String())
+
+	_, err = newPublisher("ftp://127.0.0.1")
+	assert.ErrorContains(t, "unsupported checkpoint endpoint scheme", err)
+}
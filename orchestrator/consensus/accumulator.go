@@ -0,0 +1,77 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/accumulator"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// loadAccumulator rebuilds the in-memory verified-chain accumulator from the leaves stored in db
+func (s *Service) loadAccumulator() error {
+	if s.accumulatorDB == nil {
+		return nil
+	}
+	step, err := s.accumulatorDB.LatestAccumulatorStep()
+	if err != nil {
+		return err
+	}
+	var size uint64
+	if step != nil {
+		size = step.LeafIndex + 1
+	}
+	leaves, err := s.accumulatorDB.AccumulatorLeaves(size)
+	if err != nil {
+		return err
+	}
+	acc, err := accumulator.New(leaves)
+	if err != nil {
+		return err
+	}
+	s.accumulator = acc
+	log.WithField("size", acc.Size()).WithField("root", acc.Root()).Debug("Loaded verified-chain accumulator")
+	return nil
+}
+
+// accumulate appends the verified slot info into the verified-chain accumulator and stores the new root of the slot.
+// It returns nil step when accumulator is disabled or the slot could not be accumulated.
+func (s *Service) accumulate(slot uint64, slotInfo *types.SlotInfo) (*types.AccumulatorStep, error) {
+	if s.accumulatorDB == nil || s.accumulator == nil {
+		return nil, nil
+	}
+	if step, _ := s.accumulatorDB.LatestAccumulatorStep(); step != nil && step.Slot >= slot {
+		log.WithField("slot", slot).WithField("latestAccumulatedSlot", step.Slot).
+			Warn("Slot is already accumulated, skipping")
+		return s.accumulatorDB.AccumulatorStep(slot)
+	}
+
+	leaf := slotInfo.Root()
+	if err := s.accumulator.Append(leaf); err != nil {
+		return nil, err
+	}
+	step := &types.AccumulatorStep{
+		Slot:      slot,
+		LeafIndex: s.accumulator.Size() - 1,
+		Leaf:      leaf,
+		Root:      s.accumulator.Root(),
+	}
+	if err := s.accumulatorDB.SaveAccumulatorStep(step); err != nil {
+		// keep in-memory accumulator consistent with db
+		if loadErr := s.loadAccumulator(); loadErr != nil {
+			log.WithError(loadErr).Error("Failed to reload verified-chain accumulator")
+		}
+		return nil, err
+	}
+	return step, nil
+}
+
+// stepId returns the position of the given slot in the verified chain or nil if the slot is not accumulated
+func (s *Service) stepId(slot uint64) *uint64 {
+	if s.accumulatorDB == nil {
+		return nil
+	}
+	step, err := s.accumulatorDB.AccumulatorStep(slot)
+	if err != nil || step == nil {
+		return nil
+	}
+	stepId := step.LeafIndex
+	return &stepId
+}
@@ -0,0 +1,188 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// batchFlushDelay is the longest time a partial batch waits for more slots before it is flushed
+const batchFlushDelay = params.SecondsPerSlot * time.Second
+
+// verifyBatch collects matched backlog slots which extend each other, so that they are written to db in a single
+// transaction instead of a handful of transactions per slot
+type verifyBatch struct {
+	size  uint64
+	slots []*bufferedSlot
+	// delay bounds how long the first slot of a partial batch is held back
+	delay time.Duration
+	// timer fires when the first slot waited for delay, nil while the batch is empty
+	timer *time.Timer
+}
+
+func newVerifyBatch(size uint64) *verifyBatch {
+	return &verifyBatch{
+		size:  size,
+		slots: make([]*bufferedSlot, 0, size),
+		delay: batchFlushDelay,
+	}
+}
+
+// tail returns the latest slot of the batch or nil for empty batch
+func (b *verifyBatch) tail() *bufferedSlot {
+	if len(b.slots) == 0 {
+		return nil
+	}
+	return b.slots[len(b.slots)-1]
+}
+
+// put appends the slot and returns true when the batch is full
+func (b *verifyBatch) put(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) bool {
+	if len(b.slots) == 0 {
+		b.timer = time.NewTimer(b.delay)
+	}
+	b.slots = append(b.slots, &bufferedSlot{slot: slot, vanShardInfo: vanShardInfo, header: header})
+	return uint64(len(b.slots)) >= b.size
+}
+
+// take empties the batch and returns its slots
+func (b *verifyBatch) take() []*bufferedSlot {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	slots := b.slots
+	b.slots = make([]*bufferedSlot, 0, b.size)
+	return slots
+}
+
+// expired returns the channel which receives when a partial batch waited too long for more slots. It returns nil
+// channel for empty batch.
+func (b *verifyBatch) expired() <-chan time.Time {
+	if b.timer == nil {
+		return nil
+	}
+	return b.timer.C
+}
+
+// purge drops the collected slots
+func (b *verifyBatch) purge() {
+	b.take()
+}
+
+// isBacklog returns true when at least a full batch of slots has been produced after the header, so waiting for
+// the batch to be filled does not hold the slot back behind the wall clock
+func (s *Service) isBacklog(header *eth1Types.Header) bool {
+	return time.Since(headerTime(header)) > time.Duration(s.batch.size*params.SecondsPerSlot)*time.Second
+}
+
+// extendsBatch returns true when the slot builds on the tail of the batch or on the verified head when the batch
+// is empty. Skipped slots in between are allowed since the parent hash orders the slots.
+func (s *Service) extendsBatch(slot uint64, header *eth1Types.Header) bool {
+	if tail := s.batch.tail(); tail != nil {
+		return slot > tail.slot && header.ParentHash == tail.header.Hash()
+	}
+	return s.head.known && slot > s.head.slot && header.ParentHash == s.head.hash
+}
+
+// batchExpired returns the channel which receives when the collected slots must be flushed without waiting for a
+// full batch, so that a stalled backlog does not hold verified slots back
+func (s *Service) batchExpired() <-chan time.Time {
+	if s.batch == nil {
+		return nil
+	}
+	return s.batch.expired()
+}
+
+// batchOrFlush collects the backlog slot into the batch and returns true when it is taken. Otherwise the collected
+// slots are flushed first, so that the slot is verified one at a time on top of them.
+func (s *Service) batchOrFlush(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) (bool, error) {
+	if !s.isBacklog(header) || !s.extendsBatch(slot, header) ||
+		s.mismatchedField(slot, header.Hash(), vanShardInfo, header) != "" {
+		return false, s.flushBatch()
+	}
+	if s.batch.put(slot, vanShardInfo, header) {
+		return true, s.flushBatch()
+	}
+	return true, nil
+}
+
+// flushBatch verifies the collected slots with a single db transaction
+func (s *Service) flushBatch() error {
+	if s.batch == nil || s.batch.tail() == nil {
+		return nil
+	}
+	slots := s.batch.take()
+
+	batch := &types.VerifiedSlotBatch{Slots: make([]*types.BatchedSlot, len(slots))}
+	statuses := make([]*types.SlotInfoWithStatus, len(slots))
+	for i, bs := range slots {
+		slotInfo := &types.SlotInfo{
+			PandoraHeaderHash: bs.header.Hash(),
+			VanguardBlockHash: common.BytesToHash(bs.vanShardInfo.BlockHash[:]),
+		}
+		batched := &types.BatchedSlot{Slot: bs.slot, BlockNumber: bs.header.Number.Uint64(), SlotInfo: slotInfo}
+		if s.accumulatorDB != nil && s.accumulator != nil {
+			leaf := slotInfo.Root()
+			if err := s.accumulator.Append(leaf); err != nil {
+				return s.reloadAccumulator(err)
+			}
+			batched.Step = &types.AccumulatorStep{
+				Slot:      bs.slot,
+				LeafIndex: s.accumulator.Size() - 1,
+				Leaf:      leaf,
+				Root:      s.accumulator.Root(),
+			}
+		}
+		if bs.vanShardInfo.FinalizedEpoch > batch.FinalizedEpoch {
+			batch.FinalizedEpoch = bs.vanShardInfo.FinalizedEpoch
+			batch.FinalizedSlot = bs.vanShardInfo.FinalizedSlot
+		}
+		batch.Slots[i] = batched
+		statuses[i] = &types.SlotInfoWithStatus{
+			Slot:              bs.slot,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			Status:            types.Verified,
+		}
+		if batched.Step != nil {
+			stepId := batched.Step.LeafIndex
+			statuses[i].StepId = &stepId
+		}
+	}
+
+	if err := s.batchWriteDB.SaveVerifiedSlotBatch(batch); err != nil {
+		log.WithError(err).WithField("fromSlot", slots[0].slot).WithField("toSlot", slots[len(slots)-1].slot).
+			Error("Failed to store verified slot batch")
+		return s.reloadAccumulator(err)
+	}
+
+	tail := slots[len(slots)-1]
+	s.advanceHead(tail.slot, tail.header.Hash())
+	s.updateWriteMode(tail.slot, tail.header)
+	for i, bs := range slots {
+		observeSlot(types.Verified, bs.header)
+		s.tallySlot(bs.slot, types.Verified, bs.header)
+		s.countLifetimeSlot(types.Verified)
+		s.pandoraPendingHeaderCache.Remove(s.ctx, bs.slot)
+		s.vanguardPendingShardingCache.Remove(s.ctx, bs.slot)
+		s.verifiedSlotInfoFeed.Send(statuses[i])
+	}
+	s.publishFinalizations()
+	log.WithField("fromSlot", slots[0].slot).WithField("toSlot", tail.slot).WithField("slots", len(slots)).
+		Info("Successfully verified batch of sharding infos")
+	return nil
+}
+
+// reloadAccumulator keeps in-memory accumulator consistent with db after a failed batch and returns the failure
+func (s *Service) reloadAccumulator(err error) error {
+	if s.accumulatorDB != nil {
+		if loadErr := s.loadAccumulator(); loadErr != nil {
+			log.WithError(loadErr).Error("Failed to reload verified-chain accumulator")
+		}
+	}
+	return err
+}
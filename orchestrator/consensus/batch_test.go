@@ -0,0 +1,90 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_BatchVerification(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	svc.batchWriteDB = svc.verifiedSlotInfoDB.(db.VerifiedSlotBatchDB)
+	svc.batch = newVerifyBatch(4)
+
+	statusCh := make(chan *types.SlotInfoWithStatus, 16)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(statusCh)
+	defer sub.Unsubscribe()
+
+	backlogTime := uint64(time.Now().Add(-time.Hour).Unix())
+	headers := make([]*eth1Types.Header, 8)
+	for slot := uint64(1); slot < 8; slot++ {
+		headers[slot] = testutil.NewEth1Header(slot)
+		headers[slot].Time = backlogTime + slot*6
+		if slot > 1 {
+			headers[slot].ParentHash = headers[slot-1].Hash()
+		}
+	}
+	// slot at the wall clock is not batched
+	headers[7].Time = uint64(time.Now().Unix())
+	verify := func(slot uint64) {
+		require.NoError(t, svc.verifyOrBuffer(slot, testutil.NewVanguardShardInfo(slot, headers[slot]), headers[slot]))
+	}
+
+	// batch starts on top of the verified head
+	verify(1)
+	assert.Equal(t, 0, len(svc.batch.slots))
+	for slot := uint64(2); slot < 5; slot++ {
+		verify(slot)
+	}
+	assert.Equal(t, 3, len(svc.batch.slots))
+	assert.Equal(t, uint64(1), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	// full batch is flushed at once
+	verify(5)
+	assert.Equal(t, 0, len(svc.batch.slots))
+	assert.Equal(t, uint64(5), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, headers[5].Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	for slot := uint64(1); slot <= 5; slot++ {
+		status := <-statusCh
+		assert.Equal(t, slot, status.Slot)
+		assert.Equal(t, types.Verified, status.Status)
+	}
+
+	// slot near head flushes the collected slots before it is verified
+	verify(6)
+	assert.Equal(t, 1, len(svc.batch.slots))
+	require.NotNil(t, svc.batchExpired())
+	verify(7)
+	assert.Equal(t, 0, len(svc.batch.slots))
+	assert.Equal(t, uint64(7), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(6)
+	require.NoError(t, err)
+	assert.Equal(t, headers[6].Hash(), slotInfo.PandoraHeaderHash)
+	assert.Equal(t, uint64(6), (<-statusCh).Slot)
+	assert.Equal(t, uint64(7), (<-statusCh).Slot)
+	assert.Equal(t, (<-chan time.Time)(nil), svc.batchExpired())
+}
+
+func TestVerifyBatch_Expired(t *testing.T) {
+	batch := newVerifyBatch(4)
+	batch.delay = 10 * time.Millisecond
+	assert.Equal(t, (<-chan time.Time)(nil), batch.expired())
+
+	header := testutil.NewEth1Header(1)
+	batch.put(1, testutil.NewVanguardShardInfo(1, header), header)
+	select {
+	case <-batch.expired():
+	case <-time.After(time.Second):
+		t.Fatal("partial batch is not expired")
+	}
+	assert.Equal(t, 1, len(batch.take()))
+	assert.Equal(t, (<-chan time.Time)(nil), batch.expired())
+}
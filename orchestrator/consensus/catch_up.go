@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+)
+
+// updateWriteMode switches db to batched writes while verified slots are far behind the wall clock and commits the
+// batch whenever a new epoch starts. Near head, every write is synced again.
+func (s *Service) updateWriteMode(slot uint64, header *eth1Types.Header) {
+	if s.catchUpWriteDB == nil {
+		return
+	}
+
+	if time.Since(time.Unix(int64(header.Time), 0)) <= s.catchUpDistance {
+		if s.catchUpWriteDB.IsCatchUpMode() {
+			if err := s.catchUpWriteDB.ExitCatchUpMode(); err != nil {
+				log.WithError(err).Error("Failed to exit catch-up db write mode")
+			}
+		}
+		return
+	}
+
+	epoch := slot / params.SlotsPerEpoch
+	if !s.catchUpWriteDB.IsCatchUpMode() {
+		// writes of this slot are already synced, so it is the first durable slot of the batch
+		if err := s.catchUpWriteDB.EnterCatchUpMode(slot); err != nil {
+			log.WithError(err).Error("Failed to enter catch-up db write mode")
+			return
+		}
+		s.catchUpEpoch = epoch
+		return
+	}
+	if epoch > s.catchUpEpoch {
+		if err := s.catchUpWriteDB.CommitCatchUp(slot); err != nil {
+			log.WithError(err).WithField("slot", slot).Error("Failed to commit catch-up db writes")
+			return
+		}
+		s.catchUpEpoch = epoch
+	}
+}
+
+// exitCatchUpMode syncs batched writes before db is reverted
+func (s *Service) exitCatchUpMode() error {
+	if s.catchUpWriteDB == nil {
+		return nil
+	}
+	return s.catchUpWriteDB.ExitCatchUpMode()
+}
@@ -0,0 +1,137 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// checkpointRetryInterval is the time between the attempts to check the checkpoint against the nodes
+var checkpointRetryInterval = 5 * time.Second
+
+// checkpointTimeout is the time which the nodes have to confirm the checkpoint before the service fails
+var checkpointTimeout = 5 * time.Minute
+
+// errCheckpointMismatch is returned when a node disagrees with the trusted checkpoint, which is not retried
+var errCheckpointMismatch = errors.New("checkpoint does not match the chain")
+
+// CheckpointVanguard looks up the shard info of a finalized vanguard slot
+type CheckpointVanguard interface {
+	CheckpointShardInfo(ctx context.Context, slot uint64) (*types.SlotInfo, uint64, error)
+}
+
+// CheckpointPandora tells whether pandora node has a header on its canonical chain
+type CheckpointPandora interface {
+	CanonicalStatus(ctx context.Context, hash common.Hash) (bool, bool, error)
+}
+
+// seedCheckpoint stores the trusted checkpoint as the verified head of an empty db once vanguard and pandora nodes
+// agree with it. It fails when the db or one of the nodes has another shard info at the checkpoint slot. The
+// checkpoint is identified by its slot, the step id of the checkpoint on other nodes is not known.
+func (s *Service) seedCheckpoint() error {
+	if s.checkpoint == nil {
+		return nil
+	}
+	slot := s.checkpoint.Slot
+	slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return err
+	}
+	if slotInfo != nil {
+		if slotInfo.Root() != s.checkpoint.ShardRoot {
+			return errors.Errorf("verified slot %d with shard root %s does not match checkpoint shard root %s",
+				slot, slotInfo.Root().Hex(), s.checkpoint.ShardRoot.Hex())
+		}
+		return nil
+	}
+	if s.hasVerifiedChain() {
+		log.WithField("slot", slot).Warn("Verified db is not empty, skipping checkpoint seeding")
+		return nil
+	}
+
+	log.WithField("slot", slot).WithField("shardRoot", s.checkpoint.ShardRoot).
+		Info("Checking checkpoint against vanguard and pandora nodes")
+	ctx, cancel := context.WithTimeout(s.ctx, checkpointTimeout)
+	defer cancel()
+	var blockNumber uint64
+	for {
+		slotInfo, blockNumber, err = s.checkCheckpoint(ctx)
+		if err == nil {
+			break
+		}
+		if errors.Cause(err) == errCheckpointMismatch {
+			return err
+		}
+		log.WithError(err).Warn("Could not check checkpoint, retrying")
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(err, "could not check checkpoint in time")
+		case <-time.After(checkpointRetryInterval):
+		}
+	}
+
+	if err := s.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SavePandoraBlockNumber(blockNumber, slot); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedSlot(s.ctx, slot); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestFinalizedSlot(slot); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestFinalizedEpoch(slot / params.SlotsPerEpoch); err != nil {
+		return err
+	}
+	// the history before the checkpoint is not known, so the checkpoint is the first leaf of the accumulator. Step
+	// ids, roots, inclusion proofs and resume tokens of this node differ from the ones of a node with full history.
+	if _, err := s.accumulate(slot, slotInfo); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedHeaderHash(slotInfo.PandoraHeaderHash); err != nil {
+		return err
+	}
+	s.advanceHead(slot, slotInfo.PandoraHeaderHash)
+	log.WithField("slot", slot).WithField("pandoraHeaderHash", slotInfo.PandoraHeaderHash).
+		WithField("vanguardBlockHash", slotInfo.VanguardBlockHash).Info("Seeded verified db with checkpoint")
+	return nil
+}
+
+// checkCheckpoint returns the shard info and pandora block number of the checkpoint slot when both nodes agree
+// with the checkpoint shard root
+func (s *Service) checkCheckpoint(ctx context.Context) (*types.SlotInfo, uint64, error) {
+	slot := s.checkpoint.Slot
+	slotInfo, blockNumber, err := s.checkpointVanguard.CheckpointShardInfo(ctx, slot)
+	if err != nil {
+		return nil, 0, err
+	}
+	if slotInfo.Root() != s.checkpoint.ShardRoot {
+		return nil, 0, errors.Wrapf(errCheckpointMismatch, "vanguard block %s of slot %d has shard root %s, not %s",
+			slotInfo.VanguardBlockHash.Hex(), slot, slotInfo.Root().Hex(), s.checkpoint.ShardRoot.Hex())
+	}
+	known, canonical, err := s.checkpointPandora.CanonicalStatus(ctx, slotInfo.PandoraHeaderHash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !known {
+		// pandora node may still be syncing towards the checkpoint
+		return nil, 0, errors.Errorf("pandora node does not have checkpoint header %s yet",
+			slotInfo.PandoraHeaderHash.Hex())
+	}
+	if !canonical {
+		return nil, 0, errors.Wrapf(errCheckpointMismatch, "checkpoint header %s is not canonical on pandora node",
+			slotInfo.PandoraHeaderHash.Hex())
+	}
+	return slotInfo, blockNumber, nil
+}
+
+// precedesCheckpoint returns true for the slots up to the checkpoint, which are final and never verified again
+func (s *Service) precedesCheckpoint(slot uint64) bool {
+	return s.checkpoint != nil && slot <= s.checkpoint.Slot
+}
@@ -0,0 +1,118 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+type mockCheckpointChains struct {
+	slotInfo    *types.SlotInfo
+	blockNumber uint64
+	vanguardErr error
+	known       bool
+	canonical   bool
+	calls       int
+}
+
+func (m *mockCheckpointChains) CheckpointShardInfo(context.Context, uint64) (*types.SlotInfo, uint64, error) {
+	m.calls++
+	if m.vanguardErr != nil {
+		err := m.vanguardErr
+		m.vanguardErr = nil
+		return nil, 0, err
+	}
+	return m.slotInfo, m.blockNumber, nil
+}
+
+func (m *mockCheckpointChains) CanonicalStatus(context.Context, common.Hash) (bool, bool, error) {
+	return m.known, m.canonical, nil
+}
+
+func setupCheckpoint(t *testing.T, chains *mockCheckpointChains, root common.Hash) *Service {
+	svc, _ := setup(context.Background(), t)
+	svc.checkpoint = &types.TrustedCheckpoint{Slot: 96, ShardRoot: root}
+	svc.checkpointVanguard = chains
+	svc.checkpointPandora = chains
+	return svc
+}
+
+func TestService_SeedCheckpoint(t *testing.T) {
+	header := testutil.NewEth1Header(40)
+	slotInfo := &types.SlotInfo{PandoraHeaderHash: header.Hash(), VanguardBlockHash: common.HexToHash("0x60")}
+	chains := &mockCheckpointChains{
+		slotInfo:    slotInfo,
+		blockNumber: 40,
+		vanguardErr: errors.New("vanguard node is not connected"),
+		known:       true,
+		canonical:   true,
+	}
+	svc := setupCheckpoint(t, chains, slotInfo.Root())
+	defer svc.Stop()
+	require.NoError(t, svc.loadAccumulator())
+	defer func(interval time.Duration) { checkpointRetryInterval = interval }(checkpointRetryInterval)
+	checkpointRetryInterval = 10 * time.Millisecond
+
+	// transient errors are retried
+	require.NoError(t, svc.seedCheckpoint())
+	assert.Equal(t, 2, chains.calls)
+
+	seeded, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(96)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, seeded)
+	assert.Equal(t, uint64(96), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, uint64(96), svc.verifiedSlotInfoDB.LatestLatestFinalizedSlot())
+	assert.Equal(t, header.Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	slot, found, err := svc.verifiedSlotInfoDB.SlotByPandoraBlockNumber(40)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(96), slot)
+
+	// the next slot builds on the checkpoint, earlier slots are not verified
+	child := testutil.NewEth1Header(41)
+	child.ParentHash = header.Hash()
+	assert.Equal(t, true, svc.isHeadChild(97, child))
+	old := testutil.NewEth1Header(39)
+	require.NoError(t, svc.verifyOrBuffer(95, testutil.NewVanguardShardInfo(95, old), old))
+	skipped, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(95)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), skipped)
+
+	// seeding is idempotent but rejects another checkpoint of the same slot
+	require.NoError(t, svc.seedCheckpoint())
+	svc.checkpoint.ShardRoot = common.HexToHash("0x01")
+	assert.ErrorContains(t, "does not match checkpoint shard root", svc.seedCheckpoint())
+}
+
+func TestService_SeedCheckpoint_Mismatch(t *testing.T) {
+	header := testutil.NewEth1Header(40)
+	slotInfo := &types.SlotInfo{PandoraHeaderHash: header.Hash(), VanguardBlockHash: common.HexToHash("0x60")}
+
+	// vanguard node has another block at the checkpoint slot
+	svc := setupCheckpoint(t, &mockCheckpointChains{slotInfo: slotInfo, known: true, canonical: true},
+		common.HexToHash("0x01"))
+	defer svc.Stop()
+	assert.ErrorContains(t, "has shard root", svc.seedCheckpoint())
+	seeded, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(96)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), seeded)
+
+	// pandora node is on another fork
+	svc = setupCheckpoint(t, &mockCheckpointChains{slotInfo: slotInfo, known: true}, slotInfo.Root())
+	defer svc.Stop()
+	assert.ErrorContains(t, "is not canonical on pandora node", svc.seedCheckpoint())
+
+	// pandora node never gets the checkpoint header
+	defer func(timeout time.Duration) { checkpointTimeout = timeout }(checkpointTimeout)
+	checkpointTimeout = 100 * time.Millisecond
+	svc = setupCheckpoint(t, &mockCheckpointChains{slotInfo: slotInfo}, slotInfo.Root())
+	defer svc.Stop()
+	assert.ErrorContains(t, "could not check checkpoint in time", svc.seedCheckpoint())
+}
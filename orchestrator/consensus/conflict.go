@@ -0,0 +1,60 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// conflictingShardInfo returns the conflict when the slot is already verified with a different pandora header.
+// Verified slots of a vanguard reorg are reverted before the new chain arrives, so a different header of a verified
+// slot means that the linked validator signed conflicting shard infos for the slot.
+func (s *Service) conflictingShardInfo(
+	slot uint64,
+	vanShardInfo *types.VanguardShardInfo,
+	header *eth1Types.Header,
+) *types.ShardConflict {
+
+	verified, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if verified == nil || verified.PandoraHeaderHash == header.Hash() {
+		return nil
+	}
+	return &types.ShardConflict{
+		Slot:     slot,
+		Verified: verified,
+		Conflicting: &types.SlotInfo{
+			VanguardBlockHash: common.BytesToHash(vanShardInfo.BlockHash[:]),
+			PandoraHeaderHash: header.Hash(),
+		},
+		Shard:      types.NewShardInfoFields(vanShardInfo.ShardInfo),
+		DetectedAt: time.Now().Unix(),
+	}
+}
+
+// refuseConflict keeps the verified slot info, records the conflict and alerts the subscribers
+func (s *Service) refuseConflict(conflict *types.ShardConflict) error {
+	shardConflictsCounter.Inc(1)
+	log.WithField("slot", conflict.Slot).
+		WithField("verifiedHeaderHash", conflict.Verified.PandoraHeaderHash).
+		WithField("conflictingHeaderHash", conflict.Conflicting.PandoraHeaderHash).
+		WithField("conflictingBlockHash", conflict.Conflicting.VanguardBlockHash).
+		Error("Refusing shard info which conflicts with the verified slot, linked validator may have equivocated")
+
+	if err := s.invalidSlotInfoDB.SaveShardConflict(conflict); err != nil {
+		log.WithError(err).WithField("slot", conflict.Slot).Error("Failed to store shard conflict")
+		return err
+	}
+	// refused entries must not be matched again
+	s.pandoraPendingHeaderCache.Remove(s.ctx, conflict.Slot)
+	s.vanguardPendingShardingCache.Remove(s.ctx, conflict.Slot)
+	s.shardConflictFeed.Send(conflict)
+	return nil
+}
+
+// SubscribeShardConflictEvent
+func (s *Service) SubscribeShardConflictEvent(ch chan<- *types.ShardConflict) event.Subscription {
+	return s.scope.Track(s.shardConflictFeed.Subscribe(ch))
+}
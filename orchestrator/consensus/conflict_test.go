@@ -0,0 +1,47 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_RefuseConflictingShardInfo(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+
+	header := testutil.NewEth1Header(3)
+	require.NoError(t, svc.verifyOrBuffer(3, testutil.NewVanguardShardInfo(3, header), header))
+
+	conflictCh := make(chan *types.ShardConflict, 1)
+	sub := svc.SubscribeShardConflictEvent(conflictCh)
+	defer sub.Unsubscribe()
+
+	// same header of the verified slot is not a conflict
+	assert.Equal(t, (*types.ShardConflict)(nil), svc.conflictingShardInfo(3, testutil.NewVanguardShardInfo(3, header), header))
+
+	other := testutil.NewEth1Header(3)
+	other.Time++
+	conflicts := shardConflictsCounter.Count()
+	require.NoError(t, svc.verifyOrBuffer(3, testutil.NewVanguardShardInfo(3, other), other))
+	assert.Equal(t, conflicts+1, shardConflictsCounter.Count())
+
+	// verified slot info is kept
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(3)
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), slotInfo.PandoraHeaderHash)
+
+	conflict := <-conflictCh
+	assert.Equal(t, uint64(3), conflict.Slot)
+	assert.Equal(t, header.Hash(), conflict.Verified.PandoraHeaderHash)
+	assert.Equal(t, other.Hash(), conflict.Conflicting.PandoraHeaderHash)
+
+	stored, err := svc.invalidSlotInfoDB.ShardConflicts(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(stored))
+	assert.Equal(t, other.Hash(), stored[0].Conflicting.PandoraHeaderHash)
+}
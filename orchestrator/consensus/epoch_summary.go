@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// epochTally counts the outcome of the slots of the epoch which is being verified
+type epochTally struct {
+	epoch    uint64
+	started  bool
+	verified uint64
+	invalid  uint64
+	reorgs   uint64
+	latency  time.Duration
+}
+
+// tallySlot records the outcome of the slot. The tally of the previous epoch is summarized once the first slot of
+// a later epoch is recorded. Late slots of already summarized epochs are not counted.
+func (s *Service) tallySlot(slot uint64, status types.Status, header *eth1Types.Header) {
+	if s.epochSummaryDB == nil {
+		return
+	}
+	epoch := slot / params.SlotsPerEpoch
+	if !s.tally.started {
+		s.tally = epochTally{epoch: epoch, started: true, reorgs: s.tally.reorgs}
+	}
+	if epoch < s.tally.epoch {
+		log.WithField("slot", slot).WithField("epoch", s.tally.epoch).Debug("Slot of summarized epoch is not counted")
+		return
+	}
+	if epoch > s.tally.epoch {
+		s.summarizeEpoch()
+		s.tally = epochTally{epoch: epoch, started: true}
+	}
+
+	switch status {
+	case types.Verified:
+		s.tally.verified++
+		if latency := time.Since(headerTime(header)); latency > 0 {
+			s.tally.latency += latency
+		}
+	case types.Invalid:
+		s.tally.invalid++
+	}
+}
+
+// tallyReorg counts the reorg. Slots are replayed from the finalized slot after reorg, so the tally starts over
+// at the next slot and the reorg is counted in its epoch.
+func (s *Service) tallyReorg() {
+	if s.epochSummaryDB == nil {
+		return
+	}
+	s.tally = epochTally{reorgs: s.tally.reorgs + 1}
+}
+
+// summarizeEpoch persists the summary of the tallied epoch and sends it to the subscribers
+func (s *Service) summarizeEpoch() {
+	summary := &types.EpochSummary{
+		Epoch:         s.tally.epoch,
+		VerifiedSlots: s.tally.verified,
+		InvalidSlots:  s.tally.invalid,
+		Reorgs:        s.tally.reorgs,
+	}
+	if seen := s.tally.verified + s.tally.invalid; seen < params.SlotsPerEpoch {
+		summary.SkippedSlots = params.SlotsPerEpoch - seen
+	}
+	if s.tally.verified > 0 {
+		summary.AverageConfirmationLatency = uint64((s.tally.latency / time.Duration(s.tally.verified)).Milliseconds())
+	}
+
+	if err := s.epochSummaryDB.SaveEpochSummary(summary); err != nil {
+		log.WithError(err).WithField("epoch", summary.Epoch).Error("Failed to store epoch summary")
+		return
+	}
+	log.WithField("epoch", summary.Epoch).WithField("verifiedSlots", summary.VerifiedSlots).
+		WithField("invalidSlots", summary.InvalidSlots).WithField("skippedSlots", summary.SkippedSlots).
+		WithField("reorgs", summary.Reorgs).Info("Summarized epoch")
+	s.epochSummaryFeed.Send(summary)
+}
+
+// SubscribeEpochSummaryEvent
+func (s *Service) SubscribeEpochSummaryEvent(ch chan<- *types.EpochSummary) event.Subscription {
+	return s.scope.Track(s.epochSummaryFeed.Subscribe(ch))
+}
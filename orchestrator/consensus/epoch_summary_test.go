@@ -0,0 +1,64 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_EpochSummary(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	summaryDB := testDB.SetupDB(t)
+	svc.epochSummaryDB = summaryDB
+
+	summaryCh := make(chan *types.EpochSummary, 1)
+	sub := svc.SubscribeEpochSummaryEvent(summaryCh)
+	defer sub.Unsubscribe()
+
+	verify := func(slot uint64) {
+		header := testutil.NewEth1Header(slot)
+		header.Time = uint64(time.Now().Add(-time.Second).Unix())
+		require.NoError(t, svc.verifyShardingInfo(slot, testutil.NewVanguardShardInfo(slot, header), header))
+	}
+
+	// slots 33..62 of epoch 1 are verified, 63 is invalid and 32 is skipped
+	for slot := uint64(33); slot < 63; slot++ {
+		verify(slot)
+	}
+	header := testutil.NewEth1Header(63)
+	svc.tallySlot(63, types.Invalid, header)
+	svc.tallyReorg()
+	for slot := uint64(40); slot < 64; slot++ {
+		svc.tallySlot(slot, types.Verified, &eth1Types.Header{Time: uint64(time.Now().Unix())})
+	}
+	summary, err := summaryDB.EpochSummary(1)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.EpochSummary)(nil), summary)
+
+	// first slot of the next epoch summarizes the replayed epoch
+	verify(64)
+	summary = <-summaryCh
+	assert.Equal(t, uint64(1), summary.Epoch)
+	assert.Equal(t, uint64(24), summary.VerifiedSlots)
+	assert.Equal(t, uint64(0), summary.InvalidSlots)
+	assert.Equal(t, uint64(8), summary.SkippedSlots)
+	assert.Equal(t, uint64(1), summary.Reorgs)
+	assert.Equal(t, true, summary.AverageConfirmationLatency < 5000)
+
+	stored, err := summaryDB.EpochSummary(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, summary, stored)
+
+	// late slot of summarized epoch is not counted
+	svc.tallySlot(50, types.Verified, header)
+	assert.Equal(t, uint64(2), svc.tally.epoch)
+	assert.Equal(t, uint64(1), svc.tally.verified)
+}
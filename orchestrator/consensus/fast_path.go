@@ -0,0 +1,33 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+)
+
+// verifiedHead is the highest verified slot which is kept in memory, so that the child of the head can skip
+// the db lookups which are needed for out of order and duplicate slots
+type verifiedHead struct {
+	slot  uint64
+	hash  common.Hash
+	known bool
+}
+
+// isHeadChild returns true when the header is the next slot of the verified head and builds on it. Such slot
+// can't be verified already and does not need reordering.
+func (s *Service) isHeadChild(slot uint64, header *eth1Types.Header) bool {
+	return s.head.known && slot == s.head.slot+1 && header.ParentHash == s.head.hash
+}
+
+// advanceHead moves the verified head forward. Slots which are verified below the head don't move it back.
+func (s *Service) advanceHead(slot uint64, hash common.Hash) {
+	if s.head.known && slot <= s.head.slot {
+		return
+	}
+	s.head = verifiedHead{slot: slot, hash: hash, known: true}
+}
+
+// resetHead forgets the verified head after db is reverted, so the next slot takes the full path
+func (s *Service) resetHead() {
+	s.head = verifiedHead{}
+}
@@ -0,0 +1,34 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestService_IsHeadChild(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+
+	parent := testutil.NewEth1Header(1)
+	child := testutil.NewEth1Header(2)
+	child.ParentHash = parent.Hash()
+	// head is unknown before the first verified slot
+	assert.Equal(t, false, svc.isHeadChild(2, child))
+
+	require.NoError(t, svc.verifyShardingInfo(1, testutil.NewVanguardShardInfo(1, parent), parent))
+	assert.Equal(t, true, svc.isHeadChild(2, child))
+	// gap or foreign parent takes the full path
+	assert.Equal(t, false, svc.isHeadChild(3, child))
+	assert.Equal(t, false, svc.isHeadChild(2, testutil.NewEth1Header(2)))
+
+	// slot verified below the head does not move it back
+	svc.advanceHead(0, child.Hash())
+	assert.Equal(t, true, svc.isHeadChild(2, child))
+
+	svc.resetHead()
+	assert.Equal(t, false, svc.isHeadChild(2, child))
+}
@@ -0,0 +1,59 @@
+package consensus
+
+import (
+	"sort"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// finalizedVerifiedSlot returns the latest finalized slot which is verified. Vanguard may finalize slots which are
+// not verified yet while the verified chain catches up.
+func (s *Service) finalizedVerifiedSlot() uint64 {
+	finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	if latestSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(); finalizedSlot > latestSlot {
+		return latestSlot
+	}
+	return finalizedSlot
+}
+
+// publishFinalizations tells the subscribers that the verified slots above the last slot published as Finalized and
+// at or below the latest finalized slot are finalized, so pandora can drop reorg protection of their blocks. Finalized
+// slots which are not verified yet are published once verification reaches them. Slots are sent from the lowest slot up.
+func (s *Service) publishFinalizations() {
+	finalizedSlot := s.finalizedVerifiedSlot()
+	if finalizedSlot < s.finalizedPublished {
+		// verified chain is rolled back, so the slots are published again once they are verified again
+		s.finalizedPublished = finalizedSlot
+	}
+	if finalizedSlot == s.finalizedPublished {
+		return
+	}
+	fromSlot := s.finalizedPublished + 1
+	slotInfos, err := s.verifiedSlotInfoDB.VerifiedSlotInfoRange(fromSlot, finalizedSlot)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).WithField("toSlot", finalizedSlot).
+			Warn("Failed to read verified slots which are finalized")
+		return
+	}
+	s.finalizedPublished = finalizedSlot
+	if len(slotInfos) == 0 {
+		return
+	}
+
+	slots := make([]uint64, 0, len(slotInfos))
+	for slot := range slotInfos {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	for _, slot := range slots {
+		slotInfo := slotInfos[slot]
+		s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
+			Slot:              slot,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			Status:            types.Finalized,
+		})
+	}
+	log.WithField("fromSlot", slots[0]).WithField("toSlot", slots[len(slots)-1]).WithField("slots", len(slotInfos)).
+		Debug("Finalized verified slots")
+}
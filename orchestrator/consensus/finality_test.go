@@ -0,0 +1,61 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_PublishFinalizations(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	slotInfos := map[uint64]*types.SlotInfo{
+		2: {VanguardBlockHash: common.HexToHash("0x21"), PandoraHeaderHash: common.HexToHash("0x22")},
+		4: {VanguardBlockHash: common.HexToHash("0x41"), PandoraHeaderHash: common.HexToHash("0x42")},
+		5: {VanguardBlockHash: common.HexToHash("0x51"), PandoraHeaderHash: common.HexToHash("0x52")},
+		7: {VanguardBlockHash: common.HexToHash("0x71"), PandoraHeaderHash: common.HexToHash("0x72")},
+	}
+	for slot, slotInfo := range slotInfos {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(context.Background(), 7))
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestFinalizedSlot(5))
+
+	statusCh := make(chan *types.SlotInfoWithStatus, 4)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(statusCh)
+	defer sub.Unsubscribe()
+
+	// slots finalized before are not sent again
+	svc.finalizedPublished = 2
+	svc.publishFinalizations()
+	for _, want := range []uint64{4, 5} {
+		status := <-statusCh
+		assert.Equal(t, want, status.Slot)
+		assert.Equal(t, types.Finalized, status.Status)
+		assert.Equal(t, slotInfos[want].PandoraHeaderHash, status.PandoraHeaderHash)
+	}
+
+	// finalized slot is not moved
+	svc.publishFinalizations()
+	assert.Equal(t, 0, len(statusCh))
+
+	// finalized slots ahead of the verified chain are published as verification catches up
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestFinalizedSlot(9))
+	svc.publishFinalizations()
+	status := <-statusCh
+	assert.Equal(t, uint64(7), status.Slot)
+	assert.Equal(t, 0, len(statusCh))
+
+	slotInfos[9] = &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x91"), PandoraHeaderHash: common.HexToHash("0x92")}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(9, slotInfos[9]))
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(context.Background(), 9))
+	svc.publishFinalizations()
+	status = <-statusCh
+	assert.Equal(t, uint64(9), status.Slot)
+	assert.Equal(t, types.Finalized, status.Status)
+	assert.Equal(t, uint64(9), svc.finalizedPublished)
+}
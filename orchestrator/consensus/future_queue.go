@@ -0,0 +1,98 @@
+package consensus
+
+import (
+	"sort"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// futureQueue parks slots whose pandora header is dated further ahead of the wall clock than the tolerance.
+// Parked slots are released when their slot time comes within the tolerance.
+type futureQueue struct {
+	tolerance time.Duration
+	slots     map[uint64]*bufferedSlot
+	// timer fires when the earliest parked slot is due, nil when nothing is parked
+	timer *time.Timer
+	now   func() time.Time
+}
+
+func newFutureQueue(tolerance time.Duration) *futureQueue {
+	return &futureQueue{
+		tolerance: tolerance,
+		slots:     make(map[uint64]*bufferedSlot),
+		now:       time.Now,
+	}
+}
+
+// headerTime
+func headerTime(header *eth1Types.Header) time.Time {
+	return time.Unix(int64(header.Time), 0)
+}
+
+// isFuture returns true when the header is dated beyond the tolerance
+func (q *futureQueue) isFuture(header *eth1Types.Header) bool {
+	return headerTime(header).After(q.now().Add(q.tolerance))
+}
+
+// put parks the slot. Previous value of the same slot is replaced.
+func (q *futureQueue) put(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) {
+	q.slots[slot] = &bufferedSlot{
+		slot:         slot,
+		vanShardInfo: vanShardInfo,
+		header:       header,
+	}
+	q.schedule()
+}
+
+// due removes and returns the parked slots which are not in the future anymore, ordered by slot
+func (q *futureQueue) due() []*bufferedSlot {
+	released := make([]*bufferedSlot, 0)
+	for slot, bs := range q.slots {
+		if !q.isFuture(bs.header) {
+			released = append(released, bs)
+			delete(q.slots, slot)
+		}
+	}
+	sort.Slice(released, func(i, j int) bool { return released[i].slot < released[j].slot })
+	q.schedule()
+	return released
+}
+
+// wake returns the channel which receives when the earliest parked slot is due. It is nil when nothing is parked,
+// so selecting on it blocks.
+func (q *futureQueue) wake() <-chan time.Time {
+	if q == nil || q.timer == nil {
+		return nil
+	}
+	return q.timer.C
+}
+
+// schedule resets the timer to the release time of the earliest parked slot
+func (q *futureQueue) schedule() {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	var earliest time.Time
+	for _, bs := range q.slots {
+		if release := headerTime(bs.header).Add(-q.tolerance); earliest.IsZero() || release.Before(earliest) {
+			earliest = release
+		}
+	}
+	if earliest.IsZero() {
+		return
+	}
+	q.timer = time.NewTimer(earliest.Sub(q.now()))
+}
+
+func (q *futureQueue) len() int {
+	return len(q.slots)
+}
+
+// purge removes all the parked slots
+func (q *futureQueue) purge() {
+	q.slots = make(map[uint64]*bufferedSlot)
+	q.schedule()
+}
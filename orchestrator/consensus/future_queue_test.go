@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestFutureQueue_ReleaseWhenDue(t *testing.T) {
+	now := time.Unix(1000, 0)
+	queue := newFutureQueue(12 * time.Second)
+	queue.now = func() time.Time { return now }
+
+	header := testutil.NewEth1Header(5)
+	header.Time = 1012
+	assert.Equal(t, false, queue.isFuture(header))
+
+	farHeader := testutil.NewEth1Header(8)
+	farHeader.Time = 1030
+	nearHeader := testutil.NewEth1Header(6)
+	nearHeader.Time = 1020
+	assert.Equal(t, true, queue.isFuture(farHeader))
+	queue.put(8, testutil.NewVanguardShardInfo(8, farHeader), farHeader)
+	queue.put(6, testutil.NewVanguardShardInfo(6, nearHeader), nearHeader)
+	assert.NotNil(t, queue.wake())
+	assert.Equal(t, 0, len(queue.due()))
+
+	now = time.Unix(1018, 0)
+	released := queue.due()
+	assert.Equal(t, 2, len(released))
+	assert.Equal(t, uint64(6), released[0].slot)
+	assert.Equal(t, uint64(8), released[1].slot)
+	assert.Equal(t, 0, queue.len())
+	assert.Equal(t, (<-chan time.Time)(nil), queue.wake())
+}
+
+func TestFutureQueue_Purge(t *testing.T) {
+	queue := newFutureQueue(0)
+	header := testutil.NewEth1Header(5)
+	header.Time = uint64(time.Now().Add(time.Hour).Unix())
+	queue.put(5, testutil.NewVanguardShardInfo(5, header), header)
+	assert.Equal(t, 1, queue.len())
+
+	queue.purge()
+	assert.Equal(t, 0, queue.len())
+	assert.Equal(t, (<-chan time.Time)(nil), queue.wake())
+	// nil queue never wakes
+	assert.Equal(t, (<-chan time.Time)(nil), (*futureQueue)(nil).wake())
+}
@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+)
+
+// genesisSlot is the slot which is seeded from the genesis shard info of the network preset
+const genesisSlot = 0
+
+// seedGenesis stores the genesis shard info as the verified genesis slot when nothing is verified yet. It fails
+// when the db was seeded with another genesis.
+func (s *Service) seedGenesis() error {
+	if s.genesis == nil {
+		return nil
+	}
+	slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(genesisSlot)
+	if err != nil {
+		return err
+	}
+	if slotInfo != nil {
+		if *slotInfo != *s.genesis {
+			return errors.Errorf("verified genesis slot %+v does not match genesis shard info %+v of the network",
+				slotInfo, s.genesis)
+		}
+		s.advanceHeadToGenesis()
+		return nil
+	}
+	if s.hasVerifiedChain() {
+		// genesis slot was pruned or the db was started without a genesis shard info
+		log.Debug("Verified db is not empty, skipping genesis seeding")
+		return nil
+	}
+
+	if err := s.verifiedSlotInfoDB.SaveVerifiedSlotInfo(genesisSlot, s.genesis); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SavePandoraBlockNumber(0, genesisSlot); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedSlot(s.ctx, genesisSlot); err != nil {
+		return err
+	}
+	if _, err := s.accumulate(genesisSlot, s.genesis); err != nil {
+		return err
+	}
+	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedHeaderHash(s.genesis.PandoraHeaderHash); err != nil {
+		return err
+	}
+	s.advanceHeadToGenesis()
+	log.WithField("pandoraHeaderHash", s.genesis.PandoraHeaderHash).
+		WithField("vanguardBlockHash", s.genesis.VanguardBlockHash).Info("Seeded verified db with genesis slot")
+	return nil
+}
+
+// advanceHeadToGenesis makes the genesis slot the verified head while nothing else is verified
+func (s *Service) advanceHeadToGenesis() {
+	if s.verifiedSlotInfoDB.LatestSavedVerifiedSlot() == genesisSlot {
+		s.advanceHead(genesisSlot, s.genesis.PandoraHeaderHash)
+	}
+}
+
+// hasVerifiedChain returns false when no slot is verified yet. The first pandora header is then accepted without
+// a verified parent.
+func (s *Service) hasVerifiedChain() bool {
+	return s.head.known || s.verifiedSlotInfoDB.LatestVerifiedHeaderHash() != (common.Hash{})
+}
+
+// conflictsWithGenesis returns true for the genesis slot when it is seeded from the network preset. Matching
+// genesis data is already skipped as verified, so any other data of the genesis slot is rejected.
+func (s *Service) conflictsWithGenesis(slot uint64) bool {
+	return s.genesis != nil && slot == genesisSlot
+}
@@ -0,0 +1,73 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_SeedGenesis(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+
+	genesisHeader := testutil.NewEth1Header(0)
+	svc.genesis = &types.SlotInfo{
+		PandoraHeaderHash: genesisHeader.Hash(),
+		VanguardBlockHash: common.HexToHash("0x01"),
+	}
+	require.NoError(t, svc.seedGenesis())
+
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(genesisSlot)
+	require.NoError(t, err)
+	assert.DeepEqual(t, svc.genesis, slotInfo)
+	assert.Equal(t, genesisHeader.Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	slot, found, err := svc.verifiedSlotInfoDB.SlotByPandoraBlockNumber(0)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(genesisSlot), slot)
+
+	// the first slot must build on the genesis header
+	child := testutil.NewEth1Header(1)
+	child.ParentHash = genesisHeader.Hash()
+	assert.Equal(t, true, svc.isHeadChild(1, child))
+
+	// other data of the genesis slot never replaces the seeded one
+	other := testutil.NewEth1Header(0)
+	other.Time++
+	require.NoError(t, svc.verifyOrBuffer(genesisSlot, testutil.NewVanguardShardInfo(0, other), other))
+	slotInfo, err = svc.verifiedSlotInfoDB.VerifiedSlotInfo(genesisSlot)
+	require.NoError(t, err)
+	assert.DeepEqual(t, svc.genesis, slotInfo)
+
+	// seeding is idempotent but rejects another genesis
+	require.NoError(t, svc.seedGenesis())
+	svc.genesis = &types.SlotInfo{PandoraHeaderHash: other.Hash(), VanguardBlockHash: common.HexToHash("0x01")}
+	assert.ErrorContains(t, "does not match genesis shard info", svc.seedGenesis())
+}
+
+func TestService_FirstSlotWithoutParent(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	svc.reorderBuffer = newReorderBuffer(8)
+	assert.Equal(t, false, svc.hasVerifiedChain())
+
+	// nothing is verified yet, so the first header is verified without waiting for its parent
+	header := testutil.NewEth1Header(5)
+	header.ParentHash = common.HexToHash("0x05")
+	require.NoError(t, svc.verifyOrBuffer(5, testutil.NewVanguardShardInfo(5, header), header))
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(5)
+	require.NoError(t, err)
+	assert.NotNil(t, slotInfo)
+	assert.Equal(t, true, svc.hasVerifiedChain())
+
+	// without a genesis shard info, nothing is seeded
+	require.NoError(t, svc.seedGenesis())
+	slotInfo, err = svc.verifiedSlotInfoDB.VerifiedSlotInfo(genesisSlot)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+}
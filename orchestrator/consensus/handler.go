@@ -2,9 +2,11 @@ package consensus
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
@@ -13,8 +15,8 @@ func (s *Service) processPandoraHeader(headerInfo *types.PandoraHeaderInfo) erro
 	slot := headerInfo.Slot
 	s.pandoraPendingHeaderCache.Put(s.ctx, slot, headerInfo.Header)
 	vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
-	if vanShardInfo != nil {
-		return s.verifyShardingInfo(slot, vanShardInfo, headerInfo.Header)
+	if vanShardInfo != nil && !s.holdForBackfill(slot) {
+		return s.verifyOrBuffer(slot, vanShardInfo, headerInfo.Header)
 	}
 	return nil
 }
@@ -24,24 +26,153 @@ func (s *Service) processVanguardShardInfo(vanShardInfo *types.VanguardShardInfo
 	slot := vanShardInfo.Slot
 	s.vanguardPendingShardingCache.Put(s.ctx, slot, vanShardInfo)
 	headerInfo, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
-	if headerInfo != nil {
-		return s.verifyShardingInfo(slot, vanShardInfo, headerInfo)
+	if headerInfo != nil && !s.holdForBackfill(slot) {
+		return s.verifyOrBuffer(slot, vanShardInfo, headerInfo)
+	}
+	return nil
+}
+
+// verifyOrBuffer verifies the slot when its parent is already verified. Otherwise the slot is held in the reorder
+// buffer until the parent gets verified or the slot stays there for the whole reordering window.
+func (s *Service) verifyOrBuffer(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) error {
+	if s.conflictsWithGenesis(slot) {
+		log.WithField("headerHash", header.Hash()).WithField("genesisHeaderHash", s.genesis.PandoraHeaderHash).
+			Warn("Genesis slot does not match genesis shard info of the network, skipping")
+		return nil
+	}
+	if s.precedesCheckpoint(slot) {
+		log.WithField("slot", slot).WithField("checkpointSlot", s.checkpoint.Slot).
+			Debug("Slot is not after the trusted checkpoint, skipping")
+		return nil
+	}
+	if conflict := s.conflictingShardInfo(slot, vanShardInfo, header); conflict != nil {
+		return s.refuseConflict(conflict)
+	}
+
+	if s.futureQueue != nil && s.futureQueue.isFuture(header) {
+		log.WithField("slot", slot).WithField("headerTime", headerTime(header)).
+			Warn("Pandora header is dated ahead of wall clock, parking slot until its slot time")
+		s.futureQueue.put(slot, vanShardInfo, header)
+		return nil
+	}
+
+	if s.batch != nil {
+		if batched, err := s.batchOrFlush(slot, vanShardInfo, header); batched || err != nil {
+			return err
+		}
+	}
+
+	if s.reorderBuffer == nil {
+		return s.verifyShardingInfo(slot, vanShardInfo, header)
+	}
+
+	// fast path at head, the parent is verified and the slot is in order
+	if s.isHeadChild(slot, header) {
+		s.reorderBuffer.observe(slot)
+		if err := s.verifyShardingInfo(slot, vanShardInfo, header); err != nil {
+			return err
+		}
+		return s.releaseBufferedSlots()
+	}
+
+	latestVerifiedSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	latestVerifiedHash := s.verifiedSlotInfoDB.LatestVerifiedHeaderHash()
+	s.reorderBuffer.observe(slot)
+
+	// nothing is verified yet or the slot is out of reordering window, so there is no reason to wait for the parent
+	isAheadOfParent := s.hasVerifiedChain() && header.ParentHash != latestVerifiedHash &&
+		slot > latestVerifiedSlot && slot <= latestVerifiedSlot+s.reorderBuffer.window
+	if !isAheadOfParent {
+		if err := s.verifyShardingInfo(slot, vanShardInfo, header); err != nil {
+			return err
+		}
+		return s.releaseBufferedSlots()
+	}
+
+	log.WithField("slot", slot).WithField("parentHash", header.ParentHash).
+		WithField("latestVerifiedSlot", latestVerifiedSlot).Debug("Parent is not verified yet, buffering slot")
+	s.reorderBuffer.put(slot, vanShardInfo, header)
+	return s.releaseBufferedSlots()
+}
+
+// releaseBufferedSlots verifies buffered slots in order as long as their parent is verified or they are expired
+func (s *Service) releaseBufferedSlots() error {
+	for {
+		bs := s.reorderBuffer.pop(s.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+		if bs == nil {
+			return nil
+		}
+		log.WithField("slot", bs.slot).Debug("Releasing slot from reorder buffer")
+		if err := s.verifyShardingInfo(bs.slot, bs.vanShardInfo, bs.header); err != nil {
+			return err
+		}
+	}
+}
+
+// holdForBackfill returns true when a lower slot is still queued in the backfill lane. The matched slot is held
+// in the pending caches, so that slots are verified in order.
+func (s *Service) holdForBackfill(slot uint64) bool {
+	if s.lanes.backfillBelow(slot) {
+		s.heldSlots[slot] = struct{}{}
+		return true
+	}
+	return false
+}
+
+// releaseHeldSlots verifies the held slots in order once no lower slot is queued in the backfill lane
+func (s *Service) releaseHeldSlots() error {
+	if len(s.heldSlots) == 0 {
+		return nil
+	}
+	slots := make([]uint64, 0, len(s.heldSlots))
+	for slot := range s.heldSlots {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	for _, slot := range slots {
+		if s.lanes.backfillBelow(slot) {
+			return nil
+		}
+		delete(s.heldSlots, slot)
+		vanShardInfo, _ := s.vanguardPendingShardingCache.Get(s.ctx, slot)
+		header, _ := s.pandoraPendingHeaderCache.Get(s.ctx, slot)
+		if vanShardInfo == nil || header == nil {
+			continue
+		}
+		log.WithField("slot", slot).Debug("Releasing slot held for backfill")
+		if err := s.verifyOrBuffer(slot, vanShardInfo, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseFutureSlots processes the parked slots whose slot time has come
+func (s *Service) releaseFutureSlots() error {
+	for _, bs := range s.futureQueue.due() {
+		log.WithField("slot", bs.slot).Debug("Releasing slot from future queue")
+		if err := s.verifyOrBuffer(bs.slot, bs.vanShardInfo, bs.header); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // verifyShardingInfo
 func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) error {
+	headerHash := header.Hash()
 	slotInfo := &types.SlotInfo{
-		PandoraHeaderHash: header.Hash(),
+		PandoraHeaderHash: headerHash,
 		VanguardBlockHash: common.BytesToHash(vanShardInfo.BlockHash[:]),
 	}
-	status := CompareShardingInfo(header, vanShardInfo.ShardInfo)
+	mismatchedField := s.mismatchedField(slot, headerHash, vanShardInfo, header)
 	slotInfoWithStatus := &types.SlotInfoWithStatus{
-		PandoraHeaderHash: header.Hash(),
+		Slot:              slot,
+		PandoraHeaderHash: headerHash,
 		VanguardBlockHash: common.BytesToHash(vanShardInfo.BlockHash[:]),
 	}
-	if !status {
+	if mismatchedField != "" {
 		// store invalid slot info into invalid slot info bucket
 		if err := s.invalidSlotInfoDB.SaveInvalidSlotInfo(slot, slotInfo); err != nil {
 			log.WithField("slot", slot).WithField(
@@ -49,13 +180,32 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 				"Failed to store invalid slot info")
 			return err
 		}
+		// keep both sides so that client teams can debug the mismatch
+		disagreement := newShardDisagreement(slot, mismatchedField, header, vanShardInfo.ShardInfo)
+		if err := s.invalidSlotInfoDB.SaveShardDisagreement(disagreement); err != nil {
+			log.WithField("slot", slot).WithError(err).Warn("Failed to store shard info disagreement")
+		}
 		slotInfoWithStatus.Status = types.Invalid
+		observeSlot(types.Invalid, header)
+		s.tallySlot(slot, types.Invalid, header)
+		s.countLifetimeSlot(types.Invalid)
 		log.WithField("slot", slot).Info("Invalid sharding info")
 		// sending verified slot info to rpc service
 		s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
 		return nil
 	}
 
+	// marker is persisted before the first write, so a crash in the middle is rolled back at next start
+	if err := s.verifiedSlotInfoDB.MarkSlotInProgress(slot); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to mark slot in progress")
+		return err
+	}
+
+	if err := chaos.Inject(chaos.VerifiedSlotWritePoint); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to store verified slot info")
+		return err
+	}
+
 	// store verified slot info into verified slot info bucket
 	if err := s.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo); err != nil {
 		log.WithField("slot", slot).WithField(
@@ -63,15 +213,33 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 		return err
 	}
 
+	// indexing verified slot by pandora block number
+	if err := s.verifiedSlotInfoDB.SavePandoraBlockNumber(header.Number.Uint64(), slot); err != nil {
+		log.WithField("slot", slot).WithField("blockNumber", header.Number).WithError(err).
+			Error("Failed to store pandora block number of verified slot")
+		return err
+	}
+
 	// storing latest verified slot into db
 	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedSlot(s.ctx, slot); err != nil {
 		log.WithError(err).Error("Failed to store latest verified slot")
 	}
 
+	// appending verified slot info into verified-chain accumulator
+	step, err := s.accumulate(slot, slotInfo)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Error("Failed to accumulate verified slot info")
+	}
+	if step != nil {
+		stepId := step.LeafIndex
+		slotInfoWithStatus.StepId = &stepId
+	}
+
 	// storing latest verified pandora header hash into db
 	if err := s.verifiedSlotInfoDB.SaveLatestVerifiedHeaderHash(slotInfo.PandoraHeaderHash); err != nil {
 		log.WithError(err).Error("Failed to store latest verified slot")
 	}
+	s.advanceHead(slot, slotInfo.PandoraHeaderHash)
 
 	// Storing latest finalized slot and epoch
 	if s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch() < vanShardInfo.FinalizedEpoch {
@@ -86,17 +254,84 @@ func (s *Service) verifyShardingInfo(slot uint64, vanShardInfo *types.VanguardSh
 			WithField("newFinalizedEpoch", vanShardInfo.FinalizedEpoch).Debug("Saved latest finalized info")
 	}
 
+	if err := s.verifiedSlotInfoDB.ClearSlotInProgress(slot); err != nil {
+		log.WithField("slot", slot).WithError(err).Error("Failed to clear in-progress marker of slot")
+		return err
+	}
+
+	// batch db writes during catch-up
+	s.updateWriteMode(slot, header)
+
 	slotInfoWithStatus.Status = types.Verified
+	observeSlot(types.Verified, header)
+	s.tallySlot(slot, types.Verified, header)
+	s.countLifetimeSlot(types.Verified)
 	//removing previous cached slots which dont verified yet. By convention, they are skipped
 	s.pandoraPendingHeaderCache.Remove(s.ctx, slot)
 	s.vanguardPendingShardingCache.Remove(s.ctx, slot)
 	log.WithField("slot", slot).Info("Successfully verified sharding info")
 	// sending verified slot info to rpc service
 	s.verifiedSlotInfoFeed.Send(slotInfoWithStatus)
+	s.publishFinalizations()
+	return nil
+}
+
+// reconcileInProgressSlots rolls back the slots whose verification writes were interrupted by a crash, along with
+// every later slot. Rolled back slots are verified again once subscriptions resume from the latest verified slot.
+func (s *Service) reconcileInProgressSlots() error {
+	slots, err := s.verifiedSlotInfoDB.InProgressSlots()
+	if err != nil {
+		return err
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+
+	fromSlot, toSlot := slots[0], slots[len(slots)-1]
+	if latestSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(); latestSlot > toSlot {
+		toSlot = latestSlot
+	}
+	log.WithField("inProgressSlots", slots).WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).
+		Warn("Found half-written slots of previous run, rolling them back")
+
+	s.resetHead()
+	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(fromSlot, toSlot); err != nil {
+		return err
+	}
+	if fromSlot > 0 {
+		if err := s.verifiedSlotInfoDB.UpdateVerifiedSlotInfo(fromSlot - 1); err != nil {
+			return err
+		}
+		// pending entries of rolled back slots are stale, they are fetched again along with the rolled back slots
+		s.pandoraPendingHeaderCache.RemoveAbove(s.ctx, fromSlot-1)
+		s.vanguardPendingShardingCache.RemoveAbove(s.ctx, fromSlot-1)
+	} else {
+		s.pandoraPendingHeaderCache.Purge()
+		s.vanguardPendingShardingCache.Purge()
+	}
+	// markers are cleared last, so an interrupted rollback is repeated at next start
+	for _, slot := range slots {
+		if err := s.verifiedSlotInfoDB.ClearSlotInProgress(slot); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// dropRevertedPending removes the pending entries above the revert slot which belong to the reverted chain. Entries
+// at or below the revert slot still wait for their counterpart, so they are kept.
+func (s *Service) dropRevertedPending(revertSlot uint64) {
+	s.pandoraPendingHeaderCache.RemoveAbove(s.ctx, revertSlot)
+	s.vanguardPendingShardingCache.RemoveAbove(s.ctx, revertSlot)
+}
+
 func (s *Service) reorgDB(revertSlot uint64) error {
+	if err := s.exitCatchUpMode(); err != nil {
+		log.WithError(err).Error("failed to exit catch-up db write mode in reorg phase")
+		return err
+	}
+
+	s.resetHead()
 	// Removing slot infos from verified slot info db
 	if err := s.verifiedSlotInfoDB.RemoveRangeVerifiedInfo(revertSlot+1, s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()); err != nil {
 		log.WithError(err).Error("found error while reverting orchestrator database in reorg phase")
@@ -107,5 +342,11 @@ func (s *Service) reorgDB(revertSlot uint64) error {
 		log.WithError(err).Error("failed to update latest verified slot info in reorg phase")
 		return err
 	}
+
+	// verified slot infos are removed along with their accumulator leaves, so rebuild accumulator
+	if err := s.loadAccumulator(); err != nil {
+		log.WithError(err).Error("failed to reload verified-chain accumulator in reorg phase")
+		return err
+	}
 	return nil
 }
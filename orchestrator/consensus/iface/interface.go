@@ -1,6 +1,7 @@
 package iface
 
 import (
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
@@ -8,3 +9,23 @@ import (
 type VerifiedSlotInfoFeed interface {
 	SubscribeVerifiedSlotInfoEvent(chan<- *types.SlotInfoWithStatus) event.Subscription
 }
+
+// EpochSummaryFeed
+type EpochSummaryFeed interface {
+	SubscribeEpochSummaryEvent(chan<- *types.EpochSummary) event.Subscription
+}
+
+// ShardConflictFeed
+type ShardConflictFeed interface {
+	SubscribeShardConflictEvent(chan<- *types.ShardConflict) event.Subscription
+}
+
+// HeaderVerifier
+type HeaderVerifier interface {
+	VerifyHeaders(headers []*eth1Types.Header) []*types.HeaderVerification
+}
+
+// LifetimeStatsProvider
+type LifetimeStatsProvider interface {
+	LifetimeStats() *types.LifetimeStats
+}
@@ -0,0 +1,211 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// headLaneDistance is the number of slots behind the highest received slot which still goes to the head lane
+const headLaneDistance = params.SlotsPerEpoch
+
+// DefaultLaneBuffer is the number of items which are queued in each verification lane
+const DefaultLaneBuffer = 1024
+
+// laneItem is a pandora header or vanguard shard info which waits to be processed by the consensus loop
+type laneItem struct {
+	slot         uint64
+	headerInfo   *types.PandoraHeaderInfo
+	vanShardInfo *types.VanguardShardInfo
+	queuedAt     time.Time
+}
+
+// verificationLanes queues the incoming pandora headers and vanguard shard infos in two lanes. Slots near the
+// highest received slot go to the head lane which is always drained first, so that current confirmations are not
+// delayed behind historical slots of the backfill lane. Matched slots are still verified in order, so a head slot
+// is held while a lower slot is queued in the backfill lane.
+//
+// Each lane holds up to maxDepth items. A full lane applies the overflow policy of the pending pandora header
+// queue: drop-oldest drops the oldest item of the lane, resubscribe refuses every item until the consensus loop
+// purges the lanes and renews the subscriptions, which replay the dropped slots from the finalized slot.
+type verificationLanes struct {
+	lock     sync.Mutex
+	head     []*laneItem
+	backfill []*laneItem
+	maxDepth int
+	policy   pandorachain.OverflowPolicy
+	// overflowed is set when a lane is full with resubscribe policy. It is cleared by purge.
+	overflowed bool
+	// tip is the highest slot which is received from any side
+	tip    uint64
+	notify chan struct{}
+}
+
+func newVerificationLanes(maxDepth int, policy pandorachain.OverflowPolicy) *verificationLanes {
+	if maxDepth <= 0 {
+		maxDepth = DefaultLaneBuffer
+	}
+	return &verificationLanes{
+		head:     make([]*laneItem, 0),
+		backfill: make([]*laneItem, 0),
+		maxDepth: maxDepth,
+		policy:   policy,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// pushHeader queues the pandora header
+func (l *verificationLanes) pushHeader(headerInfo *types.PandoraHeaderInfo) {
+	l.push(&laneItem{slot: headerInfo.Slot, headerInfo: headerInfo})
+}
+
+// pushShardInfo queues the vanguard shard info
+func (l *verificationLanes) pushShardInfo(vanShardInfo *types.VanguardShardInfo) {
+	l.push(&laneItem{slot: vanShardInfo.Slot, vanShardInfo: vanShardInfo})
+}
+
+func (l *verificationLanes) push(item *laneItem) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if item.slot > l.tip {
+		l.tip = item.slot
+	}
+	item.queuedAt = time.Now()
+	if l.overflowed {
+		droppedLaneItemsCounter.Inc(1)
+		return
+	}
+	if l.tip-item.slot <= headLaneDistance {
+		l.head = l.enqueue(l.head, item)
+	} else {
+		l.backfill = l.enqueue(l.backfill, item)
+	}
+	l.updateDepth()
+	l.signal()
+}
+
+// enqueue appends the item to the lane and applies the overflow policy when the lane is full
+func (l *verificationLanes) enqueue(lane []*laneItem, item *laneItem) []*laneItem {
+	if len(lane) < l.maxDepth {
+		return append(lane, item)
+	}
+	droppedLaneItemsCounter.Inc(1)
+	if l.policy != pandorachain.DropOldestOnOverflow {
+		laneOverflowsCounter.Inc(1)
+		l.overflowed = true
+		l.signal()
+		log.WithField("slot", item.slot).WithField("buffer", l.maxDepth).
+			Warn("Verification lane is full, subscribing again from finalized slot")
+		return lane
+	}
+	log.WithField("slot", lane[0].slot).WithField("buffer", l.maxDepth).
+		Warn("Verification lane is full, dropped the oldest item")
+	return append(lane[1:], item)
+}
+
+// hasOverflowed returns true when a lane overflowed with resubscribe policy since the last purge
+func (l *verificationLanes) hasOverflowed() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.overflowed
+}
+
+// pop returns the oldest item of the head lane or of the backfill lane when the head lane is empty. It returns nil
+// when both lanes are empty.
+func (l *verificationLanes) pop() *laneItem {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var item *laneItem
+	switch {
+	case len(l.head) > 0:
+		item, l.head = l.head[0], l.head[1:]
+		headLaneLatency.UpdateSince(item.queuedAt)
+	case len(l.backfill) > 0:
+		item, l.backfill = l.backfill[0], l.backfill[1:]
+		backfillLaneLatency.UpdateSince(item.queuedAt)
+	default:
+		return nil
+	}
+	l.updateDepth()
+	// keep the consensus loop waking up until both lanes are drained
+	if len(l.head)+len(l.backfill) > 0 {
+		l.signal()
+	}
+	return item
+}
+
+// backfillBelow returns true when a slot lower than the given slot is queued in the backfill lane
+func (l *verificationLanes) backfillBelow(slot uint64) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, item := range l.backfill {
+		if item.slot < slot {
+			return true
+		}
+	}
+	return false
+}
+
+// wake returns the channel which receives when an item is queued
+func (l *verificationLanes) wake() <-chan struct{} {
+	return l.notify
+}
+
+// len returns the depth of the head lane and the backfill lane
+func (l *verificationLanes) len() (int, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.head), len(l.backfill)
+}
+
+// purge drops the queued items of both lanes. Tip is kept since it is raised again by the resumed subscriptions.
+func (l *verificationLanes) purge() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.head = make([]*laneItem, 0)
+	l.backfill = make([]*laneItem, 0)
+	l.overflowed = false
+	l.updateDepth()
+}
+
+func (l *verificationLanes) signal() {
+	select {
+	case l.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (l *verificationLanes) updateDepth() {
+	headLaneDepth.Update(int64(len(l.head)))
+	backfillLaneDepth.Update(int64(len(l.backfill)))
+}
+
+// resubscribeLanes drops the queued slots of the overflowed lanes and renews the subscriptions, which replay the
+// unverified slots from the finalized slot
+func (s *Service) resubscribeLanes() error {
+	if err := s.flushBatch(); err != nil {
+		return err
+	}
+	if s.reorderBuffer != nil {
+		s.reorderBuffer.purge()
+	}
+	if s.futureQueue != nil {
+		s.futureQueue.purge()
+	}
+	if s.pipeline != nil {
+		s.pipeline.purge()
+	}
+	s.lanes.purge()
+	s.heldSlots = make(map[uint64]struct{})
+
+	log.Debug("Stopping subscription for vanguard and pandora")
+	s.vanguardService.StopSubscription()
+	s.pandoraService.StopPandoraSubscription()
+	return nil
+}
@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestVerificationLanes_HeadLaneFirst(t *testing.T) {
+	lanes := newVerificationLanes(0, pandorachain.ResubscribeOnOverflow)
+	for _, slot := range []uint64{1, 2, 100} {
+		lanes.pushHeader(&types.PandoraHeaderInfo{Slot: slot, Header: testutil.NewEth1Header(slot)})
+	}
+	header := testutil.NewEth1Header(99)
+	lanes.pushShardInfo(testutil.NewVanguardShardInfo(99, header))
+
+	// slots 1 and 2 were near the tip when they were received
+	headDepth, backfillDepth := lanes.len()
+	assert.Equal(t, 4, headDepth)
+	assert.Equal(t, 0, backfillDepth)
+
+	lanes.pushHeader(&types.PandoraHeaderInfo{Slot: 3, Header: testutil.NewEth1Header(3)})
+	lanes.pushHeader(&types.PandoraHeaderInfo{Slot: 101, Header: testutil.NewEth1Header(101)})
+	headDepth, backfillDepth = lanes.len()
+	assert.Equal(t, 5, headDepth)
+	assert.Equal(t, 1, backfillDepth)
+	assert.Equal(t, true, lanes.backfillBelow(4))
+	assert.Equal(t, false, lanes.backfillBelow(3))
+
+	popped := make([]uint64, 0)
+	for item := lanes.pop(); item != nil; item = lanes.pop() {
+		popped = append(popped, item.slot)
+	}
+	assert.DeepEqual(t, []uint64{1, 2, 100, 99, 101, 3}, popped)
+	assert.Equal(t, false, lanes.backfillBelow(4))
+}
+
+func TestVerificationLanes_Purge(t *testing.T) {
+	lanes := newVerificationLanes(0, pandorachain.ResubscribeOnOverflow)
+	lanes.pushHeader(&types.PandoraHeaderInfo{Slot: 100, Header: testutil.NewEth1Header(100)})
+	lanes.pushHeader(&types.PandoraHeaderInfo{Slot: 1, Header: testutil.NewEth1Header(1)})
+	<-lanes.wake()
+
+	lanes.purge()
+	headDepth, backfillDepth := lanes.len()
+	assert.Equal(t, 0, headDepth)
+	assert.Equal(t, 0, backfillDepth)
+	assert.Equal(t, (*laneItem)(nil), lanes.pop())
+}
+
+func TestVerificationLanes_Overflow(t *testing.T) {
+	lanes := newVerificationLanes(2, pandorachain.DropOldestOnOverflow)
+	for _, slot := range []uint64{1, 2, 3} {
+		lanes.pushHeader(&types.PandoraHeaderInfo{Slot: slot, Header: testutil.NewEth1Header(slot)})
+	}
+	assert.Equal(t, false, lanes.hasOverflowed())
+	assert.Equal(t, uint64(2), lanes.pop().slot)
+	assert.Equal(t, uint64(3), lanes.pop().slot)
+
+	lanes = newVerificationLanes(2, pandorachain.ResubscribeOnOverflow)
+	for _, slot := range []uint64{1, 2, 3, 4} {
+		lanes.pushHeader(&types.PandoraHeaderInfo{Slot: slot, Header: testutil.NewEth1Header(slot)})
+	}
+	assert.Equal(t, true, lanes.hasOverflowed())
+	headDepth, _ := lanes.len()
+	assert.Equal(t, 2, headDepth)
+
+	lanes.purge()
+	assert.Equal(t, false, lanes.hasOverflowed())
+	lanes.pushHeader(&types.PandoraHeaderInfo{Slot: 5, Header: testutil.NewEth1Header(5)})
+	assert.Equal(t, uint64(5), lanes.pop().slot)
+}
@@ -0,0 +1,113 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// lifetimePersistInterval is the interval which the lifetime stats are persisted with while slots are counted
+const lifetimePersistInterval = time.Minute
+
+// lifetimeStats counts slots and reorgs on top of the counters of the previous runs of the node
+type lifetimeStats struct {
+	lock  sync.Mutex
+	stats types.LifetimeStats
+	// started is the time which the uptime of the current run is counted from
+	started     time.Time
+	persistedAt time.Time
+}
+
+func newLifetimeStats() *lifetimeStats {
+	now := time.Now()
+	return &lifetimeStats{started: now, persistedAt: now}
+}
+
+// snapshot returns the counters with the uptime of the current run. Caller must hold the lock.
+func (l *lifetimeStats) snapshot(now time.Time) *types.LifetimeStats {
+	stats := l.stats
+	stats.Uptime += uint64(now.Sub(l.started) / time.Second)
+	return &stats
+}
+
+// loadLifetimeStats continues counting from the stored counters of the previous runs
+func (s *Service) loadLifetimeStats() error {
+	if s.lifetimeStatsDB == nil {
+		return nil
+	}
+	stored, err := s.lifetimeStatsDB.LifetimeStats()
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return nil
+	}
+	s.lifetime.lock.Lock()
+	defer s.lifetime.lock.Unlock()
+	s.lifetime.stats = *stored
+	return nil
+}
+
+// countLifetimeSlot counts the outcome of the slot and persists the counters once in a while
+func (s *Service) countLifetimeSlot(status types.Status) {
+	if s.lifetimeStatsDB == nil {
+		return
+	}
+	s.lifetime.lock.Lock()
+	switch status {
+	case types.Verified:
+		s.lifetime.stats.VerifiedSlots++
+	case types.Invalid:
+		s.lifetime.stats.InvalidSlots++
+	}
+	s.lifetime.lock.Unlock()
+	s.maybePersistLifetimeStats()
+}
+
+// countLifetimeReorg counts the reorg and persists the counters once in a while
+func (s *Service) countLifetimeReorg() {
+	if s.lifetimeStatsDB == nil {
+		return
+	}
+	s.lifetime.lock.Lock()
+	s.lifetime.stats.Reorgs++
+	s.lifetime.lock.Unlock()
+	s.maybePersistLifetimeStats()
+}
+
+func (s *Service) maybePersistLifetimeStats() {
+	s.lifetime.lock.Lock()
+	due := time.Since(s.lifetime.persistedAt) >= lifetimePersistInterval
+	s.lifetime.lock.Unlock()
+	if !due {
+		return
+	}
+	if err := s.persistLifetimeStats(); err != nil {
+		log.WithError(err).Warn("Failed to store lifetime stats")
+	}
+}
+
+// persistLifetimeStats stores the counters along with the uptime up to now
+func (s *Service) persistLifetimeStats() error {
+	if s.lifetimeStatsDB == nil {
+		return nil
+	}
+	now := time.Now()
+	s.lifetime.lock.Lock()
+	stats := s.lifetime.snapshot(now)
+	s.lifetime.persistedAt = now
+	s.lifetime.lock.Unlock()
+	return s.lifetimeStatsDB.SaveLifetimeStats(stats)
+}
+
+// LifetimeStats returns verified and invalid slots, reorgs and uptime over all runs of the node. It returns nil
+// when lifetime stats are disabled.
+func (s *Service) LifetimeStats() *types.LifetimeStats {
+	if s.lifetimeStatsDB == nil {
+		return nil
+	}
+	s.lifetime.lock.Lock()
+	defer s.lifetime.lock.Unlock()
+	return s.lifetime.snapshot(time.Now())
+}
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_LifetimeStats(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	assert.Equal(t, (*types.LifetimeStats)(nil), svc.LifetimeStats())
+
+	lifetimeDB := svc.verifiedSlotInfoDB.(db.LifetimeStatsDB)
+	require.NoError(t, lifetimeDB.SaveLifetimeStats(&types.LifetimeStats{VerifiedSlots: 10, InvalidSlots: 1, Uptime: 60}))
+	svc.lifetimeStatsDB = lifetimeDB
+	require.NoError(t, svc.loadLifetimeStats())
+
+	// counting continues from the stored counters of the previous run
+	svc.lifetime.started = time.Now().Add(-30 * time.Second)
+	svc.countLifetimeSlot(types.Verified)
+	svc.countLifetimeSlot(types.Invalid)
+	svc.countLifetimeReorg()
+	stats := svc.LifetimeStats()
+	assert.Equal(t, uint64(11), stats.VerifiedSlots)
+	assert.Equal(t, uint64(2), stats.InvalidSlots)
+	assert.Equal(t, uint64(1), stats.Reorgs)
+	assert.Equal(t, uint64(90), stats.Uptime)
+
+	// counters are persisted once the interval is passed
+	stored, err := lifetimeDB.LifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), stored.VerifiedSlots)
+	svc.lifetime.persistedAt = time.Now().Add(-lifetimePersistInterval)
+	svc.countLifetimeSlot(types.Verified)
+	stored, err = lifetimeDB.LifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), stored.VerifiedSlots)
+	assert.Equal(t, uint64(90), stored.Uptime)
+}
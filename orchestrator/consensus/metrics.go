@@ -0,0 +1,53 @@
+package consensus
+
+import (
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+var (
+	// headLaneDepth is the number of queued items in the head lane
+	headLaneDepth = metrics.NewRegisteredGauge("orc_consensus_lane_head_depth", nil)
+	// backfillLaneDepth is the number of queued items in the backfill lane
+	backfillLaneDepth = metrics.NewRegisteredGauge("orc_consensus_lane_backfill_depth", nil)
+	// headLaneLatency is the time which items of the head lane wait before they are processed
+	headLaneLatency = metrics.NewRegisteredTimer("orc_consensus_lane_head_latency", nil)
+	// backfillLaneLatency is the time which items of the backfill lane wait before they are processed
+	backfillLaneLatency = metrics.NewRegisteredTimer("orc_consensus_lane_backfill_latency", nil)
+	// droppedLaneItemsCounter is the number of received items which are dropped because a lane is full
+	droppedLaneItemsCounter = metrics.NewRegisteredCounter("orc_consensus_lane_dropped_total", nil)
+	// laneOverflowsCounter is the number of times the subscriptions are renewed because of a full lane
+	laneOverflowsCounter = metrics.NewRegisteredCounter("orc_consensus_lane_overflows_total", nil)
+
+	// verifiedSlotsCounter is the number of verified slots
+	verifiedSlotsCounter = metrics.NewRegisteredCounter("orc_verified_slots_total", nil)
+	// invalidSlotsCounter is the number of slots whose sharding info does not match the pandora header
+	invalidSlotsCounter = metrics.NewRegisteredCounter("orc_invalid_slots_total", nil)
+	// reorgEventsCounter is the number of handled reorg events
+	reorgEventsCounter = metrics.NewRegisteredCounter("orc_reorg_events_total", nil)
+	// precomputedVerdictsCounter is the number of slots which are committed with the verdict of a pipeline worker
+	precomputedVerdictsCounter = metrics.NewRegisteredCounter("orc_precomputed_verdicts_total", nil)
+	// shardConflictsCounter is the number of refused shard infos which conflict with an already verified slot
+	shardConflictsCounter = metrics.NewRegisteredCounter("orc_shard_conflicts_total", nil)
+	// uncleanShutdownsCounter is the number of starts which found no clean shutdown marker of the previous run
+	uncleanShutdownsCounter = metrics.NewRegisteredCounter("orc_unclean_shutdowns_total", nil)
+	// confirmationLatencyHistogram is the time in milliseconds from the pandora header time until the slot is verified
+	confirmationLatencyHistogram = metrics.NewRegisteredHistogram("orc_confirmation_latency_ms", nil,
+		metrics.NewExpDecaySample(1028, 0.015))
+)
+
+// observeSlot records the outcome of the slot into metrics
+func observeSlot(status types.Status, header *eth1Types.Header) {
+	switch status {
+	case types.Verified:
+		verifiedSlotsCounter.Inc(1)
+		if latency := time.Since(headerTime(header)); latency > 0 {
+			confirmationLatencyHistogram.Update(latency.Milliseconds())
+		}
+	case types.Invalid:
+		invalidSlotsCounter.Inc(1)
+	}
+}
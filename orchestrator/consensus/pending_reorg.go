@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// revertReorg reverts the verified chain to the finalized slot for the reorg and returns the revert slot. The reorg
+// is kept as pending until the revert is committed, so that a revert which is interrupted by a restart is resumed
+// by resumePendingReorg.
+func (s *Service) revertReorg(reorgInfo *types.Reorg) (uint64, error) {
+	if err := s.savePendingReorg(reorgInfo); err != nil {
+		return 0, errors.Wrap(err, "could not store pending reorg")
+	}
+
+	finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
+	finalizedEpoch := s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch()
+	log.WithField("curSlot", reorgInfo.NewSlot).WithField("revertSlot", finalizedSlot).
+		WithField("finalizedEpoch", finalizedEpoch).Warn("Triggered reorg event")
+
+	record := s.recordReorg(reorgInfo, finalizedSlot)
+	orphanedSlots := s.retractableSlots(finalizedSlot)
+	if err := s.reorgDB(finalizedSlot); err != nil {
+		return 0, err
+	}
+	s.resolveReorg(record)
+	if err := s.clearPendingReorg(); err != nil {
+		return 0, errors.Wrap(err, "could not clear resolved reorg")
+	}
+	s.publishRetractions(orphanedSlots, reorgInfo)
+	s.tallyReorg()
+	s.countLifetimeReorg()
+	reorgEventsCounter.Inc(1)
+	return finalizedSlot, nil
+}
+
+// resumePendingReorg finishes the revert of a reorg which was detected but not resolved before the previous run
+// stopped. Vanguard does not send the reorg again, so the verified chain would keep the reverted slots otherwise.
+func (s *Service) resumePendingReorg() error {
+	if s.pendingReorgDB == nil {
+		return nil
+	}
+	reorgInfo, err := s.pendingReorgDB.PendingReorg()
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve pending reorg")
+	}
+	if reorgInfo == nil {
+		return nil
+	}
+	log.WithField("slot", reorgInfo.NewSlot).Warn("Resuming reorg which was interrupted by the previous shutdown")
+	_, err = s.revertReorg(reorgInfo)
+	return err
+}
+
+func (s *Service) savePendingReorg(reorgInfo *types.Reorg) error {
+	if s.pendingReorgDB == nil {
+		return nil
+	}
+	return s.pendingReorgDB.SavePendingReorg(reorgInfo)
+}
+
+func (s *Service) clearPendingReorg() error {
+	if s.pendingReorgDB == nil {
+		return nil
+	}
+	return s.pendingReorgDB.DeletePendingReorg()
+}
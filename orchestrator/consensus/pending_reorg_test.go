@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_ResumePendingReorg(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	for slot := uint64(4); slot <= 7; slot++ {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, &types.SlotInfo{
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 1}),
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 2}),
+		}))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(context.Background(), 7))
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestFinalizedSlot(4))
+
+	// nothing is pending without a pending reorg db
+	require.NoError(t, svc.resumePendingReorg())
+	assert.Equal(t, uint64(7), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	// reorg was detected and recorded, then the previous run stopped before the revert was committed
+	svc.pendingReorgDB = svc.verifiedSlotInfoDB.(db.PendingReorgDB)
+	svc.reorgHistoryDB = svc.verifiedSlotInfoDB.(db.ReorgHistoryDB)
+	reorgInfo := &types.Reorg{NewSlot: 6, VanParentHash: []byte{5, 1}, PanParentHash: []byte{5, 2}}
+	require.NoError(t, svc.pendingReorgDB.SavePendingReorg(reorgInfo))
+	require.NotNil(t, svc.recordReorg(reorgInfo, 4))
+
+	require.NoError(t, svc.resumePendingReorg())
+	assert.Equal(t, uint64(4), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(5)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+
+	pending, err := svc.pendingReorgDB.PendingReorg()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.Reorg)(nil), pending)
+
+	// the unresolved record is resolved instead of recording the reorg twice
+	records, err := svc.reorgHistoryDB.ReorgHistory(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.NotEqual(t, int64(0), records[0].ResolvedAt)
+}
@@ -0,0 +1,183 @@
+package consensus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// pipelinePruneSize is the number of tracked slots above which the slots below the committed slot are dropped
+const pipelinePruneSize = 4 * headLaneDistance
+
+// verdict is the outcome of the stateless checks of a matched slot, computed ahead of the db commit stage
+type verdict struct {
+	headerHash      common.Hash
+	vanBlockHash    common.Hash
+	mismatchedField string
+}
+
+// verificationJob is a matched slot of the verification stage. Its verdict is set before done is closed.
+type verificationJob struct {
+	slot         uint64
+	header       *eth1Types.Header
+	vanShardInfo *types.VanguardShardInfo
+	verdict      *verdict
+	done         chan struct{}
+}
+
+// verificationPipeline runs the consensus service as three stages. The matching stage pairs pandora headers and
+// vanguard shard infos by slot as soon as they are received, the verification stage hashes and compares the matched
+// slots with a pool of workers, and the consensus loop is the single stage which commits to db. The commit stage
+// takes the verdicts in slot order and waits for the verdict of a slot which is still in the verification stage,
+// so bursts of slots are hashed and compared in parallel while the loop only writes their outcome.
+type verificationPipeline struct {
+	lock    sync.Mutex
+	ctx     context.Context
+	headers map[uint64]*eth1Types.Header
+	shards  map[uint64]*types.VanguardShardInfo
+	// matched are the jobs of the verification stage by slot, until the commit stage takes them
+	matched map[uint64]*verificationJob
+	jobs    chan *verificationJob
+}
+
+func newVerificationPipeline(ctx context.Context, workers int) *verificationPipeline {
+	return &verificationPipeline{
+		ctx:     ctx,
+		headers: make(map[uint64]*eth1Types.Header),
+		shards:  make(map[uint64]*types.VanguardShardInfo),
+		matched: make(map[uint64]*verificationJob),
+		jobs:    make(chan *verificationJob, workers*int(headLaneDistance)),
+	}
+}
+
+// start runs the verification workers until the context of the pipeline is cancelled
+func (p *verificationPipeline) start(workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case job := <-p.jobs:
+					job.verdict = &verdict{
+						headerHash:      job.header.Hash(),
+						vanBlockHash:    common.BytesToHash(job.vanShardInfo.BlockHash[:]),
+						mismatchedField: shardInfoMismatch(job.header, job.vanShardInfo.ShardInfo),
+					}
+					close(job.done)
+				case <-p.ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// observeHeader is the matching stage of the pandora header
+func (p *verificationPipeline) observeHeader(slot uint64, header *eth1Types.Header) {
+	p.lock.Lock()
+	p.headers[slot] = header
+	job := p.match(slot)
+	p.lock.Unlock()
+	p.enqueue(job)
+}
+
+// observeShardInfo is the matching stage of the vanguard shard info
+func (p *verificationPipeline) observeShardInfo(slot uint64, vanShardInfo *types.VanguardShardInfo) {
+	p.lock.Lock()
+	p.shards[slot] = vanShardInfo
+	job := p.match(slot)
+	p.lock.Unlock()
+	p.enqueue(job)
+}
+
+// match returns the job of the slot once both sides are received, otherwise nil. Caller must hold the lock.
+func (p *verificationPipeline) match(slot uint64) *verificationJob {
+	header, vanShardInfo := p.headers[slot], p.shards[slot]
+	if header == nil || vanShardInfo == nil {
+		return nil
+	}
+	delete(p.headers, slot)
+	delete(p.shards, slot)
+	job := &verificationJob{slot: slot, header: header, vanShardInfo: vanShardInfo, done: make(chan struct{})}
+	p.matched[slot] = job
+	return job
+}
+
+// enqueue hands the job over to the workers. A full queue holds back the matching stage, so every matched slot
+// reaches the verification stage.
+func (p *verificationPipeline) enqueue(job *verificationJob) {
+	if job == nil {
+		return
+	}
+	select {
+	case p.jobs <- job:
+	case <-p.ctx.Done():
+	}
+}
+
+// take returns the verdict of the slot when it was computed for the same header and shard info, otherwise nil. It
+// waits while the slot is in the verification stage.
+func (p *verificationPipeline) take(slot uint64, headerHash common.Hash, vanBlockHash common.Hash) *verdict {
+	p.lock.Lock()
+	job := p.matched[slot]
+	delete(p.matched, slot)
+	if len(p.headers)+len(p.shards)+len(p.matched) > pipelinePruneSize {
+		p.prune(slot)
+	}
+	p.lock.Unlock()
+
+	if job == nil {
+		return nil
+	}
+	select {
+	case <-job.done:
+	case <-p.ctx.Done():
+		return nil
+	}
+	v := job.verdict
+	if v.headerHash != headerHash || v.vanBlockHash != vanBlockHash {
+		return nil
+	}
+	return v
+}
+
+// prune drops the unmatched sides and untaken jobs of the slots up to the given slot. Caller must hold the lock.
+func (p *verificationPipeline) prune(slot uint64) {
+	for s := range p.headers {
+		if s <= slot {
+			delete(p.headers, s)
+		}
+	}
+	for s := range p.shards {
+		if s <= slot {
+			delete(p.shards, s)
+		}
+	}
+	for s := range p.matched {
+		if s <= slot {
+			delete(p.matched, s)
+		}
+	}
+}
+
+// purge drops every tracked slot. Jobs which are still queued are completed by the workers and never taken.
+func (p *verificationPipeline) purge() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.headers = make(map[uint64]*eth1Types.Header)
+	p.shards = make(map[uint64]*types.VanguardShardInfo)
+	p.matched = make(map[uint64]*verificationJob)
+}
+
+// mismatchedField returns the first mismatched field of the slot, from the precomputed verdict when there is one
+func (s *Service) mismatchedField(slot uint64, headerHash common.Hash, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) string {
+	if s.pipeline != nil {
+		if v := s.pipeline.take(slot, headerHash, common.BytesToHash(vanShardInfo.BlockHash[:])); v != nil {
+			precomputedVerdictsCounter.Inc(1)
+			return v.mismatchedField
+		}
+	}
+	return shardInfoMismatch(header, vanShardInfo.ShardInfo)
+}
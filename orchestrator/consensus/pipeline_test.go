@@ -0,0 +1,84 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestVerificationPipeline_MatchAndTake(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipeline := newVerificationPipeline(ctx, 2)
+	pipeline.start(2)
+
+	header := testutil.NewEth1Header(5)
+	vanShardInfo := testutil.NewVanguardShardInfo(5, header)
+	vanBlockHash := common.BytesToHash(vanShardInfo.BlockHash[:])
+	// unmatched side is not verified
+	pipeline.observeHeader(5, header)
+	assert.Equal(t, (*verdict)(nil), pipeline.take(5, header.Hash(), vanBlockHash))
+
+	pipeline.observeHeader(5, header)
+	pipeline.observeShardInfo(5, vanShardInfo)
+	// commit stage waits for the verdict of the matched slot
+	taken := pipeline.take(5, header.Hash(), vanBlockHash)
+	require.NotNil(t, taken)
+	assert.Equal(t, "", taken.mismatchedField)
+	// verdict is consumed
+	assert.Equal(t, (*verdict)(nil), pipeline.take(5, header.Hash(), vanBlockHash))
+
+	// verdict of another header is not used
+	pipeline.observeHeader(5, header)
+	pipeline.observeShardInfo(5, vanShardInfo)
+	mismatching := testutil.NewEth1Header(5)
+	mismatching.Time++
+	assert.Equal(t, (*verdict)(nil), pipeline.take(5, mismatching.Hash(), vanBlockHash))
+}
+
+func TestVerificationPipeline_NoDroppedJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipeline := newVerificationPipeline(ctx, 1)
+	slots := uint64(cap(pipeline.jobs)) * 2
+	// matching stage is held back until the workers start
+	go func() {
+		for slot := uint64(1); slot <= slots; slot++ {
+			header := testutil.NewEth1Header(slot)
+			pipeline.observeHeader(slot, header)
+			pipeline.observeShardInfo(slot, testutil.NewVanguardShardInfo(slot, header))
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+	pipeline.start(1)
+
+	for slot := uint64(1); slot <= slots; slot++ {
+		header := testutil.NewEth1Header(slot)
+		vanShardInfo := testutil.NewVanguardShardInfo(slot, header)
+		var v *verdict
+		for deadline := time.Now().Add(5 * time.Second); v == nil && time.Now().Before(deadline); {
+			v = pipeline.take(slot, header.Hash(), common.BytesToHash(vanShardInfo.BlockHash[:]))
+			if v == nil {
+				time.Sleep(time.Millisecond)
+			}
+		}
+		require.NotNil(t, v, "verdict of slot %d is not computed", slot)
+	}
+}
+
+func TestVerificationPipeline_Prune(t *testing.T) {
+	pipeline := newVerificationPipeline(context.Background(), 1)
+	for slot := uint64(1); slot <= pipelinePruneSize+1; slot++ {
+		pipeline.observeHeader(slot, testutil.NewEth1Header(slot))
+	}
+	pipeline.take(10, common.Hash{}, common.Hash{})
+	assert.Equal(t, int(pipelinePruneSize+1-10), len(pipeline.headers))
+
+	pipeline.purge()
+	assert.Equal(t, 0, len(pipeline.headers))
+}
@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// bufferedSlot is a slot which has both pandora header and vanguard shard info but arrived ahead of its parent
+type bufferedSlot struct {
+	slot         uint64
+	vanShardInfo *types.VanguardShardInfo
+	header       *eth1Types.Header
+}
+
+// reorderBuffer holds slots which arrived ahead of their parent for up to window slots
+type reorderBuffer struct {
+	window      uint64
+	highestSlot uint64
+	slots       map[uint64]*bufferedSlot
+}
+
+func newReorderBuffer(window uint64) *reorderBuffer {
+	return &reorderBuffer{
+		window: window,
+		slots:  make(map[uint64]*bufferedSlot),
+	}
+}
+
+// put stores the slot into the buffer. Previous value of the same slot is replaced.
+func (b *reorderBuffer) put(slot uint64, vanShardInfo *types.VanguardShardInfo, header *eth1Types.Header) {
+	b.slots[slot] = &bufferedSlot{
+		slot:         slot,
+		vanShardInfo: vanShardInfo,
+		header:       header,
+	}
+	b.observe(slot)
+}
+
+// observe moves the highest seen slot forward which makes old buffered slots expire
+func (b *reorderBuffer) observe(slot uint64) {
+	if slot > b.highestSlot {
+		b.highestSlot = slot
+	}
+}
+
+// pop returns the lowest buffered slot which is a child of parentHash. When there is no such slot, it returns
+// the lowest slot which stayed in the buffer for the whole reordering window. Returns nil when nothing can be released.
+func (b *reorderBuffer) pop(parentHash common.Hash) *bufferedSlot {
+	slots := make([]uint64, 0, len(b.slots))
+	for slot := range b.slots {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	for _, slot := range slots {
+		if b.slots[slot].header.ParentHash == parentHash {
+			return b.remove(slot)
+		}
+	}
+	for _, slot := range slots {
+		if slot+b.window <= b.highestSlot {
+			return b.remove(slot)
+		}
+	}
+	return nil
+}
+
+func (b *reorderBuffer) remove(slot uint64) *bufferedSlot {
+	bs := b.slots[slot]
+	delete(b.slots, slot)
+	return bs
+}
+
+func (b *reorderBuffer) len() int {
+	return len(b.slots)
+}
+
+// purge removes all the buffered slots
+func (b *reorderBuffer) purge() {
+	b.slots = make(map[uint64]*bufferedSlot)
+	b.highestSlot = 0
+}
@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestReorderBuffer_PopChildOfParent(t *testing.T) {
+	buffer := newReorderBuffer(4)
+	parent := testutil.NewEth1Header(1)
+	child := testutil.NewEth1Header(2)
+	child.ParentHash = parent.Hash()
+	grandChild := testutil.NewEth1Header(3)
+	grandChild.ParentHash = child.Hash()
+
+	buffer.put(3, testutil.NewVanguardShardInfo(3, grandChild), grandChild)
+	buffer.put(2, testutil.NewVanguardShardInfo(2, child), child)
+
+	// nothing can be released before the parent is verified
+	assert.Equal(t, (*bufferedSlot)(nil), buffer.pop(common.Hash{}))
+
+	released := buffer.pop(parent.Hash())
+	assert.NotNil(t, released)
+	assert.Equal(t, uint64(2), released.slot)
+
+	released = buffer.pop(child.Hash())
+	assert.NotNil(t, released)
+	assert.Equal(t, uint64(3), released.slot)
+	assert.Equal(t, 0, buffer.len())
+}
+
+func TestReorderBuffer_PopExpired(t *testing.T) {
+	buffer := newReorderBuffer(2)
+	header := testutil.NewEth1Header(5)
+	buffer.put(5, testutil.NewVanguardShardInfo(5, header), header)
+
+	buffer.observe(6)
+	assert.Equal(t, (*bufferedSlot)(nil), buffer.pop(common.Hash{}))
+
+	buffer.observe(7)
+	released := buffer.pop(common.Hash{})
+	assert.NotNil(t, released)
+	assert.Equal(t, uint64(5), released.slot)
+
+	buffer.put(8, testutil.NewVanguardShardInfo(8, header), header)
+	buffer.purge()
+	assert.Equal(t, 0, buffer.len())
+}
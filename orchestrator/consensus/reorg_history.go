@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// recordReorg stores the reorg before the verified chain is reverted, so that a reorg which fails to revert is kept
+// as unresolved. The unresolved record of a resumed reorg is reused. It returns nil when reorg history is disabled.
+func (s *Service) recordReorg(reorgInfo *types.Reorg, revertSlot uint64) *types.ReorgRecord {
+	if s.reorgHistoryDB == nil {
+		return nil
+	}
+	if record := s.unresolvedReorg(reorgInfo); record != nil {
+		return record
+	}
+	record := &types.ReorgRecord{
+		Slot:            reorgInfo.NewSlot,
+		VanParentHash:   reorgInfo.VanParentHash,
+		PanParentHash:   reorgInfo.PanParentHash,
+		OldHeadSlot:     s.verifiedSlotInfoDB.LatestSavedVerifiedSlot(),
+		OldHeadHash:     s.verifiedSlotInfoDB.LatestVerifiedHeaderHash(),
+		RevertSlot:      revertSlot,
+		NewParentStepId: s.stepId(revertSlot),
+		DetectedAt:      time.Now().Unix(),
+	}
+	if record.OldHeadSlot > revertSlot {
+		record.Depth = record.OldHeadSlot - revertSlot
+	}
+	if err := s.reorgHistoryDB.SaveReorg(record); err != nil {
+		log.WithError(err).WithField("slot", record.Slot).Warn("Failed to store reorg history")
+		return nil
+	}
+	return record
+}
+
+// resolveReorg marks the stored reorg as resolved once the verified chain is reverted
+func (s *Service) resolveReorg(record *types.ReorgRecord) {
+	if record == nil {
+		return
+	}
+	record.ResolvedAt = time.Now().Unix()
+	if err := s.reorgHistoryDB.SaveReorg(record); err != nil {
+		log.WithError(err).WithField("slot", record.Slot).Warn("Failed to resolve reorg history")
+	}
+}
+
+// unresolvedReorg returns the stored record of the reorg when it has not been resolved yet
+func (s *Service) unresolvedReorg(reorgInfo *types.Reorg) *types.ReorgRecord {
+	records, err := s.reorgHistoryDB.ReorgHistory(reorgInfo.NewSlot, 0)
+	if err != nil {
+		log.WithError(err).WithField("slot", reorgInfo.NewSlot).Warn("Failed to retrieve reorg history")
+		return nil
+	}
+	for _, record := range records {
+		if record.Slot != reorgInfo.NewSlot {
+			break
+		}
+		if record.ResolvedAt == 0 && bytes.Equal(record.VanParentHash, reorgInfo.VanParentHash) &&
+			bytes.Equal(record.PanParentHash, reorgInfo.PanParentHash) {
+			return record
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_ReorgHistory(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+
+	// disabled history records nothing
+	assert.Equal(t, (*types.ReorgRecord)(nil), svc.recordReorg(&types.Reorg{NewSlot: 12}, 4))
+
+	headHash := common.BytesToHash([]byte{9})
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(context.Background(), 10))
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedHeaderHash(headHash))
+	svc.reorgHistoryDB = svc.verifiedSlotInfoDB.(db.ReorgHistoryDB)
+
+	record := svc.recordReorg(&types.Reorg{NewSlot: 12, VanParentHash: []byte{1}, PanParentHash: []byte{2}}, 4)
+	require.NotNil(t, record)
+	records, err := svc.reorgHistoryDB.ReorgHistory(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, uint64(10), records[0].OldHeadSlot)
+	assert.Equal(t, headHash, records[0].OldHeadHash)
+	assert.Equal(t, uint64(6), records[0].Depth)
+	assert.Equal(t, int64(0), records[0].ResolvedAt)
+
+	svc.resolveReorg(record)
+	records, err = svc.reorgHistoryDB.ReorgHistory(12, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.NotEqual(t, int64(0), records[0].ResolvedAt)
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"context"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// RepublishConfirmation sends the verified status of the slot to the subscribers again, so that a pandora node which
+// missed the original confirmation is not stuck waiting for it
+func (s *Service) RepublishConfirmation(slot uint64) (*types.SlotInfoWithStatus, error) {
+	slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, errors.Errorf("slot %d is not verified", slot)
+	}
+	return s.republish(slot, slotInfo), nil
+}
+
+// RepublishConfirmations sends the verified status of every verified slot of [fromSlot, toSlot] again in slot order.
+// Slots which are not verified are skipped.
+func (s *Service) RepublishConfirmations(fromSlot, toSlot uint64) ([]*types.SlotInfoWithStatus, error) {
+	slotInfos, err := s.verifiedSlotInfoDB.VerifiedSlotInfoRange(fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*types.SlotInfoWithStatus, 0, len(slotInfos))
+	for slot := fromSlot; slot <= toSlot && len(statuses) < len(slotInfos); slot++ {
+		if slotInfo, ok := slotInfos[slot]; ok {
+			statuses = append(statuses, s.republish(slot, slotInfo))
+		}
+	}
+	return statuses, nil
+}
+
+func (s *Service) republish(slot uint64, slotInfo *types.SlotInfo) *types.SlotInfoWithStatus {
+	status := &types.SlotInfoWithStatus{
+		Slot:              slot,
+		VanguardBlockHash: slotInfo.VanguardBlockHash,
+		PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+		StepId:            s.stepId(slot),
+		Status:            types.Verified,
+	}
+	s.verifiedSlotInfoFeed.Send(status)
+	log.WithField("slot", slot).WithField("headerHash", slotInfo.PandoraHeaderHash).Info("Republished confirmation")
+	return status
+}
+
+// republishTask sends the confirmations of the deferred task's slot range again
+func (s *Service) republishTask(ctx context.Context, task *types.DeferredTask) error {
+	_, err := s.RepublishConfirmations(task.FromSlot, task.ToSlot)
+	return err
+}
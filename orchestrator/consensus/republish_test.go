@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_RepublishConfirmation(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	slotInfos := map[uint64]*types.SlotInfo{
+		2: {VanguardBlockHash: common.HexToHash("0x21"), PandoraHeaderHash: common.HexToHash("0x22")},
+		4: {VanguardBlockHash: common.HexToHash("0x41"), PandoraHeaderHash: common.HexToHash("0x42")},
+	}
+	for slot, slotInfo := range slotInfos {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo))
+	}
+
+	statusCh := make(chan *types.SlotInfoWithStatus, 4)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(statusCh)
+	defer sub.Unsubscribe()
+
+	status, err := svc.RepublishConfirmation(2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, status, <-statusCh)
+	assert.Equal(t, types.Verified, status.Status)
+	assert.Equal(t, slotInfos[2].PandoraHeaderHash, status.PandoraHeaderHash)
+	_, err = svc.RepublishConfirmation(3)
+	assert.ErrorContains(t, "slot 3 is not verified", err)
+
+	// range form skips the slots which are not verified
+	statuses, err := svc.RepublishConfirmations(1, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(statuses))
+	for _, want := range []uint64{2, 4} {
+		status := <-statusCh
+		assert.Equal(t, want, status.Slot)
+		assert.Equal(t, slotInfos[want].VanguardBlockHash, status.VanguardBlockHash)
+	}
+}
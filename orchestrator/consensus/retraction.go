@@ -0,0 +1,55 @@
+package consensus
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// retractableSlots returns the verified slot infos above the revert slot, which are orphaned once the verified
+// chain is reverted. It must be called before the db is reverted.
+func (s *Service) retractableSlots(revertSlot uint64) map[uint64]*types.SlotInfo {
+	latestSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	if latestSlot <= revertSlot {
+		return nil
+	}
+	slotInfos, err := s.verifiedSlotInfoDB.VerifiedSlotInfoRange(revertSlot+1, latestSlot)
+	if err != nil {
+		log.WithError(err).WithField("revertSlot", revertSlot).Warn("Failed to read verified slots which are retracted")
+		return nil
+	}
+	return slotInfos
+}
+
+// publishRetractions tells the subscribers that the orphaned slots are no longer verified. Retractions are sent
+// from the highest slot down, so that pandora can unwind its chain from the tip. replacedBy is the pandora parent
+// hash of the new chain.
+func (s *Service) publishRetractions(slotInfos map[uint64]*types.SlotInfo, reorgInfo *types.Reorg) {
+	if len(slotInfos) == 0 {
+		return
+	}
+	var replacedBy *common.Hash
+	if len(reorgInfo.PanParentHash) > 0 {
+		hash := common.BytesToHash(reorgInfo.PanParentHash)
+		replacedBy = &hash
+	}
+
+	slots := make([]uint64, 0, len(slotInfos))
+	for slot := range slotInfos {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] > slots[j] })
+	for _, slot := range slots {
+		slotInfo := slotInfos[slot]
+		s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
+			Slot:              slot,
+			VanguardBlockHash: slotInfo.VanguardBlockHash,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			Status:            types.Retracted,
+			ReplacedBy:        replacedBy,
+		})
+	}
+	log.WithField("fromSlot", slots[len(slots)-1]).WithField("toSlot", slots[0]).WithField("slots", len(slotInfos)).
+		Info("Retracted orphaned verified slots")
+}
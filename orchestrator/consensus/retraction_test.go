@@ -0,0 +1,46 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_PublishRetractions(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	defer svc.Stop()
+	slotInfos := map[uint64]*types.SlotInfo{
+		4: {VanguardBlockHash: common.HexToHash("0x41"), PandoraHeaderHash: common.HexToHash("0x42")},
+		5: {VanguardBlockHash: common.HexToHash("0x51"), PandoraHeaderHash: common.HexToHash("0x52")},
+		7: {VanguardBlockHash: common.HexToHash("0x71"), PandoraHeaderHash: common.HexToHash("0x72")},
+	}
+	for slot, slotInfo := range slotInfos {
+		require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(slot, slotInfo))
+	}
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveLatestVerifiedSlot(context.Background(), 7))
+
+	// finalized slot is kept
+	orphaned := svc.retractableSlots(4)
+	require.Equal(t, 2, len(orphaned))
+	require.NoError(t, svc.reorgDB(4))
+	assert.Equal(t, 0, len(svc.retractableSlots(4)))
+
+	statusCh := make(chan *types.SlotInfoWithStatus, 4)
+	sub := svc.SubscribeVerifiedSlotInfoEvent(statusCh)
+	defer sub.Unsubscribe()
+
+	parentHash := common.HexToHash("0x99")
+	svc.publishRetractions(orphaned, &types.Reorg{NewSlot: 6, PanParentHash: parentHash.Bytes()})
+	for _, want := range []uint64{7, 5} {
+		status := <-statusCh
+		assert.Equal(t, want, status.Slot)
+		assert.Equal(t, types.Retracted, status.Status)
+		assert.Equal(t, slotInfos[want].PandoraHeaderHash, status.PandoraHeaderHash)
+		require.NotNil(t, status.ReplacedBy)
+		assert.Equal(t, parentHash, *status.ReplacedBy)
+	}
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -10,9 +11,14 @@ import (
 
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
 	iface2 "github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain/iface"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/accumulator"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
 )
 
 type Config struct {
@@ -23,6 +29,64 @@ type Config struct {
 
 	VanguardShardFeed iface.VanguardService
 	PandoraHeaderFeed iface2.PandoraService
+
+	// AccumulatorDB stores merkle accumulator over verified slot infos. Accumulator is disabled when it is nil.
+	AccumulatorDB db.AccumulatorDB
+
+	// CatchUpWriteDB batches db writes while verified slots are older than CatchUpDistance. Disabled when it is nil.
+	CatchUpWriteDB  db.CatchUpWriteDB
+	CatchUpDistance time.Duration
+
+	// ReorderWindow is the number of slots to hold a slot which arrived ahead of its parent. Zero disables reordering.
+	ReorderWindow uint64
+
+	// MaxFutureSlots is the number of slots which a pandora header may be dated ahead of the wall clock. Slots
+	// beyond it are parked until their slot time. Zero disables parking.
+	MaxFutureSlots uint64
+
+	// BatchWriteDB writes backlog slots in batches of BatchSize slots with a single transaction. Zero BatchSize
+	// disables batch verification.
+	BatchWriteDB db.VerifiedSlotBatchDB
+	BatchSize    uint64
+
+	// VerificationWorkers is the number of workers which check matched slots ahead of the db commit stage. Zero
+	// disables the pipeline, so slots are checked by the consensus loop.
+	VerificationWorkers int
+
+	// LaneBuffer bounds each verification lane, LaneOverflowPolicy applies when a lane is full. Zero LaneBuffer
+	// uses DefaultLaneBuffer.
+	LaneBuffer         int
+	LaneOverflowPolicy pandorachain.OverflowPolicy
+
+	// EpochSummaryDB stores the summary of every finished epoch. Summaries are disabled when it is nil.
+	EpochSummaryDB db.EpochSummaryDB
+
+	// LifetimeStatsDB keeps slot and reorg counters across restarts. Lifetime stats are disabled when it is nil.
+	LifetimeStatsDB db.LifetimeStatsDB
+
+	// ReorgHistoryDB keeps every detected reorg. Reorg history is disabled when it is nil.
+	ReorgHistoryDB db.ReorgHistoryDB
+
+	// PendingReorgDB keeps the reorg until the verified chain is reverted, so that a reorg which is interrupted by a
+	// restart is resumed at next start. Interrupted reorgs are lost when it is nil.
+	PendingReorgDB db.PendingReorgDB
+
+	// Tasks republishes confirmations which could not be delivered to pandora. Undelivered confirmations are not
+	// retried when it is nil.
+	Tasks taskqueue.Queue
+
+	// ShutdownDB records the clean shutdown of the service. The previous shutdown is not checked when it is nil.
+	ShutdownDB db.ShutdownMarkerDB
+
+	// GenesisShardInfo seeds the genesis slot of an empty verified db. When it is nil, the first pandora header is
+	// accepted without a verified parent.
+	GenesisShardInfo *types.SlotInfo
+
+	// Checkpoint seeds an empty verified db from a trusted finalized slot instead of the genesis, once
+	// CheckpointVanguard and CheckpointPandora agree with it. Slots up to the checkpoint are not verified.
+	Checkpoint         *types.TrustedCheckpoint
+	CheckpointVanguard CheckpointVanguard
+	CheckpointPandora  CheckpointPandora
 }
 
 // Service This part could be moved to other place during refactor, might be registered as a service
@@ -32,6 +96,8 @@ type Service struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	runError       error
+	// loopDone is closed when the consensus loop has returned
+	loopDone chan struct{}
 
 	scope                        event.SubscriptionScope
 	verifiedSlotInfoDB           db.VerifiedSlotInfoDB
@@ -43,6 +109,50 @@ type Service struct {
 	pandoraService       iface2.PandoraService
 	verifiedSlotInfoFeed event.Feed
 	reorgInProgress      bool
+	reorderBuffer        *reorderBuffer
+	futureQueue          *futureQueue
+	lanes                *verificationLanes
+	// heldSlots is only accessed by the consensus loop
+	heldSlots map[uint64]struct{}
+	// head is only accessed by the consensus loop
+	head verifiedHead
+	// finalizedPublished is the latest slot which is published as Finalized. It is only accessed by the consensus loop
+	finalizedPublished uint64
+
+	accumulatorDB db.AccumulatorDB
+	accumulator   *accumulator.Accumulator
+
+	catchUpWriteDB  db.CatchUpWriteDB
+	catchUpDistance time.Duration
+	catchUpEpoch    uint64
+
+	batchWriteDB db.VerifiedSlotBatchDB
+	// batch is only accessed by the consensus loop
+	batch *verifyBatch
+
+	epochSummaryDB   db.EpochSummaryDB
+	epochSummaryFeed event.Feed
+
+	shardConflictFeed event.Feed
+	// tally is only accessed by the consensus loop
+	tally epochTally
+
+	genesis *types.SlotInfo
+
+	checkpoint         *types.TrustedCheckpoint
+	checkpointVanguard CheckpointVanguard
+	checkpointPandora  CheckpointPandora
+
+	shutdownDB db.ShutdownMarkerDB
+
+	lifetimeStatsDB db.LifetimeStatsDB
+	lifetime        *lifetimeStats
+
+	reorgHistoryDB db.ReorgHistoryDB
+	pendingReorgDB db.PendingReorgDB
+
+	pipeline        *verificationPipeline
+	pipelineWorkers int
 }
 
 //
@@ -50,7 +160,27 @@ func New(ctx context.Context, cfg *Config) (service *Service) {
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
-	return &Service{
+	var buffer *reorderBuffer
+	if cfg.ReorderWindow > 0 {
+		buffer = newReorderBuffer(cfg.ReorderWindow)
+	}
+
+	var future *futureQueue
+	if cfg.MaxFutureSlots > 0 {
+		future = newFutureQueue(time.Duration(cfg.MaxFutureSlots*params.SecondsPerSlot) * time.Second)
+	}
+
+	var batch *verifyBatch
+	if cfg.BatchSize > 0 && cfg.BatchWriteDB != nil {
+		batch = newVerifyBatch(cfg.BatchSize)
+	}
+
+	var pipeline *verificationPipeline
+	if cfg.VerificationWorkers > 0 {
+		pipeline = newVerificationPipeline(ctx, cfg.VerificationWorkers)
+	}
+
+	service = &Service{
 		ctx:                          ctx,
 		cancel:                       cancel,
 		verifiedSlotInfoDB:           cfg.VerifiedSlotInfoDB,
@@ -59,7 +189,32 @@ func New(ctx context.Context, cfg *Config) (service *Service) {
 		pandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 		vanguardService:              cfg.VanguardShardFeed,
 		pandoraService:               cfg.PandoraHeaderFeed,
+		reorderBuffer:                buffer,
+		futureQueue:                  future,
+		lanes:                        newVerificationLanes(cfg.LaneBuffer, cfg.LaneOverflowPolicy),
+		heldSlots:                    make(map[uint64]struct{}),
+		accumulatorDB:                cfg.AccumulatorDB,
+		catchUpWriteDB:               cfg.CatchUpWriteDB,
+		catchUpDistance:              cfg.CatchUpDistance,
+		batchWriteDB:                 cfg.BatchWriteDB,
+		batch:                        batch,
+		epochSummaryDB:               cfg.EpochSummaryDB,
+		genesis:                      cfg.GenesisShardInfo,
+		checkpoint:                   cfg.Checkpoint,
+		checkpointVanguard:           cfg.CheckpointVanguard,
+		checkpointPandora:            cfg.CheckpointPandora,
+		shutdownDB:                   cfg.ShutdownDB,
+		lifetimeStatsDB:              cfg.LifetimeStatsDB,
+		lifetime:                     newLifetimeStats(),
+		reorgHistoryDB:               cfg.ReorgHistoryDB,
+		pendingReorgDB:               cfg.PendingReorgDB,
+		pipeline:                     pipeline,
+		pipelineWorkers:              cfg.VerificationWorkers,
+	}
+	if cfg.Tasks != nil {
+		cfg.Tasks.Handle(taskqueue.RepublishTask, service.republishTask)
 	}
+	return service
 }
 
 func (s *Service) Start() {
@@ -68,7 +223,46 @@ func (s *Service) Start() {
 		return
 	}
 	s.isRunning = true
+	if err := s.checkPreviousShutdown(); err != nil {
+		log.WithError(err).Error("Failed to check shutdown marker of previous run")
+		s.runError = err
+		return
+	}
+	if err := s.loadLifetimeStats(); err != nil {
+		log.WithError(err).Error("Failed to load lifetime stats")
+		s.runError = err
+		return
+	}
+	if err := s.reconcileInProgressSlots(); err != nil {
+		log.WithError(err).Error("Failed to roll back half-written slots")
+		s.runError = err
+		return
+	}
+	if err := s.loadAccumulator(); err != nil {
+		log.WithError(err).Error("Failed to load verified-chain accumulator")
+		s.runError = err
+		return
+	}
+	if err := s.resumePendingReorg(); err != nil {
+		log.WithError(err).Error("Failed to resume interrupted reorg")
+		s.runError = err
+		return
+	}
+	if err := s.seedCheckpoint(); err != nil {
+		log.WithError(err).Error("Failed to seed checkpoint")
+		s.runError = err
+		return
+	}
+	if err := s.seedGenesis(); err != nil {
+		log.WithError(err).Error("Failed to seed genesis slot")
+		s.runError = err
+		return
+	}
+	// finalized slots of the previous run are not published again, subscribers get them from the slot status
+	s.finalizedPublished = s.finalizedVerifiedSlot()
+	s.loopDone = make(chan struct{})
 	go func() {
+		defer close(s.loopDone)
 		log.Info("Starting consensus service")
 		vanShardInfoCh := make(chan *types.VanguardShardInfo, 1)
 		reorgSignalCh := make(chan *types.Reorg, 1)
@@ -77,56 +271,56 @@ func (s *Service) Start() {
 		vanShardInfoSub := s.vanguardService.SubscribeShardInfoEvent(vanShardInfoCh)
 		vanShutdownSub := s.vanguardService.SubscribeShutdownSignalEvent(reorgSignalCh)
 		panHeaderInfoSub := s.pandoraService.SubscribeHeaderInfoEvent(panHeaderInfoCh)
+		defer func() {
+			vanShardInfoSub.Unsubscribe()
+			vanShutdownSub.Unsubscribe()
+			panHeaderInfoSub.Unsubscribe()
+		}()
+
+		if s.pipeline != nil {
+			s.pipeline.start(s.pipelineWorkers)
+		}
+		// intake drains the subscriptions into the lanes, so that head slots are not stuck behind the backfill.
+		// It is stopped when the loop returns, also when the loop fails.
+		intakeCtx, stopIntake := context.WithCancel(s.ctx)
+		defer stopIntake()
+		go s.intake(intakeCtx, panHeaderInfoCh, vanShardInfoCh)
 
 		for {
 			select {
-			case newPanHeaderInfo := <-panHeaderInfoCh:
-
-				if s.reorgInProgress {
-					log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
+			case <-s.lanes.wake():
+				if s.lanes.hasOverflowed() {
+					if err := s.resubscribeLanes(); err != nil {
+						log.WithField("error", err).Error("error found while renewing overflowed subscriptions")
+						return
+					}
 					continue
 				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
-					if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
-						log.WithField("slot", newPanHeaderInfo.Slot).
-							WithField("headerHash", newPanHeaderInfo.Header.Hash()).
-							Info("Pandora header is already in verified slot info db")
-
-						s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
-							VanguardBlockHash: slotInfo.VanguardBlockHash,
-							PandoraHeaderHash: slotInfo.PandoraHeaderHash,
-							Status:            types.Verified,
-						})
-
-						continue
-					}
+				item := s.lanes.pop()
+				if item == nil {
+					continue
 				}
-
-				if err := s.processPandoraHeader(newPanHeaderInfo); err != nil {
-					log.WithField("error", err).Error("error found while processing pandora header")
+				if item.headerInfo != nil {
+					if err := s.handlePandoraHeader(item.headerInfo); err != nil {
+						log.WithField("error", err).Error("error found while processing pandora header")
+						return
+					}
+				} else if err := s.handleVanguardShardInfo(item.vanShardInfo); err != nil {
+					log.WithField("error", err).Error("error found while processing vanguard sharding info")
 					return
 				}
-			case newVanShardInfo := <-vanShardInfoCh:
-
-				if s.reorgInProgress {
-					log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
-					continue
+				if err := s.releaseHeldSlots(); err != nil {
+					log.WithField("error", err).Error("error found while processing held slots")
+					return
 				}
-
-				if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
-					blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
-					if slotInfo.VanguardBlockHash == blockHashHex {
-						log.WithField("slot", newVanShardInfo.Slot).
-							WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
-							Info("Vanguard shard info is already in verified slot info db")
-
-						continue
-					}
+			case <-s.batchExpired():
+				if err := s.flushBatch(); err != nil {
+					log.WithField("error", err).Error("error found while flushing expired batch")
+					return
 				}
-
-				if err := s.processVanguardShardInfo(newVanShardInfo); err != nil {
-					log.WithField("error", err).Error("error found while processing vanguard sharding info")
+			case <-s.futureQueue.wake():
+				if err := s.releaseFutureSlots(); err != nil {
+					log.WithField("error", err).Error("error found while processing future slots")
 					return
 				}
 			case reorgInfo := <-reorgSignalCh:
@@ -136,18 +330,27 @@ func (s *Service) Start() {
 				}
 				s.reorgInProgress = true
 				// reorg happened. So remove info from database
-				finalizedSlot := s.verifiedSlotInfoDB.LatestLatestFinalizedSlot()
-				finalizedEpoch := s.verifiedSlotInfoDB.LatestLatestFinalizedEpoch()
-				log.WithField("curSlot", reorgInfo.NewSlot).WithField("revertSlot", finalizedSlot).
-					WithField("finalizedEpoch", finalizedEpoch).Warn("Triggered reorg event")
-
-				if err := s.reorgDB(finalizedSlot); err != nil {
+				revertSlot, err := s.revertReorg(reorgInfo)
+				if err != nil {
 					log.WithError(err).Warn("Failed to revert verified info db, exiting consensus go routine")
 					return
 				}
-				// Removing slot infos from vanguard cache and pandora cache
-				s.vanguardPendingShardingCache.Purge()
-				s.pandoraPendingHeaderCache.Purge()
+				// Removing slot infos of the reverted chain from vanguard cache and pandora cache
+				s.dropRevertedPending(revertSlot)
+				if s.reorderBuffer != nil {
+					s.reorderBuffer.purge()
+				}
+				if s.futureQueue != nil {
+					s.futureQueue.purge()
+				}
+				if s.batch != nil {
+					s.batch.purge()
+				}
+				if s.pipeline != nil {
+					s.pipeline.purge()
+				}
+				s.lanes.purge()
+				s.heldSlots = make(map[uint64]struct{})
 				log.Debug("Starting subscription for vanguard and pandora")
 
 				// disconnect subscription
@@ -157,9 +360,6 @@ func (s *Service) Start() {
 
 				s.reorgInProgress = false
 			case <-s.ctx.Done():
-				vanShardInfoSub.Unsubscribe()
-				vanShutdownSub.Unsubscribe()
-				panHeaderInfoSub.Unsubscribe()
 				log.Info("Received cancelled context,closing existing consensus service")
 				return
 			}
@@ -167,11 +367,92 @@ func (s *Service) Start() {
 	}()
 }
 
+// intake queues the received pandora headers and vanguard shard infos into the verification lanes
+func (s *Service) intake(
+	ctx context.Context,
+	panHeaderInfoCh <-chan *types.PandoraHeaderInfo,
+	vanShardInfoCh <-chan *types.VanguardShardInfo,
+) {
+	for {
+		select {
+		case newPanHeaderInfo := <-panHeaderInfoCh:
+			if s.pipeline != nil {
+				s.pipeline.observeHeader(newPanHeaderInfo.Slot, newPanHeaderInfo.Header)
+			}
+			s.lanes.pushHeader(newPanHeaderInfo)
+		case newVanShardInfo := <-vanShardInfoCh:
+			if s.pipeline != nil {
+				s.pipeline.observeShardInfo(newVanShardInfo.Slot, newVanShardInfo)
+			}
+			s.lanes.pushShardInfo(newVanShardInfo)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handlePandoraHeader skips the already verified header, otherwise it processes the header
+func (s *Service) handlePandoraHeader(newPanHeaderInfo *types.PandoraHeaderInfo) error {
+	if s.reorgInProgress {
+		log.WithField("slot", newPanHeaderInfo.Slot).Info("Reorg is progressing, so skipping new pandora header")
+		return nil
+	}
+
+	// child of the verified head can't be verified yet, so it skips the duplicate lookup
+	if !s.isHeadChild(newPanHeaderInfo.Slot, newPanHeaderInfo.Header) {
+		if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newPanHeaderInfo.Slot); slotInfo != nil {
+			if slotInfo.PandoraHeaderHash == newPanHeaderInfo.Header.Hash() {
+				log.WithField("slot", newPanHeaderInfo.Slot).
+					WithField("headerHash", newPanHeaderInfo.Header.Hash()).
+					Info("Pandora header is already in verified slot info db")
+
+				s.verifiedSlotInfoFeed.Send(&types.SlotInfoWithStatus{
+					Slot:              newPanHeaderInfo.Slot,
+					VanguardBlockHash: slotInfo.VanguardBlockHash,
+					PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+					StepId:            s.stepId(newPanHeaderInfo.Slot),
+					Status:            types.Verified,
+				})
+				return nil
+			}
+		}
+	}
+	return s.processPandoraHeader(newPanHeaderInfo)
+}
+
+// handleVanguardShardInfo skips the already verified shard info, otherwise it processes the shard info
+func (s *Service) handleVanguardShardInfo(newVanShardInfo *types.VanguardShardInfo) error {
+	if s.reorgInProgress {
+		log.WithField("slot", newVanShardInfo.Slot).Info("Reorg is progressing, so skipping new vanguard shard")
+		return nil
+	}
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(newVanShardInfo.Slot); slotInfo != nil {
+		blockHashHex := common.BytesToHash(newVanShardInfo.BlockHash[:])
+		if slotInfo.VanguardBlockHash == blockHashHex {
+			log.WithField("slot", newVanShardInfo.Slot).
+				WithField("shardInfoHash", hexutil.Encode(newVanShardInfo.ShardInfo.Hash)).
+				Info("Vanguard shard info is already in verified slot info db")
+			return nil
+		}
+	}
+	return s.processVanguardShardInfo(newVanShardInfo)
+}
+
+// Stop cancels the consensus loop and drains it before the db is closed
 func (s *Service) Stop() error {
 	if s.cancel != nil {
-		defer s.cancel()
+		s.cancel()
 	}
-	return nil
+	if s.loopDone == nil {
+		return nil
+	}
+	select {
+	case <-s.loopDone:
+	case <-time.After(loopDrainTimeout):
+		return errors.New("timed out waiting for consensus loop to stop")
+	}
+	return s.drain()
 }
 
 func (s *Service) Status() error {
@@ -73,3 +73,64 @@ func TestService(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ReconcileInProgressSlots(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	svc, _ := setup(ctx, t)
+	defer svc.Stop()
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 4)
+	for i := range headerInfos {
+		require.NoError(t, svc.verifyShardingInfo(headerInfos[i].Slot, shardInfos[i], headerInfos[i].Header))
+	}
+	// crash happened while slot 3 was written
+	require.NoError(t, svc.verifiedSlotInfoDB.MarkSlotInProgress(3))
+	require.NoError(t, svc.pandoraPendingHeaderCache.Put(ctx, 2, headerInfos[1].Header))
+	require.NoError(t, svc.pandoraPendingHeaderCache.Put(ctx, 3, headerInfos[2].Header))
+
+	require.NoError(t, svc.reconcileInProgressSlots())
+	assert.LogsContain(t, hook, "Found half-written slots of previous run")
+
+	slotInfo, err := svc.verifiedSlotInfoDB.VerifiedSlotInfo(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	assert.Equal(t, uint64(2), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, headerInfos[1].Header.Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	// pending entries of rolled back slots are invalidated
+	_, err = svc.pandoraPendingHeaderCache.Get(ctx, 3)
+	assert.ErrorContains(t, "Invalid slot", err)
+	_, err = svc.pandoraPendingHeaderCache.Get(ctx, 2)
+	require.NoError(t, err)
+
+	slots, err := svc.verifiedSlotInfoDB.InProgressSlots()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slots))
+}
+
+func TestService_DropRevertedPending(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setup(ctx, t)
+	defer svc.Stop()
+
+	headerInfos, shardInfos := getHeaderInfosAndShardInfos(1, 7)
+	for i := range headerInfos {
+		require.NoError(t, svc.pandoraPendingHeaderCache.Put(ctx, headerInfos[i].Slot, headerInfos[i].Header))
+		require.NoError(t, svc.vanguardPendingShardingCache.Put(ctx, shardInfos[i].Slot, shardInfos[i]))
+	}
+
+	// reorg reverts the chain to slot 4
+	svc.dropRevertedPending(4)
+	for slot := uint64(1); slot <= 4; slot++ {
+		_, err := svc.pandoraPendingHeaderCache.Get(ctx, slot)
+		require.NoError(t, err)
+		_, err = svc.vanguardPendingShardingCache.Get(ctx, slot)
+		require.NoError(t, err)
+	}
+	for slot := uint64(5); slot <= 6; slot++ {
+		_, err := svc.pandoraPendingHeaderCache.Get(ctx, slot)
+		assert.ErrorContains(t, "Invalid slot", err)
+		_, err = svc.vanguardPendingShardingCache.Get(ctx, slot)
+		assert.ErrorContains(t, "Invalid slot", err)
+	}
+}
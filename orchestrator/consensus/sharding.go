@@ -9,17 +9,35 @@ import (
 	eth2Types "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 )
 
+// fields of sharding info which are compared
+const (
+	fieldBlockNumber = "blockNumber"
+	fieldHash        = "hash"
+	fieldParentHash  = "parentHash"
+	fieldStateRoot   = "stateRoot"
+	fieldTxHash      = "txHash"
+	fieldReceiptHash = "receiptHash"
+	fieldExtraData   = "extraData"
+	fieldSignature   = "signature"
+)
+
 func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool {
+	return shardInfoMismatch(ph, vs) == ""
+}
+
+// shardInfoMismatch returns the first field which does not match between pandora header and vanguard
+// shard info. Empty string means sharding info is matched.
+func shardInfoMismatch(ph *eth1Types.Header, vs *eth2Types.PandoraShard) string {
 	if ph == nil && vs == nil {
 		// in existing code this will happen. as some part may have no sharding info for testing.
-		return true
+		return ""
 	}
 
 	if vs.BlockNumber != ph.Number.Uint64() {
 		log.WithField("pandora data block number", ph.Number.Uint64()).
 			WithField("vanguard block number", vs.BlockNumber).
 			Error("block number mismatched")
-		return false
+		return fieldBlockNumber
 	}
 
 	// match header hash
@@ -27,7 +45,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora header hash", ph.Hash()).
 			WithField("vanguard header hash", hexutil.Encode(vs.GetHash())).
 			Error("header hash mismatched")
-		return false
+		return fieldHash
 	}
 
 	// match parent hash
@@ -35,7 +53,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora data parent hash", ph.ParentHash).
 			WithField("vanguard parent hash", hexutil.Encode(vs.ParentHash)).
 			Error("parent hash mismatched")
-		return false
+		return fieldParentHash
 	}
 
 	// match state root hash
@@ -43,7 +61,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora data root hash", ph.Root).
 			WithField("vanguard state root hash", hexutil.Encode(vs.StateRoot)).
 			Error("state root hash mismatched")
-		return false
+		return fieldStateRoot
 	}
 
 	// match TxHash
@@ -51,7 +69,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora data tx hash", ph.TxHash).
 			WithField("vanguard tx hash", hexutil.Encode(vs.TxHash)).
 			Error("tx hash mismatched")
-		return false
+		return fieldTxHash
 	}
 
 	// match receiptHash
@@ -59,7 +77,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora data receipt hash", ph.ReceiptHash).
 			WithField("vanguard receipt hash", hexutil.Encode(vs.ReceiptHash)).
 			Error("receipt hash mismatched")
-		return false
+		return fieldReceiptHash
 	}
 
 	// retrieve extra data
@@ -68,7 +86,7 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 	if nil != err {
 		log.WithField("error", err).
 			Error("error converting extra data to extraDataWithSig")
-		return false
+		return fieldExtraData
 	}
 
 	// match signature
@@ -76,8 +94,38 @@ func CompareShardingInfo(ph *eth1Types.Header, vs *eth2Types.PandoraShard) bool
 		log.WithField("pandora data signature", hexutil.Encode(pandoraExtraDataWithSig.BlsSignatureBytes.Bytes())).
 			WithField("vanguard signature", hexutil.Encode(vs.GetSignature())).
 			Error("signature mismatched")
-		return false
+		return fieldSignature
 	}
 
-	return true
+	return ""
+}
+
+// newShardDisagreement captures both sides of the mismatched sharding info
+func newShardDisagreement(slot uint64, field string, ph *eth1Types.Header, vs *eth2Types.PandoraShard) *types.ShardDisagreement {
+	disagreement := &types.ShardDisagreement{
+		Slot:  slot,
+		Field: field,
+	}
+	if ph != nil {
+		pandoraFields := &types.ShardInfoFields{
+			Hash:        ph.Hash(),
+			ParentHash:  ph.ParentHash,
+			StateRoot:   ph.Root,
+			TxHash:      ph.TxHash,
+			ReceiptHash: ph.ReceiptHash,
+		}
+		if ph.Number != nil {
+			pandoraFields.BlockNumber = ph.Number.Uint64()
+		}
+		pandoraExtraDataWithSig := new(types.PanExtraDataWithBLSSig)
+		if err := rlp.DecodeBytes(ph.Extra, pandoraExtraDataWithSig); err == nil {
+			pandoraFields.Signature = pandoraExtraDataWithSig.BlsSignatureBytes.Bytes()
+		}
+		disagreement.Pandora = pandoraFields
+		disagreement.PandoraExtraData = common.CopyBytes(ph.Extra)
+	}
+	if vs != nil {
+		disagreement.Vanguard = types.NewShardInfoFields(vs)
+	}
+	return disagreement
 }
@@ -1 +1,37 @@
 package consensus
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	eth2Types "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+func TestShardInfoMismatch(t *testing.T) {
+	header := &eth1Types.Header{
+		Number:     big.NewInt(10),
+		ParentHash: common.HexToHash("0x01"),
+		TxHash:     common.HexToHash("0x02"),
+	}
+	shard := &eth2Types.PandoraShard{
+		BlockNumber: 11,
+		Hash:        header.Hash().Bytes(),
+		ParentHash:  header.ParentHash.Bytes(),
+	}
+	assert.Equal(t, fieldBlockNumber, shardInfoMismatch(header, shard))
+	assert.Equal(t, false, CompareShardingInfo(header, shard))
+
+	shard.BlockNumber = 10
+	shard.Hash = common.HexToHash("0x03").Bytes()
+	assert.Equal(t, fieldHash, shardInfoMismatch(header, shard))
+
+	disagreement := newShardDisagreement(5, fieldHash, header, shard)
+	assert.Equal(t, uint64(5), disagreement.Slot)
+	assert.Equal(t, fieldHash, disagreement.Field)
+	assert.Equal(t, header.Hash(), disagreement.Pandora.Hash)
+	assert.Equal(t, common.HexToHash("0x03"), disagreement.Vanguard.Hash)
+	assert.Equal(t, header.TxHash, disagreement.Pandora.TxHash)
+}
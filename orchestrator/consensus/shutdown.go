@@ -0,0 +1,58 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// loopDrainTimeout is the time which Stop waits for the slot in verification to be finished
+const loopDrainTimeout = 30 * time.Second
+
+// checkPreviousShutdown consumes the clean shutdown marker of the previous run. Half-written slots of an unclean
+// shutdown are rolled back by reconcileInProgressSlots.
+func (s *Service) checkPreviousShutdown() error {
+	if s.shutdownDB == nil {
+		return nil
+	}
+	clean, err := s.shutdownDB.ConsumeCleanShutdown()
+	if err != nil {
+		return err
+	}
+	if !clean {
+		log.WithField("latestVerifiedSlot", s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()).
+			Warn("Previous run was not shut down cleanly, checking for half-written slots")
+		uncleanShutdownsCounter.Inc(1)
+	}
+	return nil
+}
+
+// drain makes the pending writes of the stopped consensus loop durable and marks the clean shutdown. Shutdown is
+// not marked clean when a slot is left half-written, so that it is rolled back at next start.
+func (s *Service) drain() error {
+	if err := s.flushBatch(); err != nil {
+		return errors.Wrap(err, "could not flush verified slot batch")
+	}
+	if err := s.persistLifetimeStats(); err != nil {
+		return errors.Wrap(err, "could not store lifetime stats")
+	}
+	if err := s.exitCatchUpMode(); err != nil {
+		return errors.Wrap(err, "could not sync catch-up writes")
+	}
+	if s.shutdownDB == nil {
+		return nil
+	}
+	slots, err := s.verifiedSlotInfoDB.InProgressSlots()
+	if err != nil {
+		return err
+	}
+	if len(slots) > 0 {
+		log.WithField("inProgressSlots", slots).Warn("Slots are left half-written, shutdown is not marked clean")
+		return nil
+	}
+	if err := s.shutdownDB.SaveCleanShutdown(); err != nil {
+		return errors.Wrap(err, "could not save clean shutdown marker")
+	}
+	log.Info("Drained consensus service")
+	return nil
+}
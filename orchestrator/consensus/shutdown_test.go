@@ -0,0 +1,54 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestService_GracefulShutdown(t *testing.T) {
+	svc, _ := setup(context.Background(), t)
+	shutdownDB := svc.verifiedSlotInfoDB.(db.ShutdownMarkerDB)
+	svc.shutdownDB = shutdownDB
+	svc.batchWriteDB = svc.verifiedSlotInfoDB.(db.VerifiedSlotBatchDB)
+	svc.batch = newVerifyBatch(8)
+
+	backlogTime := uint64(time.Now().Add(-time.Hour).Unix())
+	headers := make([]*eth1Types.Header, 4)
+	for slot := uint64(1); slot < 4; slot++ {
+		headers[slot] = testutil.NewEth1Header(slot)
+		headers[slot].Time = backlogTime + slot*6
+		if slot > 1 {
+			headers[slot].ParentHash = headers[slot-1].Hash()
+		}
+		require.NoError(t, svc.verifyOrBuffer(slot, testutil.NewVanguardShardInfo(slot, headers[slot]), headers[slot]))
+	}
+	assert.Equal(t, 2, len(svc.batch.slots))
+	assert.Equal(t, uint64(1), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+
+	unclean := uncleanShutdownsCounter.Count()
+	svc.Start()
+	require.NoError(t, svc.Status())
+	assert.Equal(t, unclean+1, uncleanShutdownsCounter.Count())
+
+	// stopping flushes the pending batch before the shutdown is marked clean
+	require.NoError(t, svc.Stop())
+	assert.Equal(t, uint64(3), svc.verifiedSlotInfoDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, headers[3].Hash(), svc.verifiedSlotInfoDB.LatestVerifiedHeaderHash())
+	clean, err := shutdownDB.ConsumeCleanShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, true, clean)
+
+	// half-written slot is rolled back at next start, so shutdown is not marked clean
+	require.NoError(t, svc.verifiedSlotInfoDB.MarkSlotInProgress(4))
+	require.NoError(t, svc.drain())
+	clean, err = shutdownDB.ConsumeCleanShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, false, clean)
+}
@@ -0,0 +1,63 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// VerifyHeaders checks pandora headers of external callers against the vanguard shard info of their slots with the
+// same checks as the consensus loop. Nothing is stored, so headers can be checked before they are broadcast.
+func (s *Service) VerifyHeaders(headers []*eth1Types.Header) []*types.HeaderVerification {
+	results := make([]*types.HeaderVerification, len(headers))
+	for i, header := range headers {
+		results[i] = s.verifyHeader(header)
+	}
+	return results
+}
+
+// verifyHeader is pending while neither the slot is verified nor the vanguard shard info of the slot is received
+func (s *Service) verifyHeader(header *eth1Types.Header) *types.HeaderVerification {
+	result := &types.HeaderVerification{Hash: header.Hash()}
+	extraData := new(types.PanExtraDataWithBLSSig)
+	if err := rlp.DecodeBytes(header.Extra, extraData); err != nil {
+		result.Status = types.Invalid
+		result.Reason = fmt.Sprintf("could not decode extraData: %v", err)
+		return result
+	}
+	result.Slot = extraData.Slot
+
+	if slotInfo, _ := s.verifiedSlotInfoDB.VerifiedSlotInfo(result.Slot); slotInfo != nil {
+		if slotInfo.PandoraHeaderHash == result.Hash {
+			result.Status = types.Verified
+			return result
+		}
+		result.Status = types.Invalid
+		result.Reason = fmt.Sprintf("slot %d is verified with header %s", result.Slot, slotInfo.PandoraHeaderHash)
+		return result
+	}
+
+	vanShardInfo, _ := s.vanguardPendingShardingCache.Get(context.Background(), result.Slot)
+	if vanShardInfo == nil {
+		result.Status = types.Pending
+		result.Reason = fmt.Sprintf("vanguard shard info of slot %d is not received yet", result.Slot)
+		return result
+	}
+	if vanShardInfo.ShardInfo == nil {
+		result.Status = types.Invalid
+		result.Reason = fmt.Sprintf("vanguard block %s has no shard info",
+			common.BytesToHash(vanShardInfo.BlockHash[:]))
+		return result
+	}
+	if field := shardInfoMismatch(header, vanShardInfo.ShardInfo); field != "" {
+		result.Status = types.Invalid
+		result.Reason = fmt.Sprintf("%s mismatched with vanguard shard info", field)
+		return result
+	}
+	result.Status = types.Verified
+	return result
+}
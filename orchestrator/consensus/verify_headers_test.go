@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_VerifyHeaders(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setup(ctx, t)
+	defer svc.Stop()
+
+	verified := testutil.NewEth1Header(1)
+	require.NoError(t, svc.verifiedSlotInfoDB.SaveVerifiedSlotInfo(1, &types.SlotInfo{
+		PandoraHeaderHash: verified.Hash(),
+		VanguardBlockHash: common.HexToHash("0x01"),
+	}))
+	conflicting := testutil.NewEth1Header(1)
+	conflicting.Time++
+
+	pending := testutil.NewEth1Header(2)
+
+	matching := testutil.NewEth1Header(3)
+	require.NoError(t, svc.vanguardPendingShardingCache.Put(ctx, 3, testutil.NewVanguardShardInfo(3, matching)))
+
+	mismatching := testutil.NewEth1Header(4)
+	require.NoError(t, svc.vanguardPendingShardingCache.Put(ctx, 4, testutil.NewVanguardShardInfo(4, testutil.NewEth1Header(4))))
+	mismatching.Time++
+
+	malformed := testutil.NewEth1Header(5)
+	malformed.Extra = []byte{0x01, 0x02}
+
+	results := svc.VerifyHeaders([]*eth1Types.Header{verified, conflicting, pending, matching, mismatching, malformed})
+	require.Equal(t, 6, len(results))
+	want := []struct {
+		slot   uint64
+		status types.Status
+		reason string
+	}{
+		{1, types.Verified, ""},
+		{1, types.Invalid, "slot 1 is verified with header"},
+		{2, types.Pending, "vanguard shard info of slot 2 is not received yet"},
+		{3, types.Verified, ""},
+		{4, types.Invalid, "hash mismatched with vanguard shard info"},
+		{0, types.Invalid, "could not decode extraData"},
+	}
+	for i, result := range results {
+		assert.Equal(t, want[i].slot, result.Slot, i)
+		assert.Equal(t, want[i].status, result.Status, i)
+		if want[i].reason == "" {
+			assert.Equal(t, "", result.Reason, i)
+		} else {
+			assert.Equal(t, true, strings.Contains(result.Reason, want[i].reason), result.Reason)
+		}
+	}
+
+	// nothing is stored by the verification
+	slotInfo, err := svc.invalidSlotInfoDB.InvalidSlotInfo(4)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	slotInfo, err = svc.verifiedSlotInfoDB.VerifiedSlotInfo(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+}
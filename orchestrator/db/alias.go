@@ -14,4 +14,40 @@ type VerifiedSlotInfoDB = iface.VerifiedSlotDatabase
 
 type InvalidSlotInfoDB = iface.InvalidSlotDatabase
 
+type VerifiedSlotBatchDB = iface.VerifiedSlotBatchDatabase
+
+type ConfirmationAckDB = iface.ConfirmationAckDatabase
+
+type ROnlyAccumulatorDB = iface.ReadOnlyAccumulatorDatabase
+
+type AccumulatorDB = iface.AccumulatorDatabase
+
+type ROnlyEpochSummaryDB = iface.ReadOnlyEpochSummaryDatabase
+
+type EpochSummaryDB = iface.EpochSummaryDatabase
+
+type ROnlyLifetimeStatsDB = iface.ReadOnlyLifetimeStatsDatabase
+
+type LifetimeStatsDB = iface.LifetimeStatsDatabase
+
+type ROnlyReorgHistoryDB = iface.ReadOnlyReorgHistoryDatabase
+
+type ReorgHistoryDB = iface.ReorgHistoryDatabase
+
+type PendingReorgDB = iface.PendingReorgDatabase
+
+type ROnlyDeferredTaskDB = iface.ReadOnlyDeferredTaskDatabase
+
+type DeferredTaskDB = iface.DeferredTaskDatabase
+
+type CatchUpWriteDB = iface.CatchUpWriteDatabase
+
+type DiskPressureDB = iface.DiskPressureDatabase
+
+type RetentionDB = iface.RetentionDatabase
+
+type ShutdownMarkerDB = iface.ShutdownMarkerDatabase
+
+type SnapshotDB = iface.SnapshotDatabase
+
 type Database = iface.Database
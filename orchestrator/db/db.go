@@ -8,6 +8,9 @@ import (
 // Assure that Store implements Database interface
 var _ Database = &kv.Store{}
 
+// ErrEpochInfoPruned is returned for epoch infos which are pruned from the db
+var ErrEpochInfoPruned = kv.ErrEpochInfoPruned
+
 // NewDB initializes a new DB.
 func NewDB(ctx context.Context, dirPath string, config *kv.Config) (Database, error) {
 	return kv.NewKVStore(ctx, dirPath, config)
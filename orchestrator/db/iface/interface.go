@@ -11,7 +11,11 @@ import (
 type ReadOnlyConsensusInfoDatabase interface {
 	ConsensusInfo(ctx context.Context, epoch uint64) (*types.MinimalEpochConsensusInfo, error)
 	ConsensusInfos(fromEpoch uint64) ([]*types.MinimalEpochConsensusInfo, error)
+	ConsensusInfoRange(fromEpoch, toEpoch uint64) ([]*types.MinimalEpochConsensusInfo, error)
+	ConsensusInfoSource(epoch uint64) (*types.EpochInfoSource, error)
 	LatestSavedEpoch() uint64
+	// EarliestConsensusInfoEpoch returns the first epoch whose consensus info is not pruned
+	EarliestConsensusInfoEpoch() uint64
 }
 
 // ConsensusInfoAccessDatabase
@@ -20,15 +24,22 @@ type ConsensusInfoAccessDatabase interface {
 
 	SaveConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfo) error
 	SaveLatestEpoch(ctx context.Context, epoch uint64) error
+	SaveConsensusInfoSource(epoch uint64, source *types.EpochInfoSource) error
 }
 
 type ReadOnlyVerifiedSlotInfoDatabase interface {
 	VerifiedSlotInfo(slot uint64) (*types.SlotInfo, error)
+	SeekSlotInfo(slot uint64) (uint64, *types.SlotInfo, error)
 	VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo, error)
 	LatestSavedVerifiedSlot() uint64
 	LatestVerifiedHeaderHash() common.Hash
 	LatestLatestFinalizedSlot() uint64
 	LatestLatestFinalizedEpoch() uint64
+	VerifiedSlotInfoRange(fromSlot, toSlot uint64) (map[uint64]*types.SlotInfo, error)
+	SlotByPandoraHeaderHash(hash common.Hash) (uint64, bool, error)
+	SlotByVanguardBlockHash(hash common.Hash) (uint64, bool, error)
+	SlotByPandoraBlockNumber(number uint64) (uint64, bool, error)
+	IsArchive() bool
 }
 
 type VerifiedSlotDatabase interface {
@@ -37,20 +48,157 @@ type VerifiedSlotDatabase interface {
 	SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
 	SaveLatestVerifiedSlot(ctx context.Context, slot uint64) error
 	SaveLatestVerifiedHeaderHash(hash common.Hash) error
+	SavePandoraBlockNumber(number, slot uint64) error
 	SaveLatestFinalizedSlot(latestFinalizedSlot uint64) error
 	SaveLatestFinalizedEpoch(latestFinalizedEpoch uint64) error
 	RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error
 	UpdateVerifiedSlotInfo(slot uint64) error
+
+	MarkSlotInProgress(slot uint64) error
+	ClearSlotInProgress(slot uint64) error
+	InProgressSlots() ([]uint64, error)
+}
+
+// VerifiedSlotBatchDatabase commits a range of verified slots at once while the node verifies its backlog
+type VerifiedSlotBatchDatabase interface {
+	SaveVerifiedSlotBatch(batch *types.VerifiedSlotBatch) error
 }
 
 type ReadOnlyInvalidSlotInfoDatabase interface {
 	InvalidSlotInfo(slots uint64) (*types.SlotInfo, error)
+	ShardDisagreement(slot uint64) (*types.ShardDisagreement, error)
+	ShardDisagreements(fromSlot uint64, limit int) ([]*types.ShardDisagreement, error)
+	ShardEquivocations(fromSlot uint64, limit int) ([]*types.ShardEquivocation, error)
+	ShardConflicts(fromSlot uint64, limit int) ([]*types.ShardConflict, error)
 }
 
 type InvalidSlotDatabase interface {
 	ReadOnlyInvalidSlotInfoDatabase
 
 	SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
+	SaveShardDisagreement(disagreement *types.ShardDisagreement) error
+	SaveShardEquivocation(equivocation *types.ShardEquivocation) error
+	SaveShardConflict(conflict *types.ShardConflict) error
+}
+
+type ReadOnlyConfirmationAckDatabase interface {
+	LatestAckedSlot() uint64
+	ConsumerAckedSlot(consumer string) uint64
+}
+
+type ConfirmationAckDatabase interface {
+	ReadOnlyConfirmationAckDatabase
+
+	SaveLatestAckedSlot(slot uint64) error
+	SaveConsumerAckedSlot(consumer string, slot uint64) error
+}
+
+type ReadOnlyAccumulatorDatabase interface {
+	AccumulatorStep(slot uint64) (*types.AccumulatorStep, error)
+	LatestAccumulatorStep() (*types.AccumulatorStep, error)
+	AccumulatorLeaves(count uint64) ([]common.Hash, error)
+	AccumulatorLeafRange(fromIndex, count uint64) ([]common.Hash, error)
+}
+
+// AccumulatorDatabase stores the merkle accumulator over verified slot infos
+type AccumulatorDatabase interface {
+	ReadOnlyAccumulatorDatabase
+
+	SaveAccumulatorStep(step *types.AccumulatorStep) error
+}
+
+type ReadOnlyEpochSummaryDatabase interface {
+	EpochSummary(epoch uint64) (*types.EpochSummary, error)
+}
+
+// EpochSummaryDatabase stores the summary of every finished epoch
+type EpochSummaryDatabase interface {
+	ReadOnlyEpochSummaryDatabase
+
+	SaveEpochSummary(summary *types.EpochSummary) error
+}
+
+type ReadOnlyLifetimeStatsDatabase interface {
+	LifetimeStats() (*types.LifetimeStats, error)
+}
+
+// LifetimeStatsDatabase keeps the counters of all runs of the node
+type LifetimeStatsDatabase interface {
+	ReadOnlyLifetimeStatsDatabase
+
+	SaveLifetimeStats(stats *types.LifetimeStats) error
+}
+
+// ChainIdentityDatabase keeps the network identity of pandora and vanguard nodes pinned on first connection
+type ChainIdentityDatabase interface {
+	PandoraChainIdentity() (*types.PandoraChainIdentity, error)
+	SavePandoraChainIdentity(identity *types.PandoraChainIdentity) error
+	VanguardGenesisValidatorsRoot() ([]byte, error)
+	SaveVanguardGenesisValidatorsRoot(root []byte) error
+}
+
+// CatchUpWriteDatabase syncs db writes to disk in batches while the node is catching up
+type CatchUpWriteDatabase interface {
+	EnterCatchUpMode(committedSlot uint64) error
+	CommitCatchUp(slot uint64) error
+	ExitCatchUpMode() error
+	IsCatchUpMode() bool
+}
+
+// DiskPressureDatabase lets the disk guard shrink db growth when free disk space runs low
+type DiskPressureDatabase interface {
+	Size() (int64, error)
+	PauseDiagnostics(paused bool)
+	PruneDiagnostics(beforeSlot uint64) (int, error)
+}
+
+// RetentionDatabase removes verified slots and consensus infos which are older than the retention
+type RetentionDatabase interface {
+	PruneVerifiedSlots(beforeSlot uint64) (int, error)
+	PruneConsensusInfos(beforeEpoch uint64) (int, error)
+}
+
+// ShutdownMarkerDatabase records whether the node stopped after flushing its pending writes
+type ShutdownMarkerDatabase interface {
+	SaveCleanShutdown() error
+	ConsumeCleanShutdown() (bool, error)
+}
+
+// PendingReorgDatabase keeps the reorg which the verified chain is being reverted for until the revert is committed
+type PendingReorgDatabase interface {
+	SavePendingReorg(reorgInfo *types.Reorg) error
+	PendingReorg() (*types.Reorg, error)
+	DeletePendingReorg() error
+}
+
+type ReadOnlyReorgHistoryDatabase interface {
+	ReorgHistory(fromSlot uint64, limit int) ([]*types.ReorgRecord, error)
+	ReorgHistoryFrom(fromSlot, fromId uint64, limit int) ([]*types.ReorgRecord, error)
+}
+
+// ReorgHistoryDatabase keeps every detected reorg for debugging chain splits
+type ReorgHistoryDatabase interface {
+	ReadOnlyReorgHistoryDatabase
+
+	SaveReorg(record *types.ReorgRecord) error
+}
+
+type ReadOnlyDeferredTaskDatabase interface {
+	DeferredTasks() ([]*types.DeferredTask, error)
+}
+
+// DeferredTaskDatabase persists the task queue of deferred work, so that pending tasks survive restarts
+type DeferredTaskDatabase interface {
+	ReadOnlyDeferredTaskDatabase
+
+	EnqueueTask(task *types.DeferredTask) error
+	SaveTask(task *types.DeferredTask) error
+	DeleteTask(id uint64) error
+}
+
+// SnapshotDatabase exports a consistent copy of the db
+type SnapshotDatabase interface {
+	Snapshot(file string) error
 }
 
 // Database interface with full access.
@@ -61,8 +209,37 @@ type Database interface {
 
 	VerifiedSlotDatabase
 
+	VerifiedSlotBatchDatabase
+
 	InvalidSlotDatabase
 
+	ConfirmationAckDatabase
+
+	ChainIdentityDatabase
+
+	AccumulatorDatabase
+
+	EpochSummaryDatabase
+
+	LifetimeStatsDatabase
+
+	ReorgHistoryDatabase
+
+	PendingReorgDatabase
+
+	DeferredTaskDatabase
+
+	CatchUpWriteDatabase
+
+	DiskPressureDatabase
+
+	RetentionDatabase
+
+	ShutdownMarkerDatabase
+
+	SnapshotDatabase
+
 	DatabasePath() string
+	SchemaVersion() uint64
 	ClearDB() error
 }
@@ -0,0 +1,120 @@
+package kv
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveAccumulatorStep stores the accumulator leaf and the accumulator root of the verified slot
+func (s *Store) SaveAccumulatorStep(step *types.AccumulatorStep) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		enc, err := s.codec.encode(step)
+		if err != nil {
+			return err
+		}
+		leafBkt := tx.Bucket(accumulatorLeavesBucket)
+		if err := leafBkt.Put(bytesutil.Uint64ToBytesBigEndian(step.LeafIndex), step.Leaf.Bytes()); err != nil {
+			return err
+		}
+		stepBkt := tx.Bucket(accumulatorStepsBucket)
+		return stepBkt.Put(bytesutil.Uint64ToBytesBigEndian(step.Slot), enc)
+	})
+}
+
+// AccumulatorStep returns the accumulator step of the given slot. Returns nil when the slot is not accumulated.
+func (s *Store) AccumulatorStep(slot uint64) (*types.AccumulatorStep, error) {
+	var step *types.AccumulatorStep
+	err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(accumulatorStepsBucket)
+		enc := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &step)
+	})
+	return step, err
+}
+
+// LatestAccumulatorStep returns the accumulator step with the highest slot. Returns nil for empty accumulator.
+func (s *Store) LatestAccumulatorStep() (*types.AccumulatorStep, error) {
+	var step *types.AccumulatorStep
+	err := s.db.View(func(tx Tx) error {
+		_, enc := tx.Bucket(accumulatorStepsBucket).Cursor().Last()
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &step)
+	})
+	return step, err
+}
+
+// AccumulatorLeaves returns the first count leaves of the accumulator
+func (s *Store) AccumulatorLeaves(count uint64) ([]common.Hash, error) {
+	leaves := make([]common.Hash, 0, count)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(accumulatorLeavesBucket).Cursor()
+		for k, v := c.First(); k != nil && uint64(len(leaves)) < count; k, v = c.Next() {
+			leaves = append(leaves, common.BytesToHash(v))
+		}
+		return nil
+	})
+	return leaves, err
+}
+
+// AccumulatorLeafRange returns at most count leaves of the accumulator starting from the given leaf index
+func (s *Store) AccumulatorLeafRange(fromIndex, count uint64) ([]common.Hash, error) {
+	leaves := make([]common.Hash, 0, count)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(accumulatorLeavesBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromIndex)); k != nil && uint64(len(leaves)) < count; k, v = c.Next() {
+			leaves = append(leaves, common.BytesToHash(v))
+		}
+		return nil
+	})
+	return leaves, err
+}
+
+// removeAccumulatorSteps removes accumulator steps of [fromSlot, toSlot] and every leaf which was added by them
+func (s *Store) removeAccumulatorSteps(tx Tx, fromSlot, toSlot uint64) error {
+	stepBkt := tx.Bucket(accumulatorStepsBucket)
+	leafBkt := tx.Bucket(accumulatorLeavesBucket)
+
+	var stepKeys [][]byte
+	var fromLeafIndex uint64
+	c := stepBkt.Cursor()
+	for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil && bytesutil.BytesToUint64BigEndian(k) <= toSlot; k, v = c.Next() {
+		var step *types.AccumulatorStep
+		if err := s.codec.decode(v, &step); err != nil {
+			return err
+		}
+		if len(stepKeys) == 0 || step.LeafIndex < fromLeafIndex {
+			fromLeafIndex = step.LeafIndex
+		}
+		stepKeys = append(stepKeys, bytesutil.SafeCopyBytes(k))
+	}
+	if len(stepKeys) == 0 {
+		return nil
+	}
+	for _, k := range stepKeys {
+		if err := stepBkt.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	// leaves are appended in slot order, so all the leaves after the first removed one must be removed too
+	var leafKeys [][]byte
+	lc := leafBkt.Cursor()
+	for k, _ := lc.Seek(bytesutil.Uint64ToBytesBigEndian(fromLeafIndex)); k != nil; k, _ = lc.Next() {
+		leafKeys = append(leafKeys, bytesutil.SafeCopyBytes(k))
+	}
+	for _, k := range leafKeys {
+		if err := leafBkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_AccumulatorSteps(t *testing.T) {
+	db := setupDB(t, true)
+
+	step, err := db.LatestAccumulatorStep()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.AccumulatorStep)(nil), step)
+
+	for i := uint64(0); i < 10; i++ {
+		require.NoError(t, db.SaveAccumulatorStep(&types.AccumulatorStep{
+			Slot:      i + 1,
+			LeafIndex: i,
+			Leaf:      common.BytesToHash([]byte{byte(i)}),
+			Root:      common.BytesToHash([]byte{byte(i + 100)}),
+		}))
+	}
+
+	step, err = db.LatestAccumulatorStep()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), step.Slot)
+
+	leaves, err := db.AccumulatorLeaves(5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(leaves))
+	assert.Equal(t, common.BytesToHash([]byte{4}), leaves[4])
+	leaves, err = db.AccumulatorLeafRange(3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(leaves))
+	assert.Equal(t, common.BytesToHash([]byte{3}), leaves[0])
+
+	// removing verified slots reverts the accumulator too
+	require.NoError(t, db.RemoveRangeVerifiedInfo(6, 10))
+	step, err = db.LatestAccumulatorStep()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), step.Slot)
+
+	leaves, err = db.AccumulatorLeaves(10)
+	require.NoError(t, err)
+	assert.Equal(t, 5, len(leaves))
+}
@@ -0,0 +1,135 @@
+package kv
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// ValueSizeBounds are the upper bounds in bytes of the value size distribution of the usage report
+var ValueSizeBounds = []int{64, 256, 1024, 4096, 16384}
+
+// UsageReport is the key-space usage of every bucket of the db
+type UsageReport struct {
+	Time int64 `json:"time"`
+	// PreviousTime is the time of the previous analysis which the growth is calculated against. Zero when the db
+	// is analyzed for the first time.
+	PreviousTime int64          `json:"previousTime,omitempty"`
+	FileSize     int64          `json:"fileSize"`
+	Buckets      []*BucketUsage `json:"buckets"`
+}
+
+// BucketUsage is the key count, size, value size distribution and largest entries of a bucket
+type BucketUsage struct {
+	Name       string `json:"name"`
+	Keys       uint64 `json:"keys"`
+	KeyBytes   uint64 `json:"keyBytes"`
+	ValueBytes uint64 `json:"valueBytes"`
+	// ValueSizes counts the values whose size is up to the bound of the same index of ValueSizeBounds. The last
+	// count is of the larger values.
+	ValueSizes []uint64      `json:"valueSizes"`
+	Largest    []*EntryUsage `json:"largest"`
+	// Growth is nil when the bucket was not analyzed before
+	Growth *UsageGrowth `json:"growth,omitempty"`
+}
+
+// EntryUsage is the hex encoded key and the value size of an entry
+type EntryUsage struct {
+	Key  string `json:"key"`
+	Size int    `json:"size"`
+}
+
+// UsageGrowth is the change of key count and bytes of a bucket since the previous analysis
+type UsageGrowth struct {
+	Keys  int64 `json:"keys"`
+	Bytes int64 `json:"bytes"`
+}
+
+// usageBaseline is persisted by every analysis, so that the next one reports the growth
+type usageBaseline struct {
+	Time    int64                      `json:"time"`
+	Buckets map[string]*bucketBaseline `json:"buckets"`
+}
+
+type bucketBaseline struct {
+	Keys  uint64 `json:"keys"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// AnalyzeUsage walks every bucket and reports its usage with the top largest entries. Growth is reported against
+// the previous analysis, whose baseline is replaced by the current one.
+func (s *Store) AnalyzeUsage(top int) (*UsageReport, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	report := &UsageReport{Time: time.Now().Unix()}
+	err := s.db.Update(func(tx Tx) error {
+		report.FileSize = tx.Size()
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		var previous *usageBaseline
+		if enc := markerBkt.Get(usageBaselineKey); enc != nil {
+			if err := json.Unmarshal(enc, &previous); err != nil {
+				return err
+			}
+			report.PreviousTime = previous.Time
+		}
+
+		if err := tx.ForEach(func(name []byte, bkt Bucket) error {
+			usage, err := analyzeBucket(string(name), bkt, top)
+			if err != nil {
+				return err
+			}
+			if previous != nil {
+				if base, ok := previous.Buckets[usage.Name]; ok {
+					usage.Growth = &UsageGrowth{
+						Keys:  int64(usage.Keys) - int64(base.Keys),
+						Bytes: int64(usage.KeyBytes+usage.ValueBytes) - int64(base.Bytes),
+					}
+				}
+			}
+			report.Buckets = append(report.Buckets, usage)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		baseline := &usageBaseline{Time: report.Time, Buckets: make(map[string]*bucketBaseline, len(report.Buckets))}
+		for _, usage := range report.Buckets {
+			baseline.Buckets[usage.Name] = &bucketBaseline{Keys: usage.Keys, Bytes: usage.KeyBytes + usage.ValueBytes}
+		}
+		enc, err := json.Marshal(baseline)
+		if err != nil {
+			return err
+		}
+		return markerBkt.Put(usageBaselineKey, enc)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func analyzeBucket(name string, bkt Bucket, top int) (*BucketUsage, error) {
+	usage := &BucketUsage{Name: name, ValueSizes: make([]uint64, len(ValueSizeBounds)+1)}
+	err := bkt.ForEach(func(key, value []byte) error {
+		usage.Keys++
+		usage.KeyBytes += uint64(len(key))
+		usage.ValueBytes += uint64(len(value))
+		usage.ValueSizes[sort.SearchInts(ValueSizeBounds, len(value))]++
+
+		if top <= 0 || (len(usage.Largest) == top && len(value) <= usage.Largest[top-1].Size) {
+			return nil
+		}
+		entry := &EntryUsage{Key: hex.EncodeToString(key), Size: len(value)}
+		i := sort.Search(len(usage.Largest), func(i int) bool { return usage.Largest[i].Size < entry.Size })
+		usage.Largest = append(usage.Largest, nil)
+		copy(usage.Largest[i+1:], usage.Largest[i:])
+		usage.Largest[i] = entry
+		if len(usage.Largest) > top {
+			usage.Largest = usage.Largest[:top]
+		}
+		return nil
+	})
+	return usage, err
+}
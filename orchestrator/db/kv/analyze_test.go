@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_AnalyzeUsage(t *testing.T) {
+	db := setupDB(t, true)
+	for slot := uint64(1); slot <= 3; slot++ {
+		slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, slotInfo))
+	}
+	bucketUsage := func(report *UsageReport, name []byte) *BucketUsage {
+		for _, usage := range report.Buckets {
+			if usage.Name == string(name) {
+				return usage
+			}
+		}
+		t.Fatalf("bucket %s is not reported", name)
+		return nil
+	}
+
+	report, err := db.AnalyzeUsage(2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), report.PreviousTime)
+	assert.Equal(t, true, report.FileSize > 0)
+	usage := bucketUsage(report, verifiedSlotInfosBucket)
+	assert.Equal(t, uint64(3), usage.Keys)
+	assert.Equal(t, uint64(3*8), usage.KeyBytes)
+	assert.Equal(t, 2, len(usage.Largest))
+	assert.Equal(t, (*UsageGrowth)(nil), usage.Growth)
+	var counted uint64
+	for _, count := range usage.ValueSizes {
+		counted += count
+	}
+	assert.Equal(t, usage.Keys, counted)
+
+	// growth is reported against the previous analysis
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(4, slotInfo))
+	report, err = db.AnalyzeUsage(0)
+	require.NoError(t, err)
+	assert.Equal(t, true, report.PreviousTime > 0)
+	usage = bucketUsage(report, verifiedSlotInfosBucket)
+	assert.Equal(t, 0, len(usage.Largest))
+	require.NotNil(t, usage.Growth)
+	assert.Equal(t, int64(1), usage.Growth.Keys)
+	assert.Equal(t, int64(usage.KeyBytes+usage.ValueBytes)/4, usage.Growth.Bytes)
+}
+
+func TestAnalyzeBucket_Largest(t *testing.T) {
+	db := setupDB(t, true)
+	require.NoError(t, db.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(consumerAcksBucket)
+		for i, size := range []int{10, 300, 5, 20000, 300} {
+			if err := bkt.Put([]byte{byte(i)}, make([]byte, size)); err != nil {
+				return err
+			}
+		}
+		usage, err := analyzeBucket("acks", bkt, 3)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []*EntryUsage{{Key: "03", Size: 20000}, {Key: "01", Size: 300}, {Key: "04", Size: 300}}, usage.Largest)
+		assert.DeepEqual(t, []uint64{2, 0, 2, 0, 0, 1}, usage.ValueSizes)
+		return nil
+	}))
+}
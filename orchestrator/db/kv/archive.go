@@ -0,0 +1,129 @@
+package kv
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// setupArchive builds reverse indexes of verified slot infos when archive mode is enabled and the db
+// was not an archive yet. Indexes are dropped when archive mode is disabled since they would go stale.
+func (s *Store) setupArchive(enabled bool) error {
+	return s.db.Update(func(tx Tx) error {
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		wasArchive := markerBkt.Get(archiveModeKey) != nil
+		if enabled == wasArchive {
+			return nil
+		}
+
+		if !enabled {
+			log.Warn("Archive mode is disabled, dropping reverse indexes")
+			for _, bucket := range [][]byte{pandoraHashIndexBucket, vanguardHashIndexBucket} {
+				if err := clearBucket(tx, bucket); err != nil {
+					return err
+				}
+			}
+			return markerBkt.Delete(archiveModeKey)
+		}
+
+		log.Info("Archive mode is enabled, building reverse indexes of verified slot infos")
+		var count int
+		if err := tx.Bucket(verifiedSlotInfosBucket).ForEach(func(k, v []byte) error {
+			var slotInfo *types.SlotInfo
+			if err := s.codec.decode(v, &slotInfo); err != nil {
+				return err
+			}
+			count++
+			return indexSlotInfo(tx, bytesutil.BytesToUint64BigEndian(k), slotInfo)
+		}); err != nil {
+			return err
+		}
+		log.WithField("slots", count).Info("Built reverse indexes of verified slot infos")
+		return markerBkt.Put(archiveModeKey, []byte{1})
+	})
+}
+
+// IsArchive returns true when db keeps full history with reverse indexes
+func (s *Store) IsArchive() bool {
+	return s.archive
+}
+
+// SlotByPandoraHeaderHash returns the verified slot of pandora header. Only archive db keeps this index.
+func (s *Store) SlotByPandoraHeaderHash(hash common.Hash) (uint64, bool, error) {
+	return s.indexedSlot(pandoraHashIndexBucket, hash)
+}
+
+// SlotByVanguardBlockHash returns the verified slot of vanguard block. Only archive db keeps this index.
+func (s *Store) SlotByVanguardBlockHash(hash common.Hash) (uint64, bool, error) {
+	return s.indexedSlot(vanguardHashIndexBucket, hash)
+}
+
+// VerifiedSlotInfoRange returns verified slot infos of [fromSlot, toSlot] by walking the bucket with a cursor
+func (s *Store) VerifiedSlotInfoRange(fromSlot, toSlot uint64) (map[uint64]*types.SlotInfo, error) {
+	slotInfos := make(map[uint64]*types.SlotInfo)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(verifiedSlotInfosBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil && bytesutil.BytesToUint64BigEndian(k) <= toSlot; k, v = c.Next() {
+			var slotInfo *types.SlotInfo
+			if err := s.codec.decode(v, &slotInfo); err != nil {
+				return err
+			}
+			slotInfos[bytesutil.BytesToUint64BigEndian(k)] = slotInfo
+		}
+		return nil
+	})
+	return slotInfos, err
+}
+
+// indexedSlot
+func (s *Store) indexedSlot(bucket []byte, hash common.Hash) (uint64, bool, error) {
+	var slot uint64
+	var found bool
+	err := s.db.View(func(tx Tx) error {
+		if enc := tx.Bucket(bucket).Get(hash.Bytes()); enc != nil {
+			slot = bytesutil.BytesToUint64BigEndian(enc)
+			found = true
+		}
+		return nil
+	})
+	return slot, found, err
+}
+
+// indexSlotInfo
+func indexSlotInfo(tx Tx, slot uint64, slotInfo *types.SlotInfo) error {
+	slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
+	if err := tx.Bucket(pandoraHashIndexBucket).Put(slotInfo.PandoraHeaderHash.Bytes(), slotBytes); err != nil {
+		return err
+	}
+	return tx.Bucket(vanguardHashIndexBucket).Put(slotInfo.VanguardBlockHash.Bytes(), slotBytes)
+}
+
+// unindexSlotInfo removes index entries only when they still point to the slot
+func unindexSlotInfo(tx Tx, slot uint64, slotInfo *types.SlotInfo) error {
+	indexes := []struct {
+		bucket []byte
+		hash   common.Hash
+	}{
+		{pandoraHashIndexBucket, slotInfo.PandoraHeaderHash},
+		{vanguardHashIndexBucket, slotInfo.VanguardBlockHash},
+	}
+	for _, index := range indexes {
+		bkt := tx.Bucket(index.bucket)
+		hash := index.hash
+		if enc := bkt.Get(hash.Bytes()); enc != nil && bytesutil.BytesToUint64BigEndian(enc) == slot {
+			if err := bkt.Delete(hash.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// clearBucket
+func clearBucket(tx Tx, bucket []byte) error {
+	if err := tx.DeleteBucket(bucket); err != nil {
+		return err
+	}
+	_, err := tx.CreateBucket(bucket)
+	return err
+}
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"testing"
+)
+
+func TestStore_Archive_ReverseIndexes(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	slotInfo1 := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x11"), PandoraHeaderHash: common.HexToHash("0x12")}
+	slotInfo2 := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x21"), PandoraHeaderHash: common.HexToHash("0x22")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo1))
+	_, found, err := db.SlotByPandoraHeaderHash(slotInfo1.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+	require.NoError(t, db.Close())
+
+	// indexes of already stored slots are built when archive mode is enabled
+	db, err = NewKVStore(context.Background(), dir, &Config{Archive: true})
+	require.NoError(t, err)
+	assert.Equal(t, true, db.IsArchive())
+	require.NoError(t, db.SaveVerifiedSlotInfo(2, slotInfo2))
+
+	slot, found, err := db.SlotByPandoraHeaderHash(slotInfo1.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(1), slot)
+	slot, found, err = db.SlotByVanguardBlockHash(slotInfo2.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(2), slot)
+	assert.Equal(t, uint64(2), db.FindVerifiedSlotNumber(slotInfo2, 10))
+
+	slotInfos, err := db.VerifiedSlotInfoRange(2, 5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, map[uint64]*types.SlotInfo{2: slotInfo2}, slotInfos)
+
+	require.NoError(t, db.RemoveRangeVerifiedInfo(2, 2))
+	_, found, err = db.SlotByVanguardBlockHash(slotInfo2.VanguardBlockHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+	require.NoError(t, db.Close())
+
+	// indexes are dropped when archive mode is disabled
+	db, err = NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	_, found, err = db.SlotByPandoraHeaderHash(slotInfo1.PandoraHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+}
@@ -0,0 +1,130 @@
+package kv
+
+import (
+	"os"
+	"path"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+)
+
+// BackendType is the storage engine which keeps the buckets of the db
+type BackendType string
+
+const (
+	// BoltBackend keeps the db in a single memory mapped file. It is the backend of dbs created before the backend
+	// was selectable.
+	BoltBackend BackendType = "bolt"
+	// LevelDBBackend keeps the db in a log-structured merge tree, which has better write throughput on slow disks
+	LevelDBBackend BackendType = "leveldb"
+
+	// LevelDBDirName is the directory of the leveldb backend inside the db directory
+	LevelDBDirName = "orchestrator.ldb"
+)
+
+// Backend is the storage engine of the store. Keys are grouped into buckets and every read and write happens in a
+// transaction, so the store does not depend on the engine.
+type Backend interface {
+	// View runs the function in a read-only transaction
+	View(fn func(tx Tx) error) error
+	// Update runs the function in a read-write transaction, which is committed when the function returns nil
+	Update(fn func(tx Tx) error) error
+	// SetNoSync stops syncing every committed transaction to disk until it is turned off again
+	SetNoSync(noSync bool)
+	// Sync makes every committed transaction durable
+	Sync() error
+	// Check walks the stored pages and returns the first inconsistency which is found
+	Check() error
+	Close() error
+}
+
+// Tx is a transaction of the backend
+type Tx interface {
+	// Bucket returns nil when the bucket does not exist
+	Bucket(name []byte) Bucket
+	CreateBucket(name []byte) (Bucket, error)
+	CreateBucketIfNotExists(name []byte) (Bucket, error)
+	DeleteBucket(name []byte) error
+	// ForEach calls the function for every bucket in order of name
+	ForEach(fn func(name []byte, bkt Bucket) error) error
+	// Size returns the size of the db on disk in bytes
+	Size() int64
+	// CopyFile writes a consistent copy of the db as seen by the transaction to the given path
+	CopyFile(path string, mode os.FileMode) error
+}
+
+// Bucket is a collection of key-value pairs sorted by key. Returned keys and values are only valid during the
+// transaction.
+type Bucket interface {
+	Get(key []byte) []byte
+	Put(key []byte, value []byte) error
+	Delete(key []byte) error
+	Cursor() Cursor
+	// ForEach calls the function for every key-value pair in order of key
+	ForEach(fn func(key, value []byte) error) error
+	// NextSequence returns an auto-incrementing integer of the bucket
+	NextSequence() (uint64, error)
+	// KeyCount returns the number of keys in the bucket
+	KeyCount() int
+}
+
+// Cursor iterates over the keys of a bucket in order. Each method returns nil key when there is no such key.
+type Cursor interface {
+	First() (key []byte, value []byte)
+	Last() (key []byte, value []byte)
+	Seek(seek []byte) (key []byte, value []byte)
+	Next() (key []byte, value []byte)
+	Prev() (key []byte, value []byte)
+}
+
+// existingBackend returns the backend of the db in the directory or empty type when there is no db yet
+func existingBackend(dirPath string) (BackendType, error) {
+	hasBolt := fileutil.FileExists(path.Join(dirPath, DatabaseFileName))
+	hasLevelDB, err := fileutil.HasDir(path.Join(dirPath, LevelDBDirName))
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case hasBolt && hasLevelDB:
+		return "", errors.Errorf("db directory %s has both a %s and a %s db", dirPath, BoltBackend, LevelDBBackend)
+	case hasBolt:
+		return BoltBackend, nil
+	case hasLevelDB:
+		return LevelDBBackend, nil
+	default:
+		return "", nil
+	}
+}
+
+// selectBackend returns the backend of the existing db or the configured one for a new db. Existing db is never
+// converted, so the configured backend must match it.
+func selectBackend(dirPath string, configured BackendType) (BackendType, error) {
+	switch configured {
+	case "", BoltBackend, LevelDBBackend:
+	default:
+		return "", errors.Errorf("unsupported db backend %q", configured)
+	}
+	existing, err := existingBackend(dirPath)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case existing == "" && configured == "":
+		return BoltBackend, nil
+	case existing == "":
+		return configured, nil
+	case configured != "" && configured != existing:
+		return "", errors.Errorf("db directory %s has a %s db, it can't be opened with the %s backend. "+
+			"Export it and import the snapshot into a new db to change the backend", dirPath, existing, configured)
+	default:
+		return existing, nil
+	}
+}
+
+// removeBackendFiles removes the db files of every backend from the directory
+func removeBackendFiles(dirPath string) error {
+	if err := os.Remove(path.Join(dirPath, DatabaseFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(path.Join(dirPath, LevelDBDirName))
+}
@@ -0,0 +1,144 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/retry"
+	"github.com/pkg/errors"
+)
+
+// boltBackend keeps the buckets in a bolt db file
+type boltBackend struct {
+	db *bolt.DB
+}
+
+// openBolt opens the bolt db file and retries while the file lock is held by another process
+func openBolt(ctx context.Context, datafile string, config *Config) (*boltBackend, error) {
+	var boltDB *bolt.DB
+	err := retry.Do(ctx, lockRetryPolicy(config.LockRetries), func(attempt int) error {
+		var err error
+		boltDB, err = bolt.Open(
+			datafile,
+			params.OrchestratorIoConfig().ReadWritePermissions,
+			&bolt.Options{
+				Timeout:         1 * time.Second,
+				InitialMmapSize: config.InitialMMapSize,
+				ReadOnly:        config.ReadOnly,
+			},
+		)
+		if err == nil || !errors.Is(err, bolt.ErrTimeout) {
+			return retry.Permanent(err)
+		}
+		if attempt <= config.LockRetries {
+			log.WithField("attempt", attempt).Warn("Database lock is held by another process, retrying")
+		}
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, errDBLocked
+		}
+		return nil, err
+	}
+	boltDB.AllocSize = boltAllocSize
+	return &boltBackend{db: boltDB}, nil
+}
+
+func (b *boltBackend) View(fn func(tx Tx) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltBackend) Update(fn func(tx Tx) error) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (b *boltBackend) SetNoSync(noSync bool) {
+	b.db.NoSync = noSync
+}
+
+func (b *boltBackend) Sync() error {
+	return b.db.Sync()
+}
+
+func (b *boltBackend) Check() error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		var checkErr error
+		// channel is drained, so the check goroutine finishes
+		for err := range tx.Check() {
+			if checkErr == nil {
+				checkErr = err
+			}
+		}
+		return checkErr
+	})
+}
+
+func (b *boltBackend) Close() error {
+	return b.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) Bucket(name []byte) Bucket {
+	bkt := t.tx.Bucket(name)
+	if bkt == nil {
+		return nil
+	}
+	return &boltBucket{Bucket: bkt}
+}
+
+func (t *boltTx) CreateBucket(name []byte) (Bucket, error) {
+	bkt, err := t.tx.CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{Bucket: bkt}, nil
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	bkt, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{Bucket: bkt}, nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	return t.tx.DeleteBucket(name)
+}
+
+func (t *boltTx) ForEach(fn func(name []byte, bkt Bucket) error) error {
+	return t.tx.ForEach(func(name []byte, bkt *bolt.Bucket) error {
+		return fn(name, &boltBucket{Bucket: bkt})
+	})
+}
+
+func (t *boltTx) Size() int64 {
+	return t.tx.Size()
+}
+
+func (t *boltTx) CopyFile(path string, mode os.FileMode) error {
+	return t.tx.CopyFile(path, mode)
+}
+
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+func (b *boltBucket) Cursor() Cursor {
+	return b.Bucket.Cursor()
+}
+
+func (b *boltBucket) KeyCount() int {
+	return b.Bucket.Stats().KeyN
+}
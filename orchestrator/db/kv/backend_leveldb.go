@@ -0,0 +1,478 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/retry"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Buckets are emulated with key prefixes. Every bucket has a record keyed by the bucket prefix and its name, which
+// holds the sequence of the bucket. Keys of the bucket are prefixed by the length and the name of the bucket, so that
+// a bucket name which is a prefix of another one does not mix their keys.
+const (
+	levelDBBucketPrefix byte = 0x00
+	levelDBKeyPrefix    byte = 0x01
+	levelDBSyncPrefix   byte = 0x02
+
+	// overlay values are prefixed with a liveness flag, so that deleted keys hide the committed ones
+	overlayDeleted byte = 0x00
+	overlayLive    byte = 0x01
+
+	// levelDBCopyBatchSize is the number of keys written at once while copying the db
+	levelDBCopyBatchSize = 4096
+)
+
+var (
+	errTxNotWritable  = errors.New("tx not writable")
+	errBucketExists   = errors.New("bucket already exists")
+	errBucketNotFound = errors.New("bucket not found")
+	errBucketName     = errors.New("bucket name must be between 1 and 255 bytes")
+
+	levelDBSyncKey = []byte{levelDBSyncPrefix}
+)
+
+// levelDBBackend keeps the buckets in a leveldb. Read-write transactions collect their writes in memory and apply
+// them in a single atomic batch on commit, so that a failed transaction leaves the db as it was like in bolt.
+type levelDBBackend struct {
+	db *leveldb.DB
+	// dir is empty for a db in memory
+	dir string
+	// writeLock allows only one read-write transaction at a time
+	writeLock sync.Mutex
+
+	syncLock sync.RWMutex
+	noSync   bool
+}
+
+// openLevelDB opens the leveldb in the directory and retries while it is locked by another process
+func openLevelDB(ctx context.Context, dir string, config *Config) (*levelDBBackend, error) {
+	var db *leveldb.DB
+	err := retry.Do(ctx, lockRetryPolicy(config.LockRetries), func(attempt int) error {
+		var err error
+		db, err = leveldb.OpenFile(dir, &opt.Options{ReadOnly: config.ReadOnly})
+		if err == nil || !errors.Is(err, syscall.EWOULDBLOCK) {
+			return retry.Permanent(err)
+		}
+		if attempt <= config.LockRetries {
+			log.WithField("attempt", attempt).Warn("Database lock is held by another process, retrying")
+		}
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errDBLocked
+		}
+		return nil, err
+	}
+	return &levelDBBackend{db: db, dir: dir}, nil
+}
+
+func (b *levelDBBackend) View(fn func(tx Tx) error) error {
+	snap, err := b.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	tx := &levelDBTx{backend: b, snap: snap}
+	defer tx.release()
+	return fn(tx)
+}
+
+func (b *levelDBBackend) Update(fn func(tx Tx) error) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	snap, err := b.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	tx := &levelDBTx{backend: b, snap: snap, writes: memdb.New(comparer.DefaultComparer, 0)}
+	defer tx.release()
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	it := tx.writes.NewIterator(nil)
+	defer it.Release()
+	for it.Next() {
+		if value := it.Value(); value[0] == overlayDeleted {
+			batch.Delete(it.Key())
+		} else {
+			batch.Put(it.Key(), value[1:])
+		}
+	}
+	return b.db.Write(batch, &opt.WriteOptions{Sync: !b.isNoSync()})
+}
+
+func (b *levelDBBackend) SetNoSync(noSync bool) {
+	b.syncLock.Lock()
+	defer b.syncLock.Unlock()
+	b.noSync = noSync
+}
+
+func (b *levelDBBackend) isNoSync() bool {
+	b.syncLock.RLock()
+	defer b.syncLock.RUnlock()
+	return b.noSync
+}
+
+// Sync writes a marker with a synced write, which makes the journal durable up to and including every earlier write
+func (b *levelDBBackend) Sync() error {
+	return b.db.Put(levelDBSyncKey, nil, &opt.WriteOptions{Sync: true})
+}
+
+// Check has nothing to walk, torn journal records of unsynced writes are dropped when the db is opened
+func (b *levelDBBackend) Check() error {
+	return nil
+}
+
+func (b *levelDBBackend) Close() error {
+	return b.db.Close()
+}
+
+// size returns the size of the files of the db. A db in memory takes no disk space.
+func (b *levelDBBackend) size() int64 {
+	if b.dir == "" {
+		return 0
+	}
+	var size int64
+	filepath.Walk(b.dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// levelDBTx reads from the snapshot of the db at the start of the transaction. Writes of a read-write transaction
+// are kept in an in-memory overlay which is read before the snapshot.
+type levelDBTx struct {
+	backend *levelDBBackend
+	snap    *leveldb.Snapshot
+	// writes is nil for read-only transaction
+	writes    *memdb.DB
+	iterators []iterator.Iterator
+}
+
+func (t *levelDBTx) release() {
+	for _, it := range t.iterators {
+		it.Release()
+	}
+	t.iterators = nil
+}
+
+func (t *levelDBTx) get(key []byte) []byte {
+	if t.writes != nil {
+		if value, err := t.writes.Get(key); err == nil {
+			if value[0] == overlayDeleted {
+				return nil
+			}
+			return value[1:]
+		}
+	}
+	value, err := t.snap.Get(key, nil)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+func (t *levelDBTx) put(key, value []byte) error {
+	if t.writes == nil {
+		return errTxNotWritable
+	}
+	return t.writes.Put(key, append([]byte{overlayLive}, value...))
+}
+
+func (t *levelDBTx) delete(key []byte) error {
+	if t.writes == nil {
+		return errTxNotWritable
+	}
+	return t.writes.Put(key, []byte{overlayDeleted})
+}
+
+// cursor returns a cursor over the keys with the given prefix
+func (t *levelDBTx) cursor(prefix []byte) *levelDBCursor {
+	c := &levelDBCursor{tx: t, prefix: prefix}
+	c.snapIt = t.snap.NewIterator(util.BytesPrefix(prefix), nil)
+	t.iterators = append(t.iterators, c.snapIt)
+	if t.writes != nil {
+		c.writesIt = t.writes.NewIterator(util.BytesPrefix(prefix))
+		t.iterators = append(t.iterators, c.writesIt)
+	}
+	return c
+}
+
+func bucketRecordKey(name []byte) []byte {
+	return append([]byte{levelDBBucketPrefix}, name...)
+}
+
+func bucketKeyPrefix(name []byte) []byte {
+	prefix := make([]byte, 0, len(name)+2)
+	prefix = append(prefix, levelDBKeyPrefix, byte(len(name)))
+	return append(prefix, name...)
+}
+
+func (t *levelDBTx) Bucket(name []byte) Bucket {
+	if len(name) == 0 || len(name) > 255 || t.get(bucketRecordKey(name)) == nil {
+		return nil
+	}
+	return &levelDBBucket{tx: t, name: append([]byte{}, name...), prefix: bucketKeyPrefix(name)}
+}
+
+func (t *levelDBTx) CreateBucket(name []byte) (Bucket, error) {
+	if len(name) == 0 || len(name) > 255 {
+		return nil, errBucketName
+	}
+	if t.Bucket(name) != nil {
+		return nil, errBucketExists
+	}
+	if err := t.put(bucketRecordKey(name), make([]byte, 8)); err != nil {
+		return nil, err
+	}
+	return t.Bucket(name), nil
+}
+
+func (t *levelDBTx) CreateBucketIfNotExists(name []byte) (Bucket, error) {
+	if bkt := t.Bucket(name); bkt != nil {
+		return bkt, nil
+	}
+	return t.CreateBucket(name)
+}
+
+func (t *levelDBTx) DeleteBucket(name []byte) error {
+	if t.writes == nil {
+		return errTxNotWritable
+	}
+	bkt := t.Bucket(name)
+	if bkt == nil {
+		return errBucketNotFound
+	}
+	c := bkt.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return t.delete(bucketRecordKey(name))
+}
+
+func (t *levelDBTx) ForEach(fn func(name []byte, bkt Bucket) error) error {
+	c := t.cursor([]byte{levelDBBucketPrefix})
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if err := fn(k, t.Bucket(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *levelDBTx) Size() int64 {
+	return t.backend.size()
+}
+
+// CopyFile writes the snapshot of the transaction as a new leveldb into the given directory
+func (t *levelDBTx) CopyFile(path string, mode os.FileMode) error {
+	db, err := leveldb.OpenFile(path, &opt.Options{ErrorIfExist: true})
+	if err != nil {
+		return err
+	}
+	c := t.cursor(nil)
+	batch := new(leveldb.Batch)
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		batch.Put(k, v)
+		if batch.Len() < levelDBCopyBatchSize {
+			continue
+		}
+		if err := db.Write(batch, nil); err != nil {
+			db.Close()
+			return err
+		}
+		batch.Reset()
+	}
+	if err := db.Write(batch, &opt.WriteOptions{Sync: true}); err != nil {
+		db.Close()
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(path, mode|0100)
+}
+
+type levelDBBucket struct {
+	tx     *levelDBTx
+	name   []byte
+	prefix []byte
+}
+
+func (b *levelDBBucket) key(key []byte) []byte {
+	return append(append(make([]byte, 0, len(b.prefix)+len(key)), b.prefix...), key...)
+}
+
+func (b *levelDBBucket) Get(key []byte) []byte {
+	return b.tx.get(b.key(key))
+}
+
+func (b *levelDBBucket) Put(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return errors.New("key required")
+	}
+	return b.tx.put(b.key(key), value)
+}
+
+func (b *levelDBBucket) Delete(key []byte) error {
+	return b.tx.delete(b.key(key))
+}
+
+func (b *levelDBBucket) Cursor() Cursor {
+	return b.tx.cursor(b.prefix)
+}
+
+func (b *levelDBBucket) ForEach(fn func(key, value []byte) error) error {
+	c := b.tx.cursor(b.prefix)
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *levelDBBucket) NextSequence() (uint64, error) {
+	recordKey := bucketRecordKey(b.name)
+	seq := binary.BigEndian.Uint64(b.tx.get(recordKey)) + 1
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, seq)
+	if err := b.tx.put(recordKey, enc); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+func (b *levelDBBucket) KeyCount() int {
+	count := 0
+	c := b.tx.cursor(b.prefix)
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		count++
+	}
+	return count
+}
+
+// levelDBCursor merges the keys of the snapshot and the overlay of the transaction. Every move seeks from the key
+// of the current position, so keys which are written or deleted while iterating are seen like in bolt.
+type levelDBCursor struct {
+	tx       *levelDBTx
+	prefix   []byte
+	snapIt   iterator.Iterator
+	writesIt iterator.Iterator
+	// current is the full key of the current position, nil when the cursor is not positioned
+	current []byte
+}
+
+func (c *levelDBCursor) First() ([]byte, []byte) {
+	return c.move(nil, true, true)
+}
+
+func (c *levelDBCursor) Last() ([]byte, []byte) {
+	return c.move(nil, false, true)
+}
+
+func (c *levelDBCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.move(append(append([]byte{}, c.prefix...), seek...), true, true)
+}
+
+func (c *levelDBCursor) Next() ([]byte, []byte) {
+	if c.current == nil {
+		return nil, nil
+	}
+	return c.move(c.current, true, false)
+}
+
+func (c *levelDBCursor) Prev() ([]byte, []byte) {
+	if c.current == nil {
+		return nil, nil
+	}
+	return c.move(c.current, false, false)
+}
+
+// move positions the cursor on the nearest live key from the target in the given direction. Nil target is the
+// first or the last key.
+func (c *levelDBCursor) move(target []byte, forward, inclusive bool) ([]byte, []byte) {
+	for {
+		snapKey, snapValue, snapOk := nearest(c.snapIt, target, forward, inclusive)
+		var writeKey, writeValue []byte
+		var writeOk bool
+		if c.writesIt != nil {
+			writeKey, writeValue, writeOk = nearest(c.writesIt, target, forward, inclusive)
+		}
+		if !snapOk && !writeOk {
+			c.current = nil
+			return nil, nil
+		}
+		// overlay wins on the same key
+		if writeOk && (!snapOk || !closer(snapKey, writeKey, forward)) {
+			if writeValue[0] == overlayDeleted {
+				target, inclusive = append([]byte{}, writeKey...), false
+				continue
+			}
+			return c.position(writeKey, writeValue[1:])
+		}
+		return c.position(snapKey, snapValue)
+	}
+}
+
+func (c *levelDBCursor) position(key, value []byte) ([]byte, []byte) {
+	c.current = append([]byte{}, key...)
+	return c.current[len(c.prefix):], append([]byte{}, value...)
+}
+
+// closer returns true when key a is met before key b in the given direction
+func closer(a, b []byte, forward bool) bool {
+	if forward {
+		return bytes.Compare(a, b) < 0
+	}
+	return bytes.Compare(a, b) > 0
+}
+
+// nearest returns the nearest key of the iterator from the target in the given direction
+func nearest(it iterator.Iterator, target []byte, forward, inclusive bool) ([]byte, []byte, bool) {
+	var ok bool
+	switch {
+	case target == nil && forward:
+		ok = it.First()
+	case target == nil:
+		ok = it.Last()
+	case forward:
+		ok = it.Seek(target)
+		if ok && !inclusive && bytes.Equal(it.Key(), target) {
+			ok = it.Next()
+		}
+	default:
+		if ok = it.Seek(target); !ok {
+			ok = it.Last()
+		} else if !inclusive || !bytes.Equal(it.Key(), target) {
+			ok = it.Prev()
+		}
+	}
+	if !ok {
+		return nil, nil, false
+	}
+	return it.Key(), it.Value(), true
+}
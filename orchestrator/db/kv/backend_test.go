@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_LevelDBBackend(t *testing.T) {
+	dataDir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dataDir, &Config{Backend: LevelDBBackend})
+	require.NoError(t, err)
+	assert.Equal(t, LevelDBBackend, db.Backend())
+
+	for slot := uint64(1); slot <= 8; slot++ {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{}))
+	}
+	require.NoError(t, db.RemoveRangeVerifiedInfo(5, 8))
+	require.NoError(t, db.Close())
+
+	// the existing backend is kept when none is configured
+	db, err = NewKVStore(context.Background(), dataDir, &Config{})
+	require.NoError(t, err)
+	assert.Equal(t, LevelDBBackend, db.Backend())
+	slotInfo, err := db.VerifiedSlotInfo(4)
+	require.NoError(t, err)
+	assert.NotNil(t, slotInfo)
+	slotInfo, err = db.VerifiedSlotInfo(5)
+	require.NoError(t, err)
+	assert.Equal(t, true, slotInfo == nil)
+	require.NoError(t, db.Close())
+
+	_, err = NewKVStore(context.Background(), dataDir, &Config{Backend: BoltBackend})
+	assert.ErrorContains(t, "can't be opened with the bolt backend", err)
+}
+
+func TestLevelDBBackend_Cursor(t *testing.T) {
+	db, err := openLevelDB(context.Background(), t.TempDir(), &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	bucketName := []byte("bucket")
+	require.NoError(t, db.Update(func(tx Tx) error {
+		bkt, err := tx.CreateBucket(bucketName)
+		if err != nil {
+			return err
+		}
+		for _, key := range []string{"a", "b", "c", "d"} {
+			if err := bkt.Put([]byte(key), []byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	require.NoError(t, db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(bucketName)
+		require.NotNil(t, bkt)
+		// uncommitted writes of the transaction are visible to its cursor
+		require.NoError(t, bkt.Put([]byte("bb"), []byte("bb")))
+		var keys []string
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, string(k))
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		assert.DeepEqual(t, []string{"a", "b", "bb", "c", "d"}, keys)
+		assert.Equal(t, 0, bkt.KeyCount())
+		return nil
+	}))
+
+	require.NoError(t, db.View(func(tx Tx) error {
+		k, _ := tx.Bucket(bucketName).Cursor().Last()
+		assert.Equal(t, true, k == nil)
+		return nil
+	}))
+}
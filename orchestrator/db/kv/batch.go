@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// SaveVerifiedSlotBatch stores verified slot infos, their accumulator steps, latest verified slot and header hash
+// and newer finalized info of the batch in a single transaction, so the batch is either fully written or not at all.
+func (s *Store) SaveVerifiedSlotBatch(batch *types.VerifiedSlotBatch) error {
+	if len(batch.Slots) == 0 {
+		return nil
+	}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if err := s.db.Update(func(tx Tx) error {
+		slotBkt := tx.Bucket(verifiedSlotInfosBucket)
+		for _, batched := range batch.Slots {
+			enc, err := s.codec.encode(batched.SlotInfo)
+			if err != nil {
+				return err
+			}
+			slotBytes := bytesutil.Uint64ToBytesBigEndian(batched.Slot)
+			if err := slotBkt.Put(slotBytes, enc); err != nil {
+				return err
+			}
+			if s.archive {
+				if err := indexSlotInfo(tx, batched.Slot, batched.SlotInfo); err != nil {
+					return err
+				}
+			}
+			if err := putBlockNumber(tx, batched.BlockNumber, batched.Slot); err != nil {
+				return err
+			}
+			if batched.Step == nil {
+				continue
+			}
+			if enc, err = s.codec.encode(batched.Step); err != nil {
+				return err
+			}
+			if err := tx.Bucket(accumulatorLeavesBucket).Put(bytesutil.Uint64ToBytesBigEndian(batched.Step.LeafIndex), batched.Step.Leaf.Bytes()); err != nil {
+				return err
+			}
+			if err := tx.Bucket(accumulatorStepsBucket).Put(slotBytes, enc); err != nil {
+				return err
+			}
+		}
+
+		latest := batch.Slots[len(batch.Slots)-1]
+		markerBkt := tx.Bucket(latestInfoMarkerBucket)
+		if err := markerBkt.Put(latestSavedVerifiedSlotKey, bytesutil.Uint64ToBytesBigEndian(latest.Slot)); err != nil {
+			return err
+		}
+		if err := markerBkt.Put(latestHeaderHashKey, latest.SlotInfo.PandoraHeaderHash.Bytes()); err != nil {
+			return err
+		}
+
+		var finalizedEpoch uint64
+		if epochBytes := markerBkt.Get(latestFinalizedEpochKey); epochBytes != nil {
+			finalizedEpoch = bytesutil.BytesToUint64BigEndian(epochBytes)
+		}
+		if finalizedEpoch >= batch.FinalizedEpoch {
+			return nil
+		}
+		if err := markerBkt.Put(latestFinalizedSlotKey, bytesutil.Uint64ToBytesBigEndian(batch.FinalizedSlot)); err != nil {
+			return err
+		}
+		return markerBkt.Put(latestFinalizedEpochKey, bytesutil.Uint64ToBytesBigEndian(batch.FinalizedEpoch))
+	}); err != nil {
+		return errors.Wrapf(err, "could not save verified slot batch of [%d, %d]",
+			batch.Slots[0].Slot, batch.Slots[len(batch.Slots)-1].Slot)
+	}
+
+	// cache is filled only after the batch is committed
+	for _, batched := range batch.Slots {
+		if status := s.verifiedSlotInfoCache.Set(batched.Slot, batched.SlotInfo, 0); !status {
+			log.WithField("slot", batched.Slot).Warn("could not store verified slot info into cache")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_SaveVerifiedSlotBatch(t *testing.T) {
+	db := setupDB(t, true)
+	require.NoError(t, db.SaveLatestFinalizedEpoch(3))
+	require.NoError(t, db.SaveLatestFinalizedSlot(96))
+
+	batch := &types.VerifiedSlotBatch{FinalizedSlot: 64, FinalizedEpoch: 2}
+	for slot := uint64(100); slot < 104; slot++ {
+		slotInfo := &types.SlotInfo{
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot)}),
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 1}),
+		}
+		batch.Slots = append(batch.Slots, &types.BatchedSlot{
+			Slot:        slot,
+			BlockNumber: slot - 50,
+			SlotInfo:    slotInfo,
+			Step:        &types.AccumulatorStep{Slot: slot, LeafIndex: slot - 100, Leaf: slotInfo.Root()},
+		})
+	}
+	require.NoError(t, db.SaveVerifiedSlotBatch(batch))
+
+	assert.Equal(t, uint64(103), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, batch.Slots[3].SlotInfo.PandoraHeaderHash, db.LatestVerifiedHeaderHash())
+	db.verifiedSlotInfoCache.Clear()
+	for _, batched := range batch.Slots {
+		slotInfo, err := db.VerifiedSlotInfo(batched.Slot)
+		require.NoError(t, err)
+		assert.DeepEqual(t, batched.SlotInfo, slotInfo)
+	}
+	leaves, err := db.AccumulatorLeaves(4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(leaves))
+	step, err := db.LatestAccumulatorStep()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(103), step.Slot)
+	slot, found, err := db.SlotByPandoraBlockNumber(52)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(102), slot)
+
+	// older finalized info of the batch does not move finalized info back
+	assert.Equal(t, uint64(3), db.LatestLatestFinalizedEpoch())
+	assert.Equal(t, uint64(96), db.LatestLatestFinalizedSlot())
+
+	batch = &types.VerifiedSlotBatch{
+		Slots:          []*types.BatchedSlot{{Slot: 104, SlotInfo: &types.SlotInfo{}}},
+		FinalizedSlot:  128,
+		FinalizedEpoch: 4,
+	}
+	require.NoError(t, db.SaveVerifiedSlotBatch(batch))
+	assert.Equal(t, uint64(4), db.LatestLatestFinalizedEpoch())
+	assert.Equal(t, uint64(128), db.LatestLatestFinalizedSlot())
+}
@@ -0,0 +1,49 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+)
+
+// SavePandoraBlockNumber indexes the verified slot by its pandora block number. Number of a slot which is verified
+// again after reorg overwrites the previous entry.
+func (s *Store) SavePandoraBlockNumber(number, slot uint64) error {
+	return s.db.Update(func(tx Tx) error {
+		return putBlockNumber(tx, number, slot)
+	})
+}
+
+// SlotByPandoraBlockNumber returns the verified slot of the pandora block number
+func (s *Store) SlotByPandoraBlockNumber(number uint64) (uint64, bool, error) {
+	var slot uint64
+	var found bool
+	err := s.db.View(func(tx Tx) error {
+		if enc := tx.Bucket(blockNumberIndexBucket).Get(bytesutil.Uint64ToBytesBigEndian(number)); enc != nil {
+			slot = bytesutil.BytesToUint64BigEndian(enc)
+			found = true
+		}
+		return nil
+	})
+	return slot, found, err
+}
+
+// putBlockNumber
+func putBlockNumber(tx Tx, number, slot uint64) error {
+	return tx.Bucket(blockNumberIndexBucket).Put(bytesutil.Uint64ToBytesBigEndian(number), bytesutil.Uint64ToBytesBigEndian(slot))
+}
+
+// removeBlockNumbers removes the numbers of the slots from fromSlot. Block numbers grow along with slots on the
+// verified chain, so the removed slots are at the tail of the index.
+func removeBlockNumbers(tx Tx, fromSlot uint64) error {
+	var numbers [][]byte
+	c := tx.Bucket(blockNumberIndexBucket).Cursor()
+	for k, v := c.Last(); k != nil && bytesutil.BytesToUint64BigEndian(v) >= fromSlot; k, v = c.Prev() {
+		numbers = append(numbers, bytesutil.SafeCopyBytes(k))
+	}
+	bkt := tx.Bucket(blockNumberIndexBucket)
+	for _, number := range numbers {
+		if err := bkt.Delete(number); err != nil {
+			return err
+		}
+	}
+	return nil
+}
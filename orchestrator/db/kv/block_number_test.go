@@ -0,0 +1,47 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestStore_PandoraBlockNumber_Reorg checks that numbers above the reorg point are removed with the reverted slots
+func TestStore_PandoraBlockNumber_Reorg(t *testing.T) {
+	db := setupDB(t, true)
+
+	// slot 4 is skipped, so numbers and slots diverge after it
+	for _, slot := range []uint64{1, 2, 3, 5, 6, 7} {
+		number := slot
+		if slot > 4 {
+			number--
+		}
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)})}))
+		require.NoError(t, db.SavePandoraBlockNumber(number, slot))
+	}
+
+	slot, found, err := db.SlotByPandoraBlockNumber(4)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(5), slot)
+
+	require.NoError(t, db.RemoveRangeVerifiedInfo(5, 7))
+	for number := uint64(4); number <= 6; number++ {
+		_, found, err = db.SlotByPandoraBlockNumber(number)
+		require.NoError(t, err)
+		assert.Equal(t, false, found)
+	}
+	slot, found, err = db.SlotByPandoraBlockNumber(3)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(3), slot)
+
+	// new fork reuses the number
+	require.NoError(t, db.SavePandoraBlockNumber(4, 6))
+	slot, _, err = db.SlotByPandoraBlockNumber(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), slot)
+}
@@ -0,0 +1,163 @@
+package kv
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+)
+
+// catchUpIntentFileName is the intent log which exists only while db writes are not synced to disk
+const catchUpIntentFileName = "catch-up.intent"
+
+// catchUpIntent records the latest slot which is known to be durable while catch-up mode is on
+type catchUpIntent struct {
+	CommittedSlot uint64 `json:"committedSlot"`
+}
+
+func (s *Store) catchUpIntentPath() string {
+	return path.Join(s.databasePath, catchUpIntentFileName)
+}
+
+// writeCatchUpIntent atomically replaces the intent log and syncs it to disk
+func (s *Store) writeCatchUpIntent(committedSlot uint64) error {
+	enc, err := json.Marshal(&catchUpIntent{CommittedSlot: committedSlot})
+	if err != nil {
+		return err
+	}
+	tmpPath := s.catchUpIntentPath() + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.OrchestratorIoConfig().ReadWritePermissions)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(enc); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.catchUpIntentPath())
+}
+
+// EnterCatchUpMode stops syncing every db write to disk. Writes are made durable in batches by CommitCatchUp.
+// committedSlot is the latest verified slot which is already durable.
+//
+// Catch-up mode is not crash-safe against power loss. Bolt pages which are written without sync may reach disk in
+// any order, so the db file can be corrupted rather than only missing the writes after the committed slot. Recovery
+// checks the db and refuses to start on a corrupted file, which must then be removed and synced again. A process
+// crash without power loss keeps the written pages in the page cache and is recovered by reverting to the committed
+// slot.
+func (s *Store) EnterCatchUpMode(committedSlot uint64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// writes of an in-memory db are never synced
+	if s.catchUp || s.inMemory {
+		return nil
+	}
+	// intent must be durable before any unsynced write happens
+	if err := s.writeCatchUpIntent(committedSlot); err != nil {
+		return errors.Wrap(err, "could not write catch-up intent log")
+	}
+	s.db.SetNoSync(true)
+	s.catchUp = true
+	log.WithField("committedSlot", committedSlot).Info("Entered catch-up db write mode")
+	return nil
+}
+
+// CommitCatchUp syncs all staged writes to disk and moves the durable slot forward. The db is synced before the
+// intent log is advanced, so the intent log never names a slot whose writes are not on disk.
+func (s *Store) CommitCatchUp(slot uint64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.catchUp {
+		return nil
+	}
+	if err := s.db.Sync(); err != nil {
+		return errors.Wrap(err, "could not sync db")
+	}
+	if err := s.writeCatchUpIntent(slot); err != nil {
+		return errors.Wrap(err, "could not write catch-up intent log")
+	}
+	log.WithField("committedSlot", slot).Debug("Committed catch-up db writes")
+	return nil
+}
+
+// ExitCatchUpMode syncs staged writes and switches back to syncing every db write
+func (s *Store) ExitCatchUpMode() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.catchUp {
+		return nil
+	}
+	if err := s.db.Sync(); err != nil {
+		return errors.Wrap(err, "could not sync db")
+	}
+	s.db.SetNoSync(false)
+	s.catchUp = false
+	if err := os.Remove(s.catchUpIntentPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	log.Info("Exited catch-up db write mode")
+	return nil
+}
+
+// IsCatchUpMode
+func (s *Store) IsCatchUpMode() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.catchUp
+}
+
+// recoverCatchUp reverts writes which may not have reached disk when the node stopped during catch-up mode
+func (s *Store) recoverCatchUp() error {
+	intentPath := s.catchUpIntentPath()
+	if !fileutil.FileExists(intentPath) {
+		return nil
+	}
+	data, err := ioutil.ReadFile(intentPath)
+	if err != nil {
+		return err
+	}
+	intent := new(catchUpIntent)
+	if err := json.Unmarshal(data, intent); err != nil {
+		return errors.Wrap(err, "could not parse catch-up intent log")
+	}
+
+	if err := s.db.Check(); err != nil {
+		return errors.Wrap(err, "db was corrupted while it was in catch-up db write mode, remove the db and sync again")
+	}
+
+	latestVerifiedSlot := s.LatestSavedVerifiedSlot()
+	log.WithField("committedSlot", intent.CommittedSlot).WithField("latestVerifiedSlot", latestVerifiedSlot).
+		Warn("Node was stopped in catch-up db write mode, reverting to the latest committed slot")
+
+	if latestVerifiedSlot > intent.CommittedSlot {
+		if err := s.RemoveRangeVerifiedInfo(intent.CommittedSlot+1, latestVerifiedSlot); err != nil {
+			return err
+		}
+		if err := s.UpdateVerifiedSlotInfo(intent.CommittedSlot); err != nil {
+			return err
+		}
+	}
+	if s.LatestLatestFinalizedSlot() > intent.CommittedSlot {
+		if err := s.SaveLatestFinalizedSlot(intent.CommittedSlot); err != nil {
+			return err
+		}
+		if err := s.SaveLatestFinalizedEpoch(intent.CommittedSlot / params.SlotsPerEpoch); err != nil {
+			return err
+		}
+	}
+	return os.Remove(intentPath)
+}
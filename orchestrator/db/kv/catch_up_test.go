@@ -0,0 +1,56 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_CatchUpMode_Recover(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, true)
+
+	saveSlot := func(slot uint64) {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot)}),
+		}))
+		require.NoError(t, db.SaveLatestVerifiedSlot(ctx, slot))
+		require.NoError(t, db.SaveLatestVerifiedHeaderHash(common.BytesToHash([]byte{byte(slot)})))
+	}
+
+	saveSlot(1)
+	require.NoError(t, db.EnterCatchUpMode(1))
+	assert.Equal(t, true, db.IsCatchUpMode())
+	assert.Equal(t, true, fileutil.FileExists(db.catchUpIntentPath()))
+
+	saveSlot(2)
+	saveSlot(3)
+	require.NoError(t, db.CommitCatchUp(3))
+	saveSlot(4)
+
+	// node stopped before the next commit, so slot 4 is not durable
+	require.NoError(t, db.recoverCatchUp())
+	assert.Equal(t, uint64(3), db.LatestSavedVerifiedSlot())
+	assert.Equal(t, common.BytesToHash([]byte{3}), db.LatestVerifiedHeaderHash())
+	slotInfo, err := db.VerifiedSlotInfo(4)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	assert.Equal(t, false, fileutil.FileExists(db.catchUpIntentPath()))
+}
+
+func TestStore_CatchUpMode_Exit(t *testing.T) {
+	db := setupDB(t, true)
+
+	require.NoError(t, db.EnterCatchUpMode(0))
+	require.NoError(t, db.ExitCatchUpMode())
+	assert.Equal(t, false, db.IsCatchUpMode())
+	assert.Equal(t, false, fileutil.FileExists(db.catchUpIntentPath()))
+	// recovery is a no-op after clean exit
+	require.NoError(t, db.recoverCatchUp())
+}
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// PandoraChainIdentity returns pinned pandora network identity. Returns nil when nothing is pinned yet.
+func (s *Store) PandoraChainIdentity() (*types.PandoraChainIdentity, error) {
+	var identity *types.PandoraChainIdentity
+	err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(chainIdentityBucket)
+		enc := bkt.Get(pandoraChainIdentityKey)
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &identity)
+	})
+	return identity, err
+}
+
+// SavePandoraChainIdentity
+func (s *Store) SavePandoraChainIdentity(identity *types.PandoraChainIdentity) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(chainIdentityBucket)
+		enc, err := s.codec.encode(identity)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(pandoraChainIdentityKey, enc)
+	})
+}
+
+// VanguardGenesisValidatorsRoot returns pinned vanguard genesis validators root. Returns nil when nothing is pinned yet.
+func (s *Store) VanguardGenesisValidatorsRoot() ([]byte, error) {
+	var root []byte
+	err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(chainIdentityBucket)
+		if enc := bkt.Get(vanguardGenesisValidatorsRootKey); enc != nil {
+			root = make([]byte, len(enc))
+			copy(root, enc)
+		}
+		return nil
+	})
+	return root, err
+}
+
+// SaveVanguardGenesisValidatorsRoot
+func (s *Store) SaveVanguardGenesisValidatorsRoot(root []byte) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(chainIdentityBucket)
+		return bkt.Put(vanguardGenesisValidatorsRootKey, root)
+	})
+}
@@ -0,0 +1,42 @@
+package kv
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_PandoraChainIdentity(t *testing.T) {
+	db := setupDB(t, true)
+
+	identity, err := db.PandoraChainIdentity()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.PandoraChainIdentity)(nil), identity)
+
+	expected := &types.PandoraChainIdentity{
+		ChainID:     big.NewInt(4004181),
+		GenesisHash: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SavePandoraChainIdentity(expected))
+	identity, err = db.PandoraChainIdentity()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, identity)
+}
+
+func TestStore_VanguardGenesisValidatorsRoot(t *testing.T) {
+	db := setupDB(t, true)
+
+	root, err := db.VanguardGenesisValidatorsRoot()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(root))
+
+	expected := common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2").Bytes()
+	require.NoError(t, db.SaveVanguardGenesisValidatorsRoot(expected))
+	root, err = db.VanguardGenesisValidatorsRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, root)
+}
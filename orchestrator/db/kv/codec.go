@@ -0,0 +1,283 @@
+package kv
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/gob"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+)
+
+// Encoding is the serialization format of the values stored in db
+type Encoding string
+
+// Compression is the compression algorithm applied on top of the encoded values
+type Compression string
+
+const (
+	// JSONEncoding is the encoding of dbs created before the encoding was selectable
+	JSONEncoding Encoding = "json"
+	// GobEncoding is more compact and cheaper to decode than json
+	GobEncoding Encoding = "gob"
+
+	// NoCompression stores encoded values as they are
+	NoCompression Compression = "none"
+	// FlateCompression trades cpu for disk space
+	FlateCompression Compression = "flate"
+)
+
+// legacyCodec is used by the dbs which were created without storing their codec
+var legacyCodec = &codec{encoding: JSONEncoding, compression: NoCompression}
+
+// encodedValue is a bucket whose values are written by the codec
+type encodedValue struct {
+	bucket []byte
+	// key is set when only one key of the bucket is written by the codec
+	key []byte
+	// newValue returns a pointer to the decoded type of the values
+	newValue func() interface{}
+}
+
+var encodedValues = []encodedValue{
+	{bucket: consensusInfosBucket, newValue: func() interface{} { return new(*eventTypes.MinimalEpochConsensusInfo) }},
+	{bucket: consensusInfoSrcBucket, newValue: func() interface{} { return new(*eventTypes.EpochInfoSource) }},
+	{bucket: verifiedSlotInfosBucket, newValue: func() interface{} { return new(*eventTypes.SlotInfo) }},
+	{bucket: invalidSlotInfosBucket, newValue: func() interface{} { return new(*eventTypes.SlotInfo) }},
+	{bucket: disagreementsBucket, newValue: func() interface{} { return new(*eventTypes.ShardDisagreement) }},
+	{bucket: equivocationsBucket, newValue: func() interface{} { return new(*eventTypes.ShardEquivocation) }},
+	{bucket: shardConflictsBucket, newValue: func() interface{} { return new(*eventTypes.ShardConflict) }},
+	{bucket: accumulatorStepsBucket, newValue: func() interface{} { return new(*eventTypes.AccumulatorStep) }},
+	{bucket: epochSummariesBucket, newValue: func() interface{} { return new(*eventTypes.EpochSummary) }},
+	{bucket: reorgsBucket, newValue: func() interface{} { return new(*eventTypes.ReorgRecord) }},
+	{bucket: deferredTasksBucket, newValue: func() interface{} { return new(*eventTypes.DeferredTask) }},
+	{bucket: latestInfoMarkerBucket, key: lifetimeStatsKey, newValue: func() interface{} { return new(*eventTypes.LifetimeStats) }},
+	{bucket: chainIdentityBucket, key: pandoraChainIdentityKey, newValue: func() interface{} { return new(*eventTypes.PandoraChainIdentity) }},
+}
+
+// codec encodes and decodes db values with the encoding and compression selected at db creation
+type codec struct {
+	encoding    Encoding
+	compression Compression
+}
+
+// newCodec returns the codec of given encoding and compression. Empty values fall back to the legacy codec.
+func newCodec(encoding Encoding, compression Compression) (*codec, error) {
+	if encoding == "" {
+		encoding = legacyCodec.encoding
+	}
+	if compression == "" {
+		compression = legacyCodec.compression
+	}
+	switch encoding {
+	case JSONEncoding, GobEncoding:
+	default:
+		return nil, errors.Errorf("unsupported db value encoding %q", encoding)
+	}
+	switch compression {
+	case NoCompression, FlateCompression:
+	default:
+		return nil, errors.Errorf("unsupported db value compression %q", compression)
+	}
+	return &codec{encoding: encoding, compression: compression}, nil
+}
+
+// parseCodec parses the codec name which is stored in db
+func parseCodec(name string) (*codec, error) {
+	parts := strings.SplitN(name, "+", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid db codec %q", name)
+	}
+	return newCodec(Encoding(parts[0]), Compression(parts[1]))
+}
+
+// String returns the name of codec which is stored in db
+func (c *codec) String() string {
+	return string(c.encoding) + "+" + string(c.compression)
+}
+
+// encode
+func (c *codec) encode(v interface{}) ([]byte, error) {
+	var enc []byte
+	switch c.encoding {
+	case GobEncoding:
+		buffer := new(bytes.Buffer)
+		if err := gob.NewEncoder(buffer).Encode(v); err != nil {
+			return nil, err
+		}
+		enc = buffer.Bytes()
+	default:
+		var err error
+		if enc, err = encode(v); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.compression == FlateCompression {
+		return compress(enc)
+	}
+	return enc, nil
+}
+
+// decode
+func (c *codec) decode(data []byte, v interface{}) error {
+	if c.compression == FlateCompression {
+		var err error
+		if data, err = decompress(data); err != nil {
+			return err
+		}
+	}
+
+	switch c.encoding {
+	case GobEncoding:
+		return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+	default:
+		return decode(data, v)
+	}
+}
+
+// compress
+func compress(data []byte) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	writer, err := flate.NewWriter(buffer, flate.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decompress
+func decompress(data []byte) ([]byte, error) {
+	reader := flate.NewReader(bytes.NewReader(data))
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// loadCodec returns the codec stored in db. Fresh db stores and uses the given encoding and compression
+// whereas db which was created before the codec was stored keeps using the legacy codec.
+func (s *Store) loadCodec(encoding Encoding, compression Compression) (*codec, error) {
+	selected, err := newCodec(encoding, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored *codec
+	if err := s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		if name := bkt.Get(valueCodecKey); name != nil {
+			stored, err = parseCodec(string(name))
+			return err
+		}
+		stored = selected
+		if !hasNoEncodedValues(tx) {
+			stored = legacyCodec
+		}
+		return bkt.Put(valueCodecKey, []byte(stored.String()))
+	}); err != nil {
+		return nil, err
+	}
+
+	if stored.String() != selected.String() {
+		log.WithField("dbCodec", stored.String()).WithField("selectedCodec", selected.String()).
+			Warn("Db keeps the value encoding selected at creation. Run migrate-db command to change it")
+	}
+	return stored, nil
+}
+
+// MigrateCodec re-encodes every stored value with the given encoding and compression in a single transaction.
+func (s *Store) MigrateCodec(encoding Encoding, compression Compression) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	target, err := newCodec(encoding, compression)
+	if err != nil {
+		return err
+	}
+	if target.String() == s.codec.String() {
+		return nil
+	}
+
+	if err := s.db.Update(func(tx Tx) error {
+		for _, value := range encodedValues {
+			if err := reEncodeBucket(tx, value, s.codec, target); err != nil {
+				return errors.Wrapf(err, "could not re-encode %s bucket", value.bucket)
+			}
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(valueCodecKey, []byte(target.String()))
+	}); err != nil {
+		return err
+	}
+
+	log.WithField("from", s.codec.String()).WithField("to", target.String()).Info("Migrated db value encoding")
+	s.codec = target
+	return nil
+}
+
+// Codec returns the name of the codec which is used for db values
+func (s *Store) Codec() string {
+	return s.codec.String()
+}
+
+// reEncodeBucket decodes values of the bucket with the source codec and writes them back with the target codec
+func reEncodeBucket(tx Tx, value encodedValue, source, target *codec) error {
+	bkt := tx.Bucket(value.bucket)
+
+	var keys, values [][]byte
+	reEncode := func(k, v []byte) error {
+		decoded := value.newValue()
+		if err := source.decode(v, decoded); err != nil {
+			return err
+		}
+		enc, err := target.encode(reflect.ValueOf(decoded).Elem().Interface())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		values = append(values, enc)
+		return nil
+	}
+
+	if value.key != nil {
+		if v := bkt.Get(value.key); v != nil {
+			if err := reEncode(value.key, v); err != nil {
+				return err
+			}
+		}
+	} else if err := bkt.ForEach(reEncode); err != nil {
+		return err
+	}
+
+	// Bucket is not modified while it is iterated
+	for i, k := range keys {
+		if err := bkt.Put(k, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasNoEncodedValues returns true when none of the codec buckets has a value
+func hasNoEncodedValues(tx Tx) bool {
+	for _, value := range encodedValues {
+		bkt := tx.Bucket(value.bucket)
+		if value.key != nil {
+			if bkt.Get(value.key) != nil {
+				return false
+			}
+			continue
+		}
+		if k, _ := bkt.Cursor().First(); k != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package kv
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"math/big"
+	"testing"
+)
+
+var testCodecs = []struct {
+	encoding    Encoding
+	compression Compression
+}{
+	{JSONEncoding, NoCompression},
+	{JSONEncoding, FlateCompression},
+	{GobEncoding, NoCompression},
+	{GobEncoding, FlateCompression},
+}
+
+// Test_Codec_EncodingDecoding_Success
+func Test_Codec_EncodingDecoding_Success(t *testing.T) {
+	consensusInfo := testutil.NewMinimalConsensusInfo(1).ConvertToEpochInfo()
+	identity := &types.PandoraChainIdentity{ChainID: big.NewInt(4004181), GenesisHash: common.HexToHash("0x1")}
+
+	for _, tt := range testCodecs {
+		c, err := newCodec(tt.encoding, tt.compression)
+		require.NoError(t, err)
+
+		enc, err := c.encode(consensusInfo)
+		require.NoError(t, err)
+		var decodedConsensusInfo *types.MinimalEpochConsensusInfo
+		require.NoError(t, c.decode(enc, &decodedConsensusInfo))
+		assert.DeepEqual(t, consensusInfo, decodedConsensusInfo)
+
+		enc, err = c.encode(identity)
+		require.NoError(t, err)
+		var decodedIdentity *types.PandoraChainIdentity
+		require.NoError(t, c.decode(enc, &decodedIdentity))
+		assert.Equal(t, 0, identity.ChainID.Cmp(decodedIdentity.ChainID))
+		assert.Equal(t, identity.GenesisHash, decodedIdentity.GenesisHash)
+	}
+
+	_, err := newCodec("rlp", NoCompression)
+	assert.ErrorContains(t, "unsupported db value encoding", err)
+}
+
+// TestStore_Codec_SelectedAtCreation checks that reopened db keeps the codec which it was created with
+func TestStore_Codec_SelectedAtCreation(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir, &Config{Encoding: GobEncoding, Compression: FlateCompression})
+	require.NoError(t, err)
+	assert.Equal(t, "gob+flate", db.Codec())
+
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo))
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	assert.Equal(t, "gob+flate", db.Codec())
+
+	retrievedSlotInfo, err := db.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, retrievedSlotInfo)
+}
+
+// TestStore_MigrateCodec
+func TestStore_MigrateCodec(t *testing.T) {
+	db := setupDB(t, true)
+	assert.Equal(t, "json+none", db.Codec())
+
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo))
+	require.NoError(t, db.SaveInvalidSlotInfo(2, slotInfo))
+	identity := &types.PandoraChainIdentity{ChainID: big.NewInt(4004181), GenesisHash: common.HexToHash("0x3")}
+	require.NoError(t, db.SavePandoraChainIdentity(identity))
+	require.NoError(t, db.SaveVanguardGenesisValidatorsRoot([]byte{1, 2, 3}))
+
+	require.NoError(t, db.MigrateCodec(GobEncoding, FlateCompression))
+	assert.Equal(t, "gob+flate", db.Codec())
+	db.verifiedSlotInfoCache.Clear()
+
+	retrievedSlotInfo, err := db.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, retrievedSlotInfo)
+	retrievedSlotInfo, err = db.InvalidSlotInfo(2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, retrievedSlotInfo)
+	retrievedIdentity, err := db.PandoraChainIdentity()
+	require.NoError(t, err)
+	assert.Equal(t, identity.GenesisHash, retrievedIdentity.GenesisHash)
+	// raw values are not touched by migration
+	root, err := db.VanguardGenesisValidatorsRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1, 2, 3}, root)
+}
+
+// Benchmark_Codec reports encoded size of a consensus info next to encode and decode cost of every codec
+func Benchmark_Codec(b *testing.B) {
+	consensusInfo := testutil.NewMinimalConsensusInfo(1).ConvertToEpochInfo()
+	for _, tt := range testCodecs {
+		c, err := newCodec(tt.encoding, tt.compression)
+		require.NoError(b, err)
+
+		b.Run(c.String(), func(b *testing.B) {
+			var enc []byte
+			for i := 0; i < b.N; i++ {
+				enc, err = c.encode(consensusInfo)
+				require.NoError(b, err)
+				var decoded *types.MinimalEpochConsensusInfo
+				require.NoError(b, c.decode(enc, &decoded))
+			}
+			b.ReportMetric(float64(len(enc)), "bytes/value")
+		})
+	}
+}
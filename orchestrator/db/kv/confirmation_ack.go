@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+)
+
+// SaveLatestAckedSlot stores the highest slot which has been acknowledged by the pandora subscriber
+func (s *Store) SaveLatestAckedSlot(slot uint64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
+		if err := bkt.Put(latestAckedSlotKey, slotBytes); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+// LatestAckedSlot
+func (s *Store) LatestAckedSlot() uint64 {
+	var latestAckedSlot uint64
+	s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		slotBytes := bkt.Get(latestAckedSlotKey[:])
+		// not found the acked slot in db. so subscriber did not acknowledge anything yet
+		if slotBytes == nil {
+			log.Trace("Latest acked slot could not find in db. It may happen for brand new DB")
+			return nil
+		}
+		latestAckedSlot = bytesutil.BytesToUint64BigEndian(slotBytes)
+		return nil
+	})
+	return latestAckedSlot
+}
+
+// SaveConsumerAckedSlot stores the highest slot which has been acknowledged by the named pandora consumer
+func (s *Store) SaveConsumerAckedSlot(consumer string, slot uint64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(consumerAcksBucket).Put([]byte(consumer), bytesutil.Uint64ToBytesBigEndian(slot))
+	})
+}
+
+// ConsumerAckedSlot returns the highest slot which has been acknowledged by the named pandora consumer
+func (s *Store) ConsumerAckedSlot(consumer string) uint64 {
+	var ackedSlot uint64
+	s.db.View(func(tx Tx) error {
+		if slotBytes := tx.Bucket(consumerAcksBucket).Get([]byte(consumer)); slotBytes != nil {
+			ackedSlot = bytesutil.BytesToUint64BigEndian(slotBytes)
+		}
+		return nil
+	})
+	return ackedSlot
+}
@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestStore_LatestAckedSlot(t *testing.T) {
+	db := setupDB(t, true)
+	assert.Equal(t, uint64(0), db.LatestAckedSlot())
+
+	require.NoError(t, db.SaveLatestAckedSlot(42))
+	assert.Equal(t, uint64(42), db.LatestAckedSlot())
+
+	require.NoError(t, db.SaveLatestAckedSlot(43))
+	assert.Equal(t, uint64(43), db.LatestAckedSlot())
+
+	// named consumers are tracked independently of the default consumer
+	assert.Equal(t, uint64(0), db.ConsumerAckedSlot("standby"))
+	require.NoError(t, db.SaveConsumerAckedSlot("standby", 40))
+	assert.Equal(t, uint64(40), db.ConsumerAckedSlot("standby"))
+	assert.Equal(t, uint64(43), db.LatestAckedSlot())
+}
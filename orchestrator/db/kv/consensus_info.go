@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/boltdb/bolt"
 	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
@@ -12,6 +11,9 @@ import (
 
 var errInvalidEpoch = errors.New("invalid epoch and not found any consensusInfo for the given epoch")
 
+// ErrEpochInfoPruned is returned for a range which starts before the earliest epoch whose consensus info is kept
+var ErrEpochInfoPruned = errors.New("epoch info is pruned")
+
 // ConsensusInfo
 func (s *Store) ConsensusInfo(ctx context.Context, epoch uint64) (*eventTypes.MinimalEpochConsensusInfo, error) {
 	// Return consensus info from cache if it exists.
@@ -20,14 +22,14 @@ func (s *Store) ConsensusInfo(ctx context.Context, epoch uint64) (*eventTypes.Mi
 	}
 	// consensus info not found in cache so retrieve from db
 	var consensusInfo *eventTypes.MinimalEpochConsensusInfo
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(consensusInfosBucket)
 		key := bytesutil.Uint64ToBytesBigEndian(epoch)
 		enc := bkt.Get(key[:])
 		if enc == nil {
 			return nil
 		}
-		return decode(enc, &consensusInfo)
+		return s.codec.decode(enc, &consensusInfo)
 	})
 	return consensusInfo, err
 }
@@ -36,16 +38,30 @@ func (s *Store) ConsensusInfo(ctx context.Context, epoch uint64) (*eventTypes.Mi
 func (s *Store) ConsensusInfos(fromEpoch uint64) (
 	[]*eventTypes.MinimalEpochConsensusInfo, error,
 ) {
+	return s.ConsensusInfoRange(fromEpoch, s.LatestSavedEpoch())
+}
+
+// ConsensusInfoRange returns the consecutive consensus infos of [fromEpoch, toEpoch]. It stops at the first missing
+// epoch or at the latest saved epoch. A range from a pruned epoch fails with ErrEpochInfoPruned.
+func (s *Store) ConsensusInfoRange(fromEpoch, toEpoch uint64) (
+	[]*eventTypes.MinimalEpochConsensusInfo, error,
+) {
+	if earliestEpoch := s.EarliestConsensusInfoEpoch(); fromEpoch < earliestEpoch {
+		return nil, errors.Wrapf(ErrEpochInfoPruned, "epoch %d is before the earliest epoch %d", fromEpoch, earliestEpoch)
+	}
 	latestEpoch := s.LatestSavedEpoch()
 	// when requested epoch is greater than stored latest epoch
 	if fromEpoch > latestEpoch {
 		return nil, errors.Wrap(errInvalidEpoch, fmt.Sprintf("fromEpoch: %d", fromEpoch))
 	}
+	if toEpoch > latestEpoch {
+		toEpoch = latestEpoch
+	}
 
 	consensusInfos := make([]*eventTypes.MinimalEpochConsensusInfo, 0)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(consensusInfosBucket)
-		for epoch := fromEpoch; epoch <= latestEpoch; epoch++ {
+		for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
 			// fast finding into cache, if the value does not exist in cache, it starts finding into db
 			if v, _ := s.consensusInfoCache.Get(epoch); v != nil {
 				consensusInfos = append(consensusInfos, v.(*eventTypes.MinimalEpochConsensusInfo))
@@ -58,7 +74,7 @@ func (s *Store) ConsensusInfos(fromEpoch uint64) (
 				return nil
 			}
 			var consensusInfo *eventTypes.MinimalEpochConsensusInfo
-			decode(enc, &consensusInfo)
+			s.codec.decode(enc, &consensusInfo)
 			consensusInfos = append(consensusInfos, consensusInfo)
 		}
 		return nil
@@ -80,10 +96,10 @@ func (s *Store) SaveConsensusInfo(
 	defer s.Mutex.Unlock()
 
 	// storing consensus info into cache and db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(consensusInfosBucket)
 		epochBytes := bytesutil.Uint64ToBytesBigEndian(consensusInfo.Epoch)
-		enc, err := encode(consensusInfo)
+		enc, err := s.codec.encode(consensusInfo)
 		if err != nil {
 			return err
 		}
@@ -102,25 +118,58 @@ func (s *Store) RemoveRangeConsensusInfo(startEpoch, endEpoch uint64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(consensusInfosBucket)
+		srcBkt := tx.Bucket(consensusInfoSrcBucket)
 		for i := startEpoch; i <= endEpoch; i++ {
 			s.consensusInfoCache.Del(i)
 			epochBytes := bytesutil.Uint64ToBytesBigEndian(i)
 			if err := bkt.Delete(epochBytes); err != nil {
 				return err
 			}
+			if err := srcBkt.Delete(epochBytes); err != nil {
+				return err
+			}
 		}
 		return nil
 	})
 }
 
+// ConsensusInfoSource returns the vanguard block from which the epoch's proposer list is derived
+func (s *Store) ConsensusInfoSource(epoch uint64) (*eventTypes.EpochInfoSource, error) {
+	var source *eventTypes.EpochInfoSource
+	err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(consensusInfoSrcBucket)
+		enc := bkt.Get(bytesutil.Uint64ToBytesBigEndian(epoch))
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &source)
+	})
+	return source, err
+}
+
+// SaveConsensusInfoSource
+func (s *Store) SaveConsensusInfoSource(epoch uint64, source *eventTypes.EpochInfoSource) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(consensusInfoSrcBucket)
+		enc, err := s.codec.encode(source)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(epoch), enc)
+	})
+}
+
 // LatestSavedEpoch
 func (s *Store) LatestSavedEpoch() uint64 {
 	var latestSavedEpoch uint64
 	// Db is not prepared yet. Retrieve latest saved epoch number from db
 	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
+		s.db.View(func(tx Tx) error {
 			bkt := tx.Bucket(latestInfoMarkerBucket)
 			epochBytes := bkt.Get(lastStoredEpochKey[:])
 			// not found the latest epoch in db. so latest epoch will be zero
@@ -143,7 +192,7 @@ func (s *Store) SaveLatestEpoch(ctx context.Context, epoch uint64) error {
 	defer s.Mutex.Unlock()
 
 	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(latestInfoMarkerBucket)
 		epochBytes := bytesutil.Uint64ToBytesBigEndian(epoch)
 		if err := bkt.Put(lastStoredEpochKey, epochBytes); err != nil {
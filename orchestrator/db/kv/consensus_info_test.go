@@ -2,6 +2,7 @@ package kv
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
@@ -72,6 +73,15 @@ func TestStore_ConsensusInfos_RetrieveByEpoch(t *testing.T) {
 	retrievedConsensusInfos, err := db.ConsensusInfos(10)
 	require.NoError(t, err)
 	assert.DeepEqual(t, totalConsensusInfos[10:], retrievedConsensusInfos)
+
+	retrievedConsensusInfos, err = db.ConsensusInfoRange(10, 19)
+	require.NoError(t, err)
+	assert.DeepEqual(t, totalConsensusInfos[10:20], retrievedConsensusInfos)
+
+	// range is clamped to the latest saved epoch
+	retrievedConsensusInfos, err = db.ConsensusInfoRange(190, 1000)
+	require.NoError(t, err)
+	assert.DeepEqual(t, totalConsensusInfos[190:], retrievedConsensusInfos)
 }
 
 // TestStore_LatestSavedEpoch
@@ -110,3 +120,27 @@ func TestStore_LatestEpoch_ClosingDB_OpeningDB(t *testing.T) {
 
 	require.NoError(t, db.Close())
 }
+
+func TestStore_ConsensusInfoSource(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	source, err := db.ConsensusInfoSource(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*eventTypes.EpochInfoSource)(nil), source)
+
+	expected := &eventTypes.EpochInfoSource{
+		Slot:      95,
+		BlockRoot: common.HexToHash("0x6f701e4e8b260f38a43cdc0d97cfdc7f0cd33f58ef26bbc6c327ac87d76304d2"),
+		StateRoot: common.HexToHash("0x0846da512db0a6888a59aa5f7235b741e36a9dcacc9dad33ee2a228878aefa74"),
+	}
+	require.NoError(t, db.SaveConsensusInfoSource(3, expected))
+	source, err = db.ConsensusInfoSource(3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected, source)
+
+	require.NoError(t, db.RemoveRangeConsensusInfo(3, 3))
+	source, err = db.ConsensusInfoSource(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*eventTypes.EpochInfoSource)(nil), source)
+}
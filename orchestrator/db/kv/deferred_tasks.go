@@ -0,0 +1,86 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// EnqueueTask appends the task to the queue and assigns its id. When the latest queued task is of the same kind,
+// was not tried yet and its slot range overlaps or touches the new one, the ranges are merged into that task, so that
+// a burst of failures of consecutive slots is queued as a single task.
+func (s *Store) EnqueueTask(task *types.DeferredTask) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(deferredTasksBucket)
+		if _, v := bkt.Cursor().Last(); v != nil {
+			var last *types.DeferredTask
+			if err := s.codec.decode(v, &last); err != nil {
+				return err
+			}
+			if last.Kind == task.Kind && last.Attempts == 0 &&
+				task.FromSlot <= last.ToSlot+1 && last.FromSlot <= task.ToSlot+1 {
+				if task.FromSlot < last.FromSlot {
+					last.FromSlot = task.FromSlot
+				}
+				if task.ToSlot > last.ToSlot {
+					last.ToSlot = task.ToSlot
+				}
+				*task = *last
+				return s.putTask(bkt, last)
+			}
+		}
+		id, err := bkt.NextSequence()
+		if err != nil {
+			return err
+		}
+		task.Id = id
+		return s.putTask(bkt, task)
+	})
+}
+
+// SaveTask updates the queued task in place
+func (s *Store) SaveTask(task *types.DeferredTask) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		return s.putTask(tx.Bucket(deferredTasksBucket), task)
+	})
+}
+
+// DeleteTask removes the task from the queue
+func (s *Store) DeleteTask(id uint64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(deferredTasksBucket).Delete(bytesutil.Uint64ToBytesBigEndian(id))
+	})
+}
+
+// DeferredTasks returns every queued task in the order they were enqueued
+func (s *Store) DeferredTasks() ([]*types.DeferredTask, error) {
+	tasks := make([]*types.DeferredTask, 0)
+	err := s.db.View(func(tx Tx) error {
+		return tx.Bucket(deferredTasksBucket).ForEach(func(k, v []byte) error {
+			var task *types.DeferredTask
+			if err := s.codec.decode(v, &task); err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+			return nil
+		})
+	})
+	return tasks, err
+}
+
+// putTask
+func (s *Store) putTask(bkt Bucket, task *types.DeferredTask) error {
+	enc, err := s.codec.encode(task)
+	if err != nil {
+		return err
+	}
+	return bkt.Put(bytesutil.Uint64ToBytesBigEndian(task.Id), enc)
+}
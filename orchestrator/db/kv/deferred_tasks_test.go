@@ -0,0 +1,48 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_DeferredTasks(t *testing.T) {
+	db := setupDB(t, true)
+
+	first := &types.DeferredTask{Kind: "republish", FromSlot: 10, ToSlot: 10, CreatedAt: 1000}
+	require.NoError(t, db.EnqueueTask(first))
+	assert.Equal(t, uint64(1), first.Id)
+
+	// consecutive slots of the same kind are merged into the untried task
+	merged := &types.DeferredTask{Kind: "republish", FromSlot: 11, ToSlot: 12, CreatedAt: 1001}
+	require.NoError(t, db.EnqueueTask(merged))
+	assert.Equal(t, uint64(1), merged.Id)
+	assert.Equal(t, uint64(10), merged.FromSlot)
+	assert.Equal(t, uint64(12), merged.ToSlot)
+
+	// other kinds and gaps are queued separately
+	prune := &types.DeferredTask{Kind: "prune", ToSlot: 64}
+	require.NoError(t, db.EnqueueTask(prune))
+	assert.Equal(t, uint64(2), prune.Id)
+	gap := &types.DeferredTask{Kind: "prune", FromSlot: 100, ToSlot: 128}
+	require.NoError(t, db.EnqueueTask(gap))
+	assert.Equal(t, uint64(3), gap.Id)
+
+	// tried tasks are not extended
+	gap.Attempts = 1
+	gap.LastError = "db is busy"
+	require.NoError(t, db.SaveTask(gap))
+	next := &types.DeferredTask{Kind: "prune", FromSlot: 129, ToSlot: 160}
+	require.NoError(t, db.EnqueueTask(next))
+	assert.Equal(t, uint64(4), next.Id)
+
+	require.NoError(t, db.DeleteTask(prune.Id))
+	tasks, err := db.DeferredTasks()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(tasks))
+	assert.DeepEqual(t, merged, tasks[0])
+	assert.DeepEqual(t, gap, tasks[1])
+	assert.DeepEqual(t, next, tasks[2])
+}
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+)
+
+// diagnosticBuckets keep evidences which are useful for debugging but not needed for verification
+var diagnosticBuckets = [][]byte{disagreementsBucket, equivocationsBucket}
+
+// Size returns the size of the db file in bytes
+func (s *Store) Size() (int64, error) {
+	var size int64
+	err := s.db.View(func(tx Tx) error {
+		size = tx.Size()
+		return nil
+	})
+	return size, err
+}
+
+// PauseDiagnostics stops or resumes recording shard disagreements and equivocations
+func (s *Store) PauseDiagnostics(paused bool) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+	s.diagnosticsPaused = paused
+}
+
+// PruneDiagnostics removes shard disagreements and equivocations of slots before the given slot and returns the
+// number of removed entries. Archive db is never pruned. Freed pages are reused by later writes, so the db file
+// stops growing until they are filled.
+func (s *Store) PruneDiagnostics(beforeSlot uint64) (int, error) {
+	if s.archive {
+		return 0, nil
+	}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	removed := 0
+	err := s.db.Update(func(tx Tx) error {
+		end := bytesutil.Uint64ToBytesBigEndian(beforeSlot)
+		for _, bucket := range diagnosticBuckets {
+			bkt := tx.Bucket(bucket)
+			var keys [][]byte
+			c := bkt.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+			removed += len(keys)
+		}
+		return nil
+	})
+	return removed, err
+}
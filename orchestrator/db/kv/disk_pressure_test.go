@@ -0,0 +1,47 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_PruneDiagnostics(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	size, err := db.Size()
+	require.NoError(t, err)
+	assert.Equal(t, true, size > 0)
+
+	for slot := uint64(1); slot <= 5; slot++ {
+		require.NoError(t, db.SaveShardDisagreement(&types.ShardDisagreement{Slot: slot, Field: "txHash"}))
+		require.NoError(t, db.SaveShardEquivocation(&types.ShardEquivocation{Slot: slot, ProposerIndex: 7}))
+	}
+
+	removed, err := db.PruneDiagnostics(4)
+	require.NoError(t, err)
+	assert.Equal(t, 6, removed)
+	disagreements, err := db.ShardDisagreements(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(disagreements))
+	assert.Equal(t, uint64(4), disagreements[0].Slot)
+	equivocations, err := db.ShardEquivocations(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(equivocations))
+
+	// paused diagnostics are not recorded
+	db.PauseDiagnostics(true)
+	require.NoError(t, db.SaveShardDisagreement(&types.ShardDisagreement{Slot: 6, Field: "txHash"}))
+	disagreement, err := db.ShardDisagreement(6)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.ShardDisagreement)(nil), disagreement)
+
+	db.PauseDiagnostics(false)
+	require.NoError(t, db.SaveShardDisagreement(&types.ShardDisagreement{Slot: 6, Field: "txHash"}))
+	disagreement, err = db.ShardDisagreement(6)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), disagreement.Slot)
+}
@@ -0,0 +1,34 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveEpochSummary stores the summary of the epoch. Summary of an epoch which is replayed after reorg is overwritten.
+func (s *Store) SaveEpochSummary(summary *types.EpochSummary) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		enc, err := s.codec.encode(summary)
+		if err != nil {
+			return err
+		}
+		bkt := tx.Bucket(epochSummariesBucket)
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(summary.Epoch), enc)
+	})
+}
+
+// EpochSummary returns the summary of the given epoch. Returns nil when the epoch is not summarized yet.
+func (s *Store) EpochSummary(epoch uint64) (*types.EpochSummary, error) {
+	var summary *types.EpochSummary
+	err := s.db.View(func(tx Tx) error {
+		enc := tx.Bucket(epochSummariesBucket).Get(bytesutil.Uint64ToBytesBigEndian(epoch))
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &summary)
+	})
+	return summary, err
+}
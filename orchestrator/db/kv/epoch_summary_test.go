@@ -0,0 +1,37 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_EpochSummary(t *testing.T) {
+	db := setupDB(t, true)
+
+	summary, err := db.EpochSummary(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.EpochSummary)(nil), summary)
+
+	want := &types.EpochSummary{
+		Epoch:                      3,
+		VerifiedSlots:              29,
+		InvalidSlots:               1,
+		SkippedSlots:               2,
+		Reorgs:                     1,
+		AverageConfirmationLatency: 850,
+	}
+	require.NoError(t, db.SaveEpochSummary(want))
+	summary, err = db.EpochSummary(3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, summary)
+
+	// replayed epoch overwrites the previous summary
+	want.VerifiedSlots, want.InvalidSlots = 30, 0
+	require.NoError(t, db.SaveEpochSummary(want))
+	summary, err = db.EpochSummary(3)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(30), summary.VerifiedSlots)
+}
@@ -0,0 +1,264 @@
+package kv
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// snapshotVersion is the version of the exported snapshot layout
+	snapshotVersion = 1
+	// snapshotManifestName is the first entry of the snapshot, so that buckets are verified while they are read
+	snapshotManifestName = "manifest.json"
+	// snapshotBucketDir holds one entry per bucket with the key-value records of the bucket
+	snapshotBucketDir = "buckets"
+)
+
+var errSnapshotNotEmpty = errors.New("database is not empty, import only into a new database")
+
+// snapshotManifest lists the exported buckets with their integrity checksums
+type snapshotManifest struct {
+	Version int               `json:"version"`
+	Buckets []*snapshotBucket `json:"buckets"`
+}
+
+// snapshotBucket is the size, key count and sha256 checksum of the records of an exported bucket
+type snapshotBucket struct {
+	Name     string `json:"name"`
+	Keys     uint64 `json:"keys"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// Export writes every bucket of the db as a gzipped tar snapshot. Buckets are read within a single transaction, so
+// the snapshot is consistent.
+func (s *Store) Export(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := s.db.View(func(tx Tx) error {
+		// records are counted and hashed first, since the manifest and tar headers precede the records
+		manifest := &snapshotManifest{Version: snapshotVersion}
+		if err := tx.ForEach(func(name []byte, bkt Bucket) error {
+			counter := &countingWriter{hash: sha256.New()}
+			keys, err := writeRecords(counter, bkt)
+			if err != nil {
+				return err
+			}
+			manifest.Buckets = append(manifest.Buckets, &snapshotBucket{
+				Name:     string(name),
+				Keys:     keys,
+				Size:     counter.size,
+				Checksum: hex.EncodeToString(counter.hash.Sum(nil)),
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		enc, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+		if err := writeTarEntry(tw, snapshotManifestName, int64(len(enc))); err != nil {
+			return err
+		}
+		if _, err := tw.Write(enc); err != nil {
+			return err
+		}
+		for _, exported := range manifest.Buckets {
+			if err := writeTarEntry(tw, path.Join(snapshotBucketDir, exported.Name), exported.Size); err != nil {
+				return err
+			}
+			if _, err := writeRecords(tw, tx.Bucket([]byte(exported.Name))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Import restores the buckets of a snapshot written by Export into a new db. Every bucket is checked against the
+// checksum of the manifest and the import is rolled back when any of them does not match. The db must be reopened
+// after import, so that the value codec of the snapshot is loaded.
+func (s *Store) Import(r io.Reader) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "could not read snapshot")
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return err
+	}
+	expected := make(map[string]*snapshotBucket, len(manifest.Buckets))
+	for _, imported := range manifest.Buckets {
+		expected[imported.Name] = imported
+	}
+
+	return s.db.Update(func(tx Tx) error {
+		for _, name := range [][]byte{verifiedSlotInfosBucket, consensusInfosBucket} {
+			if key, _ := tx.Bucket(name).Cursor().First(); key != nil {
+				return errSnapshotNotEmpty
+			}
+		}
+
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "could not read snapshot")
+			}
+			name := strings.TrimPrefix(header.Name, snapshotBucketDir+"/")
+			want, ok := expected[name]
+			if !ok || name == header.Name {
+				return errors.Errorf("unexpected snapshot entry %s", header.Name)
+			}
+			if err := importBucket(tx, []byte(name), tr, want); err != nil {
+				return errors.Wrapf(err, "could not import bucket %s", name)
+			}
+			delete(expected, name)
+		}
+		for name := range expected {
+			return errors.Errorf("bucket %s is missing from snapshot", name)
+		}
+		return nil
+	})
+}
+
+// importBucket replaces the bucket with the records of the snapshot entry and verifies them against the manifest
+func importBucket(tx Tx, name []byte, r io.Reader, want *snapshotBucket) error {
+	if tx.Bucket(name) != nil {
+		if err := tx.DeleteBucket(name); err != nil {
+			return err
+		}
+	}
+	bkt, err := tx.CreateBucket(name)
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.New()
+	reader := bufio.NewReader(io.TeeReader(r, checksum))
+	var keys uint64
+	for {
+		key, err := readRecordField(reader)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		value, err := readRecordField(reader)
+		if err != nil {
+			return errors.Wrap(err, "truncated record")
+		}
+		if err := bkt.Put(key, value); err != nil {
+			return err
+		}
+		keys++
+	}
+	if got := hex.EncodeToString(checksum.Sum(nil)); got != want.Checksum || keys != want.Keys {
+		return errors.Errorf("checksum mismatch, want %s with %d keys, got %s with %d keys",
+			want.Checksum, want.Keys, got, keys)
+	}
+	return nil
+}
+
+func readManifest(tr *tar.Reader) (*snapshotManifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read snapshot")
+	}
+	if header.Name != snapshotManifestName {
+		return nil, errors.Errorf("snapshot starts with %s instead of manifest", header.Name)
+	}
+	var manifest *snapshotManifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, errors.Wrap(err, "could not decode snapshot manifest")
+	}
+	if manifest.Version != snapshotVersion {
+		return nil, errors.Errorf("unsupported snapshot version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+func writeTarEntry(tw *tar.Writer, name string, size int64) error {
+	return tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	})
+}
+
+// writeRecords writes the key-value pairs of the bucket as length prefixed records and returns the number of keys
+func writeRecords(w io.Writer, bkt Bucket) (uint64, error) {
+	var keys uint64
+	err := bkt.ForEach(func(key, value []byte) error {
+		if err := writeRecordField(w, key); err != nil {
+			return err
+		}
+		keys++
+		return writeRecordField(w, value)
+	})
+	return keys, err
+}
+
+func writeRecordField(w io.Writer, field []byte) error {
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(field)))
+	if _, err := w.Write(size[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(field)
+	return err
+}
+
+func readRecordField(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	field := make([]byte, size)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// countingWriter hashes and counts the written bytes
+type countingWriter struct {
+	hash hash.Hash
+	size int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+	return w.hash.Write(p)
+}
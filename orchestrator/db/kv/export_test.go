@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, true)
+	epochInfo := testutil.NewMinimalConsensusInfo(1).ConvertToEpochInfo()
+	require.NoError(t, db.SaveConsensusInfo(ctx, epochInfo))
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo))
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 1))
+	step := &types.AccumulatorStep{Slot: 1, Leaf: slotInfo.Root(), Root: common.HexToHash("0x3")}
+	require.NoError(t, db.SaveAccumulatorStep(step))
+	require.NoError(t, db.SaveLatestFinalizedSlot(1))
+
+	var snapshot bytes.Buffer
+	require.NoError(t, db.Export(&snapshot))
+
+	// snapshot is only imported into a new db
+	assert.ErrorContains(t, errSnapshotNotEmpty.Error(), db.Import(bytes.NewReader(snapshot.Bytes())))
+
+	// truncated snapshot is rejected as a whole
+	dir := t.TempDir()
+	imported, err := NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	assert.NotNil(t, imported.Import(bytes.NewReader(snapshot.Bytes()[:snapshot.Len()/2])))
+	retrieved, err := imported.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), retrieved)
+
+	require.NoError(t, imported.Import(bytes.NewReader(snapshot.Bytes())))
+	require.NoError(t, imported.Close())
+
+	imported, err = NewKVStore(ctx, dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, imported.Close())
+	}()
+	retrieved, err = imported.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, retrieved)
+	assert.Equal(t, uint64(1), imported.LatestSavedVerifiedSlot())
+	assert.Equal(t, uint64(1), imported.LatestLatestFinalizedSlot())
+	retrievedEpochInfo, err := imported.ConsensusInfo(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, epochInfo, retrievedEpochInfo)
+	retrievedStep, err := imported.AccumulatorStep(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, step, retrievedStep)
+}
+
+func TestImportBucket_ChecksumMismatch(t *testing.T) {
+	db := setupDB(t, true)
+	want := &snapshotBucket{Name: string(consensusInfosBucket), Keys: 1, Checksum: "00"}
+	var records bytes.Buffer
+	require.NoError(t, writeRecordField(&records, []byte("key")))
+	require.NoError(t, writeRecordField(&records, []byte("value")))
+	err := db.db.Update(func(tx Tx) error {
+		return importBucket(tx, consensusInfosBucket, &records, want)
+	})
+	assert.ErrorContains(t, "checksum mismatch", err)
+}
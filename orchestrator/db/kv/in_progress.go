@@ -0,0 +1,32 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+)
+
+// MarkSlotInProgress persists that writes of the slot's verification have started. The marker is cleared
+// after the last write, so markers found at startup point to slots which are half-written.
+func (s *Store) MarkSlotInProgress(slot uint64) error {
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(inProgressSlotsBucket).Put(bytesutil.Uint64ToBytesBigEndian(slot), []byte{})
+	})
+}
+
+// ClearSlotInProgress removes the in-progress marker of the slot
+func (s *Store) ClearSlotInProgress(slot uint64) error {
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(inProgressSlotsBucket).Delete(bytesutil.Uint64ToBytesBigEndian(slot))
+	})
+}
+
+// InProgressSlots returns the slots whose verification writes were not completed, in ascending order
+func (s *Store) InProgressSlots() ([]uint64, error) {
+	slots := make([]uint64, 0)
+	err := s.db.View(func(tx Tx) error {
+		return tx.Bucket(inProgressSlotsBucket).ForEach(func(k, v []byte) error {
+			slots = append(slots, bytesutil.BytesToUint64BigEndian(k))
+			return nil
+		})
+	})
+	return slots, err
+}
@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestStore_InProgressSlots(t *testing.T) {
+	db := setupDB(t, true)
+
+	slots, err := db.InProgressSlots()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(slots))
+
+	require.NoError(t, db.MarkSlotInProgress(300))
+	require.NoError(t, db.MarkSlotInProgress(5))
+	require.NoError(t, db.MarkSlotInProgress(17))
+	require.NoError(t, db.ClearSlotInProgress(17))
+
+	slots, err = db.InProgressSlots()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []uint64{5, 300}, slots)
+}
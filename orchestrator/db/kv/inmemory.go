@@ -0,0 +1,20 @@
+package kv
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// openInMemory opens a leveldb whose tables and journal are kept in memory storage, so nothing is written to disk
+// and nothing is left behind when the process dies. The db has no directory, so its size on disk is zero.
+func openInMemory(config *Config) (*levelDBBackend, error) {
+	if config.Backend != "" && config.Backend != LevelDBBackend {
+		return nil, errors.Errorf("in-memory db is only supported by the %s backend", LevelDBBackend)
+	}
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBBackend{db: db, noSync: true}, nil
+}
@@ -0,0 +1,62 @@
+package kv
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SlotOfPandoraHeaderHash returns the verified or invalid slot of the pandora header. Archive db looks verified
+// slots up in its index, otherwise the slot infos are scanned from the latest slot down.
+func (s *Store) SlotOfPandoraHeaderHash(hash common.Hash) (uint64, bool, error) {
+	if s.archive {
+		if slot, found, err := s.SlotByPandoraHeaderHash(hash); err != nil || found {
+			return slot, found, err
+		}
+	}
+	var (
+		slot  uint64
+		found bool
+	)
+	err := s.db.View(func(tx Tx) error {
+		for _, bucket := range [][]byte{verifiedSlotInfosBucket, invalidSlotInfosBucket} {
+			c := tx.Bucket(bucket).Cursor()
+			for k, v := c.Last(); k != nil; k, v = c.Prev() {
+				var slotInfo *types.SlotInfo
+				if err := s.codec.decode(v, &slotInfo); err != nil {
+					return err
+				}
+				if slotInfo != nil && slotInfo.PandoraHeaderHash == hash {
+					slot, found = bytesutil.BytesToUint64BigEndian(k), true
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	return slot, found, err
+}
+
+// AccumulatorStepByIndex returns the accumulator step of the given leaf index. Returns nil when there is no such step.
+func (s *Store) AccumulatorStepByIndex(index uint64) (*types.AccumulatorStep, error) {
+	var step *types.AccumulatorStep
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(accumulatorStepsBucket).Cursor()
+		// leaf indexes grow with the slot
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var candidate *types.AccumulatorStep
+			if err := s.codec.decode(v, &candidate); err != nil {
+				return err
+			}
+			if candidate.LeafIndex == index {
+				step = candidate
+				return nil
+			}
+			if candidate.LeafIndex > index {
+				return nil
+			}
+		}
+		return nil
+	})
+	return step, err
+}
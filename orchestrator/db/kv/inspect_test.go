@@ -0,0 +1,57 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ReadOnlyInspection(t *testing.T) {
+	dbPath := t.TempDir()
+	_, err := NewKVStore(context.Background(), dbPath, &Config{ReadOnly: true})
+	require.ErrorContains(t, "no db in", err)
+
+	db, err := NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	for slot := uint64(1); slot <= 5; slot++ {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot + 100)}),
+		}))
+		require.NoError(t, db.SaveAccumulatorStep(&types.AccumulatorStep{Slot: slot, LeafIndex: slot - 1}))
+	}
+	require.NoError(t, db.SaveInvalidSlotInfo(6, &types.SlotInfo{PandoraHeaderHash: common.BytesToHash([]byte{6})}))
+	require.NoError(t, db.Close())
+
+	readOnly, err := NewKVStore(context.Background(), dbPath, &Config{ReadOnly: true})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, readOnly.Close())
+	}()
+
+	slot, found, err := readOnly.SlotOfPandoraHeaderHash(common.BytesToHash([]byte{3}))
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(3), slot)
+	slot, found, err = readOnly.SlotOfPandoraHeaderHash(common.BytesToHash([]byte{6}))
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(6), slot)
+	_, found, err = readOnly.SlotOfPandoraHeaderHash(common.BytesToHash([]byte{7}))
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+
+	step, err := readOnly.AccumulatorStepByIndex(2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), step.Slot)
+	step, err = readOnly.AccumulatorStepByIndex(10)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.AccumulatorStep)(nil), step)
+
+	// writes are refused
+	require.NotNil(t, readOnly.SaveInvalidSlotInfo(7, &types.SlotInfo{}))
+}
@@ -1,7 +1,6 @@
 package kv
 
 import (
-	"github.com/boltdb/bolt"
 	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
@@ -9,14 +8,14 @@ import (
 // InvalidSlotInfo
 func (s *Store) InvalidSlotInfo(slot uint64) (*types.SlotInfo, error) {
 	var slotInfo *types.SlotInfo
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(invalidSlotInfosBucket)
 		key := bytesutil.Uint64ToBytesBigEndian(slot)
 		value := bkt.Get(key[:])
 		if value == nil {
 			return nil
 		}
-		return decode(value, &slotInfo)
+		return s.codec.decode(value, &slotInfo)
 	})
 	return slotInfo, err
 }
@@ -27,10 +26,10 @@ func (s *Store) SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
 	defer s.Mutex.Unlock()
 
 	// storing consensus info into cache and db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(invalidSlotInfosBucket)
 		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
-		enc, err := encode(slotInfo)
+		enc, err := s.codec.encode(slotInfo)
 		if err != nil {
 			return err
 		}
@@ -40,3 +39,140 @@ func (s *Store) SaveInvalidSlotInfo(slot uint64, slotInfo *types.SlotInfo) error
 		return nil
 	})
 }
+
+// ShardDisagreement returns both sides of the given slot's sharding info when they did not match
+func (s *Store) ShardDisagreement(slot uint64) (*types.ShardDisagreement, error) {
+	var disagreement *types.ShardDisagreement
+	err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(disagreementsBucket)
+		value := bkt.Get(bytesutil.Uint64ToBytesBigEndian(slot))
+		if value == nil {
+			return nil
+		}
+		return s.codec.decode(value, &disagreement)
+	})
+	return disagreement, err
+}
+
+// ShardDisagreements returns at most limit disagreements starting from the given slot. Zero limit means no limit.
+func (s *Store) ShardDisagreements(fromSlot uint64, limit int) ([]*types.ShardDisagreement, error) {
+	disagreements := make([]*types.ShardDisagreement, 0)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(disagreementsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil; k, v = c.Next() {
+			if limit > 0 && len(disagreements) >= limit {
+				return nil
+			}
+			var disagreement *types.ShardDisagreement
+			if err := s.codec.decode(v, &disagreement); err != nil {
+				return err
+			}
+			disagreements = append(disagreements, disagreement)
+		}
+		return nil
+	})
+	return disagreements, err
+}
+
+// SaveShardDisagreement
+func (s *Store) SaveShardDisagreement(disagreement *types.ShardDisagreement) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if s.diagnosticsPaused {
+		log.WithField("slot", disagreement.Slot).Debug("Disk pressure, not recording shard disagreement")
+		return nil
+	}
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(disagreementsBucket)
+		enc, err := s.codec.encode(disagreement)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesBigEndian(disagreement.Slot), enc)
+	})
+}
+
+// ShardEquivocations returns at most limit proposer equivocations starting from the given slot. Zero limit means no limit.
+func (s *Store) ShardEquivocations(fromSlot uint64, limit int) ([]*types.ShardEquivocation, error) {
+	equivocations := make([]*types.ShardEquivocation, 0)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(equivocationsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil; k, v = c.Next() {
+			if limit > 0 && len(equivocations) >= limit {
+				return nil
+			}
+			var equivocation *types.ShardEquivocation
+			if err := s.codec.decode(v, &equivocation); err != nil {
+				return err
+			}
+			equivocations = append(equivocations, equivocation)
+		}
+		return nil
+	})
+	return equivocations, err
+}
+
+// SaveShardEquivocation keeps the first evidence of every slot
+func (s *Store) SaveShardEquivocation(equivocation *types.ShardEquivocation) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if s.diagnosticsPaused {
+		log.WithField("slot", equivocation.Slot).Debug("Disk pressure, not recording shard equivocation")
+		return nil
+	}
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(equivocationsBucket)
+		key := bytesutil.Uint64ToBytesBigEndian(equivocation.Slot)
+		if bkt.Get(key) != nil {
+			return nil
+		}
+		enc, err := s.codec.encode(equivocation)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, enc)
+	})
+}
+
+// ShardConflicts returns at most limit conflicts of verified slots starting from the given slot. Zero limit means no
+// limit.
+func (s *Store) ShardConflicts(fromSlot uint64, limit int) ([]*types.ShardConflict, error) {
+	conflicts := make([]*types.ShardConflict, 0)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(shardConflictsBucket).Cursor()
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(fromSlot)); k != nil; k, v = c.Next() {
+			if limit > 0 && len(conflicts) >= limit {
+				return nil
+			}
+			var conflict *types.ShardConflict
+			if err := s.codec.decode(v, &conflict); err != nil {
+				return err
+			}
+			conflicts = append(conflicts, conflict)
+		}
+		return nil
+	})
+	return conflicts, err
+}
+
+// SaveShardConflict keeps the first conflict of every verified slot. Conflicts are evidences of equivocation, so
+// they are recorded under disk pressure too.
+func (s *Store) SaveShardConflict(conflict *types.ShardConflict) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(shardConflictsBucket)
+		key := bytesutil.Uint64ToBytesBigEndian(conflict.Slot)
+		if bkt.Get(key) != nil {
+			return nil
+		}
+		enc, err := s.codec.encode(conflict)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(key, enc)
+	})
+}
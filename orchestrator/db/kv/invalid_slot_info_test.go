@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ShardDisagreements(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	disagreement, err := db.ShardDisagreement(1)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.ShardDisagreement)(nil), disagreement)
+
+	expected := make([]*types.ShardDisagreement, 0)
+	for slot := uint64(1); slot <= 5; slot++ {
+		d := &types.ShardDisagreement{
+			Slot:  slot,
+			Field: "txHash",
+			Pandora: &types.ShardInfoFields{
+				BlockNumber: slot,
+				TxHash:      common.BytesToHash([]byte{byte(slot)}),
+				Signature:   []byte{0x01},
+			},
+			Vanguard:         &types.ShardInfoFields{BlockNumber: slot, Signature: []byte{0x02}},
+			PandoraExtraData: []byte{0x03},
+		}
+		require.NoError(t, db.SaveShardDisagreement(d))
+		expected = append(expected, d)
+	}
+
+	disagreement, err = db.ShardDisagreement(3)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected[2], disagreement)
+
+	disagreements, err := db.ShardDisagreements(2, 2)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected[1:3], disagreements)
+
+	disagreements, err = db.ShardDisagreements(3, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, expected[2:], disagreements)
+}
+
+func TestStore_ShardEquivocations(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	newEquivocation := func(slot uint64, source string) *types.ShardEquivocation {
+		return &types.ShardEquivocation{
+			Slot:          slot,
+			ProposerIndex: 7,
+			First: &types.ShardEquivocationSide{
+				Source: "primary",
+				Shard:  &types.ShardInfoFields{BlockNumber: slot, Signature: []byte{0x01}},
+			},
+			Second: &types.ShardEquivocationSide{
+				Source: source,
+				Shard:  &types.ShardInfoFields{BlockNumber: slot, Signature: []byte{0x02}},
+			},
+		}
+	}
+	first := newEquivocation(2, "secondary")
+	require.NoError(t, db.SaveShardEquivocation(first))
+	// only the first evidence of a slot is kept
+	require.NoError(t, db.SaveShardEquivocation(newEquivocation(2, "other")))
+	second := newEquivocation(4, "secondary")
+	require.NoError(t, db.SaveShardEquivocation(second))
+
+	equivocations, err := db.ShardEquivocations(0, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*types.ShardEquivocation{first, second}, equivocations)
+
+	equivocations, err = db.ShardEquivocations(3, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*types.ShardEquivocation{second}, equivocations)
+}
+
+func TestStore_ShardConflicts(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	newConflict := func(slot uint64, conflictingHash byte) *types.ShardConflict {
+		return &types.ShardConflict{
+			Slot: slot,
+			Verified: &types.SlotInfo{
+				VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 1}),
+				PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 2}),
+			},
+			Conflicting: &types.SlotInfo{
+				VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 3}),
+				PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), conflictingHash}),
+			},
+			Shard:      &types.ShardInfoFields{BlockNumber: slot, Signature: []byte{0x01}},
+			DetectedAt: 1000 + int64(slot),
+		}
+	}
+	first := newConflict(2, 4)
+	require.NoError(t, db.SaveShardConflict(first))
+	// only the first conflict of a slot is kept
+	require.NoError(t, db.SaveShardConflict(newConflict(2, 5)))
+	second := newConflict(4, 4)
+	require.NoError(t, db.SaveShardConflict(second))
+
+	conflicts, err := db.ShardConflicts(0, 0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*types.ShardConflict{first, second}, conflicts)
+
+	conflicts, err = db.ShardConflicts(3, 1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*types.ShardConflict{second}, conflicts)
+}
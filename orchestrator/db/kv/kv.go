@@ -2,10 +2,9 @@ package kv
 
 import (
 	"context"
-	"github.com/boltdb/bolt"
 	"github.com/dgraph-io/ristretto"
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
-	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/retry"
 	"github.com/pkg/errors"
 	"os"
 	"path"
@@ -24,52 +23,78 @@ const (
 	boltAllocSize = 8 * 1024 * 1024
 )
 
-// Config for the bolt db kv store.
+var errDBLocked = errors.New("cannot obtain database lock, database may be in use by another process")
+
+// Config for the kv store.
 type Config struct {
+	// Backend is the storage engine of a newly created db. Existing db is opened with the backend it was created with.
+	Backend BackendType
+	// InitialMMapSize is only used by the bolt backend
 	InitialMMapSize int
+	// Encoding and Compression of values are only applied when the db is created
+	Encoding    Encoding
+	Compression Compression
+	// Archive keeps reverse indexes of verified slot infos and disables every pruning
+	Archive bool
+	// LockRetries is the number of retries when the db lock is held by another process
+	LockRetries int
+	// InMemory keeps the db in memory with the leveldb backend. Nothing is written to disk and dirPath is ignored.
+	InMemory bool
+	// ReadOnly opens an existing db for inspection. Nothing is written, so the db is neither migrated nor recovered.
+	ReadOnly bool
 }
 
 type Store struct {
 	ctx                   context.Context
 	isRunning             bool
-	db                    *bolt.DB
+	db                    Backend
+	backend               BackendType
 	databasePath          string
 	consensusInfoCache    *ristretto.Cache
 	verifiedSlotInfoCache *ristretto.Cache
 
+	// codec encodes values with the encoding selected at db creation
+	codec *codec
+
+	// archive is true when full history and reverse indexes are kept
+	archive bool
+
+	// inMemory is true when the db is kept in memory only
+	inMemory bool
+
+	// catchUp is true while db writes are synced to disk in batches
+	catchUp bool
+
+	// diagnosticsPaused is true while disagreements and equivocations are not recorded because of disk pressure
+	diagnosticsPaused bool
+
 	// There should be mutex in store
 	sync.Mutex
 }
 
-// NewKVStore initializes a new boltDB key-value store at the directory
+// NewKVStore initializes a new key-value store at the directory
 // path specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
 func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, error) {
-	hasDir, err := fileutil.HasDir(dirPath)
-	if err != nil {
-		return nil, err
+	var (
+		backend         Backend
+		backendType     BackendType
+		movedLegacyFile bool
+		err             error
+	)
+	if config.InMemory && config.ReadOnly {
+		return nil, errors.New("in-memory db can't be opened read-only")
 	}
-	if !hasDir {
-		if err := fileutil.MkdirAll(dirPath); err != nil {
-			return nil, err
+	if config.InMemory {
+		dirPath, backendType = "", LevelDBBackend
+		if backend, err = openInMemory(config); err != nil {
+			return nil, errors.Wrap(err, "could not open in-memory db")
 		}
-	}
-	datafile := path.Join(dirPath, DatabaseFileName)
-	boltDB, err := bolt.Open(
-		datafile,
-		params.OrchestratorIoConfig().ReadWritePermissions,
-		&bolt.Options{
-			Timeout:         1 * time.Second,
-			InitialMmapSize: config.InitialMMapSize,
-		},
-	)
-	if err != nil {
-		if errors.Is(err, bolt.ErrTimeout) {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+	} else {
+		if backend, backendType, movedLegacyFile, err = openBackend(ctx, dirPath, config); err != nil {
+			return nil, err
 		}
-		return nil, err
 	}
-	boltDB.AllocSize = boltAllocSize
 	consensusInfoCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,                    // number of keys to track frequency of (1000).
 		MaxCost:     ConsensusInfosCacheSize, // maximum cost of cache (1000 consensus info).
@@ -89,24 +114,67 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 
 	kv := &Store{
 		ctx:                   ctx,
-		db:                    boltDB,
+		db:                    backend,
+		backend:               backendType,
 		databasePath:          dirPath,
 		consensusInfoCache:    consensusInfoCache,
 		verifiedSlotInfoCache: verifiedSlotInfoCache,
+		inMemory:              config.InMemory,
+	}
+
+	if config.ReadOnly {
+		if err := kv.prepareReadOnly(); err != nil {
+			kv.db.Close()
+			return nil, errors.Wrap(err, "could not open db read-only")
+		}
+		return kv, nil
 	}
 
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
+	if err := kv.db.Update(func(tx Tx) error {
 		return createBuckets(
 			tx,
 			consensusInfosBucket,
+			consensusInfoSrcBucket,
 			verifiedSlotInfosBucket,
 			invalidSlotInfosBucket,
+			disagreementsBucket,
+			equivocationsBucket,
+			shardConflictsBucket,
 			latestInfoMarkerBucket,
+			chainIdentityBucket,
+			accumulatorLeavesBucket,
+			accumulatorStepsBucket,
+			pandoraHashIndexBucket,
+			vanguardHashIndexBucket,
+			inProgressSlotsBucket,
+			epochSummariesBucket,
+			blockNumberIndexBucket,
+			consumerAcksBucket,
+			migrationHistoryBucket,
+			reorgsBucket,
+			deferredTasksBucket,
 		)
 	}); err != nil {
 		return nil, err
 	}
 
+	if kv.codec, err = kv.loadCodec(config.Encoding, config.Compression); err != nil {
+		return nil, errors.Wrap(err, "could not load db value codec")
+	}
+
+	if err := kv.migrateSchema(movedLegacyFile); err != nil {
+		return nil, errors.Wrap(err, "could not migrate db")
+	}
+
+	if err := kv.setupArchive(config.Archive); err != nil {
+		return nil, errors.Wrap(err, "could not setup archive mode")
+	}
+	kv.archive = config.Archive
+
+	if err := kv.recoverCatchUp(); err != nil {
+		return nil, errors.Wrap(err, "could not recover from catch-up db write mode")
+	}
+
 	latestFinalizedSlot := kv.LatestLatestFinalizedSlot()
 	latestFinalizedEpoch := kv.LatestLatestFinalizedEpoch()
 	latestVerifiedSlot := kv.LatestSavedVerifiedSlot()
@@ -120,30 +188,91 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 	return kv, err
 }
 
+// openBackend opens the db in the directory with the backend it was created with
+func openBackend(ctx context.Context, dirPath string, config *Config) (Backend, BackendType, bool, error) {
+	hasDir, err := fileutil.HasDir(dirPath)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if config.ReadOnly {
+		existing, err := existingBackend(dirPath)
+		if err != nil {
+			return nil, "", false, err
+		}
+		if existing == "" {
+			return nil, "", false, errors.Errorf("no db in %s", dirPath)
+		}
+	}
+	if !hasDir {
+		if err := fileutil.MkdirAll(dirPath); err != nil {
+			return nil, "", false, err
+		}
+	}
+	backendType, err := selectBackend(dirPath, config.Backend)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if backendType == LevelDBBackend {
+		backend, err := openLevelDB(ctx, path.Join(dirPath, LevelDBDirName), config)
+		return backend, backendType, false, err
+	}
+	// early releases kept the bolt db file in the root of the datadir
+	var movedLegacyFile bool
+	if !config.ReadOnly {
+		if movedLegacyFile, err = moveLegacyDBFile(dirPath); err != nil {
+			return nil, "", false, err
+		}
+	}
+	backend, err := openBolt(ctx, path.Join(dirPath, DatabaseFileName), config)
+	return backend, backendType, movedLegacyFile, err
+}
+
 // ClearDB removes the previously stored database in the data directory.
 func (s *Store) ClearDB() error {
+	if s.inMemory {
+		return nil
+	}
 	if _, err := os.Stat(s.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	if err := os.Remove(path.Join(s.databasePath, DatabaseFileName)); err != nil {
-		return errors.Wrap(err, "could not remove database file")
+	if err := removeBackendFiles(s.databasePath); err != nil {
+		return errors.Wrap(err, "could not remove database files")
 	}
 	return nil
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying database.
 func (s *Store) Close() error {
 	log.Info("Received cancelled context, closing db")
+	if err := s.ExitCatchUpMode(); err != nil {
+		log.WithError(err).Error("Failed to exit catch-up db write mode")
+	}
 	return s.db.Close()
 }
 
-// DatabasePath at which this database writes files.
+// Backend returns the storage engine of the db
+func (s *Store) Backend() BackendType {
+	return s.backend
+}
+
+// DatabasePath at which this database writes files. It is empty for an in-memory db.
 func (s *Store) DatabasePath() string {
 	return s.databasePath
 }
 
+// lockRetryPolicy retries opening the db with exponential backoff while another process holds its lock
+func lockRetryPolicy(retries int) retry.Policy {
+	return retry.Policy{
+		MaxAttempts:  retries + 1,
+		InitialDelay: time.Second,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
 // createBuckets
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+func createBuckets(tx Tx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
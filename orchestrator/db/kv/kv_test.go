@@ -2,8 +2,11 @@ package kv
 
 import (
 	"context"
-	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"io/ioutil"
 	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 )
 
 // setupDB instantiates and returns a Store instance.
@@ -31,3 +34,30 @@ func TestKV_Start_Stop(t *testing.T) {
 	require.NoError(t, kv.Close())
 	kv = setupDB(t, false)
 }
+
+func TestKV_InMemory(t *testing.T) {
+	dataDir := t.TempDir()
+	kv, err := NewKVStore(context.Background(), dataDir, &Config{InMemory: true})
+	require.NoError(t, err)
+	assert.Equal(t, "", kv.DatabasePath())
+	assert.Equal(t, LevelDBBackend, kv.Backend())
+
+	require.NoError(t, kv.SaveLatestFinalizedSlot(64))
+	assert.Equal(t, uint64(64), kv.LatestLatestFinalizedSlot())
+	// catch-up mode is a no-op since writes are never synced
+	require.NoError(t, kv.EnterCatchUpMode(64))
+	assert.Equal(t, false, kv.IsCatchUpMode())
+
+	size, err := kv.Size()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), size)
+	require.NoError(t, kv.Close())
+
+	// nothing is written to disk
+	entries, err := ioutil.ReadDir(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(entries))
+
+	_, err = NewKVStore(context.Background(), dataDir, &Config{InMemory: true, Backend: BoltBackend})
+	assert.ErrorContains(t, "only supported by the leveldb backend", err)
+}
@@ -0,0 +1,32 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SaveLifetimeStats stores the counters of all runs of the node
+func (s *Store) SaveLifetimeStats(stats *types.LifetimeStats) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		enc, err := s.codec.encode(stats)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(lifetimeStatsKey, enc)
+	})
+}
+
+// LifetimeStats returns the stored counters of all runs of the node. Returns nil for a brand new db.
+func (s *Store) LifetimeStats() (*types.LifetimeStats, error) {
+	var stats *types.LifetimeStats
+	err := s.db.View(func(tx Tx) error {
+		enc := tx.Bucket(latestInfoMarkerBucket).Get(lifetimeStatsKey)
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &stats)
+	})
+	return stats, err
+}
@@ -0,0 +1,23 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_LifetimeStats(t *testing.T) {
+	db := setupDB(t, true)
+
+	stats, err := db.LifetimeStats()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.LifetimeStats)(nil), stats)
+
+	want := &types.LifetimeStats{VerifiedSlots: 120, InvalidSlots: 2, Reorgs: 1, Uptime: 3600}
+	require.NoError(t, db.SaveLifetimeStats(want))
+	stats, err = db.LifetimeStats()
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, stats)
+}
@@ -0,0 +1,168 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+)
+
+// legacyFileMigration is the history name of moving a db file out of the root of the datadir
+const legacyFileMigration = "move-legacy-db-file"
+
+// schemaMigration converts the buckets and keys written by an older release to the layout of the next schema version
+type schemaMigration struct {
+	version uint64
+	name    string
+	migrate func(tx Tx) error
+}
+
+// schemaMigrations are applied in order on dbs with a lower schema version. Dbs of the releases before schema
+// versioning have the layout of version 1.
+var schemaMigrations = []schemaMigration{
+	{version: 1, name: "initial-schema-version", migrate: func(Tx) error { return nil }},
+}
+
+// MigrationRecord is an entry of the migration history which is kept for support diagnostics
+type MigrationRecord struct {
+	Version   uint64 `json:"version"`
+	Name      string `json:"name"`
+	AppliedAt int64  `json:"appliedAt"`
+	// Backup is the copy of the db file taken before the migration, empty when the db had no data
+	Backup string `json:"backup,omitempty"`
+}
+
+// currentSchemaVersion is the schema version written by this release
+func currentSchemaVersion() uint64 {
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// moveLegacyDBFile moves the db file which early releases kept in the root of the datadir into the db directory.
+// It returns false when there is no legacy file or the db directory already has a db file.
+func moveLegacyDBFile(dirPath string) (bool, error) {
+	legacyFile := path.Join(filepath.Dir(dirPath), DatabaseFileName)
+	datafile := path.Join(dirPath, DatabaseFileName)
+	if !fileutil.FileExists(legacyFile) {
+		return false, nil
+	}
+	if fileutil.FileExists(datafile) {
+		log.WithField("legacyFile", legacyFile).Warn("Ignoring legacy db file, db directory already has a db")
+		return false, nil
+	}
+	if err := os.Rename(legacyFile, datafile); err != nil {
+		return false, errors.Wrap(err, "could not move legacy db file")
+	}
+	log.WithField("from", legacyFile).WithField("to", datafile).Info("Moved legacy db file into db directory")
+	return true, nil
+}
+
+// migrateSchema backs up the db file and applies every pending schema migration in a single transaction, so a
+// failed migration leaves the db as it was
+func (s *Store) migrateSchema(movedLegacyFile bool) error {
+	var (
+		version uint64
+		empty   bool
+	)
+	if err := s.db.View(func(tx Tx) error {
+		version = bytesutil.BytesToUint64BigEndian(tx.Bucket(latestInfoMarkerBucket).Get(schemaVersionKey))
+		empty = hasNoEncodedValues(tx)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var pending []schemaMigration
+	for _, migration := range schemaMigrations {
+		if migration.version > version {
+			pending = append(pending, migration)
+		}
+	}
+	if len(pending) == 0 && !movedLegacyFile {
+		return nil
+	}
+
+	var backup string
+	if !empty && len(pending) > 0 {
+		backup = path.Join(s.databasePath, fmt.Sprintf("%s.v%d.bak", DatabaseFileName, version))
+		if err := s.db.View(func(tx Tx) error {
+			return tx.CopyFile(backup, params.OrchestratorIoConfig().ReadWritePermissions)
+		}); err != nil {
+			return errors.Wrap(err, "could not back up db before migration")
+		}
+		log.WithField("backup", backup).WithField("fromVersion", version).Info("Backed up db before migration")
+	}
+
+	return s.db.Update(func(tx Tx) error {
+		appliedAt := time.Now().Unix()
+		if movedLegacyFile {
+			if err := putMigrationRecord(tx, &MigrationRecord{Name: legacyFileMigration, AppliedAt: appliedAt}); err != nil {
+				return err
+			}
+		}
+		for _, migration := range pending {
+			// a new db has nothing to convert
+			if !empty {
+				if err := migration.migrate(tx); err != nil {
+					return errors.Wrapf(err, "could not apply db migration %d (%s)", migration.version, migration.name)
+				}
+				log.WithField("version", migration.version).WithField("name", migration.name).Info("Applied db migration")
+			}
+			if err := putMigrationRecord(tx, &MigrationRecord{
+				Version:   migration.version,
+				Name:      migration.name,
+				AppliedAt: appliedAt,
+				Backup:    backup,
+			}); err != nil {
+				return err
+			}
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(schemaVersionKey, bytesutil.Uint64ToBytesBigEndian(currentSchemaVersion()))
+	})
+}
+
+// putMigrationRecord appends the record to the migration history
+func putMigrationRecord(tx Tx, record *MigrationRecord) error {
+	bkt := tx.Bucket(migrationHistoryBucket)
+	seq, err := bkt.NextSequence()
+	if err != nil {
+		return err
+	}
+	enc, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return bkt.Put(bytesutil.Uint64ToBytesBigEndian(seq), enc)
+}
+
+// SchemaVersion returns the schema version of the db
+func (s *Store) SchemaVersion() (version uint64) {
+	// Ignore the error, a missing version reads as zero
+	_ = s.db.View(func(tx Tx) error {
+		version = bytesutil.BytesToUint64BigEndian(tx.Bucket(latestInfoMarkerBucket).Get(schemaVersionKey))
+		return nil
+	})
+	return
+}
+
+// MigrationHistory returns the applied migrations in the order they were applied
+func (s *Store) MigrationHistory() ([]*MigrationRecord, error) {
+	var history []*MigrationRecord
+	err := s.db.View(func(tx Tx) error {
+		return tx.Bucket(migrationHistoryBucket).ForEach(func(k, v []byte) error {
+			record := new(MigrationRecord)
+			if err := json.Unmarshal(v, record); err != nil {
+				return err
+			}
+			history = append(history, record)
+			return nil
+		})
+	})
+	return history, err
+}
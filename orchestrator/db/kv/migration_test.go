@@ -0,0 +1,65 @@
+package kv
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_MigrateSchema_NewDB(t *testing.T) {
+	db := setupDB(t, true)
+
+	assert.Equal(t, currentSchemaVersion(), db.SchemaVersion())
+	history, err := db.MigrationHistory()
+	require.NoError(t, err)
+	require.Equal(t, len(schemaMigrations), len(history))
+	// nothing to back up in a new db
+	assert.Equal(t, "", history[0].Backup)
+}
+
+func TestStore_MigrateSchema_LegacyLayout(t *testing.T) {
+	dataDir := t.TempDir()
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.BytesToHash([]byte{1}),
+		PandoraHeaderHash: common.BytesToHash([]byte{2}),
+	}
+
+	// db of an early release is in the root of the datadir and has no schema version
+	legacy, err := NewKVStore(context.Background(), dataDir, &Config{})
+	require.NoError(t, err)
+	require.NoError(t, legacy.SaveVerifiedSlotInfo(5, slotInfo))
+	require.NoError(t, legacy.db.Update(func(tx Tx) error {
+		if err := tx.DeleteBucket(migrationHistoryBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Delete(schemaVersionKey)
+	}))
+	require.NoError(t, legacy.Close())
+
+	dbPath := path.Join(dataDir, OrchestratorNodeDbDirName)
+	db, err := NewKVStore(context.Background(), dbPath, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+
+	assert.Equal(t, false, fileutil.FileExists(path.Join(dataDir, DatabaseFileName)))
+	stored, err := db.VerifiedSlotInfo(5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, stored)
+	assert.Equal(t, currentSchemaVersion(), db.SchemaVersion())
+
+	history, err := db.MigrationHistory()
+	require.NoError(t, err)
+	require.Equal(t, 1+len(schemaMigrations), len(history))
+	assert.Equal(t, legacyFileMigration, history[0].Name)
+	backup := history[1].Backup
+	assert.Equal(t, path.Join(dbPath, DatabaseFileName+".v0.bak"), backup)
+	assert.Equal(t, true, fileutil.FileExists(backup))
+}
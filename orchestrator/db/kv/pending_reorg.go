@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// SavePendingReorg stores the reorg which the verified chain is being reverted for, so that an interrupted revert is
+// resumed at next start
+func (s *Store) SavePendingReorg(reorgInfo *types.Reorg) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		enc, err := s.codec.encode(reorgInfo)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(pendingReorgKey, enc)
+	})
+}
+
+// PendingReorg returns the reorg which is not resolved yet. Returns nil when there is no pending reorg.
+func (s *Store) PendingReorg() (*types.Reorg, error) {
+	var reorgInfo *types.Reorg
+	err := s.db.View(func(tx Tx) error {
+		enc := tx.Bucket(latestInfoMarkerBucket).Get(pendingReorgKey)
+		if enc == nil {
+			return nil
+		}
+		return s.codec.decode(enc, &reorgInfo)
+	})
+	return reorgInfo, err
+}
+
+// DeletePendingReorg clears the pending reorg once the revert of the verified chain is committed
+func (s *Store) DeletePendingReorg() error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(latestInfoMarkerBucket).Delete(pendingReorgKey)
+	})
+}
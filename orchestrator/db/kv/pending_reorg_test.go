@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_PendingReorg(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+
+	// brand new db has no pending reorg
+	reorgInfo, err := db.PendingReorg()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.Reorg)(nil), reorgInfo)
+
+	pending := &types.Reorg{VanParentHash: []byte{1}, PanParentHash: []byte{2}, NewSlot: 42}
+	require.NoError(t, db.SavePendingReorg(pending))
+	require.NoError(t, db.Close())
+
+	// pending reorg survives a restart
+	db, err = NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	reorgInfo, err = db.PendingReorg()
+	require.NoError(t, err)
+	assert.DeepEqual(t, pending, reorgInfo)
+
+	require.NoError(t, db.DeletePendingReorg())
+	reorgInfo, err = db.PendingReorg()
+	require.NoError(t, err)
+	assert.Equal(t, (*types.Reorg)(nil), reorgInfo)
+}
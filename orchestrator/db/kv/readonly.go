@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+)
+
+// errReadOnlySchema is returned when a read-only db would need a migration before it can be read
+var errReadOnlySchema = errors.New("db schema version differs from the release, start the node once to migrate it")
+
+// prepareReadOnly loads the codec and the archive mode of the db without writing to it. Read-only db is neither
+// migrated nor recovered, so it must have the schema version of the release.
+func (s *Store) prepareReadOnly() error {
+	var (
+		version   uint64
+		codecName string
+		archive   bool
+	)
+	if err := s.db.View(func(tx Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		if bkt == nil {
+			return errors.New("db has no latest info markers")
+		}
+		version = bytesutil.BytesToUint64BigEndian(bkt.Get(schemaVersionKey))
+		codecName = string(bkt.Get(valueCodecKey))
+		archive = bkt.Get(archiveModeKey) != nil
+		return nil
+	}); err != nil {
+		return err
+	}
+	if version != currentSchemaVersion() {
+		return errors.Wrapf(errReadOnlySchema, "db has version %d, release has %d", version, currentSchemaVersion())
+	}
+
+	s.codec = legacyCodec
+	if codecName != "" {
+		codec, err := parseCodec(codecName)
+		if err != nil {
+			return err
+		}
+		s.codec = codec
+	}
+	s.archive = archive
+
+	if fileutil.FileExists(s.catchUpIntentPath()) {
+		log.Warn("Node was stopped in catch-up db write mode, the latest verified slots may not be committed")
+	}
+	return nil
+}
@@ -1,14 +1,13 @@
 package kv
 
 import (
-	"github.com/boltdb/bolt"
 	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
 )
 
 // SaveLatestFinalizedSlot
 func (s *Store) SaveLatestFinalizedSlot(latestFinalizedSlot uint64) error {
 	// storing latest finalized slot number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(latestInfoMarkerBucket)
 		slotBytes := bytesutil.Uint64ToBytesBigEndian(latestFinalizedSlot)
 		if err := bkt.Put(latestFinalizedSlotKey, slotBytes); err != nil {
@@ -23,7 +22,7 @@ func (s *Store) LatestLatestFinalizedSlot() uint64 {
 	var latestFinalizedSlot uint64
 	// Db is not prepared yet. Retrieve latest saved finalized slot number from db
 	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
+		s.db.View(func(tx Tx) error {
 			bkt := tx.Bucket(latestInfoMarkerBucket)
 			slotBytes := bkt.Get(latestFinalizedSlotKey[:])
 			// not found the latest finalized slot in db. so latest finalized slot will be zero
@@ -43,7 +42,7 @@ func (s *Store) LatestLatestFinalizedSlot() uint64 {
 // SaveLatestFinalizedEpoch
 func (s *Store) SaveLatestFinalizedEpoch(latestFinalizedEpoch uint64) error {
 	// storing latest finalized slot number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(latestInfoMarkerBucket)
 		epochBytes := bytesutil.Uint64ToBytesBigEndian(latestFinalizedEpoch)
 		if err := bkt.Put(latestFinalizedEpochKey, epochBytes); err != nil {
@@ -58,7 +57,7 @@ func (s *Store) LatestLatestFinalizedEpoch() uint64 {
 	var latestFinalizedEpoch uint64
 	// Db is not prepared yet. Retrieve latest saved finalized slot number from db
 	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
+		s.db.View(func(tx Tx) error {
 			bkt := tx.Bucket(latestInfoMarkerBucket)
 			epochBytes := bkt.Get(latestFinalizedEpochKey[:])
 			// not found the latest finalized slot in db. so latest finalized slot will be zero
@@ -0,0 +1,60 @@
+package kv
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// reorgKey orders reorgs by their slot and then by their id, as more than one reorg may be triggered at a slot
+func reorgKey(slot, id uint64) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(slot), bytesutil.Uint64ToBytesBigEndian(id)...)
+}
+
+// SaveReorg stores the reorg. A new id is assigned when the record has none, so that the record is updated in place
+// once the reorg is resolved.
+func (s *Store) SaveReorg(record *types.ReorgRecord) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(reorgsBucket)
+		if record.Id == 0 {
+			id, err := bkt.NextSequence()
+			if err != nil {
+				return err
+			}
+			record.Id = id
+		}
+		enc, err := s.codec.encode(record)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(reorgKey(record.Slot, record.Id), enc)
+	})
+}
+
+// ReorgHistory returns at most limit reorgs starting from the given slot. Zero limit means no limit.
+func (s *Store) ReorgHistory(fromSlot uint64, limit int) ([]*types.ReorgRecord, error) {
+	return s.ReorgHistoryFrom(fromSlot, 0, limit)
+}
+
+// ReorgHistoryFrom returns at most limit reorgs starting from the reorg of the given slot and id, so that a page
+// can end between reorgs of the same slot. Zero limit means no limit.
+func (s *Store) ReorgHistoryFrom(fromSlot, fromId uint64, limit int) ([]*types.ReorgRecord, error) {
+	records := make([]*types.ReorgRecord, 0)
+	err := s.db.View(func(tx Tx) error {
+		c := tx.Bucket(reorgsBucket).Cursor()
+		for k, v := c.Seek(reorgKey(fromSlot, fromId)); k != nil; k, v = c.Next() {
+			if limit > 0 && len(records) >= limit {
+				return nil
+			}
+			var record *types.ReorgRecord
+			if err := s.codec.decode(v, &record); err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	return records, err
+}
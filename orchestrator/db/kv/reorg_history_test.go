@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ReorgHistory(t *testing.T) {
+	db := setupDB(t, true)
+
+	stepId := uint64(9)
+	first := &types.ReorgRecord{
+		Slot:            20,
+		VanParentHash:   []byte{1},
+		PanParentHash:   []byte{2},
+		OldHeadSlot:     19,
+		OldHeadHash:     common.BytesToHash([]byte{3}),
+		RevertSlot:      10,
+		NewParentStepId: &stepId,
+		Depth:           9,
+		DetectedAt:      1000,
+	}
+	require.NoError(t, db.SaveReorg(first))
+	assert.Equal(t, uint64(1), first.Id)
+
+	// resolving updates the stored record in place
+	first.ResolvedAt = 1001
+	require.NoError(t, db.SaveReorg(first))
+
+	// second reorg at the same slot is kept next to the first
+	second := &types.ReorgRecord{Slot: 20, VanParentHash: []byte{4}, PanParentHash: []byte{5}, DetectedAt: 1002}
+	require.NoError(t, db.SaveReorg(second))
+	require.NoError(t, db.SaveReorg(&types.ReorgRecord{Slot: 5, VanParentHash: []byte{6}, PanParentHash: []byte{7}}))
+
+	records, err := db.ReorgHistory(10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(records))
+	assert.DeepEqual(t, first, records[0])
+	assert.Equal(t, second.Id, records[1].Id)
+
+	records, err = db.ReorgHistory(0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, uint64(5), records[0].Slot)
+
+	// continuing between the reorgs of a slot
+	records, err = db.ReorgHistoryFrom(20, second.Id, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(records))
+	assert.Equal(t, second.Id, records[0].Id)
+}
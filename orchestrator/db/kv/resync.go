@@ -0,0 +1,142 @@
+package kv
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// ResyncPlan is the data which is removed when the db is reset to its finalized checkpoint
+type ResyncPlan struct {
+	FinalizedSlot      uint64 `json:"finalizedSlot"`
+	FinalizedEpoch     uint64 `json:"finalizedEpoch"`
+	LatestVerifiedSlot uint64 `json:"latestVerifiedSlot"`
+	LatestEpoch        uint64 `json:"latestEpoch"`
+	VerifiedSlots      int    `json:"verifiedSlots"`
+	InvalidSlots       int    `json:"invalidSlots"`
+	EpochInfos         int    `json:"epochInfos"`
+	InProgressSlots    int    `json:"inProgressSlots"`
+}
+
+// PlanResync counts the non-finalized data without removing it, so that the operator can review it first
+func (s *Store) PlanResync() (*ResyncPlan, error) {
+	plan := &ResyncPlan{
+		FinalizedSlot:      s.LatestLatestFinalizedSlot(),
+		FinalizedEpoch:     s.LatestLatestFinalizedEpoch(),
+		LatestVerifiedSlot: s.LatestSavedVerifiedSlot(),
+		LatestEpoch:        s.LatestSavedEpoch(),
+	}
+	err := s.db.View(func(tx Tx) error {
+		plan.VerifiedSlots = countKeysAfter(tx.Bucket(verifiedSlotInfosBucket), plan.FinalizedSlot)
+		plan.InvalidSlots = countKeysAfter(tx.Bucket(invalidSlotInfosBucket), plan.FinalizedSlot)
+		plan.EpochInfos = countKeysAfter(tx.Bucket(consensusInfosBucket), plan.FinalizedEpoch)
+		plan.InProgressSlots = tx.Bucket(inProgressSlotsBucket).KeyCount()
+		return nil
+	})
+	return plan, err
+}
+
+// Backup copies the db file into the db directory with the given label and returns the path of the copy
+func (s *Store) Backup(label string) (string, error) {
+	backup := path.Join(s.databasePath, fmt.Sprintf("%s.%s-%d.bak", DatabaseFileName, label, time.Now().Unix()))
+	if err := s.db.View(func(tx Tx) error {
+		return tx.CopyFile(backup, params.OrchestratorIoConfig().ReadWritePermissions)
+	}); err != nil {
+		return "", errors.Wrap(err, "could not back up db")
+	}
+	return backup, nil
+}
+
+// ResyncFromFinalized removes every verified slot, invalid slot and epoch info after the finalized checkpoint in a
+// single transaction, so that verification starts again from the finalized slot at next start. Finalized data and
+// operational history like reorgs and migrations are kept.
+func (s *Store) ResyncFromFinalized() (*ResyncPlan, error) {
+	plan, err := s.PlanResync()
+	if err != nil {
+		return nil, err
+	}
+
+	s.Mutex.Lock()
+	err = s.db.Update(func(tx Tx) error {
+		verifiedBkt := tx.Bucket(verifiedSlotInfosBucket)
+		for _, k := range keysAfter(verifiedBkt, plan.FinalizedSlot) {
+			slot := bytesutil.BytesToUint64BigEndian(k)
+			s.verifiedSlotInfoCache.Del(slot)
+			if enc := verifiedBkt.Get(k); s.archive && enc != nil {
+				var slotInfo *types.SlotInfo
+				if err := s.codec.decode(enc, &slotInfo); err != nil {
+					return err
+				}
+				if err := unindexSlotInfo(tx, slot, slotInfo); err != nil {
+					return err
+				}
+			}
+			if err := verifiedBkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		if err := s.removeAccumulatorSteps(tx, plan.FinalizedSlot+1, ^uint64(0)); err != nil {
+			return err
+		}
+		if err := removeBlockNumbers(tx, plan.FinalizedSlot+1); err != nil {
+			return err
+		}
+
+		invalidBkt := tx.Bucket(invalidSlotInfosBucket)
+		for _, k := range keysAfter(invalidBkt, plan.FinalizedSlot) {
+			if err := invalidBkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		for _, bkt := range []Bucket{tx.Bucket(consensusInfosBucket), tx.Bucket(consensusInfoSrcBucket)} {
+			for _, k := range keysAfter(bkt, plan.FinalizedEpoch) {
+				s.consensusInfoCache.Del(bytesutil.BytesToUint64BigEndian(k))
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		if err := tx.DeleteBucket(inProgressSlotsBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(inProgressSlotsBucket); err != nil {
+			return err
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(lastStoredEpochKey, bytesutil.Uint64ToBytesBigEndian(plan.FinalizedEpoch))
+	})
+	s.Mutex.Unlock()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not remove non-finalized data")
+	}
+
+	// latest verified markers point to the last verified slot up to the finalized slot
+	if err := s.UpdateVerifiedSlotInfo(plan.FinalizedSlot); err != nil {
+		return nil, errors.Wrap(err, "could not update latest verified slot")
+	}
+	return plan, nil
+}
+
+// keysAfter returns the keys of the bucket which are greater than the given big endian number
+func keysAfter(bkt Bucket, number uint64) [][]byte {
+	var keys [][]byte
+	c := bkt.Cursor()
+	for k, _ := c.Seek(bytesutil.Uint64ToBytesBigEndian(number + 1)); k != nil; k, _ = c.Next() {
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+	}
+	return keys
+}
+
+// countKeysAfter returns the number of keys of the bucket which are greater than the given big endian number
+func countKeysAfter(bkt Bucket, number uint64) int {
+	count := 0
+	c := bkt.Cursor()
+	for k, _ := c.Seek(bytesutil.Uint64ToBytesBigEndian(number + 1)); k != nil; k, _ = c.Next() {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_ResyncFromFinalized(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t, true)
+
+	for slot := uint64(1); slot <= 10; slot++ {
+		slotInfo := &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 1}),
+		}
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, slotInfo))
+	}
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 10))
+	require.NoError(t, db.SaveInvalidSlotInfo(4, &types.SlotInfo{}))
+	require.NoError(t, db.SaveInvalidSlotInfo(8, &types.SlotInfo{}))
+	for epoch := uint64(0); epoch <= 3; epoch++ {
+		require.NoError(t, db.SaveConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(epoch).ConvertToEpochInfo()))
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, 3))
+	require.NoError(t, db.MarkSlotInProgress(9))
+	require.NoError(t, db.SaveLatestFinalizedSlot(6))
+	require.NoError(t, db.SaveLatestFinalizedEpoch(1))
+
+	plan, err := db.PlanResync()
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ResyncPlan{
+		FinalizedSlot:      6,
+		FinalizedEpoch:     1,
+		LatestVerifiedSlot: 10,
+		LatestEpoch:        3,
+		VerifiedSlots:      4,
+		InvalidSlots:       1,
+		EpochInfos:         2,
+		InProgressSlots:    1,
+	}, plan)
+
+	backup, err := db.Backup("resync")
+	require.NoError(t, err)
+	assert.Equal(t, true, fileutil.FileExists(backup))
+
+	removed, err := db.ResyncFromFinalized()
+	require.NoError(t, err)
+	assert.DeepEqual(t, plan, removed)
+
+	// finalized data is kept and nothing is left to remove
+	plan, err = db.PlanResync()
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ResyncPlan{FinalizedSlot: 6, FinalizedEpoch: 1, LatestVerifiedSlot: 6, LatestEpoch: 1}, plan)
+	slotInfo, err := db.VerifiedSlotInfo(6)
+	require.NoError(t, err)
+	assert.Equal(t, common.BytesToHash([]byte{6}), slotInfo.PandoraHeaderHash)
+	invalid, err := db.InvalidSlotInfo(4)
+	require.NoError(t, err)
+	assert.NotNil(t, invalid)
+	assert.Equal(t, common.BytesToHash([]byte{6}), db.LatestVerifiedHeaderHash())
+}
@@ -0,0 +1,117 @@
+package kv
+
+import (
+	"bytes"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
+)
+
+// PruneVerifiedSlots removes verified slot infos, their accumulator steps and pandora block numbers of slots before
+// the given slot and returns the number of removed slots. Accumulator leaves are kept since the accumulator is
+// rebuilt from them, so inclusion proofs of pruned slots are no longer served. Archive db is never pruned.
+func (s *Store) PruneVerifiedSlots(beforeSlot uint64) (int, error) {
+	if s.archive {
+		return 0, nil
+	}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var slots [][]byte
+	err := s.db.Update(func(tx Tx) error {
+		end := bytesutil.Uint64ToBytesBigEndian(beforeSlot)
+		for _, bucket := range [][]byte{verifiedSlotInfosBucket, accumulatorStepsBucket} {
+			bkt := tx.Bucket(bucket)
+			var keys [][]byte
+			c := bkt.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+			if bytes.Equal(bucket, verifiedSlotInfosBucket) {
+				slots = keys
+			}
+		}
+		return removeBlockNumbersBefore(tx, beforeSlot)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, slot := range slots {
+		s.verifiedSlotInfoCache.Del(bytesutil.BytesToUint64BigEndian(slot))
+	}
+	return len(slots), nil
+}
+
+// removeBlockNumbersBefore removes the numbers of the slots before the given slot. Block numbers grow along with
+// slots on the verified chain, so the removed slots are at the head of the index.
+func removeBlockNumbersBefore(tx Tx, beforeSlot uint64) error {
+	var numbers [][]byte
+	c := tx.Bucket(blockNumberIndexBucket).Cursor()
+	for k, v := c.First(); k != nil && bytesutil.BytesToUint64BigEndian(v) < beforeSlot; k, v = c.Next() {
+		numbers = append(numbers, bytesutil.SafeCopyBytes(k))
+	}
+	bkt := tx.Bucket(blockNumberIndexBucket)
+	for _, number := range numbers {
+		if err := bkt.Delete(number); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneConsensusInfos removes consensus infos and their sources of epochs before the given epoch and returns the
+// number of removed epochs. The epoch is stored, so that queries of pruned history are told apart from missing
+// epochs. Archive db is never pruned.
+func (s *Store) PruneConsensusInfos(beforeEpoch uint64) (int, error) {
+	if s.archive || beforeEpoch <= s.EarliestConsensusInfoEpoch() {
+		return 0, nil
+	}
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var epochs [][]byte
+	err := s.db.Update(func(tx Tx) error {
+		end := bytesutil.Uint64ToBytesBigEndian(beforeEpoch)
+		for _, bucket := range [][]byte{consensusInfosBucket, consensusInfoSrcBucket} {
+			bkt := tx.Bucket(bucket)
+			var keys [][]byte
+			c := bkt.Cursor()
+			for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+				keys = append(keys, bytesutil.SafeCopyBytes(k))
+			}
+			for _, k := range keys {
+				if err := bkt.Delete(k); err != nil {
+					return err
+				}
+			}
+			if bytes.Equal(bucket, consensusInfosBucket) {
+				epochs = keys
+			}
+		}
+		return tx.Bucket(latestInfoMarkerBucket).Put(prunedEpochsKey, end)
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, epoch := range epochs {
+		s.consensusInfoCache.Del(bytesutil.BytesToUint64BigEndian(epoch))
+	}
+	return len(epochs), nil
+}
+
+// EarliestConsensusInfoEpoch returns the first epoch whose consensus info is not pruned
+func (s *Store) EarliestConsensusInfoEpoch() uint64 {
+	var epoch uint64
+	// error is ignored, so a db without pruned epochs serves every epoch
+	_ = s.db.View(func(tx Tx) error {
+		if enc := tx.Bucket(latestInfoMarkerBucket).Get(prunedEpochsKey); enc != nil {
+			epoch = bytesutil.BytesToUint64BigEndian(enc)
+		}
+		return nil
+	})
+	return epoch
+}
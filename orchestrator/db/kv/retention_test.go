@@ -0,0 +1,97 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_PruneVerifiedSlots(t *testing.T) {
+	t.Parallel()
+	db := setupDB(t, true)
+
+	createAndSaveEmptySlotInfos(t, 10, db)
+	for i := uint64(0); i < 10; i++ {
+		require.NoError(t, db.SaveAccumulatorStep(&types.AccumulatorStep{
+			Slot:      i,
+			LeafIndex: i,
+			Leaf:      common.BytesToHash([]byte{byte(i)}),
+		}))
+		require.NoError(t, db.SavePandoraBlockNumber(i+100, i))
+	}
+
+	removed, err := db.PruneVerifiedSlots(4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, removed)
+
+	slotInfo, err := db.VerifiedSlotInfo(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.SlotInfo)(nil), slotInfo)
+	slotInfo, err = db.VerifiedSlotInfo(4)
+	require.NoError(t, err)
+	assert.NotNil(t, slotInfo)
+
+	step, err := db.AccumulatorStep(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.AccumulatorStep)(nil), step)
+	step, err = db.AccumulatorStep(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), step.LeafIndex)
+
+	_, found, err := db.SlotByPandoraBlockNumber(103)
+	require.NoError(t, err)
+	assert.Equal(t, false, found)
+	slot, found, err := db.SlotByPandoraBlockNumber(104)
+	require.NoError(t, err)
+	assert.Equal(t, true, found)
+	assert.Equal(t, uint64(4), slot)
+
+	// leaves are kept for rebuilding the accumulator
+	leaves, err := db.AccumulatorLeaves(10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, len(leaves))
+}
+
+func TestStore_PruneConsensusInfos(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t, true)
+
+	for epoch := uint64(0); epoch < 10; epoch++ {
+		require.NoError(t, db.SaveConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(epoch).ConvertToEpochInfo()))
+		require.NoError(t, db.SaveConsensusInfoSource(epoch, &types.EpochInfoSource{Slot: epoch * 32}))
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, 9))
+	assert.Equal(t, uint64(0), db.EarliestConsensusInfoEpoch())
+
+	removed, err := db.PruneConsensusInfos(4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, removed)
+	assert.Equal(t, uint64(4), db.EarliestConsensusInfoEpoch())
+
+	source, err := db.ConsensusInfoSource(3)
+	require.NoError(t, err)
+	assert.Equal(t, (*types.EpochInfoSource)(nil), source)
+	source, err = db.ConsensusInfoSource(4)
+	require.NoError(t, err)
+	assert.NotNil(t, source)
+
+	// range from a pruned epoch fails instead of starting at the first kept epoch
+	_, err = db.ConsensusInfoRange(0, 5)
+	assert.ErrorContains(t, "epoch info is pruned", err)
+	consensusInfos, err := db.ConsensusInfoRange(4, 5)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(consensusInfos))
+	assert.Equal(t, uint64(4), consensusInfos[0].Epoch)
+
+	// pruning never moves back
+	removed, err = db.PruneConsensusInfos(2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	assert.Equal(t, uint64(4), db.EarliestConsensusInfoEpoch())
+}
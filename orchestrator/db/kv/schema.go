@@ -3,13 +3,42 @@ package kv
 var (
 	// 3 buckets for containing orchestrator data
 	consensusInfosBucket    = []byte("consensus-info")
+	consensusInfoSrcBucket  = []byte("consensus-info-source")
 	verifiedSlotInfosBucket = []byte("verified-slots")
 	invalidSlotInfosBucket  = []byte("invalid-slots")
+	disagreementsBucket     = []byte("disagreements")
+	equivocationsBucket     = []byte("shard-equivocations")
+	shardConflictsBucket    = []byte("shard-conflicts")
 	latestInfoMarkerBucket  = []byte("latest-info-marker") // Only use for storing the following keys
+	chainIdentityBucket     = []byte("chain-identity")
+	accumulatorLeavesBucket = []byte("accumulator-leaves")
+	accumulatorStepsBucket  = []byte("accumulator-steps")
+	pandoraHashIndexBucket  = []byte("pandora-hash-index")
+	vanguardHashIndexBucket = []byte("vanguard-hash-index")
+	inProgressSlotsBucket   = []byte("in-progress-slots")
+	epochSummariesBucket    = []byte("epoch-summaries")
+	blockNumberIndexBucket  = []byte("pandora-number-index")
+	consumerAcksBucket      = []byte("consumer-acks")
+	migrationHistoryBucket  = []byte("migration-history")
+	reorgsBucket            = []byte("reorgs")
+	deferredTasksBucket     = []byte("deferred-tasks")
 
 	latestHeaderHashKey        = []byte("latest-header-hash")
 	lastStoredEpochKey         = []byte("last-epoch")
+	prunedEpochsKey            = []byte("pruned-epochs")
 	latestSavedVerifiedSlotKey = []byte("latest-verified-slot")
 	latestFinalizedSlotKey     = []byte("latest-finalized-slot")
 	latestFinalizedEpochKey    = []byte("latest-finalized-epoch")
+	latestAckedSlotKey         = []byte("latest-acked-slot")
+	valueCodecKey              = []byte("value-codec")
+	archiveModeKey             = []byte("archive-mode")
+	cleanShutdownKey           = []byte("clean-shutdown")
+	lifetimeStatsKey           = []byte("lifetime-stats")
+	usageBaselineKey           = []byte("usage-baseline")
+	schemaVersionKey           = []byte("schema-version")
+	pendingReorgKey            = []byte("pending-reorg")
+
+	// keys of chain identity bucket
+	pandoraChainIdentityKey          = []byte("pandora-chain-identity")
+	vanguardGenesisValidatorsRootKey = []byte("vanguard-genesis-validators-root")
 )
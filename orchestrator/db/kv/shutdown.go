@@ -0,0 +1,29 @@
+package kv
+
+// SaveCleanShutdown marks that every pending write of the run has been flushed before the node stopped
+func (s *Store) SaveCleanShutdown() error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	return s.db.Update(func(tx Tx) error {
+		return tx.Bucket(latestInfoMarkerBucket).Put(cleanShutdownKey, []byte{1})
+	})
+}
+
+// ConsumeCleanShutdown reports whether the previous run stopped cleanly and clears the marker, so a crash of the
+// current run is not mistaken for a clean shutdown at next start
+func (s *Store) ConsumeCleanShutdown() (bool, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var clean bool
+	err := s.db.Update(func(tx Tx) error {
+		bkt := tx.Bucket(latestInfoMarkerBucket)
+		if bkt.Get(cleanShutdownKey) == nil {
+			return nil
+		}
+		clean = true
+		return bkt.Delete(cleanShutdownKey)
+	})
+	return clean, err
+}
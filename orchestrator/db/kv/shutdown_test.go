@@ -0,0 +1,37 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestStore_CleanShutdown(t *testing.T) {
+	dir := t.TempDir()
+	db, err := NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+
+	// brand new db has no marker
+	clean, err := db.ConsumeCleanShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, false, clean)
+
+	require.NoError(t, db.SaveCleanShutdown())
+	require.NoError(t, db.Close())
+
+	db, err = NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	clean, err = db.ConsumeCleanShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, true, clean)
+
+	// marker is consumed, so a crash of this run is reported as unclean
+	clean, err = db.ConsumeCleanShutdown()
+	require.NoError(t, err)
+	assert.Equal(t, false, clean)
+}
@@ -0,0 +1,20 @@
+package kv
+
+import (
+	"os"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+)
+
+// Snapshot writes a consistent copy of the db to the given file. The copy is written next to the file and renamed
+// when it is complete, so the file never holds a partial snapshot.
+func (s *Store) Snapshot(file string) error {
+	tmpFile := file + ".tmp"
+	if err := s.db.View(func(tx Tx) error {
+		return tx.CopyFile(tmpFile, params.OrchestratorIoConfig().ReadWritePermissions)
+	}); err != nil {
+		os.Remove(tmpFile)
+		return err
+	}
+	return os.Rename(tmpFile, file)
+}
@@ -0,0 +1,30 @@
+package kv
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestStore_Snapshot(t *testing.T) {
+	db := setupDB(t, true)
+	slotInfo := &types.SlotInfo{VanguardBlockHash: common.HexToHash("0x1"), PandoraHeaderHash: common.HexToHash("0x2")}
+	require.NoError(t, db.SaveVerifiedSlotInfo(1, slotInfo))
+
+	dir := t.TempDir()
+	require.NoError(t, db.Snapshot(path.Join(dir, DatabaseFileName)))
+
+	snapshot, err := NewKVStore(context.Background(), dir, &Config{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, snapshot.Close())
+	}()
+	retrieved, err := snapshot.VerifiedSlotInfo(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, slotInfo, retrieved)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/boltdb/bolt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lukso-network/lukso-orchestrator/shared/bytesutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
@@ -19,7 +18,7 @@ var (
 func (s *Store) SeekSlotInfo(slot uint64) (uint64, *types.SlotInfo, error) {
 	var slotInfo *types.SlotInfo
 	var foundSlot uint64
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
 		for i := int64(slot); i > 0; i-- {
 			slotInBytes := bytesutil.Uint64ToBytesBigEndian(uint64(i))
@@ -27,7 +26,7 @@ func (s *Store) SeekSlotInfo(slot uint64) (uint64, *types.SlotInfo, error) {
 			if info == nil {
 				continue
 			}
-			err := decode(info, &slotInfo)
+			err := s.codec.decode(info, &slotInfo)
 			if err != nil {
 				return err
 			}
@@ -48,14 +47,14 @@ func (s *Store) VerifiedSlotInfo(slot uint64) (*types.SlotInfo, error) {
 		return v.(*types.SlotInfo), nil
 	}
 	var slotInfo *types.SlotInfo
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
 		key := bytesutil.Uint64ToBytesBigEndian(slot)
 		value := bkt.Get(key[:])
 		if value == nil {
 			return nil
 		}
-		return decode(value, &slotInfo)
+		return s.codec.decode(value, &slotInfo)
 	})
 	return slotInfo, err
 }
@@ -69,7 +68,7 @@ func (s *Store) VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo,
 	}
 
 	slotInfos := make(map[uint64]*types.SlotInfo)
-	err := s.db.View(func(tx *bolt.Tx) error {
+	err := s.db.View(func(tx Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
 		for slot := fromSlot; slot <= latestVerifiedSlot; slot++ {
 			// fast finding into cache, if the value does not exist in cache, it starts finding into db
@@ -85,7 +84,7 @@ func (s *Store) VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo,
 				continue
 			}
 			var slotInfo *types.SlotInfo
-			decode(enc, &slotInfo)
+			s.codec.decode(enc, &slotInfo)
 			slotInfos[slot] = slotInfo
 		}
 		return nil
@@ -102,10 +101,10 @@ func (s *Store) VerifiedSlotInfos(fromSlot uint64) (map[uint64]*types.SlotInfo,
 // After save operations you must call SaveLatestVerifiedSlot to push in memory slot height to db
 func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) error {
 	// storing consensus info into cache and db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
 		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
-		enc, err := encode(slotInfo)
+		enc, err := s.codec.encode(slotInfo)
 		if err != nil {
 			return err
 		}
@@ -115,6 +114,9 @@ func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) erro
 		if err := bkt.Put(slotBytes, enc); err != nil {
 			return err
 		}
+		if s.archive {
+			return indexSlotInfo(tx, slot, slotInfo)
+		}
 		return nil
 	})
 }
@@ -122,7 +124,7 @@ func (s *Store) SaveVerifiedSlotInfo(slot uint64, slotInfo *types.SlotInfo) erro
 // SaveLatestEpoch
 func (s *Store) SaveLatestVerifiedSlot(ctx context.Context, slot uint64) error {
 	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(latestInfoMarkerBucket)
 		slotBytes := bytesutil.Uint64ToBytesBigEndian(slot)
 		if err := bkt.Put(latestSavedVerifiedSlotKey, slotBytes); err != nil {
@@ -137,7 +139,7 @@ func (s *Store) LatestSavedVerifiedSlot() uint64 {
 	var latestSavedVerifiedSlot uint64
 	// Db is not prepared yet. Retrieve latest saved epoch number from db
 	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
+		s.db.View(func(tx Tx) error {
 			bkt := tx.Bucket(latestInfoMarkerBucket)
 			slotBytes := bkt.Get(latestSavedVerifiedSlotKey[:])
 			// not found the latest epoch in db. so latest epoch will be zero
@@ -157,7 +159,7 @@ func (s *Store) LatestSavedVerifiedSlot() uint64 {
 // SaveLatestEpoch
 func (s *Store) SaveLatestVerifiedHeaderHash(hash common.Hash) error {
 	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(latestInfoMarkerBucket)
 		headerHashBytes := hash.Bytes()
 		if err := bkt.Put(latestHeaderHashKey, headerHashBytes); err != nil {
@@ -173,7 +175,7 @@ func (s *Store) LatestVerifiedHeaderHash() common.Hash {
 	var latestHeaderHash common.Hash
 	// Db is not prepared yet. Retrieve latest saved epoch number from db
 	if !s.isRunning {
-		s.db.View(func(tx *bolt.Tx) error {
+		s.db.View(func(tx Tx) error {
 			bkt := tx.Bucket(latestInfoMarkerBucket)
 			latestHeaderHashBytes := bkt.Get(latestHeaderHashKey[:])
 			// not found the latest epoch in db. so latest epoch will be zero
@@ -194,6 +196,16 @@ func (s *Store) LatestVerifiedHeaderHash() common.Hash {
 // fromSlot must be higher or equal slot number that is present in db
 // TODO: consider not returning 0 when slot was not found, instead extend this function with multiple return
 func (s *Store) FindVerifiedSlotNumber(info *types.SlotInfo, fromSlot uint64) uint64 {
+	if s.archive {
+		slot, found, err := s.SlotByPandoraHeaderHash(info.PandoraHeaderHash)
+		if err != nil || !found || slot > fromSlot {
+			return 0
+		}
+		if slotInfo, err := s.VerifiedSlotInfo(slot); err == nil && slotInfo != nil && slotInfo.VanguardBlockHash == info.VanguardBlockHash {
+			return slot
+		}
+		return 0
+	}
 	for i := fromSlot; i > 0; i-- {
 		slotInfo, err := s.VerifiedSlotInfo(i)
 		if err != nil {
@@ -213,17 +225,32 @@ func (s *Store) RemoveRangeVerifiedInfo(fromSlot, toSlot uint64) error {
 		Debug("Start removing slot infos from verified db!")
 
 	// storing latest epoch number into db
-	return s.db.Update(func(tx *bolt.Tx) error {
+	return s.db.Update(func(tx Tx) error {
 		bkt := tx.Bucket(verifiedSlotInfosBucket)
 
 		for slotNum := fromSlot; slotNum <= toSlot; slotNum++ {
 			removingSlotNumber := bytesutil.Uint64ToBytesBigEndian(slotNum)
 			s.verifiedSlotInfoCache.Del(slotNum)
+			if enc := bkt.Get(removingSlotNumber); s.archive && enc != nil {
+				var slotInfo *types.SlotInfo
+				if err := s.codec.decode(enc, &slotInfo); err != nil {
+					return err
+				}
+				if err := unindexSlotInfo(tx, slotNum, slotInfo); err != nil {
+					return err
+				}
+			}
 			err := bkt.Delete(removingSlotNumber)
 			if err != nil {
 				return err
 			}
 		}
+		if err := s.removeAccumulatorSteps(tx, fromSlot, toSlot); err != nil {
+			return err
+		}
+		if err := removeBlockNumbers(tx, fromSlot); err != nil {
+			return err
+		}
 		log.Debug("success:: all slots are removed from the verified database")
 		return nil
 	})
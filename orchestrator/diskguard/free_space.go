@@ -0,0 +1,10 @@
+// +build !linux,!darwin
+
+package diskguard
+
+import "github.com/pkg/errors"
+
+// freeSpace returns an error on platforms without statfs
+func freeSpace(path string) (uint64, error) {
+	return 0, errors.New("free disk space is not supported in this platform")
+}
@@ -0,0 +1,14 @@
+// +build linux darwin
+
+package diskguard
+
+import "syscall"
+
+// freeSpace returns the bytes which are available to unprivileged users on the disk of the given path
+func freeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,5 @@
+package diskguard
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "diskguard")
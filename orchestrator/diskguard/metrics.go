@@ -0,0 +1,14 @@
+package diskguard
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// dbSizeGauge is the size of the db file in bytes
+	dbSizeGauge = metrics.NewRegisteredGauge("orc_disk_db_size_bytes", nil)
+	// freeSpaceGauge is the free space of the disk which holds the db in bytes
+	freeSpaceGauge = metrics.NewRegisteredGauge("orc_disk_free_bytes", nil)
+	// growthGauge is the db growth in bytes per hour
+	growthGauge = metrics.NewRegisteredGauge("orc_disk_growth_bytes_per_hour", nil)
+	// timeToFullGauge is the forecast number of seconds until the disk is full, -1 when db does not grow
+	timeToFullGauge = metrics.NewRegisteredGauge("orc_disk_time_to_full_seconds", nil)
+)
@@ -0,0 +1,222 @@
+// Package diskguard tracks db growth, forecasts when the disk of the db is full and shrinks db growth when free
+// space drops below the configured floor.
+package diskguard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// growthWindow is the period which db growth rate is measured over
+var growthWindow = 6 * time.Hour
+
+// Database is the db which is guarded
+type Database interface {
+	db.DiskPressureDB
+	DatabasePath() string
+	LatestLatestFinalizedSlot() uint64
+}
+
+type Config struct {
+	DB       Database
+	Interval time.Duration
+	// FreeSpaceFloor is the free space in bytes below which diagnostics are paused and pruned. 0 disables it
+	FreeSpaceFloor uint64
+	// WarnHorizon raises disk pressure when the disk is forecast to be full within it
+	WarnHorizon time.Duration
+}
+
+// sample is the db size at a time
+type sample struct {
+	at   time.Time
+	size int64
+}
+
+// Service periodically samples db size and free disk space
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	db               Database
+	interval         time.Duration
+	floor            uint64
+	warnHorizon      time.Duration
+	diskPressureFeed event.Feed
+	scope            event.SubscriptionScope
+
+	// freeSpace and now are replaced in tests
+	freeSpace func(path string) (uint64, error)
+	now       func() time.Time
+
+	lock       sync.Mutex
+	samples    []sample
+	belowFloor bool
+	forecastOK bool
+	runError   error
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("disk check interval must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		db:          cfg.DB,
+		interval:    cfg.Interval,
+		floor:       cfg.FreeSpaceFloor,
+		warnHorizon: cfg.WarnHorizon,
+		freeSpace:   freeSpace,
+		now:         time.Now,
+		forecastOK:  true,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start disk guard service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("interval", s.interval).WithField("freeSpaceFloor", s.floor).
+		WithField("warnHorizon", s.warnHorizon).Info("Started disk guard")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.scope.Close()
+	s.isRunning = false
+	return nil
+}
+
+// Status returns error while free disk space is below the floor
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.runError
+}
+
+// SubscribeDiskPressureEvent registers a subscription of disk pressure alerts
+func (s *Service) SubscribeDiskPressureEvent(ch chan<- *types.DiskPressure) event.Subscription {
+	return s.scope.Track(s.diskPressureFeed.Subscribe(ch))
+}
+
+// run
+func (s *Service) run() {
+	s.check()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing disk guard service")
+			return
+		}
+	}
+}
+
+// check samples db size and free space, updates the forecast and reacts on disk pressure
+func (s *Service) check() {
+	size, err := s.db.Size()
+	if err != nil {
+		log.WithError(err).Warn("Failed to retrieve db size")
+		return
+	}
+	free, err := s.freeSpace(s.db.DatabasePath())
+	if err != nil {
+		log.WithError(err).Warn("Failed to retrieve free disk space")
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	growth := s.addSample(size)
+	pressure := &types.DiskPressure{DBSize: size, FreeSpace: free, GrowthPerHour: growth}
+	if growth > 0 {
+		pressure.TimeToFull = time.Duration(float64(free) / float64(growth) * float64(time.Hour))
+	}
+
+	dbSizeGauge.Update(size)
+	freeSpaceGauge.Update(int64(free))
+	growthGauge.Update(growth)
+	if pressure.TimeToFull > 0 {
+		timeToFullGauge.Update(int64(pressure.TimeToFull.Seconds()))
+	} else {
+		timeToFullGauge.Update(-1)
+	}
+
+	alert := false
+	forecastOK := pressure.TimeToFull == 0 || s.warnHorizon <= 0 || pressure.TimeToFull > s.warnHorizon
+	if !forecastOK && s.forecastOK {
+		log.WithField("freeSpace", free).WithField("growthPerHour", growth).WithField("timeToFull", pressure.TimeToFull).
+			Warn("Disk of the db is forecast to be full soon")
+		alert = true
+	}
+	s.forecastOK = forecastOK
+
+	switch {
+	case s.floor > 0 && free < s.floor && !s.belowFloor:
+		s.belowFloor = true
+		s.runError = errors.Errorf("free disk space %d bytes is below the floor of %d bytes", free, s.floor)
+		s.relieve()
+		alert = true
+	case s.belowFloor && free > s.floor+s.floor/10:
+		// resume only with some headroom so that the guard does not flap around the floor
+		s.belowFloor = false
+		s.runError = nil
+		s.db.PauseDiagnostics(false)
+		log.WithField("freeSpace", free).Info("Free disk space is above the floor again, resumed recording diagnostics")
+	}
+	pressure.BelowFloor = s.belowFloor
+
+	if alert {
+		s.diskPressureFeed.Send(pressure)
+	}
+}
+
+// relieve pauses and prunes diagnostics which are not needed for verification
+func (s *Service) relieve() {
+	s.db.PauseDiagnostics(true)
+	finalizedSlot := s.db.LatestLatestFinalizedSlot()
+	removed, err := s.db.PruneDiagnostics(finalizedSlot)
+	if err != nil {
+		log.WithError(err).Error("Failed to prune diagnostics under disk pressure")
+	}
+	log.WithField("floor", s.floor).WithField("prunedBeforeSlot", finalizedSlot).WithField("pruned", removed).
+		Warn("Free disk space is below the floor, paused recording diagnostics and pruned finalized ones")
+}
+
+// addSample records the db size and returns db growth in bytes per hour over the growth window
+func (s *Service) addSample(size int64) int64 {
+	now := s.now()
+	s.samples = append(s.samples, sample{at: now, size: size})
+	for len(s.samples) > 2 && now.Sub(s.samples[1].at) >= growthWindow {
+		s.samples = s.samples[1:]
+	}
+
+	oldest := s.samples[0]
+	elapsed := now.Sub(oldest.at)
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(float64(size-oldest.size) / elapsed.Hours())
+}
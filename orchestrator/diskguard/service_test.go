@@ -0,0 +1,114 @@
+package diskguard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// mockDB grows by a fixed number of bytes at every size query
+type mockDB struct {
+	Database
+	size      int64
+	growth    int64
+	paused    bool
+	prunedTo  uint64
+	finalized uint64
+}
+
+func (m *mockDB) Size() (int64, error) {
+	m.size += m.growth
+	return m.size, nil
+}
+
+func (m *mockDB) PauseDiagnostics(paused bool) { m.paused = paused }
+
+func (m *mockDB) PruneDiagnostics(beforeSlot uint64) (int, error) {
+	m.prunedTo = beforeSlot
+	return 0, nil
+}
+
+func (m *mockDB) DatabasePath() string { return "" }
+
+func (m *mockDB) LatestLatestFinalizedSlot() uint64 { return m.finalized }
+
+func setup(t *testing.T, database Database, free *uint64) (*Service, chan *types.DiskPressure) {
+	s, err := NewService(context.Background(), &Config{
+		DB:             database,
+		Interval:       time.Minute,
+		FreeSpaceFloor: 1000,
+		WarnHorizon:    24 * time.Hour,
+	})
+	require.NoError(t, err)
+	s.freeSpace = func(string) (uint64, error) { return *free, nil }
+	now := time.Unix(0, 0)
+	s.now = func() time.Time {
+		now = now.Add(time.Hour)
+		return now
+	}
+	alerts := make(chan *types.DiskPressure, 10)
+	sub := s.SubscribeDiskPressureEvent(alerts)
+	t.Cleanup(sub.Unsubscribe)
+	return s, alerts
+}
+
+func TestService_Forecast(t *testing.T) {
+	free := uint64(100000)
+	s, alerts := setup(t, &mockDB{growth: 1000}, &free)
+
+	s.check()
+	s.check()
+	assert.Equal(t, 0, len(alerts))
+
+	// 1000 bytes per hour fills 20000 bytes in 20 hours
+	free = 20000
+	s.check()
+	require.Equal(t, 1, len(alerts))
+	pressure := <-alerts
+	assert.Equal(t, int64(1000), pressure.GrowthPerHour)
+	assert.Equal(t, 20*time.Hour, pressure.TimeToFull)
+	assert.Equal(t, false, pressure.BelowFloor)
+	require.NoError(t, s.Status())
+
+	// alert is raised once until the forecast recovers
+	s.check()
+	assert.Equal(t, 0, len(alerts))
+}
+
+func TestService_FreeSpaceFloor(t *testing.T) {
+	free := uint64(100000)
+	database := &mockDB{finalized: 64}
+	s, alerts := setup(t, database, &free)
+
+	free = 500
+	s.check()
+	assert.Equal(t, true, database.paused)
+	assert.Equal(t, uint64(64), database.prunedTo)
+	assert.ErrorContains(t, "below the floor", s.Status())
+	require.Equal(t, 1, len(alerts))
+	assert.Equal(t, true, (<-alerts).BelowFloor)
+
+	// no resume until there is headroom above the floor
+	free = 1050
+	s.check()
+	assert.Equal(t, true, database.paused)
+
+	free = 2000
+	s.check()
+	assert.Equal(t, false, database.paused)
+	require.NoError(t, s.Status())
+}
+
+func TestService_RealDB(t *testing.T) {
+	database := testDB.SetupDB(t)
+	free := uint64(100000)
+	s, _ := setup(t, database, &free)
+	s.check()
+	assert.Equal(t, 1, len(s.samples))
+	assert.Equal(t, true, s.samples[0].size > 0)
+}
@@ -0,0 +1,5 @@
+package exporter
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "exporter")
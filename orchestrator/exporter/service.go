@@ -0,0 +1,116 @@
+// Package exporter serves the collected metrics in prometheus format and the optional stats endpoint over HTTP.
+package exporter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/pkg/errors"
+)
+
+// shutdownTimeout is the time which in-flight scrapes are given to finish on stop
+const shutdownTimeout = 5 * time.Second
+
+type Config struct {
+	// Addr is the host:port which the metrics HTTP server listens on
+	Addr string
+	// Handlers are served next to /metrics by path
+	Handlers map[string]http.Handler
+}
+
+// Service serves the metrics of the default registry at /metrics and the configured handlers
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	server   *http.Server
+	lock     sync.Mutex
+	addr     string
+	runError error
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler(metrics.DefaultRegistry))
+	for path, handler := range cfg.Handlers {
+		mux.Handle(path, handler)
+	}
+	return &Service{
+		ctx:    ctx,
+		cancel: cancel,
+		server: &http.Server{Addr: cfg.Addr, Handler: mux},
+		addr:   cfg.Addr,
+	}
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start metrics exporter when it was already started")
+		return
+	}
+	s.isRunning = true
+
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		log.WithError(err).WithField("addr", s.server.Addr).Error("Failed to start metrics HTTP server")
+		s.setRunError(errors.Wrap(err, "could not listen for metrics HTTP server"))
+		return
+	}
+	s.lock.Lock()
+	s.addr = listener.Addr().String()
+	s.lock.Unlock()
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("Metrics HTTP server stopped")
+			s.setRunError(err)
+		}
+	}()
+	log.WithField("url", "http://"+listener.Addr().String()+"/metrics").
+		WithField("enabled", metrics.Enabled).Info("Started metrics HTTP server")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	if !s.isRunning {
+		return nil
+	}
+	s.isRunning = false
+	ctx, cancel := context.WithTimeout(s.ctx, shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Status returns error when the metrics HTTP server could not be started or stopped unexpectedly
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.runError
+}
+
+// Addr returns the address which the metrics HTTP server listens on
+func (s *Service) Addr() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.addr
+}
+
+func (s *Service) setRunError(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runError = err
+}
@@ -0,0 +1,41 @@
+package exporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestService_ServesMetrics(t *testing.T) {
+	gauge := metrics.NewRegisteredGauge("orc_exporter_test", nil)
+	defer metrics.DefaultRegistry.Unregister("orc_exporter_test")
+	gauge.Update(7)
+
+	s := NewService(context.Background(), &Config{Addr: "127.0.0.1:0"})
+	s.Start()
+	defer func() {
+		require.NoError(t, s.Stop())
+	}()
+	require.NoError(t, s.Status())
+
+	resp, err := http.Get("http://" + s.Addr() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, true, strings.Contains(string(body), "orc_exporter_test"))
+}
+
+func TestService_ListenError(t *testing.T) {
+	s := NewService(context.Background(), &Config{Addr: "127.0.0.1:-1"})
+	s.Start()
+	assert.ErrorContains(t, "could not listen for metrics HTTP server", s.Status())
+}
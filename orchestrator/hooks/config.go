@@ -0,0 +1,108 @@
+package hooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// events which can fire hooks
+const (
+	VerifiedSlotEvent = "verified"
+	InvalidSlotEvent  = "invalid"
+	ReorgEvent        = "reorg"
+	DiskPressureEvent = "disk-pressure"
+)
+
+// defaultTimeout is the time a hook is allowed to run when timeout is not configured
+const defaultTimeout = 10 * time.Second
+
+// HookConfig is a hook definition in operator's hooks config file. Exactly one of command or url must be set.
+// Payload and command arguments are go templates executed with the fired Event.
+type HookConfig struct {
+	Name    string   `json:"name"`
+	Events  []string `json:"events"`
+	Command []string `json:"command,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Payload string   `json:"payload,omitempty"`
+	Timeout string   `json:"timeout,omitempty"`
+}
+
+// FileConfig is the content of the hooks config file
+type FileConfig struct {
+	Hooks []*HookConfig `json:"hooks"`
+}
+
+// hook is a validated and parsed hook definition
+type hook struct {
+	name    string
+	events  map[string]bool
+	command []*template.Template
+	url     string
+	payload *template.Template
+	timeout time.Duration
+}
+
+// LoadConfig reads hooks config file from the given path
+func LoadConfig(path string) (*FileConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read hooks config file")
+	}
+	cfg := new(FileConfig)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not parse hooks config file")
+	}
+	return cfg, nil
+}
+
+// parseHook validates hook config and parses its templates
+func parseHook(cfg *HookConfig) (*hook, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("hook name is missing")
+	}
+	if (len(cfg.Command) == 0) == (cfg.URL == "") {
+		return nil, errors.Errorf("hook %s must have either command or url", cfg.Name)
+	}
+	if len(cfg.Events) == 0 {
+		return nil, errors.Errorf("hook %s has no event", cfg.Name)
+	}
+
+	h := &hook{
+		name:    cfg.Name,
+		events:  make(map[string]bool, len(cfg.Events)),
+		url:     cfg.URL,
+		timeout: defaultTimeout,
+	}
+	for _, ev := range cfg.Events {
+		switch ev {
+		case VerifiedSlotEvent, InvalidSlotEvent, ReorgEvent, DiskPressureEvent:
+			h.events[ev] = true
+		default:
+			return nil, errors.Errorf("hook %s has unknown event %s", cfg.Name, ev)
+		}
+	}
+	for i, arg := range cfg.Command {
+		tmpl, err := template.New(cfg.Name).Parse(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse argument %d of hook %s", i, cfg.Name)
+		}
+		h.command = append(h.command, tmpl)
+	}
+	tmpl, err := template.New(cfg.Name).Parse(cfg.Payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse payload of hook %s", cfg.Name)
+	}
+	h.payload = tmpl
+	if cfg.Timeout != "" {
+		timeout, err := time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid timeout of hook %s", cfg.Name)
+		}
+		h.timeout = timeout
+	}
+	return h, nil
+}
@@ -0,0 +1,58 @@
+package hooks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestParseHook_Invalid(t *testing.T) {
+	_, err := parseHook(&HookConfig{Name: "both", Events: []string{VerifiedSlotEvent}, Command: []string{"true"}, URL: "http://localhost"})
+	assert.ErrorContains(t, "must have either command or url", err)
+
+	_, err = parseHook(&HookConfig{Name: "unknown", Events: []string{"finalized"}, Command: []string{"true"}})
+	assert.ErrorContains(t, "unknown event", err)
+
+	_, err = parseHook(&HookConfig{Name: "timeout", Events: []string{ReorgEvent}, Command: []string{"true"}, Timeout: "soon"})
+	assert.ErrorContains(t, "invalid timeout", err)
+}
+
+func TestHook_Webhook(t *testing.T) {
+	received := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		received <- string(body)
+	}))
+	defer srv.Close()
+
+	h, err := parseHook(&HookConfig{
+		Name:    "indexer",
+		Events:  []string{VerifiedSlotEvent},
+		URL:     srv.URL,
+		Payload: `{"event":"{{.Event}}","slot":{{.Slot}},"hash":"{{.PandoraHeaderHash.Hex}}"}`,
+	})
+	require.NoError(t, err)
+
+	hash := common.HexToHash("0x01")
+	require.NoError(t, h.run(context.Background(), &Event{Event: VerifiedSlotEvent, Slot: 7, PandoraHeaderHash: hash}))
+	assert.Equal(t, `{"event":"verified","slot":7,"hash":"`+hash.Hex()+`"}`, <-received)
+}
+
+func TestHook_CommandFailure(t *testing.T) {
+	h, err := parseHook(&HookConfig{
+		Name:    "purge",
+		Events:  []string{InvalidSlotEvent},
+		Command: []string{"sh", "-c", "exit {{.Slot}}"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, h.run(context.Background(), &Event{Event: InvalidSlotEvent, Slot: 0}))
+	assert.ErrorContains(t, "command failed", h.run(context.Background(), &Event{Event: InvalidSlotEvent, Slot: 3}))
+}
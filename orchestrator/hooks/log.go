@@ -0,0 +1,5 @@
+package hooks
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "hooks")
@@ -0,0 +1,89 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os/exec"
+	"text/template"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Event is the data which is available in hook templates
+type Event struct {
+	Event             string
+	Slot              uint64
+	PandoraHeaderHash common.Hash
+	VanguardBlockHash common.Hash
+	Status            string
+	VanParentHash     hexutil.Bytes
+	PanParentHash     hexutil.Bytes
+	// FreeSpace, TimeToFull and BelowFloor are only set on disk pressure
+	FreeSpace  uint64
+	TimeToFull time.Duration
+	BelowFloor bool
+}
+
+// render executes the template with the event
+func render(tmpl *template.Template, ev *Event) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ev); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// run fires the hook with the given event
+func (h *hook) run(ctx context.Context, ev *Event) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	payload, err := render(h.payload, ev)
+	if err != nil {
+		return errors.Wrap(err, "could not render payload")
+	}
+	if h.url != "" {
+		return h.post(ctx, payload)
+	}
+	return h.exec(ctx, ev, payload)
+}
+
+// exec runs the hook command. Rendered payload is written to the command's stdin.
+func (h *hook) exec(ctx context.Context, ev *Event, payload []byte) error {
+	args := make([]string, len(h.command))
+	for i, tmpl := range h.command {
+		arg, err := render(tmpl, ev)
+		if err != nil {
+			return errors.Wrapf(err, "could not render argument %d", i)
+		}
+		args[i] = string(arg)
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "command failed: %s", string(out))
+	}
+	return nil
+}
+
+// post sends rendered payload to the hook url
+func (h *hook) post(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
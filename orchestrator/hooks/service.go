@@ -0,0 +1,207 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/event"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// queueSize is the number of pending events per hook. Events are dropped when a hook is too slow.
+const queueSize = 64
+
+// ReorgFeed
+type ReorgFeed interface {
+	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
+}
+
+type Config struct {
+	Hooks                []*HookConfig
+	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	ReorgFeed            ReorgFeed
+	// DiskPressureFeed is optional
+	DiskPressureFeed DiskPressureFeed
+}
+
+// DiskPressureFeed
+type DiskPressureFeed interface {
+	SubscribeDiskPressureEvent(chan<- *types.DiskPressure) event.Subscription
+}
+
+// Service fires operator-defined hooks on verified slot, invalid slot, reorg and disk pressure events
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	hooks                []*hook
+	queues               []chan *Event
+	verifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	reorgFeed            ReorgFeed
+	diskPressureFeed     DiskPressureFeed
+}
+
+// NewService validates hooks and creates hook service
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+
+	hooks := make([]*hook, 0, len(cfg.Hooks))
+	queues := make([]chan *Event, 0, len(cfg.Hooks))
+	for _, hookCfg := range cfg.Hooks {
+		h, err := parseHook(hookCfg)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		hooks = append(hooks, h)
+		queues = append(queues, make(chan *Event, queueSize))
+	}
+
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		hooks:                hooks,
+		queues:               queues,
+		verifiedSlotInfoFeed: cfg.VerifiedSlotInfoFeed,
+		reorgFeed:            cfg.ReorgFeed,
+		diskPressureFeed:     cfg.DiskPressureFeed,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start hook service when it was already started")
+		return
+	}
+	s.isRunning = true
+	for i := range s.hooks {
+		go s.worker(s.hooks[i], s.queues[i])
+	}
+	go s.run()
+	log.WithField("hooks", len(s.hooks)).Info("Started hook service")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	if !s.isRunning {
+		return nil
+	}
+	return s.runError
+}
+
+// run listens verified slot info and reorg events and dispatches them to hooks
+func (s *Service) run() {
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 1)
+	slotInfoSub := s.verifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer slotInfoSub.Unsubscribe()
+
+	reorgCh := make(chan *types.Reorg, 1)
+	reorgSub := s.reorgFeed.SubscribeShutdownSignalEvent(reorgCh)
+	defer reorgSub.Unsubscribe()
+
+	// nil channels block forever when disk pressure is not tracked
+	var diskPressureCh chan *types.DiskPressure
+	var diskPressureErr <-chan error
+	if s.diskPressureFeed != nil {
+		diskPressureCh = make(chan *types.DiskPressure, 1)
+		diskPressureSub := s.diskPressureFeed.SubscribeDiskPressureEvent(diskPressureCh)
+		defer diskPressureSub.Unsubscribe()
+		diskPressureErr = diskPressureSub.Err()
+	}
+
+	for {
+		select {
+		case slotInfo := <-slotInfoCh:
+			ev := &Event{
+				Slot:              slotInfo.Slot,
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+				VanguardBlockHash: slotInfo.VanguardBlockHash,
+				Status:            string(slotInfo.Status),
+			}
+			switch slotInfo.Status {
+			case types.Verified:
+				ev.Event = VerifiedSlotEvent
+			case types.Invalid:
+				ev.Event = InvalidSlotEvent
+			default:
+				continue
+			}
+			s.dispatch(ev)
+		case reorg := <-reorgCh:
+			s.dispatch(&Event{
+				Event:         ReorgEvent,
+				Slot:          reorg.NewSlot,
+				VanParentHash: reorg.VanParentHash,
+				PanParentHash: reorg.PanParentHash,
+			})
+		case pressure := <-diskPressureCh:
+			s.dispatch(&Event{
+				Event:      DiskPressureEvent,
+				FreeSpace:  pressure.FreeSpace,
+				TimeToFull: pressure.TimeToFull,
+				BelowFloor: pressure.BelowFloor,
+			})
+		case err := <-diskPressureErr:
+			log.WithError(err).Error("Disk pressure subscription of hook service is closed")
+			s.runError = err
+			return
+		case err := <-slotInfoSub.Err():
+			log.WithError(err).Error("Verified slot info subscription of hook service is closed")
+			s.runError = err
+			return
+		case err := <-reorgSub.Err():
+			log.WithError(err).Error("Reorg subscription of hook service is closed")
+			s.runError = err
+			return
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing hook service")
+			return
+		}
+	}
+}
+
+// dispatch queues the event to every hook which is registered for it
+func (s *Service) dispatch(ev *Event) {
+	for i, h := range s.hooks {
+		if !h.events[ev.Event] {
+			continue
+		}
+		select {
+		case s.queues[i] <- ev:
+		default:
+			log.WithField("hook", h.name).WithField("event", ev.Event).WithField("slot", ev.Slot).
+				Warn("Hook is too slow, dropping event")
+		}
+	}
+}
+
+// worker fires the hook for queued events one by one
+func (s *Service) worker(h *hook, queue chan *Event) {
+	for {
+		select {
+		case ev := <-queue:
+			if err := h.run(s.ctx, ev); err != nil {
+				log.WithError(err).WithField("hook", h.name).WithField("event", ev.Event).
+					WithField("slot", ev.Slot).Warn("Failed to fire hook")
+				continue
+			}
+			log.WithField("hook", h.name).WithField("event", ev.Event).WithField("slot", ev.Slot).
+				Debug("Fired hook")
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
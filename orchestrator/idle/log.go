@@ -0,0 +1,5 @@
+package idle
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "idle")
@@ -0,0 +1,10 @@
+package idle
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// idleGauge is 1 while both chains are idle
+	idleGauge = metrics.NewRegisteredGauge("orc_idle", nil)
+	// idlePeriodsCounter is the number of times which the chains went idle
+	idlePeriodsCounter = metrics.NewRegisteredCounter("orc_idle_periods_total", nil)
+)
@@ -0,0 +1,175 @@
+// Package idle detects when both chains stop producing blocks, e.g. while a devnet is paused, so that periodic work
+// can be reduced until the first new block arrives.
+package idle
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Detector tells whether both chains are idle
+type Detector interface {
+	Idle() bool
+}
+
+// VanguardFeed
+type VanguardFeed interface {
+	SubscribeShardInfoEvent(chan<- *types.VanguardShardInfo) event.Subscription
+}
+
+// PandoraFeed
+type PandoraFeed interface {
+	SubscribeHeaderInfoEvent(chan<- *types.PandoraHeaderInfo) event.Subscription
+}
+
+type Config struct {
+	VanguardFeed VanguardFeed
+	PandoraFeed  PandoraFeed
+	// After is the time without a block of either chain after which the chains are idle
+	After time.Duration
+}
+
+// Service watches the block feeds of both chains and reports the chains idle when neither produced a block in time
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	vanguardFeed VanguardFeed
+	pandoraFeed  PandoraFeed
+	after        time.Duration
+
+	idle      int32
+	idleFeed  event.Feed
+	logLevel  logrus.Level
+	idleSince time.Time
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.After <= 0 {
+		return nil, errors.New("idle timeout must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		vanguardFeed: cfg.VanguardFeed,
+		pandoraFeed:  cfg.PandoraFeed,
+		after:        cfg.After,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start idle service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("after", s.after).Info("Started idle detection")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	return nil
+}
+
+// Idle returns true while neither chain produces blocks
+func (s *Service) Idle() bool {
+	return atomic.LoadInt32(&s.idle) == 1
+}
+
+// SubscribeIdleEvent notifies true when the chains go idle and false when they produce blocks again
+func (s *Service) SubscribeIdleEvent(ch chan<- bool) event.Subscription {
+	return s.idleFeed.Subscribe(ch)
+}
+
+// run
+func (s *Service) run() {
+	shardInfoCh := make(chan *types.VanguardShardInfo, 1)
+	shardInfoSub := s.vanguardFeed.SubscribeShardInfoEvent(shardInfoCh)
+	defer shardInfoSub.Unsubscribe()
+	headerInfoCh := make(chan *types.PandoraHeaderInfo, 1)
+	headerInfoSub := s.pandoraFeed.SubscribeHeaderInfoEvent(headerInfoCh)
+	defer headerInfoSub.Unsubscribe()
+
+	// the timer is not rearmed while idle, so an idle node does not wake up until the next block
+	timer := time.NewTimer(s.after)
+	defer timer.Stop()
+	for {
+		select {
+		case <-shardInfoCh:
+			s.onBlock(timer)
+		case <-headerInfoCh:
+			s.onBlock(timer)
+		case <-timer.C:
+			s.enterIdle()
+		case err := <-shardInfoSub.Err():
+			log.WithError(err).Debug("Vanguard shard info subscription closed")
+			return
+		case err := <-headerInfoSub.Err():
+			log.WithError(err).Debug("Pandora header info subscription closed")
+			return
+		case <-s.ctx.Done():
+			if s.Idle() {
+				s.exitIdle()
+			}
+			log.Info("Received cancelled context, closing idle service")
+			return
+		}
+	}
+}
+
+// onBlock leaves idle mode and restarts the idle timer
+func (s *Service) onBlock(timer *time.Timer) {
+	if s.Idle() {
+		s.exitIdle()
+	} else if !timer.Stop() {
+		<-timer.C
+	}
+	timer.Reset(s.after)
+}
+
+// enterIdle reduces the log level to info and notifies the subscribers
+func (s *Service) enterIdle() {
+	s.idleSince = time.Now()
+	log.WithField("after", s.after).Info("Both chains stopped producing blocks, entering idle mode")
+	s.logLevel = logrus.GetLevel()
+	if s.logLevel > logrus.InfoLevel {
+		logrus.SetLevel(logrus.InfoLevel)
+	}
+	atomic.StoreInt32(&s.idle, 1)
+	idleGauge.Update(1)
+	idlePeriodsCounter.Inc(1)
+	s.idleFeed.Send(true)
+}
+
+// exitIdle restores the log level and notifies the subscribers
+func (s *Service) exitIdle() {
+	atomic.StoreInt32(&s.idle, 0)
+	idleGauge.Update(0)
+	if logrus.GetLevel() == logrus.InfoLevel && s.logLevel > logrus.InfoLevel {
+		logrus.SetLevel(s.logLevel)
+	}
+	log.WithField("idleFor", time.Since(s.idleSince).Round(time.Second)).
+		Info("Chains produce blocks again, resuming full activity")
+	s.idleFeed.Send(false)
+}
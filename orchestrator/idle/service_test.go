@@ -0,0 +1,82 @@
+package idle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/sirupsen/logrus"
+)
+
+type mockFeeds struct {
+	shardInfoFeed  event.Feed
+	headerInfoFeed event.Feed
+}
+
+func (m *mockFeeds) SubscribeShardInfoEvent(ch chan<- *types.VanguardShardInfo) event.Subscription {
+	return m.shardInfoFeed.Subscribe(ch)
+}
+
+func (m *mockFeeds) SubscribeHeaderInfoEvent(ch chan<- *types.PandoraHeaderInfo) event.Subscription {
+	return m.headerInfoFeed.Subscribe(ch)
+}
+
+func TestService_IdleMode(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+	logrus.SetLevel(logrus.DebugLevel)
+
+	feeds := &mockFeeds{}
+	svc, err := NewService(context.Background(), &Config{
+		VanguardFeed: feeds,
+		PandoraFeed:  feeds,
+		After:        100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	idleCh := make(chan bool, 2)
+	sub := svc.SubscribeIdleEvent(idleCh)
+	defer sub.Unsubscribe()
+	svc.Start()
+	defer func() {
+		require.NoError(t, svc.Stop())
+	}()
+
+	// blocks of either chain keep the node active
+	for i := 0; i < 4; i++ {
+		time.Sleep(50 * time.Millisecond)
+		if i%2 == 0 {
+			feeds.shardInfoFeed.Send(&types.VanguardShardInfo{Slot: uint64(i)})
+		} else {
+			feeds.headerInfoFeed.Send(&types.PandoraHeaderInfo{Slot: uint64(i)})
+		}
+	}
+	assert.Equal(t, false, svc.Idle())
+
+	select {
+	case idle := <-idleCh:
+		assert.Equal(t, true, idle)
+	case <-time.After(time.Second):
+		t.Fatal("chains did not go idle")
+	}
+	assert.Equal(t, true, svc.Idle())
+	assert.Equal(t, logrus.InfoLevel, logrus.GetLevel())
+
+	// the first block resumes full activity
+	feeds.headerInfoFeed.Send(&types.PandoraHeaderInfo{Slot: 5})
+	select {
+	case idle := <-idleCh:
+		assert.Equal(t, false, idle)
+	case <-time.After(time.Second):
+		t.Fatal("chains did not become active")
+	}
+	assert.Equal(t, false, svc.Idle())
+	assert.Equal(t, logrus.DebugLevel, logrus.GetLevel())
+}
+
+func TestNewService_NoTimeout(t *testing.T) {
+	_, err := NewService(context.Background(), &Config{})
+	assert.ErrorContains(t, "idle timeout must be positive", err)
+}
@@ -0,0 +1,157 @@
+package journal
+
+import (
+	"encoding/binary"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ringHeaderSize is the number of appended records and the capacity of the ring
+	ringHeaderSize = 16
+	// RecordSize is slot + time + finalized slot + status + replacedBy flag + hash + replacedBy
+	RecordSize = 8 + 8 + 8 + 1 + 1 + common.HashLength + common.HashLength
+)
+
+var statusCodes = map[types.Status]byte{
+	types.Verified:  1,
+	types.Invalid:   2,
+	types.Retracted: 3,
+	types.Finalized: 4,
+}
+
+// ring is a file of fixed-size confirmation records. Once the ring is full, every appended record overwrites the
+// oldest one, so the file never grows beyond its capacity.
+type ring struct {
+	lock     sync.Mutex
+	file     *os.File
+	capacity uint64
+	// appended is the number of records which were ever appended
+	appended uint64
+}
+
+// openRing opens the ring file or creates it. A ring of another capacity is started over.
+func openRing(path string, capacity uint64) (*ring, error) {
+	if capacity == 0 {
+		return nil, errors.New("journal capacity must be positive")
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, params.OrchestratorIoConfig().ReadWritePermissions)
+	if err != nil {
+		return nil, err
+	}
+	r := &ring{file: file, capacity: capacity}
+
+	header := make([]byte, ringHeaderSize)
+	if _, err := file.ReadAt(header, 0); err == nil && binary.BigEndian.Uint64(header[8:]) == capacity {
+		r.appended = binary.BigEndian.Uint64(header[:8])
+		return r, nil
+	}
+	log.WithField("path", path).WithField("capacity", capacity).Debug("Starting new confirmation journal")
+	if err := file.Truncate(0); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := r.writeHeader(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// append writes the record over the oldest one when the ring is full. The header is written after the record, so a
+// crash in between only loses the record.
+func (r *ring) append(record *types.JournaledConfirmation) error {
+	code, ok := statusCodes[record.Status]
+	if !ok {
+		return errors.Errorf("status %s is not journaled", record.Status)
+	}
+	enc := make([]byte, RecordSize)
+	binary.BigEndian.PutUint64(enc[0:8], record.Slot)
+	binary.BigEndian.PutUint64(enc[8:16], record.Time)
+	binary.BigEndian.PutUint64(enc[16:24], record.FinalizedSlot)
+	enc[24] = code
+	copy(enc[26:58], record.Hash.Bytes())
+	if record.ReplacedBy != nil {
+		enc[25] = 1
+		copy(enc[58:90], record.ReplacedBy.Bytes())
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, err := r.file.WriteAt(enc, r.offset(r.appended)); err != nil {
+		return err
+	}
+	r.appended++
+	return r.writeHeader()
+}
+
+// records returns the records of the ring from the oldest to the newest
+func (r *ring) records() ([]*types.JournaledConfirmation, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	first := uint64(0)
+	if r.appended > r.capacity {
+		first = r.appended - r.capacity
+	}
+	records := make([]*types.JournaledConfirmation, 0, r.appended-first)
+	enc := make([]byte, RecordSize)
+	for i := first; i < r.appended; i++ {
+		if _, err := r.file.ReadAt(enc, r.offset(i)); err != nil {
+			return nil, errors.Wrapf(err, "could not read journal record %d", i)
+		}
+		record, err := decodeRecord(enc)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r *ring) close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.file.Close()
+}
+
+// offset returns the file offset of the record with the given sequence number. Caller must hold the lock.
+func (r *ring) offset(seq uint64) int64 {
+	return int64(ringHeaderSize + (seq%r.capacity)*RecordSize)
+}
+
+// writeHeader caller must hold the lock
+func (r *ring) writeHeader() error {
+	header := make([]byte, ringHeaderSize)
+	binary.BigEndian.PutUint64(header[:8], r.appended)
+	binary.BigEndian.PutUint64(header[8:], r.capacity)
+	_, err := r.file.WriteAt(header, 0)
+	return err
+}
+
+func decodeRecord(enc []byte) (*types.JournaledConfirmation, error) {
+	record := &types.JournaledConfirmation{
+		Slot:          binary.BigEndian.Uint64(enc[0:8]),
+		Time:          binary.BigEndian.Uint64(enc[8:16]),
+		FinalizedSlot: binary.BigEndian.Uint64(enc[16:24]),
+		Hash:          common.BytesToHash(enc[26:58]),
+	}
+	for status, code := range statusCodes {
+		if code == enc[24] {
+			record.Status = status
+		}
+	}
+	if record.Status == "" {
+		return nil, errors.Errorf("unknown journal status code %d", enc[24])
+	}
+	if enc[25] == 1 {
+		replacedBy := common.BytesToHash(enc[58:90])
+		record.ReplacedBy = &replacedBy
+	}
+	return record, nil
+}
@@ -0,0 +1,54 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestRing_Wraparound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.journal")
+	r, err := openRing(path, 3)
+	require.NoError(t, err)
+
+	replacedBy := common.HexToHash("0x0a")
+	for slot := uint64(1); slot <= 4; slot++ {
+		require.NoError(t, r.append(&types.JournaledConfirmation{
+			Slot:   slot,
+			Hash:   common.BytesToHash([]byte{byte(slot)}),
+			Status: types.Verified,
+			Time:   100 + slot,
+		}))
+	}
+	require.NoError(t, r.append(&types.JournaledConfirmation{Slot: 4, Status: types.Retracted, ReplacedBy: &replacedBy}))
+	assert.ErrorContains(t, "is not journaled", r.append(&types.JournaledConfirmation{Slot: 5, Status: types.Pending}))
+
+	records, err := r.records()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(records))
+	assert.Equal(t, uint64(3), records[0].Slot)
+	assert.Equal(t, uint64(103), records[0].Time)
+	assert.Equal(t, types.Retracted, records[2].Status)
+	assert.DeepEqual(t, &replacedBy, records[2].ReplacedBy)
+	require.NoError(t, r.close())
+
+	// records survive reopening
+	r, err = openRing(path, 3)
+	require.NoError(t, err)
+	reopened, err := r.records()
+	require.NoError(t, err)
+	assert.DeepEqual(t, records, reopened)
+	require.NoError(t, r.close())
+
+	// ring of another capacity starts over
+	r, err = openRing(path, 8)
+	require.NoError(t, err)
+	reopened, err = r.records()
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(reopened))
+	require.NoError(t, r.close())
+}
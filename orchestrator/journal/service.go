@@ -0,0 +1,199 @@
+package journal
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// DirName is the directory of the journal files inside the data directory
+const DirName = "journal"
+
+// defaultConsumerFile is the journal of the pandora node which does not pass a consumer name
+const defaultConsumerFile = "default.journal"
+
+var errUnknownConsumer = errors.New("consumer has no confirmation journal")
+
+// FinalizedSlotDB tells which confirmations are behind the finalized slot
+type FinalizedSlotDB interface {
+	LatestLatestFinalizedSlot() uint64
+}
+
+// Config of the journal service
+type Config struct {
+	// Dir keeps one journal file per consumer
+	Dir string
+	// Consumers are the named pandora nodes. The unnamed default consumer always has a journal.
+	Consumers []string
+	// MaxBytes bounds the size of each journal file, the oldest confirmations are overwritten beyond it
+	MaxBytes uint64
+	// MaxAge drops confirmations which are older from the served backlog. Zero keeps them until overwritten.
+	MaxAge time.Duration
+
+	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	FinalizedSlotDB      FinalizedSlotDB
+}
+
+// Service journals every confirmation which is published to pandora into a ring file per consumer, so a pandora
+// node which reconnects after a long outage can fetch the backlog instead of reconciling the status of every slot
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	rings                map[string]*ring
+	maxAge               time.Duration
+	verifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	finalizedSlotDB      FinalizedSlotDB
+}
+
+// NewService opens the journal of every consumer
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	capacity := cfg.MaxBytes / RecordSize
+	if capacity == 0 {
+		return nil, errors.Errorf("journal size %d is below a single record of %d bytes", cfg.MaxBytes, RecordSize)
+	}
+	if err := fileutil.MkdirAll(cfg.Dir); err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{"": defaultConsumerFile}
+	for _, consumer := range cfg.Consumers {
+		if consumer == "" || filepath.Base(consumer) != consumer {
+			return nil, errors.Errorf("invalid consumer name %q for a journal file", consumer)
+		}
+		files[consumer] = "consumer-" + consumer + ".journal"
+	}
+	rings := make(map[string]*ring, len(files))
+	for consumer, file := range files {
+		r, err := openRing(filepath.Join(cfg.Dir, file), capacity)
+		if err != nil {
+			for _, opened := range rings {
+				opened.close()
+			}
+			return nil, errors.Wrapf(err, "could not open confirmation journal %s", file)
+		}
+		rings[consumer] = r
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		rings:                rings,
+		maxAge:               cfg.MaxAge,
+		verifiedSlotInfoFeed: cfg.VerifiedSlotInfoFeed,
+		finalizedSlotDB:      cfg.FinalizedSlotDB,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start confirmation journal service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("consumers", len(s.rings)).Info("Started confirmation journal service")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.isRunning = false
+	var err error
+	for _, r := range s.rings {
+		if closeErr := r.close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// Status
+func (s *Service) Status() error {
+	if !s.isRunning {
+		return nil
+	}
+	return s.runError
+}
+
+// Backlog returns the journaled confirmations of the consumer from the given slot in the order they were published.
+// Retractions of lower slots are included, since they may affect the blocks which the consumer already has.
+func (s *Service) Backlog(consumer string, fromSlot uint64) ([]*types.JournaledConfirmation, error) {
+	r, ok := s.rings[consumer]
+	if !ok {
+		return nil, errors.Wrap(errUnknownConsumer, consumer)
+	}
+	records, err := r.records()
+	if err != nil {
+		return nil, err
+	}
+	var oldest uint64
+	if s.maxAge > 0 {
+		oldest = uint64(time.Now().Add(-s.maxAge).Unix())
+	}
+	backlog := make([]*types.JournaledConfirmation, 0, len(records))
+	for _, record := range records {
+		if record.Time < oldest {
+			continue
+		}
+		if record.Slot < fromSlot && record.Status != types.Retracted {
+			continue
+		}
+		backlog = append(backlog, record)
+	}
+	return backlog, nil
+}
+
+// run journals the published confirmations until the service is stopped
+func (s *Service) run() {
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 1)
+	slotInfoSub := s.verifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer slotInfoSub.Unsubscribe()
+
+	for {
+		select {
+		case slotInfo := <-slotInfoCh:
+			if _, ok := statusCodes[slotInfo.Status]; !ok {
+				continue
+			}
+			s.record(slotInfo)
+		case err := <-slotInfoSub.Err():
+			log.WithError(err).Error("Verified slot info subscription of confirmation journal failed")
+			s.runError = err
+			return
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing confirmation journal service")
+			return
+		}
+	}
+}
+
+// record appends the confirmation to the journal of every consumer
+func (s *Service) record(slotInfo *types.SlotInfoWithStatus) {
+	record := &types.JournaledConfirmation{
+		Slot:          slotInfo.Slot,
+		Hash:          slotInfo.PandoraHeaderHash,
+		Status:        slotInfo.Status,
+		FinalizedSlot: s.finalizedSlotDB.LatestLatestFinalizedSlot(),
+		ReplacedBy:    slotInfo.ReplacedBy,
+		Time:          uint64(time.Now().Unix()),
+	}
+	for consumer, r := range s.rings {
+		if err := r.append(record); err != nil {
+			log.WithError(err).WithField("consumer", consumer).WithField("slot", slotInfo.Slot).
+				Warn("Failed to journal confirmation")
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package journal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockFeed struct {
+	feed event.Feed
+}
+
+func (m *mockFeed) SubscribeVerifiedSlotInfoEvent(ch chan<- *types.SlotInfoWithStatus) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+type mockFinalizedSlotDB uint64
+
+func (m mockFinalizedSlotDB) LatestLatestFinalizedSlot() uint64 {
+	return uint64(m)
+}
+
+func TestService_Backlog(t *testing.T) {
+	feed := new(mockFeed)
+	svc, err := NewService(context.Background(), &Config{
+		Dir:                  t.TempDir(),
+		Consumers:            []string{"standby"},
+		MaxBytes:             16 * RecordSize,
+		VerifiedSlotInfoFeed: feed,
+		FinalizedSlotDB:      mockFinalizedSlotDB(2),
+	})
+	require.NoError(t, err)
+	svc.Start()
+	defer func() {
+		require.NoError(t, svc.Stop())
+	}()
+	// wait until the journal is subscribed
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+		if feed.feed.Send(&types.SlotInfoWithStatus{Slot: 1, Status: types.Verified}) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	feed.feed.Send(&types.SlotInfoWithStatus{Slot: 2, Status: types.Pending})
+	feed.feed.Send(&types.SlotInfoWithStatus{Slot: 3, Status: types.Invalid, PandoraHeaderHash: common.HexToHash("0x03")})
+	feed.feed.Send(&types.SlotInfoWithStatus{Slot: 1, Status: types.Retracted})
+
+	var backlog []*types.JournaledConfirmation
+	for deadline := time.Now().Add(5 * time.Second); len(backlog) < 2 && time.Now().Before(deadline); {
+		backlog, err = svc.Backlog("standby", 2)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, 2, len(backlog))
+	assert.Equal(t, uint64(3), backlog[0].Slot)
+	assert.Equal(t, types.Invalid, backlog[0].Status)
+	assert.Equal(t, uint64(2), backlog[0].FinalizedSlot)
+	// retraction of a lower slot is kept
+	assert.Equal(t, types.Retracted, backlog[1].Status)
+
+	_, err = svc.Backlog("unknown", 0)
+	assert.ErrorContains(t, "consumer has no confirmation journal", err)
+
+	_, err = NewService(context.Background(), &Config{Dir: t.TempDir(), Consumers: []string{"../escape"}, MaxBytes: RecordSize})
+	assert.ErrorContains(t, "invalid consumer name", err)
+}
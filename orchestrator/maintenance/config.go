@@ -0,0 +1,87 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// tasks which can run in a maintenance window
+const (
+	// PruneTask removes finalized shard disagreements and equivocations
+	PruneTask = "prune"
+	// SnapshotTask exports a consistent copy of the db into the snapshot directory
+	SnapshotTask = "snapshot"
+)
+
+// WindowConfig is a maintenance window in operator's maintenance config file. Schedule is a five field cron
+// expression in local time which opens the window, and tasks run in the given order until the window closes.
+type WindowConfig struct {
+	Name     string   `json:"name"`
+	Schedule string   `json:"schedule"`
+	Duration string   `json:"duration"`
+	Tasks    []string `json:"tasks"`
+}
+
+// FileConfig is the content of the maintenance config file
+type FileConfig struct {
+	Windows     []*WindowConfig `json:"windows"`
+	SnapshotDir string          `json:"snapshotDir,omitempty"`
+	// SnapshotsKept is the number of latest snapshots which are kept. 0 keeps every snapshot
+	SnapshotsKept int `json:"snapshotsKept,omitempty"`
+}
+
+// window is a validated and parsed maintenance window
+type window struct {
+	name     string
+	schedule *schedule
+	duration time.Duration
+	tasks    []string
+	// lastStart is the start of the latest window occurrence whose tasks have run
+	lastStart time.Time
+}
+
+// LoadConfig reads maintenance config file from the given path
+func LoadConfig(path string) (*FileConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read maintenance config file")
+	}
+	cfg := new(FileConfig)
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrap(err, "could not parse maintenance config file")
+	}
+	return cfg, nil
+}
+
+// parseWindow validates window config and parses its schedule
+func parseWindow(cfg *WindowConfig, snapshotDir string) (*window, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("maintenance window name is missing")
+	}
+	s, err := parseSchedule(cfg.Schedule)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule of maintenance window %s", cfg.Name)
+	}
+	duration, err := time.ParseDuration(cfg.Duration)
+	if err != nil || duration < time.Minute {
+		return nil, errors.Errorf("maintenance window %s must last at least a minute", cfg.Name)
+	}
+	if len(cfg.Tasks) == 0 {
+		return nil, errors.Errorf("maintenance window %s has no task", cfg.Name)
+	}
+	for _, task := range cfg.Tasks {
+		switch task {
+		case PruneTask:
+		case SnapshotTask:
+			if snapshotDir == "" {
+				return nil, errors.Errorf("maintenance window %s takes snapshots but snapshot directory is missing", cfg.Name)
+			}
+		default:
+			return nil, errors.Errorf("maintenance window %s has unknown task %s", cfg.Name, task)
+		}
+	}
+	return &window{name: cfg.Name, schedule: s, duration: duration, tasks: cfg.Tasks}, nil
+}
@@ -0,0 +1,111 @@
+package maintenance
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// field is the set of allowed values of a cron field
+type field struct {
+	allowed []bool
+	// any is true for "*" and "*/n" which matters for the day of month and day of week rule
+	any bool
+}
+
+// schedule is a parsed cron expression with minute, hour, day of month, month and day of week fields
+type schedule struct {
+	minute, hour, dom, month, dow field
+}
+
+// bounds of cron fields
+var fieldBounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// parseSchedule parses a five field cron expression. Fields support "*", lists, ranges and steps.
+func parseSchedule(expr string) (*schedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fieldBounds) {
+		return nil, errors.Errorf("schedule %q must have %d fields", expr, len(fieldBounds))
+	}
+	fields := make([]field, len(parts))
+	for i, part := range parts {
+		f, err := parseField(part, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s of schedule %q", fieldBounds[i].name, expr)
+		}
+		fields[i] = f
+	}
+	return &schedule{minute: fields[0], hour: fields[1], dom: fields[2], month: fields[3], dow: fields[4]}, nil
+}
+
+// parseField parses comma separated values, ranges and steps of a cron field
+func parseField(expr string, min, max int) (field, error) {
+	f := field{allowed: make([]bool, max+1), any: strings.HasPrefix(expr, "*")}
+	for _, item := range strings.Split(expr, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return field{}, errors.Errorf("invalid step %q", item[i+1:])
+			}
+			item = item[:i]
+		}
+
+		from, to := min, max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return field{}, errors.Errorf("invalid value %q", bounds[0])
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return field{}, errors.Errorf("invalid value %q", bounds[1])
+				}
+			}
+		}
+		if from < min || to > max || from > to {
+			return field{}, errors.Errorf("%q is out of range %d-%d", item, min, max)
+		}
+		for v := from; v <= to; v += step {
+			f.allowed[v] = true
+		}
+	}
+	return f, nil
+}
+
+// matches returns true when the schedule fires at the minute of t. Like cron, a day matches when either the day
+// of month or the day of week matches if both are restricted.
+func (s *schedule) matches(t time.Time) bool {
+	if !s.minute.allowed[t.Minute()] || !s.hour.allowed[t.Hour()] || !s.month.allowed[int(t.Month())] {
+		return false
+	}
+	domMatch, dowMatch := s.dom.allowed[t.Day()], s.dow.allowed[int(t.Weekday())]
+	if s.dom.any || s.dow.any {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// lastStart returns the latest start of the schedule within the given duration before t
+func (s *schedule) lastStart(t time.Time, within time.Duration) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	for start := t; t.Sub(start) < within; start = start.Add(-time.Minute) {
+		if s.matches(start) {
+			return start, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,45 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	s, err := parseSchedule("30 2-4 * * 1-5")
+	require.NoError(t, err)
+	// 2021-06-07 is a monday
+	assert.Equal(t, true, s.matches(time.Date(2021, 6, 7, 3, 30, 0, 0, time.UTC)))
+	assert.Equal(t, false, s.matches(time.Date(2021, 6, 7, 5, 30, 0, 0, time.UTC)))
+	assert.Equal(t, false, s.matches(time.Date(2021, 6, 6, 3, 30, 0, 0, time.UTC)))
+
+	s, err = parseSchedule("*/15 0 1,15 * 0")
+	require.NoError(t, err)
+	// either day of month or day of week matches when both are restricted
+	assert.Equal(t, true, s.matches(time.Date(2021, 6, 1, 0, 45, 0, 0, time.UTC)))
+	assert.Equal(t, true, s.matches(time.Date(2021, 6, 6, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, false, s.matches(time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, false, s.matches(time.Date(2021, 6, 1, 0, 10, 0, 0, time.UTC)))
+
+	_, err = parseSchedule("0 3 * *")
+	assert.ErrorContains(t, "must have 5 fields", err)
+	_, err = parseSchedule("0 24 * * *")
+	assert.ErrorContains(t, "out of range", err)
+	_, err = parseSchedule("*/0 3 * * *")
+	assert.ErrorContains(t, "invalid step", err)
+}
+
+func TestSchedule_LastStart(t *testing.T) {
+	s, err := parseSchedule("0 3 * * *")
+	require.NoError(t, err)
+
+	start, open := s.lastStart(time.Date(2021, 6, 7, 4, 59, 30, 0, time.UTC), 2*time.Hour)
+	assert.Equal(t, true, open)
+	assert.Equal(t, time.Date(2021, 6, 7, 3, 0, 0, 0, time.UTC), start)
+
+	_, open = s.lastStart(time.Date(2021, 6, 7, 5, 0, 0, 0, time.UTC), 2*time.Hour)
+	assert.Equal(t, false, open)
+}
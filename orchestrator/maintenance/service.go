@@ -0,0 +1,217 @@
+// Package maintenance runs heavy db operations only in operator-defined maintenance windows, away from
+// high-traffic periods.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+)
+
+// tickInterval is how often the service checks whether a window is open
+var tickInterval = time.Minute
+
+const snapshotPrefix = "orchestrator-snapshot-"
+
+// Database is the db which is maintained
+type Database interface {
+	db.DiskPressureDB
+	db.SnapshotDB
+	LatestLatestFinalizedSlot() uint64
+}
+
+type Config struct {
+	Windows       []*WindowConfig
+	SnapshotDir   string
+	SnapshotsKept int
+	DB            Database
+}
+
+// Service runs the tasks of maintenance windows once per window occurrence
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	windows       []*window
+	db            Database
+	snapshotDir   string
+	snapshotsKept int
+	// now is replaced in tests
+	now func() time.Time
+
+	lock    sync.Mutex
+	taskErr error
+}
+
+// NewService validates maintenance windows and creates maintenance service
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	windows := make([]*window, 0, len(cfg.Windows))
+	for _, windowCfg := range cfg.Windows {
+		w, err := parseWindow(windowCfg, cfg.SnapshotDir)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	if cfg.SnapshotDir != "" {
+		if err := fileutil.MkdirAll(cfg.SnapshotDir); err != nil {
+			return nil, errors.Wrap(err, "could not create snapshot directory")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		windows:       windows,
+		db:            cfg.DB,
+		snapshotDir:   cfg.SnapshotDir,
+		snapshotsKept: cfg.SnapshotsKept,
+		now:           time.Now,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start maintenance service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("windows", len(s.windows)).Info("Started maintenance scheduler")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status returns the error of the latest failed maintenance task
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.taskErr
+}
+
+// run
+func (s *Service) run() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runOpenWindows()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing maintenance service")
+			return
+		}
+	}
+}
+
+// runOpenWindows runs the tasks of every open window which did not run in its current occurrence
+func (s *Service) runOpenWindows() {
+	now := s.now()
+	for _, w := range s.windows {
+		start, open := w.schedule.lastStart(now, w.duration)
+		if !open || start.Equal(w.lastStart) {
+			continue
+		}
+		w.lastStart = start
+		ctx, cancel := context.WithDeadline(s.ctx, start.Add(w.duration))
+		s.runWindow(ctx, w)
+		cancel()
+	}
+}
+
+// runWindow runs the tasks of the window in order. Tasks which are not started before the window closes are
+// deferred to the next occurrence.
+func (s *Service) runWindow(ctx context.Context, w *window) {
+	log.WithField("window", w.name).WithField("tasks", w.tasks).Info("Maintenance window is open")
+	var failed error
+	for _, task := range w.tasks {
+		if ctx.Err() != nil {
+			log.WithField("window", w.name).WithField("task", task).Warn("Maintenance window closed, deferring task")
+			break
+		}
+		start := time.Now()
+		if err := s.runTask(task); err != nil {
+			failed = errors.Wrapf(err, "maintenance task %s of window %s failed", task, w.name)
+			log.WithError(err).WithField("window", w.name).WithField("task", task).Error("Maintenance task failed")
+			continue
+		}
+		log.WithField("window", w.name).WithField("task", task).WithField("elapsed", time.Since(start)).
+			Info("Maintenance task is done")
+	}
+
+	s.lock.Lock()
+	s.taskErr = failed
+	s.lock.Unlock()
+}
+
+// runTask
+func (s *Service) runTask(task string) error {
+	switch task {
+	case PruneTask:
+		removed, err := s.db.PruneDiagnostics(s.db.LatestLatestFinalizedSlot())
+		if err != nil {
+			return err
+		}
+		log.WithField("pruned", removed).Debug("Pruned finalized diagnostics")
+		return nil
+	case SnapshotTask:
+		return s.snapshot()
+	default:
+		return errors.Errorf("unknown maintenance task %s", task)
+	}
+}
+
+// snapshot exports the db into the snapshot directory and removes snapshots beyond the retention
+func (s *Service) snapshot() error {
+	file := filepath.Join(s.snapshotDir, fmt.Sprintf("%s%s.db", snapshotPrefix, s.now().UTC().Format("20060102-1504")))
+	if err := s.db.Snapshot(file); err != nil {
+		return err
+	}
+	log.WithField("file", file).Info("Exported db snapshot")
+	if s.snapshotsKept <= 0 {
+		return nil
+	}
+
+	entries, err := ioutil.ReadDir(s.snapshotDir)
+	if err != nil {
+		return err
+	}
+	var snapshots []string
+	for _, entry := range entries {
+		// snapshot of the leveldb backend is a directory
+		if strings.HasPrefix(entry.Name(), snapshotPrefix) && strings.HasSuffix(entry.Name(), ".db") {
+			snapshots = append(snapshots, entry.Name())
+		}
+	}
+	// names sort by time
+	sort.Strings(snapshots)
+	for len(snapshots) > s.snapshotsKept {
+		if err := os.RemoveAll(filepath.Join(s.snapshotDir, snapshots[0])); err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
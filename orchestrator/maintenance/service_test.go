@@ -0,0 +1,76 @@
+package maintenance
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_RunOpenWindows(t *testing.T) {
+	database := testDB.SetupDB(t)
+	require.NoError(t, database.SaveShardDisagreement(&types.ShardDisagreement{Slot: 1, Field: "txHash"}))
+	require.NoError(t, database.SaveLatestFinalizedSlot(64))
+
+	snapshotDir := t.TempDir()
+	s, err := NewService(context.Background(), &Config{
+		Windows: []*WindowConfig{{
+			Name:     "nightly",
+			Schedule: "0 3 * * *",
+			Duration: "1h",
+			Tasks:    []string{PruneTask, SnapshotTask},
+		}},
+		SnapshotDir:   snapshotDir,
+		SnapshotsKept: 1,
+		DB:            database,
+	})
+	require.NoError(t, err)
+
+	now := time.Date(2021, 6, 7, 2, 59, 0, 0, time.Local)
+	s.now = func() time.Time { return now }
+
+	// window is closed
+	s.runOpenWindows()
+	disagreements, err := database.ShardDisagreements(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(disagreements))
+
+	now = now.Add(2 * time.Minute)
+	s.runOpenWindows()
+	require.NoError(t, s.Status())
+	disagreements, err = database.ShardDisagreements(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(disagreements))
+	entries, err := ioutil.ReadDir(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	// tasks run once per window occurrence
+	now = now.Add(time.Minute)
+	s.runOpenWindows()
+	entries, err = ioutil.ReadDir(snapshotDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(entries))
+
+	// older snapshots beyond the retention are removed
+	now = now.Add(24 * time.Hour)
+	s.runOpenWindows()
+	entries, err = ioutil.ReadDir(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	assert.Equal(t, snapshotPrefix+now.UTC().Format("20060102-1504")+".db", entries[0].Name())
+}
+
+func TestParseWindow(t *testing.T) {
+	_, err := parseWindow(&WindowConfig{Name: "nightly", Schedule: "0 3 * * *", Duration: "1h", Tasks: []string{"compact"}}, "")
+	assert.ErrorContains(t, "unknown task compact", err)
+	_, err = parseWindow(&WindowConfig{Name: "nightly", Schedule: "0 3 * * *", Duration: "1h", Tasks: []string{SnapshotTask}}, "")
+	assert.ErrorContains(t, "snapshot directory is missing", err)
+	_, err = parseWindow(&WindowConfig{Name: "nightly", Schedule: "0 3 * * *", Duration: "30s", Tasks: []string{PruneTask}}, "")
+	assert.ErrorContains(t, "at least a minute", err)
+}
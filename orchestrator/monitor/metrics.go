@@ -0,0 +1,12 @@
+package monitor
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// producedBlocksCounter counts verified blocks which are proposed by operator's validators
+	producedBlocksCounter = metrics.NewRegisteredCounter("orc_validators_produced_blocks_total", nil)
+	// missedBlocksCounter counts slots of operator's validators which have no block
+	missedBlocksCounter = metrics.NewRegisteredCounter("orc_validators_missed_blocks_total", nil)
+	// invalidBlocksCounter counts invalid blocks which are proposed by operator's validators
+	invalidBlocksCounter = metrics.NewRegisteredCounter("orc_validators_invalid_blocks_total", nil)
+)
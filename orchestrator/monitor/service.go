@@ -0,0 +1,176 @@
+package monitor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type Config struct {
+	// Validators are the public keys of operator's own validators
+	Validators           []string
+	VerifiedSlotInfoFeed iface.VerifiedSlotInfoFeed
+	ConsensusInfoDB      db.ROnlyConsensusInfoDB
+	VerifiedSlotInfoDB   db.ROnlyVerifiedSlotInfoDB
+}
+
+// Service cross-references proposer schedule with verification results and reports produced, missed
+// and invalid blocks of operator's own validators.
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	validators           map[string]bool
+	verifiedSlotInfoFeed iface.VerifiedSlotInfoFeed
+	consensusInfoDB      db.ROnlyConsensusInfoDB
+	verifiedSlotInfoDB   db.ROnlyVerifiedSlotInfoDB
+
+	// lastSlot is the latest slot which is evaluated
+	lastSlot uint64
+}
+
+// normalizePubKey makes public keys comparable regardless of case and 0x prefix
+func normalizePubKey(pubKey string) string {
+	pubKey = strings.ToLower(strings.TrimSpace(pubKey))
+	if !strings.HasPrefix(pubKey, "0x") {
+		pubKey = "0x" + pubKey
+	}
+	return pubKey
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+
+	validators := make(map[string]bool, len(cfg.Validators))
+	for _, pubKey := range cfg.Validators {
+		validators[normalizePubKey(pubKey)] = true
+	}
+
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		validators:           validators,
+		verifiedSlotInfoFeed: cfg.VerifiedSlotInfoFeed,
+		consensusInfoDB:      cfg.ConsensusInfoDB,
+		verifiedSlotInfoDB:   cfg.VerifiedSlotInfoDB,
+	}
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start monitor service when it was already started")
+		return
+	}
+	s.isRunning = true
+	s.lastSlot = s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	go s.run()
+	log.WithField("validators", len(s.validators)).WithField("fromSlot", s.lastSlot).
+		Info("Started monitoring block production of own validators")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	if !s.isRunning {
+		return nil
+	}
+	return s.runError
+}
+
+// run
+func (s *Service) run() {
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 1)
+	sub := s.verifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case slotInfo := <-slotInfoCh:
+			s.onSlotInfo(slotInfo)
+		case err := <-sub.Err():
+			log.WithError(err).Error("Verified slot info subscription of monitor service is closed")
+			s.runError = err
+			return
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing monitor service")
+			return
+		}
+	}
+}
+
+// onSlotInfo evaluates the slot of the incoming verification result. Slots between the last evaluated slot and
+// the incoming slot have no block, so they are counted as missed.
+func (s *Service) onSlotInfo(slotInfo *types.SlotInfoWithStatus) {
+	if slotInfo.Status != types.Verified && slotInfo.Status != types.Invalid {
+		return
+	}
+	if slotInfo.Slot <= s.lastSlot {
+		// re-sent or re-orged slot, only an invalid block is worth reporting
+		if slotInfo.Status == types.Invalid {
+			s.report(slotInfo.Slot, types.Invalid)
+		}
+		return
+	}
+	for slot := s.lastSlot + 1; slot < slotInfo.Slot; slot++ {
+		s.report(slot, types.Skipped)
+	}
+	s.report(slotInfo.Slot, slotInfo.Status)
+	s.lastSlot = slotInfo.Slot
+}
+
+// proposer returns the public key of the slot's proposer from the proposer schedule
+func (s *Service) proposer(slot uint64) (string, bool) {
+	epoch := slot / params.SlotsPerEpoch
+	epochInfo, err := s.consensusInfoDB.ConsensusInfo(s.ctx, epoch)
+	if err != nil || epochInfo == nil {
+		log.WithError(err).WithField("slot", slot).WithField("epoch", epoch).
+			Debug("Proposer schedule is not found")
+		return "", false
+	}
+	index := slot % params.SlotsPerEpoch
+	if index >= uint64(len(epochInfo.ValidatorList)) {
+		return "", false
+	}
+	return normalizePubKey(epochInfo.ValidatorList[index]), true
+}
+
+// report updates metrics and logs when the slot's proposer is one of the own validators
+func (s *Service) report(slot uint64, status types.Status) {
+	if slot == 0 {
+		// genesis slot has no proposer
+		return
+	}
+	pubKey, ok := s.proposer(slot)
+	if !ok || !s.validators[pubKey] {
+		return
+	}
+	logger := log.WithField("slot", slot).WithField("validator", pubKey)
+	switch status {
+	case types.Verified:
+		producedBlocksCounter.Inc(1)
+		logger.Info("Own validator produced a block")
+	case types.Invalid:
+		invalidBlocksCounter.Inc(1)
+		logger.Error("Own validator produced an invalid block")
+	case types.Skipped:
+		missedBlocksCounter.Inc(1)
+		logger.Warn("Own validator missed its block")
+	}
+}
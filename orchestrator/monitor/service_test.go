@@ -0,0 +1,44 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestService_OnSlotInfo(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+
+	epochInfo := testutil.NewMinimalConsensusInfo(0).ConvertToEpochInfo()
+	for i := range epochInfo.ValidatorList {
+		epochInfo.ValidatorList[i] = fmt.Sprintf("0x%02x", i)
+	}
+	require.NoError(t, db.SaveConsensusInfo(ctx, epochInfo))
+
+	// own validators propose slot 2, 3 and 4
+	s := NewService(ctx, &Config{
+		Validators:         []string{"0x02", "03", "0X04"},
+		ConsensusInfoDB:    db,
+		VerifiedSlotInfoDB: db,
+	})
+
+	s.onSlotInfo(&types.SlotInfoWithStatus{Slot: 1, Status: types.Verified})
+	assert.LogsDoNotContain(t, hook, "Own validator")
+
+	s.onSlotInfo(&types.SlotInfoWithStatus{Slot: 3, Status: types.Verified})
+	assert.LogsContain(t, hook, "Own validator missed its block")
+	assert.LogsContain(t, hook, "Own validator produced a block")
+
+	s.onSlotInfo(&types.SlotInfoWithStatus{Slot: 4, Status: types.Invalid})
+	assert.LogsContain(t, hook, "Own validator produced an invalid block")
+	assert.Equal(t, uint64(4), s.lastSlot)
+}
@@ -0,0 +1,297 @@
+package node
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/urfave/cli/v2"
+)
+
+// configErrors collects every problem of the configuration, so that they are reported at once
+type configErrors []string
+
+func (e *configErrors) add(flag string, format string, args ...interface{}) {
+	*e = append(*e, fmt.Sprintf("--%s: %s", flag, fmt.Sprintf(format, args...)))
+}
+
+func (e configErrors) Error() string {
+	return fmt.Sprintf("invalid configuration, %d problem(s) found:\n  %s", len(e), strings.Join(e, "\n  "))
+}
+
+// validateConfig checks the flags before any service is created. It returns all problems of the configuration
+// in a single error.
+func validateConfig(cliCtx *cli.Context) error {
+	var errs configErrors
+	validateEndpoints(cliCtx, &errs)
+	validateDurations(cliCtx, &errs)
+	validateGenesis(cliCtx, &errs)
+	validateCheckpoint(cliCtx, &errs)
+	validatePorts(cliCtx, &errs)
+	validateDataDir(cliCtx, &errs)
+	validatePendingHeaderQueue(cliCtx, &errs)
+	validateVanguardTLS(cliCtx, &errs)
+	validateRPCJWT(cliCtx, &errs)
+	validateRetention(cliCtx, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateEndpoints checks that pandora endpoints are http, websocket or ipc endpoints and vanguard endpoints are
+// gRPC host:port addresses
+func validateEndpoints(cliCtx *cli.Context, errs *configErrors) {
+	pandoraEndpoints := map[string][]string{
+		cmd.PandoraRPCEndpoint.Name:           {cliCtx.String(cmd.PandoraRPCEndpoint.Name)},
+		cmd.PandoraFallbackEndpointsFlag.Name: cliCtx.StringSlice(cmd.PandoraFallbackEndpointsFlag.Name),
+	}
+	for flag, endpoints := range pandoraEndpoints {
+		for _, endpoint := range endpoints {
+			if endpoint == "" {
+				continue
+			}
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				errs.add(flag, "could not parse %q: %v", endpoint, err)
+				continue
+			}
+			switch u.Scheme {
+			case "http", "https", "ws", "wss":
+				if u.Host == "" {
+					errs.add(flag, "endpoint %q has no host", endpoint)
+				}
+			case "":
+				// endpoint without scheme is dialed as ipc socket
+			default:
+				errs.add(flag, "unsupported scheme %q of %q, use http(s)://, ws(s):// or an ipc path", u.Scheme, endpoint)
+			}
+		}
+	}
+
+	vanguardEndpoints := map[string][]string{
+		cmd.VanguardGRPCEndpoint.Name:          {cliCtx.String(cmd.VanguardGRPCEndpoint.Name)},
+		cmd.VanguardFallbackEndpointsFlag.Name: cliCtx.StringSlice(cmd.VanguardFallbackEndpointsFlag.Name),
+		cmd.VanguardFanInEndpoints.Name:        cliCtx.StringSlice(cmd.VanguardFanInEndpoints.Name),
+	}
+	for flag, endpoints := range vanguardEndpoints {
+		for _, endpoint := range endpoints {
+			if endpoint == "" {
+				continue
+			}
+			if _, port, err := net.SplitHostPort(endpoint); err != nil {
+				errs.add(flag, "gRPC endpoint %q must be given as host:port without scheme", endpoint)
+			} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+				errs.add(flag, "gRPC endpoint %q has invalid port", endpoint)
+			}
+		}
+	}
+
+	if endpoint := cliCtx.String(cmd.RemoteSignerURLFlag.Name); endpoint != "" {
+		validateHTTPURL(cmd.RemoteSignerURLFlag.Name, endpoint, errs)
+	}
+	for _, endpoint := range cliCtx.StringSlice(cmd.CheckpointEndpointsFlag.Name) {
+		validateHTTPURL(cmd.CheckpointEndpointsFlag.Name, endpoint, errs)
+	}
+}
+
+func validateHTTPURL(flag string, endpoint string, errs *configErrors) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		errs.add(flag, "could not parse %q: %v", endpoint, err)
+		return
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.add(flag, "%q must be an http:// or https:// url", endpoint)
+	}
+}
+
+// validateDurations checks that no duration is negative and the intervals of the enabled features are positive
+func validateDurations(cliCtx *cli.Context, errs *configErrors) {
+	for _, flag := range []string{
+		cmd.EndpointProbeIntervalFlag.Name,
+		cmd.CatchUpDistanceFlag.Name,
+		cmd.CheckpointIntervalFlag.Name,
+		cmd.SQLSinkFlushIntervalFlag.Name,
+		cmd.DBPruneIntervalFlag.Name,
+		cmd.TaskQueueIntervalFlag.Name,
+		cmd.TaskRetryDelayFlag.Name,
+		cmd.ReconcileIntervalFlag.Name,
+		cmd.DiskCheckIntervalFlag.Name,
+		cmd.IdleAfterFlag.Name,
+	} {
+		if d := cliCtx.Duration(flag); d < 0 {
+			errs.add(flag, "duration %s must not be negative", d)
+		}
+	}
+
+	pruning := cliCtx.Uint64(cmd.DBRetentionEpochsFlag.Name) > 0 || cliCtx.Uint64(cmd.DBEpochInfoRetentionFlag.Name) > 0
+	enabledIntervals := map[string]bool{
+		cmd.CheckpointIntervalFlag.Name:   len(cliCtx.StringSlice(cmd.CheckpointEndpointsFlag.Name)) > 0,
+		cmd.SQLSinkFlushIntervalFlag.Name: cliCtx.String(cmd.SQLSinkDSNFlag.Name) != "",
+		cmd.DBPruneIntervalFlag.Name:      pruning,
+		cmd.TaskRetryDelayFlag.Name:       cliCtx.Duration(cmd.TaskQueueIntervalFlag.Name) > 0,
+	}
+	for flag, enabled := range enabledIntervals {
+		if enabled && cliCtx.Duration(flag) == 0 {
+			errs.add(flag, "interval must be positive when the feature is enabled")
+		}
+	}
+}
+
+// validateGenesis checks that genesis hashes are given together and are 32 bytes hex values
+func validateGenesis(cliCtx *cli.Context, errs *configErrors) {
+	pandoraHash := cliCtx.String(cmd.GenesisPandoraHashFlag.Name)
+	vanguardHash := cliCtx.String(cmd.GenesisVanguardHashFlag.Name)
+	if (pandoraHash == "") != (vanguardHash == "") {
+		errs.add(cmd.GenesisPandoraHashFlag.Name, "must be given together with --%s", cmd.GenesisVanguardHashFlag.Name)
+		return
+	}
+	for flag, hash := range map[string]string{
+		cmd.GenesisPandoraHashFlag.Name:  pandoraHash,
+		cmd.GenesisVanguardHashFlag.Name: vanguardHash,
+	} {
+		if hash == "" {
+			continue
+		}
+		if _, err := parseHash(hash); err != nil {
+			errs.add(flag, "%v, expected 0x prefixed 32 bytes hex", err)
+		}
+	}
+}
+
+// validateCheckpoint checks that checkpoint slot and shard root are given together and the root is a 32 bytes hex value
+func validateCheckpoint(cliCtx *cli.Context, errs *configErrors) {
+	shardRoot := cliCtx.String(cmd.CheckpointShardRootFlag.Name)
+	if cliCtx.IsSet(cmd.CheckpointSlotFlag.Name) != (shardRoot != "") {
+		errs.add(cmd.CheckpointShardRootFlag.Name, "must be given together with --%s", cmd.CheckpointSlotFlag.Name)
+		return
+	}
+	if shardRoot == "" {
+		return
+	}
+	if _, err := parseHash(shardRoot); err != nil {
+		errs.add(cmd.CheckpointShardRootFlag.Name, "%v, expected 0x prefixed 32 bytes hex", err)
+	}
+}
+
+// validatePorts checks that the ports of the enabled servers are valid and are not shared between them
+func validatePorts(cliCtx *cli.Context, errs *configErrors) {
+	type listener struct {
+		flag string
+		addr string
+		port int
+	}
+	var listeners []listener
+	if cliCtx.Bool(cmd.HTTPEnabledFlag.Name) {
+		listeners = append(listeners, listener{
+			cmd.HTTPPortFlag.Name, cliCtx.String(cmd.HTTPListenAddrFlag.Name), cliCtx.Int(cmd.HTTPPortFlag.Name)})
+	}
+	if cliCtx.Bool(cmd.WSEnabledFlag.Name) {
+		listeners = append(listeners, listener{
+			cmd.WSPortFlag.Name, cliCtx.String(cmd.WSListenAddrFlag.Name), cliCtx.Int(cmd.WSPortFlag.Name)})
+	}
+	if cliCtx.Bool(cmd.GRPCEnabledFlag.Name) {
+		listeners = append(listeners, listener{
+			cmd.GRPCPortFlag.Name, cliCtx.String(cmd.GRPCListenAddrFlag.Name), cliCtx.Int(cmd.GRPCPortFlag.Name)})
+	}
+	if cliCtx.Bool(cmd.MetricsEnabledFlag.Name) || cliCtx.Bool(cmd.StatsEnabledFlag.Name) {
+		listeners = append(listeners, listener{
+			cmd.MetricsPortFlag.Name, cliCtx.String(cmd.MetricsListenAddrFlag.Name), cliCtx.Int(cmd.MetricsPortFlag.Name)})
+	}
+
+	used := make(map[string]string)
+	for _, l := range listeners {
+		if l.port <= 0 || l.port > 65535 {
+			errs.add(l.flag, "port %d is out of range", l.port)
+			continue
+		}
+		addr := net.JoinHostPort(l.addr, strconv.Itoa(l.port))
+		if other, ok := used[addr]; ok {
+			// http and websocket share the server when they listen on the same address
+			if !(l.flag == cmd.WSPortFlag.Name && other == cmd.HTTPPortFlag.Name) {
+				errs.add(l.flag, "%s is already used by --%s", addr, other)
+			}
+			continue
+		}
+		used[addr] = l.flag
+	}
+}
+
+// validateDataDir checks that the data directory can be created and written
+func validateDataDir(cliCtx *cli.Context, errs *configErrors) {
+	dataDir := cliCtx.String(cmd.DataDirFlag.Name)
+	if dataDir == "" {
+		errs.add(cmd.DataDirFlag.Name, "data directory must be given")
+		return
+	}
+	dataDir, err := fileutil.ExpandPath(dataDir)
+	if err != nil {
+		errs.add(cmd.DataDirFlag.Name, "could not expand %s: %v", cliCtx.String(cmd.DataDirFlag.Name), err)
+		return
+	}
+	if err := os.MkdirAll(dataDir, params.OrchestratorIoConfig().ReadWriteExecutePermissions); err != nil {
+		errs.add(cmd.DataDirFlag.Name, "could not create %s: %v", dataDir, err)
+		return
+	}
+	probe, err := ioutil.TempFile(dataDir, ".write-probe")
+	if err != nil {
+		errs.add(cmd.DataDirFlag.Name, "%s is not writable: %v", dataDir, err)
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+}
+
+// validatePendingHeaderQueue checks the size and overflow policy of the pending pandora header queue and the
+// verification lanes
+func validatePendingHeaderQueue(cliCtx *cli.Context, errs *configErrors) {
+	if size := cliCtx.Int(cmd.PandoraHeaderBufferFlag.Name); size <= 0 {
+		errs.add(cmd.PandoraHeaderBufferFlag.Name, "buffer size %d must be positive", size)
+	}
+	if size := cliCtx.Int(cmd.VerificationLaneBufferFlag.Name); size <= 0 {
+		errs.add(cmd.VerificationLaneBufferFlag.Name, "buffer size %d must be positive", size)
+	}
+	if _, err := pandorachain.ParseOverflowPolicy(cliCtx.String(cmd.PandoraOverflowPolicyFlag.Name)); err != nil {
+		errs.add(cmd.PandoraOverflowPolicyFlag.Name, "%v", err)
+	}
+}
+
+// validateVanguardTLS checks that the given vanguard certificate files exist
+func validateVanguardTLS(cliCtx *cli.Context, errs *configErrors) {
+	for _, flag := range []string{cmd.VanguardTLSCertFlag.Name, cmd.VanguardTLSCAFlag.Name} {
+		if file := cliCtx.String(flag); file != "" && !fileutil.FileExists(file) {
+			errs.add(flag, "certificate file %s does not exist", file)
+		}
+	}
+}
+
+// validateRPCJWT checks that the rpc jwt secret file holds a valid secret and the jwt scope is known
+func validateRPCJWT(cliCtx *cli.Context, errs *configErrors) {
+	if file := cliCtx.String(cmd.RPCJWTSecretFlag.Name); file != "" {
+		if _, err := rpc.LoadJWTSecret(file); err != nil {
+			errs.add(cmd.RPCJWTSecretFlag.Name, "%v", err)
+		}
+	}
+	if scope := cliCtx.String(cmd.RPCJWTScopeFlag.Name); scope != "" && !rpc.ValidJWTScope(scope) {
+		errs.add(cmd.RPCJWTScopeFlag.Name, "unknown scope %q, want %s or %s", scope, rpc.JWTScopeAll, rpc.JWTScopeMutating)
+	}
+}
+
+// validateRetention checks that epoch infos are kept for at least the minimum weak subjectivity period, so that a
+// syncing pandora node finds the epoch infos which it needs
+func validateRetention(cliCtx *cli.Context, errs *configErrors) {
+	if retention := cliCtx.Uint64(cmd.DBEpochInfoRetentionFlag.Name); retention > 0 && retention < params.MinWeakSubjectivityEpochs {
+		errs.add(cmd.DBEpochInfoRetentionFlag.Name, "%d epochs is shorter than the minimum weak subjectivity period of %d epochs",
+			retention, params.MinWeakSubjectivityEpochs)
+	}
+}
@@ -0,0 +1,75 @@
+package node
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestValidateConfig(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, filepath.Join(t.TempDir(), "datadir"), "")
+	set.String(cmd.PandoraRPCEndpoint.Name, "ws://127.0.0.1:8546", "")
+	set.String(cmd.VanguardGRPCEndpoint.Name, "127.0.0.1:4000", "")
+	set.Bool(cmd.HTTPEnabledFlag.Name, true, "")
+	set.Int(cmd.HTTPPortFlag.Name, 8545, "")
+	set.Bool(cmd.WSEnabledFlag.Name, true, "")
+	set.Int(cmd.WSPortFlag.Name, 8545, "")
+	set.String(cmd.GenesisPandoraHashFlag.Name, "", "")
+	set.String(cmd.GenesisVanguardHashFlag.Name, "", "")
+	set.Duration(cmd.ReconcileIntervalFlag.Name, time.Minute, "")
+	set.Int(cmd.PandoraHeaderBufferFlag.Name, 1024, "")
+	set.Int(cmd.VerificationLaneBufferFlag.Name, 1024, "")
+	set.String(cmd.PandoraOverflowPolicyFlag.Name, "resubscribe", "")
+	set.String(cmd.RPCJWTScopeFlag.Name, "mutating", "")
+
+	// http and websocket may share the port
+	require.NoError(t, validateConfig(cli.NewContext(&app, set, nil)))
+
+	require.NoError(t, set.Set(cmd.PandoraRPCEndpoint.Name, "tcp://127.0.0.1:8546"))
+	require.NoError(t, set.Set(cmd.VanguardGRPCEndpoint.Name, "http://127.0.0.1:4000"))
+	require.NoError(t, set.Set(cmd.GenesisPandoraHashFlag.Name, "0x01"))
+	require.NoError(t, set.Set(cmd.ReconcileIntervalFlag.Name, "-1m"))
+	require.NoError(t, set.Set(cmd.PandoraOverflowPolicyFlag.Name, "block"))
+	set.String(cmd.VanguardTLSCAFlag.Name, filepath.Join(t.TempDir(), "missing.crt"), "")
+	set.Bool(cmd.MetricsEnabledFlag.Name, true, "")
+	set.Int(cmd.MetricsPortFlag.Name, 8545, "")
+	secretFile := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte("0x0102"), 0600))
+	set.String(cmd.RPCJWTSecretFlag.Name, secretFile, "")
+	set.Uint64(cmd.CheckpointSlotFlag.Name, 0, "")
+	set.String(cmd.CheckpointShardRootFlag.Name, "0x0000000000000000000000000000000000000000000000000000000000000001", "")
+	require.NoError(t, set.Set(cmd.RPCJWTScopeFlag.Name, "reads"))
+	set.Uint64(cmd.DBEpochInfoRetentionFlag.Name, 100, "")
+	set.Duration(cmd.DBPruneIntervalFlag.Name, time.Minute, "")
+
+	// all problems are reported at once
+	err := validateConfig(cli.NewContext(&app, set, nil))
+	errs, ok := err.(configErrors)
+	require.Equal(t, true, ok)
+	assert.Equal(t, 11, len(errs))
+	for _, want := range []string{
+		"--pandora-rpc-endpoint: unsupported scheme",
+		"--vanguard-grpc-endpoint: gRPC endpoint",
+		"--genesis.pandora-hash: must be given together",
+		"--reconcile-interval: duration -1m0s must not be negative",
+		"--metrics.port: :8545 is already used by --http.port",
+		"--pandora-overflow-policy: unknown pending header overflow policy",
+		"--vanguard-tls-ca: certificate file",
+		"--rpc.jwt-secret: jwt secret has 2 bytes",
+		"--rpc.jwt-scope: unknown scope",
+		"--checkpoint-shard-root: must be given together with --checkpoint-slot",
+		"--db-epoch-info-retention: 100 epochs is shorter than the minimum weak subjectivity period",
+	} {
+		assert.Equal(t, true, strings.Contains(err.Error(), want), want)
+	}
+}
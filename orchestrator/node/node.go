@@ -2,29 +2,58 @@ package node
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/metrics"
 	ethRpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/checkpoint"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db/kv"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/diskguard"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/exporter"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/hooks"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/idle"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/journal"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/maintenance"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/monitor"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pruner"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/reconcile"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/admin"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/sqlsink"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/stats"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/upstream"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
 	"github.com/lukso-network/lukso-orchestrator/shared"
 	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
 	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/identity"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/lukso-network/lukso-orchestrator/shared/version"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"syscall"
+	"time"
 )
 
+// identityKeyFileName is the default identity key file in the data directory
+const identityKeyFileName = "identity.key"
+
 // OrchestratorNode
 type OrchestratorNode struct {
 	// basic configuration
@@ -40,6 +69,9 @@ type OrchestratorNode struct {
 	//kv database with cache
 	db db.Database
 
+	// identity signs exported proofs
+	identity identity.Signer
+
 	// lru caches
 	pandoraInfoCache  *cache.PanHeaderCache
 	vanShardInfoCache *cache.VanShardingInfoCache
@@ -48,6 +80,13 @@ type OrchestratorNode struct {
 // New creates a new node instance, sets up configuration options, and registers
 // every required service to the node.
 func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
+	if err := useNetwork(cliCtx); err != nil {
+		return nil, err
+	}
+	if err := validateConfig(cliCtx); err != nil {
+		return nil, err
+	}
+
 	registry := shared.NewServiceRegistry()
 	ctx, cancel := context.WithCancel(cliCtx.Context)
 
@@ -77,6 +116,16 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	orchestrator.limitPendingCaches(cliCtx)
+
+	if err := orchestrator.loadIdentity(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerTaskQueueService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.registerVanguardChainService(cliCtx); err != nil {
 		return nil, err
 	}
@@ -85,14 +134,69 @@ func New(cliCtx *cli.Context) (*OrchestratorNode, error) {
 		return nil, err
 	}
 
+	if err := orchestrator.registerIdleService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerUpstreamService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.registerConsensusService(cliCtx); err != nil {
 		return nil, err
 	}
 
+	if err := orchestrator.registerJournalService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	if err := orchestrator.registerRPCService(cliCtx); err != nil {
 		return nil, err
 	}
 
+	// in-memory db does not use the disk
+	if !cliCtx.Bool(cmd.DBInMemoryFlag.Name) {
+		if err := orchestrator.registerDiskGuardService(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := orchestrator.registerPrunerService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerReconcileService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerHookService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerMonitorService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerCheckpointService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerSQLSinkService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerMaintenanceService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerStatsService(cliCtx); err != nil {
+		return nil, err
+	}
+
+	if err := orchestrator.registerExporterService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	return orchestrator, nil
 }
 
@@ -105,12 +209,24 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 
-	d, err := db.NewDB(o.ctx, dbPath, &kv.Config{
+	dbConfig := &kv.Config{
+		Backend:         kv.BackendType(cliCtx.String(cmd.DBBackendFlag.Name)),
 		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-	})
+		Encoding:        kv.Encoding(cliCtx.String(cmd.DBEncodingFlag.Name)),
+		Compression:     kv.Compression(cliCtx.String(cmd.DBCompressionFlag.Name)),
+		Archive:         cliCtx.Bool(cmd.ArchiveFlag.Name),
+		LockRetries:     cliCtx.Int(cmd.DBLockRetriesFlag.Name),
+		InMemory:        cliCtx.Bool(cmd.DBInMemoryFlag.Name),
+	}
+	d, err := db.NewDB(o.ctx, dbPath, dbConfig)
 	if err != nil {
 		return err
 	}
+	if dbConfig.InMemory {
+		log.Warn("Using in-memory db, all data is lost on shutdown")
+		o.db = d
+		return nil
+	}
 
 	clearDBConfirmed := false
 	if clearDB && !forceClearDB {
@@ -131,9 +247,7 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 		if err := d.ClearDB(); err != nil {
 			return errors.Wrap(err, "could not clear database")
 		}
-		d, err = db.NewDB(o.ctx, dbPath, &kv.Config{
-			InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
-		})
+		d, err = db.NewDB(o.ctx, dbPath, dbConfig)
 		if err != nil {
 			return errors.Wrap(err, "could not create new database")
 		}
@@ -143,19 +257,59 @@ func (o *OrchestratorNode) startDB(cliCtx *cli.Context) error {
 	return nil
 }
 
+// loadIdentity loads orchestrator identity from remote signer when it is given, otherwise from the identity key file
+func (o *OrchestratorNode) loadIdentity(cliCtx *cli.Context) error {
+	if remoteSignerURL := cliCtx.String(cmd.RemoteSignerURLFlag.Name); remoteSignerURL != "" {
+		signer, err := identity.NewRemoteSigner(remoteSignerURL, cliCtx.String(cmd.RemoteSignerPublicKeyFlag.Name))
+		if err != nil {
+			return errors.Wrap(err, "could not create remote signer")
+		}
+		log.WithField("remoteSigner", remoteSignerURL).WithField("address", signer.Address()).Info("Loaded orchestrator identity")
+		o.identity = signer
+		return nil
+	}
+
+	keyPath := cliCtx.String(cmd.IdentityKeyFlag.Name)
+	if keyPath == "" {
+		keyPath = filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), identityKeyFileName)
+	}
+	signer, err := identity.LoadOrCreateFileSigner(keyPath)
+	if err != nil {
+		return err
+	}
+	log.WithField("identityKey", keyPath).WithField("address", signer.Address()).Info("Loaded orchestrator identity")
+	o.identity = signer
+	return nil
+}
+
 // registerVanguardChainService
 func (o *OrchestratorNode) registerVanguardChainService(cliCtx *cli.Context) error {
 	vanguardGRPCUrl := cliCtx.String(cmd.VanguardGRPCEndpoint.Name)
+	fanInEndpoints := cliCtx.StringSlice(cmd.VanguardFanInEndpoints.Name)
 	svc, err := vanguardchain.NewService(
 		o.ctx,
 		vanguardGRPCUrl,
 		o.db,
 		o.vanShardInfoCache,
+		fanInEndpoints...,
 	)
 	if err != nil {
 		return nil
 	}
-	log.WithField("vanguardGRPCUrl", vanguardGRPCUrl).Info("Registered vanguard chain service")
+	fallbackEndpoints := cliCtx.StringSlice(cmd.VanguardFallbackEndpointsFlag.Name)
+	svc.SetFallbackEndpoints(fallbackEndpoints)
+	if err := svc.SetTransportSecurity(&vanguardchain.TransportSecurity{
+		TLSCert: cliCtx.String(cmd.VanguardTLSCertFlag.Name),
+		TLSCA:   cliCtx.String(cmd.VanguardTLSCAFlag.Name),
+		Token:   cliCtx.String(cmd.VanguardGRPCTokenFlag.Name),
+	}); err != nil {
+		return err
+	}
+	if tasks := o.taskQueue(); tasks != nil {
+		svc.SetTaskQueue(tasks)
+	}
+	log.WithField("vanguardGRPCUrl", vanguardGRPCUrl).WithField("fanInEndpoints", fanInEndpoints).
+		WithField("fallbackEndpoints", fallbackEndpoints).Info("Registered vanguard chain service")
 	return o.services.RegisterService(svc)
 }
 
@@ -170,14 +324,110 @@ func (o *OrchestratorNode) registerPandoraChainService(cliCtx *cli.Context) erro
 		return rpcClient, nil
 	}
 	namespace := "eth"
-	svc, err := pandorachain.NewService(o.ctx, pandoraRPCUrl, namespace, o.db, o.pandoraInfoCache, dialRPCClient)
+	overflowPolicy, err := pandorachain.ParseOverflowPolicy(cliCtx.String(cmd.PandoraOverflowPolicyFlag.Name))
+	if err != nil {
+		return err
+	}
+	headerBuffer := cliCtx.Int(cmd.PandoraHeaderBufferFlag.Name)
+	svc, err := pandorachain.NewService(o.ctx, pandoraRPCUrl, namespace, o.db, o.pandoraInfoCache, dialRPCClient,
+		pandorachain.WithPendingHeaderBuffer(headerBuffer, overflowPolicy))
 	if err != nil {
 		return nil
 	}
-	log.WithField("pandoraHttpUrl", pandoraRPCUrl).Info("Registered pandora chain service")
+	fallbackEndpoints := cliCtx.StringSlice(cmd.PandoraFallbackEndpointsFlag.Name)
+	svc.SetFallbackEndpoints(fallbackEndpoints)
+	log.WithField("pandoraHttpUrl", pandoraRPCUrl).WithField("fallbackEndpoints", fallbackEndpoints).
+		WithField("headerBuffer", headerBuffer).WithField("overflowPolicy", overflowPolicy).
+		Info("Registered pandora chain service")
 	return o.services.RegisterService(svc)
 }
 
+// registerUpstreamService registers endpoint probing when a chain has more than one endpoint
+func (o *OrchestratorNode) registerUpstreamService(cliCtx *cli.Context) error {
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err != nil {
+		return err
+	}
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err != nil {
+		return err
+	}
+
+	lagPenalty := params.SecondsPerSlot * time.Second
+	var chains []*upstream.Chain
+	if fallbacks := cliCtx.StringSlice(cmd.PandoraFallbackEndpointsFlag.Name); len(fallbacks) > 0 {
+		chains = append(chains, &upstream.Chain{
+			Name:       "pandora",
+			Node:       pandoraService,
+			Endpoints:  append([]string{cliCtx.String(cmd.PandoraRPCEndpoint.Name)}, fallbacks...),
+			LagPenalty: lagPenalty,
+		})
+	}
+	vanguardEndpoints := []string{cliCtx.String(cmd.VanguardGRPCEndpoint.Name)}
+	vanguardEndpoints = append(vanguardEndpoints, cliCtx.StringSlice(cmd.VanguardFallbackEndpointsFlag.Name)...)
+	vanguardEndpoints = append(vanguardEndpoints, cliCtx.StringSlice(cmd.VanguardFanInEndpoints.Name)...)
+	if len(vanguardEndpoints) > 1 {
+		chains = append(chains, &upstream.Chain{
+			Name:       "vanguard",
+			Node:       vanguardService,
+			Endpoints:  vanguardEndpoints,
+			LagPenalty: lagPenalty,
+		})
+	}
+	if len(chains) == 0 {
+		return nil
+	}
+
+	svc, err := upstream.NewService(o.ctx, &upstream.Config{
+		Chains:       chains,
+		Interval:     cliCtx.Duration(cmd.EndpointProbeIntervalFlag.Name),
+		SwitchMargin: cliCtx.Duration(cmd.EndpointSwitchMarginFlag.Name),
+		Idle:         o.idleDetector(),
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("chains", len(chains)).Info("Registered upstream service")
+	return o.services.RegisterService(svc, pandoraService, vanguardService)
+}
+
+// registerIdleService registers detection of idle chains when an idle timeout is given
+func (o *OrchestratorNode) registerIdleService(cliCtx *cli.Context) error {
+	after := cliCtx.Duration(cmd.IdleAfterFlag.Name)
+	if after == 0 {
+		return nil
+	}
+
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err != nil {
+		return err
+	}
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err != nil {
+		return err
+	}
+
+	svc, err := idle.NewService(o.ctx, &idle.Config{
+		VanguardFeed: vanguardService,
+		PandoraFeed:  pandoraService,
+		After:        after,
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("after", after).Info("Registered idle service")
+	return o.services.RegisterService(svc, pandoraService, vanguardService)
+}
+
+// idleDetector returns the idle service when idle detection is enabled
+func (o *OrchestratorNode) idleDetector() idle.Detector {
+	var idleService *idle.Service
+	if err := o.services.FetchService(&idleService); err != nil {
+		return nil
+	}
+	return idleService
+}
+
 // registerConsensusService
 func (o *OrchestratorNode) registerConsensusService(cliCtx *cli.Context) error {
 	var vanguardShardFeed *vanguardchain.Service
@@ -190,6 +440,32 @@ func (o *OrchestratorNode) registerConsensusService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	var catchUpWriteDB db.CatchUpWriteDB
+	catchUpDistance := cliCtx.Duration(cmd.CatchUpDistanceFlag.Name)
+	if catchUpDistance > 0 {
+		catchUpWriteDB = o.db
+	}
+
+	genesis, err := genesisShardInfo(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := trustedCheckpoint(cliCtx)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		vanguardShardFeed.SetCheckpoint(checkpoint.Slot)
+		log.WithField("slot", checkpoint.Slot).WithField("shardRoot", checkpoint.ShardRoot).
+			Info("Starting from trusted checkpoint")
+	}
+
+	laneOverflowPolicy, err := pandorachain.ParseOverflowPolicy(cliCtx.String(cmd.PandoraOverflowPolicyFlag.Name))
+	if err != nil {
+		return err
+	}
+
 	svc := consensus.New(o.ctx, &consensus.Config{
 		VerifiedSlotInfoDB:           o.db,
 		InvalidSlotInfoDB:            o.db,
@@ -197,12 +473,443 @@ func (o *OrchestratorNode) registerConsensusService(cliCtx *cli.Context) error {
 		PandoraPendingHeaderCache:    o.pandoraInfoCache,
 		VanguardShardFeed:            vanguardShardFeed,
 		PandoraHeaderFeed:            pandoraHeaderFeed,
+		ReorderWindow:                cliCtx.Uint64(cmd.ReorderWindowFlag.Name),
+		MaxFutureSlots:               cliCtx.Uint64(cmd.MaxFutureSlotsFlag.Name),
+		AccumulatorDB:                o.db,
+		CatchUpWriteDB:               catchUpWriteDB,
+		CatchUpDistance:              catchUpDistance,
+		BatchWriteDB:                 o.db,
+		BatchSize:                    cliCtx.Uint64(cmd.VerificationBatchSizeFlag.Name),
+		VerificationWorkers:          cliCtx.Int(cmd.VerificationWorkersFlag.Name),
+		LaneBuffer:                   cliCtx.Int(cmd.VerificationLaneBufferFlag.Name),
+		LaneOverflowPolicy:           laneOverflowPolicy,
+		GenesisShardInfo:             genesis,
+		Checkpoint:                   checkpoint,
+		CheckpointVanguard:           vanguardShardFeed,
+		CheckpointPandora:            pandoraHeaderFeed,
+		EpochSummaryDB:               o.db,
+		ShutdownDB:                   o.db,
+		LifetimeStatsDB:              o.db,
+		ReorgHistoryDB:               o.db,
+		PendingReorgDB:               o.db,
+		Tasks:                        o.taskQueue(),
 	})
 
 	log.Info("Registered consensus service")
+	return o.services.RegisterService(svc, vanguardShardFeed, pandoraHeaderFeed)
+}
+
+// registerTaskQueueService registers the persistent queue of deferred tasks unless it is disabled
+func (o *OrchestratorNode) registerTaskQueueService(cliCtx *cli.Context) error {
+	interval := cliCtx.Duration(cmd.TaskQueueIntervalFlag.Name)
+	if interval == 0 {
+		return nil
+	}
+
+	svc, err := taskqueue.NewService(o.ctx, &taskqueue.Config{
+		DB:          o.db,
+		Interval:    interval,
+		RetryDelay:  cliCtx.Duration(cmd.TaskRetryDelayFlag.Name),
+		MaxAttempts: cliCtx.Uint64(cmd.TaskMaxAttemptsFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("interval", interval).Info("Registered task queue service")
+	return o.services.RegisterService(svc)
+}
+
+// taskQueue returns the registered task queue, nil when the task queue is disabled
+func (o *OrchestratorNode) taskQueue() taskqueue.Queue {
+	var tasks *taskqueue.Service
+	if err := o.services.FetchService(&tasks); err != nil {
+		return nil
+	}
+	return tasks
+}
+
+// registerPrunerService registers periodic pruning of verified slots and consensus infos when their db retention is
+// given
+func (o *OrchestratorNode) registerPrunerService(cliCtx *cli.Context) error {
+	retentionEpochs := cliCtx.Uint64(cmd.DBRetentionEpochsFlag.Name)
+	epochInfoRetention := cliCtx.Uint64(cmd.DBEpochInfoRetentionFlag.Name)
+	if retentionEpochs == 0 && epochInfoRetention == 0 {
+		return nil
+	}
+	if cliCtx.Bool(cmd.ArchiveFlag.Name) {
+		return errors.New("--db-retention-epochs and --db-epoch-info-retention can not be used together with --archive")
+	}
+
+	svc, err := pruner.NewService(o.ctx, &pruner.Config{
+		DB:                           o.db,
+		RetentionEpochs:              retentionEpochs,
+		ConsensusInfoRetentionEpochs: epochInfoRetention,
+		Interval:                     cliCtx.Duration(cmd.DBPruneIntervalFlag.Name),
+		Tasks:                        o.taskQueue(),
+		Idle:                         o.idleDetector(),
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("retentionEpochs", retentionEpochs).
+		WithField("consensusInfoRetentionEpochs", epochInfoRetention).Info("Registered pruner service")
 	return o.services.RegisterService(svc)
 }
 
+// registerReconcileService registers periodic comparison of slot statuses with pandora
+func (o *OrchestratorNode) registerReconcileService(cliCtx *cli.Context) error {
+	interval := cliCtx.Duration(cmd.ReconcileIntervalFlag.Name)
+	if interval == 0 {
+		return nil
+	}
+
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err != nil {
+		return err
+	}
+
+	svc, err := reconcile.NewService(o.ctx, &reconcile.Config{
+		VerifiedSlotInfoDB: o.db,
+		InvalidSlotInfoDB:  o.db,
+		PandoraChain:       pandoraService,
+		Interval:           interval,
+		SampleSize:         cliCtx.Int(cmd.ReconcileSampleSizeFlag.Name),
+		Idle:               o.idleDetector(),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Registered reconciliation service")
+	return o.services.RegisterService(svc, pandoraService)
+}
+
+// useNetwork selects the network preset and uses its endpoints for the endpoint flags which are not given
+func useNetwork(cliCtx *cli.Context) error {
+	if err := params.UseNetwork(cliCtx.String(cmd.NetworkFlag.Name)); err != nil {
+		return err
+	}
+	network := params.OrchestratorNetworkConfig()
+	endpoints := map[string]string{
+		cmd.VanguardGRPCEndpoint.Name: network.VanguardGRPCEndpoint,
+		cmd.PandoraRPCEndpoint.Name:   network.PandoraRPCEndpoint,
+	}
+	for flag, endpoint := range endpoints {
+		if endpoint == "" || cliCtx.IsSet(flag) {
+			continue
+		}
+		if err := cliCtx.Set(flag, endpoint); err != nil {
+			return err
+		}
+	}
+	log.WithField("network", network.Name).Info("Using network preset")
+	return nil
+}
+
+// genesisShardInfo returns the genesis shard info of the network preset unless it is overridden by the flags
+func genesisShardInfo(cliCtx *cli.Context) (*types.SlotInfo, error) {
+	pandoraHash := cliCtx.String(cmd.GenesisPandoraHashFlag.Name)
+	vanguardHash := cliCtx.String(cmd.GenesisVanguardHashFlag.Name)
+	if pandoraHash == "" && vanguardHash == "" {
+		return params.OrchestratorNetworkConfig().GenesisShardInfo, nil
+	}
+	if pandoraHash == "" || vanguardHash == "" {
+		return nil, errors.New("--genesis.pandora-hash and --genesis.vanguard-hash must be given together")
+	}
+
+	pandoraHeaderHash, err := parseHash(pandoraHash)
+	if err != nil {
+		return nil, err
+	}
+	vanguardBlockHash, err := parseHash(vanguardHash)
+	if err != nil {
+		return nil, err
+	}
+	return &types.SlotInfo{PandoraHeaderHash: pandoraHeaderHash, VanguardBlockHash: vanguardBlockHash}, nil
+}
+
+// trustedCheckpoint returns the checkpoint which an empty verified db is seeded from or nil when none is given
+func trustedCheckpoint(cliCtx *cli.Context) (*types.TrustedCheckpoint, error) {
+	shardRoot := cliCtx.String(cmd.CheckpointShardRootFlag.Name)
+	if shardRoot == "" {
+		return nil, nil
+	}
+	root, err := parseHash(shardRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &types.TrustedCheckpoint{Slot: cliCtx.Uint64(cmd.CheckpointSlotFlag.Name), ShardRoot: root}, nil
+}
+
+// parseHash
+func parseHash(value string) (common.Hash, error) {
+	b, err := hexutil.Decode(value)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, errors.Errorf("invalid hash %q", value)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// registerHookService registers operator-defined hooks when hooks config file is given
+func (o *OrchestratorNode) registerHookService(cliCtx *cli.Context) error {
+	hooksConfigPath := cliCtx.String(cmd.HooksConfigFlag.Name)
+	if hooksConfigPath == "" {
+		return nil
+	}
+	hooksConfig, err := hooks.LoadConfig(hooksConfigPath)
+	if err != nil {
+		return err
+	}
+
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err != nil {
+		return err
+	}
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err != nil {
+		return err
+	}
+
+	// disk guard is not registered for an in-memory db
+	dependencies := []shared.Service{vanguardService, consensusService}
+	var diskPressureFeed hooks.DiskPressureFeed
+	var diskGuardService *diskguard.Service
+	if err := o.services.FetchService(&diskGuardService); err == nil {
+		diskPressureFeed = diskGuardService
+		dependencies = append(dependencies, diskGuardService)
+	}
+
+	svc, err := hooks.NewService(o.ctx, &hooks.Config{
+		Hooks:                hooksConfig.Hooks,
+		VerifiedSlotInfoFeed: consensusService,
+		ReorgFeed:            vanguardService,
+		DiskPressureFeed:     diskPressureFeed,
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("hooksConfig", hooksConfigPath).Info("Registered hook service")
+	return o.services.RegisterService(svc, dependencies...)
+}
+
+// registerDiskGuardService registers db growth forecasting and disk pressure handling
+func (o *OrchestratorNode) registerDiskGuardService(cliCtx *cli.Context) error {
+	svc, err := diskguard.NewService(o.ctx, &diskguard.Config{
+		DB:             o.db,
+		Interval:       cliCtx.Duration(cmd.DiskCheckIntervalFlag.Name),
+		FreeSpaceFloor: cliCtx.Uint64(cmd.DiskFreeFloorFlag.Name) * 1024 * 1024,
+		WarnHorizon:    cliCtx.Duration(cmd.DiskFullWarningFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Registered disk guard service")
+	return o.services.RegisterService(svc)
+}
+
+// registerMonitorService registers block production monitoring when own validators are given
+func (o *OrchestratorNode) registerMonitorService(cliCtx *cli.Context) error {
+	validators := cliCtx.StringSlice(cmd.MyValidatorsFlag.Name)
+	if len(validators) == 0 {
+		return nil
+	}
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err != nil {
+		return err
+	}
+
+	svc := monitor.NewService(o.ctx, &monitor.Config{
+		Validators:           validators,
+		VerifiedSlotInfoFeed: consensusService,
+		ConsensusInfoDB:      o.db,
+		VerifiedSlotInfoDB:   o.db,
+	})
+	log.WithField("validators", len(validators)).Info("Registered monitor service")
+	return o.services.RegisterService(svc, consensusService)
+}
+
+// registerCheckpointService registers signed checkpoint publication when publish endpoints are given
+func (o *OrchestratorNode) registerCheckpointService(cliCtx *cli.Context) error {
+	endpoints := cliCtx.StringSlice(cmd.CheckpointEndpointsFlag.Name)
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	svc, err := checkpoint.NewService(o.ctx, &checkpoint.Config{
+		Endpoints:          endpoints,
+		Interval:           cliCtx.Duration(cmd.CheckpointIntervalFlag.Name),
+		Identity:           o.identity,
+		VerifiedSlotInfoDB: o.db,
+		Idle:               o.idleDetector(),
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("endpoints", len(endpoints)).Info("Registered checkpoint service")
+	return o.services.RegisterService(svc)
+}
+
+// registerMaintenanceService registers maintenance windows when maintenance config is given
+func (o *OrchestratorNode) registerMaintenanceService(cliCtx *cli.Context) error {
+	maintenanceConfigPath := cliCtx.String(cmd.MaintenanceConfigFlag.Name)
+	if maintenanceConfigPath == "" {
+		return nil
+	}
+	maintenanceConfig, err := maintenance.LoadConfig(maintenanceConfigPath)
+	if err != nil {
+		return err
+	}
+
+	svc, err := maintenance.NewService(o.ctx, &maintenance.Config{
+		Windows:       maintenanceConfig.Windows,
+		SnapshotDir:   maintenanceConfig.SnapshotDir,
+		SnapshotsKept: maintenanceConfig.SnapshotsKept,
+		DB:            o.db,
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("maintenanceConfig", maintenanceConfigPath).Info("Registered maintenance service")
+	return o.services.RegisterService(svc)
+}
+
+// registerStatsService registers the aggregated stats time series when stats are enabled
+func (o *OrchestratorNode) registerStatsService(cliCtx *cli.Context) error {
+	if !cliCtx.Bool(cmd.StatsEnabledFlag.Name) {
+		return nil
+	}
+
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err != nil {
+		return err
+	}
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err != nil {
+		return err
+	}
+
+	svc := stats.NewService(o.ctx, &stats.Config{
+		VerifiedSlotInfoFeed: consensusService,
+		ReorgFeed:            vanguardService,
+		ConsensusInfoFeed:    vanguardService,
+		LifetimeStats:        consensusService,
+	})
+	log.Info("Registered stats service")
+	return o.services.RegisterService(svc)
+}
+
+// registerExporterService registers the prometheus metrics endpoint when metrics are enabled and the stats
+// endpoint when stats are enabled
+func (o *OrchestratorNode) registerExporterService(cliCtx *cli.Context) error {
+	metricsEnabled := cliCtx.Bool(cmd.MetricsEnabledFlag.Name)
+	statsEnabled := cliCtx.Bool(cmd.StatsEnabledFlag.Name)
+	if !metricsEnabled && !statsEnabled {
+		return nil
+	}
+	if metricsEnabled && !metrics.Enabled {
+		log.Warn("Metrics are only collected when --metrics is given on the command line")
+	}
+
+	handlers := make(map[string]http.Handler)
+	if statsEnabled {
+		var statsService *stats.Service
+		if err := o.services.FetchService(&statsService); err != nil {
+			return err
+		}
+		handlers["/stats"] = statsService
+	}
+
+	addr := net.JoinHostPort(cliCtx.String(cmd.MetricsListenAddrFlag.Name),
+		strconv.Itoa(cliCtx.Int(cmd.MetricsPortFlag.Name)))
+	svc := exporter.NewService(o.ctx, &exporter.Config{Addr: addr, Handlers: handlers})
+	log.WithField("addr", addr).Info("Registered metrics exporter service")
+	return o.services.RegisterService(svc)
+}
+
+// registerSQLSinkService registers mirroring into postgres when its dsn is given
+func (o *OrchestratorNode) registerSQLSinkService(cliCtx *cli.Context) error {
+	dsn := cliCtx.String(cmd.SQLSinkDSNFlag.Name)
+	if dsn == "" {
+		return nil
+	}
+
+	var vanguardService *vanguardchain.Service
+	if err := o.services.FetchService(&vanguardService); err != nil {
+		return err
+	}
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err != nil {
+		return err
+	}
+
+	svc, err := sqlsink.NewService(o.ctx, &sqlsink.Config{
+		DSN:                  dsn,
+		BatchSize:            cliCtx.Int(cmd.SQLSinkBatchSizeFlag.Name),
+		FlushInterval:        cliCtx.Duration(cmd.SQLSinkFlushIntervalFlag.Name),
+		SpillDir:             filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), sqlsink.DirName),
+		VerifiedSlotInfoFeed: consensusService,
+		ReorgFeed:            vanguardService,
+		EpochSummaryFeed:     consensusService,
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Registered sql sink service")
+	return o.services.RegisterService(svc, vanguardService, consensusService)
+}
+
+// limitPendingCaches bounds the caches of pending slots. The epoch after the latest verified slot is never evicted,
+// because the consensus service waits for the missing halves of these slots.
+func (o *OrchestratorNode) limitPendingCaches(cliCtx *cli.Context) {
+	maxEntries := cliCtx.Int(cmd.PendingCacheSizeFlag.Name)
+	inProgress := func(slot uint64) bool {
+		latestSlot := o.db.LatestSavedVerifiedSlot()
+		return slot > latestSlot && slot <= latestSlot+params.SlotsPerEpoch
+	}
+	o.pandoraInfoCache.SetLimit(maxEntries, inProgress)
+	o.vanShardInfoCache.SetLimit(maxEntries, inProgress)
+}
+
+// registerJournalService registers the journal of published confirmations when its size is given
+func (o *OrchestratorNode) registerJournalService(cliCtx *cli.Context) error {
+	sizeMB := cliCtx.Uint64(cmd.ConfirmationJournalSizeFlag.Name)
+	if sizeMB == 0 {
+		return nil
+	}
+
+	var consensusService *consensus.Service
+	if err := o.services.FetchService(&consensusService); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), journal.DirName)
+	svc, err := journal.NewService(o.ctx, &journal.Config{
+		Dir:                  dir,
+		Consumers:            cliCtx.StringSlice(cmd.ConfirmationConsumersFlag.Name),
+		MaxBytes:             sizeMB * 1024 * 1024,
+		MaxAge:               cliCtx.Duration(cmd.ConfirmationJournalMaxAgeFlag.Name),
+		VerifiedSlotInfoFeed: consensusService,
+		FinalizedSlotDB:      o.db,
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("path", dir).WithField("sizeMB", sizeMB).Info("Registered confirmation journal service")
+	return o.services.RegisterService(svc, consensusService)
+}
+
+// confirmationJournal returns the registered confirmation journal, nil when the journal is disabled
+func (o *OrchestratorNode) confirmationJournal() api.ConfirmationJournal {
+	var journalService *journal.Service
+	if err := o.services.FetchService(&journalService); err != nil {
+		return nil
+	}
+	return journalService
+}
+
 // register RPC server
 func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 	var consensusInfoFeed *vanguardchain.Service
@@ -215,6 +922,18 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	var pandoraService *pandorachain.Service
+	if err := o.services.FetchService(&pandoraService); err != nil {
+		return err
+	}
+
+	// endpoint scores are only served when endpoint probing is enabled
+	var endpointScorer admin.EndpointScorer
+	var upstreamService *upstream.Service
+	if err := o.services.FetchService(&upstreamService); err == nil {
+		endpointScorer = upstreamService
+	}
+
 	var ipcapiURL string
 	if cliCtx.String(cmd.IPCPathFlag.Name) != "" {
 		ipcFilePath := cliCtx.String(cmd.IPCPathFlag.Name)
@@ -230,6 +949,17 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 	wsEnable := cliCtx.Bool(cmd.WSEnabledFlag.Name)
 	wsListenerAddr := cliCtx.String(cmd.WSListenAddrFlag.Name)
 	wsPort := cliCtx.Int(cmd.WSPortFlag.Name)
+	confirmationAck := cliCtx.Bool(cmd.ConfirmationAckFlag.Name)
+
+	var jwtSecret []byte
+	if secretFile := cliCtx.String(cmd.RPCJWTSecretFlag.Name); secretFile != "" {
+		secret, err := rpc.LoadJWTSecret(secretFile)
+		if err != nil {
+			return err
+		}
+		jwtSecret = secret
+		log.WithField("scope", cliCtx.String(cmd.RPCJWTScopeFlag.Name)).Info("rpc jwt authentication enabled")
+	}
 
 	log.WithField("httpEnable", httpEnable).WithField("httpListenAddr", httpListenAddr).WithField(
 		"httpPort", httpPort).WithField("wsEnable", wsEnable).WithField(
@@ -242,20 +972,48 @@ func (o *OrchestratorNode) registerRPCService(cliCtx *cli.Context) error {
 		HTTPEnable:        httpEnable,
 		HTTPHost:          httpListenAddr,
 		HTTPPort:          httpPort,
+		HTTPREST:          cliCtx.Bool(cmd.HTTPRESTFlag.Name),
 		WSEnable:          wsEnable,
 		WSHost:            wsListenerAddr,
 		WSPort:            wsPort,
+		GRPCEnable:        cliCtx.Bool(cmd.GRPCEnabledFlag.Name),
+		GRPCHost:          cliCtx.String(cmd.GRPCListenAddrFlag.Name),
+		GRPCPort:          cliCtx.Int(cmd.GRPCPortFlag.Name),
+		PortRetries:       cliCtx.Int(cmd.RPCPortRetriesFlag.Name),
+
+		WSCompression:      cliCtx.Bool(cmd.WSCompressionFlag.Name),
+		WSCompressionLevel: cliCtx.Int(cmd.WSCompressionLevelFlag.Name),
+
+		JWTSecret: jwtSecret,
+		JWTScope:  cliCtx.String(cmd.RPCJWTScopeFlag.Name),
+
+		RateLimit: cliCtx.Float64(cmd.RPCRateLimitFlag.Name),
+		RateBurst: cliCtx.Int(cmd.RPCRateBurstFlag.Name),
 
 		VanguardPendingShardingCache: o.vanShardInfoCache,
 		PandoraPendingHeaderCache:    o.pandoraInfoCache,
 		VerifiedSlotInfoFeed:         verifiedSlotInfoFeed,
+		EpochSummaryFeed:             verifiedSlotInfoFeed,
+		ShardConflictFeed:            verifiedSlotInfoFeed,
+		LifetimeStats:                verifiedSlotInfoFeed,
+		HeaderVerifier:               verifiedSlotInfoFeed,
+		ConfirmationAckEnabled:       confirmationAck,
+		ConfirmationConsumers:        cliCtx.StringSlice(cmd.ConfirmationConsumersFlag.Name),
+		PandoraEndpointSwitcher:      pandoraService,
+		VanguardEndpointSwitcher:     consensusInfoFeed,
+		EndpointScorer:               endpointScorer,
+		ConfirmationRepublisher:      verifiedSlotInfoFeed,
+		Identity:                     o.identity,
+		PayloadFetcher:               pandoraService,
+		Tasks:                        o.taskQueue(),
+		ConfirmationJournal:          o.confirmationJournal(),
 	})
 	if err != nil {
 		return nil
 	}
 
 	log.Info("Registered RPC service")
-	return o.services.RegisterService(svc)
+	return o.services.RegisterService(svc, consensusInfoFeed, verifiedSlotInfoFeed, pandoraService)
 }
 
 // Start the OrchestratorNode and kicks off every registered service.
@@ -267,6 +1025,7 @@ func (o *OrchestratorNode) Start() {
 	}).Info("Starting orchestrator node")
 
 	o.services.StartAll()
+	o.reportStartup()
 
 	stop := o.stop
 	o.lock.Unlock()
@@ -297,6 +1056,7 @@ func (b *OrchestratorNode) Close() {
 	defer b.lock.Unlock()
 
 	log.Info("Stopping orchestrator node")
+	// Every service uses db, so db is closed only after all of them are stopped
 	b.services.StopAll()
 	if err := b.db.Close(); err != nil {
 		log.Errorf("Failed to close database: %v", err)
@@ -0,0 +1,164 @@
+package node
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/version"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// secretFlags are left out of the config digest, so that the digest can be shared with support
+var secretFlags = map[string]bool{
+	cmd.VanguardGRPCTokenFlag.Name: true,
+	cmd.SQLSinkDSNFlag.Name:        true,
+}
+
+// reportStartup logs the setup of the started node as a single entry and hands it to the status API
+func (o *OrchestratorNode) reportStartup() {
+	report := o.startupReport(o.cliCtx)
+	log.WithFields(logrus.Fields{
+		"version":         report.Version,
+		"configDigest":    report.ConfigDigest,
+		"network":         report.Network,
+		"datadir":         report.DataDir,
+		"schemaVersion":   report.SchemaVersion,
+		"endpoints":       report.Endpoints,
+		"features":        report.Features,
+		"listenAddresses": report.ListenAddresses,
+	}).Info("Startup report")
+
+	var rpcService *rpc.Service
+	if err := o.services.FetchService(&rpcService); err == nil {
+		rpcService.SetStartupReport(report)
+	}
+}
+
+// startupReport collects the setup of the node. Listen addresses are the bound ones, so the report must be
+// collected after the services have started.
+func (o *OrchestratorNode) startupReport(cliCtx *cli.Context) *types.StartupReport {
+	network := &types.StartupNetwork{Name: params.OrchestratorNetworkConfig().Name}
+	if identity, err := o.db.PandoraChainIdentity(); err == nil {
+		network.PandoraChainIdentity = identity
+	}
+	if root, err := o.db.VanguardGenesisValidatorsRoot(); err == nil {
+		network.VanguardGenesisValidatorsRoot = root
+	}
+	if genesis, err := genesisShardInfo(cliCtx); err == nil {
+		network.GenesisShardInfo = genesis
+	}
+	if checkpoint, err := trustedCheckpoint(cliCtx); err == nil {
+		network.Checkpoint = checkpoint
+	}
+
+	return &types.StartupReport{
+		Version:       version.Version(),
+		ConfigDigest:  configDigest(cliCtx),
+		Network:       network,
+		DataDir:       cliCtx.String(cmd.DataDirFlag.Name),
+		SchemaVersion: o.db.SchemaVersion(),
+		Endpoints: map[string][]string{
+			"pandora":          {cliCtx.String(cmd.PandoraRPCEndpoint.Name)},
+			"pandoraFallbacks": cliCtx.StringSlice(cmd.PandoraFallbackEndpointsFlag.Name),
+			"vanguard":         {cliCtx.String(cmd.VanguardGRPCEndpoint.Name)},
+			"vanguardFanIn":    cliCtx.StringSlice(cmd.VanguardFanInEndpoints.Name),
+			"vanguardFallback": cliCtx.StringSlice(cmd.VanguardFallbackEndpointsFlag.Name),
+		},
+		Features:        enabledFeatures(cliCtx),
+		ListenAddresses: o.listenAddresses(cliCtx),
+		StartedAt:       time.Now(),
+	}
+}
+
+// configDigest hashes the flags which are given on the command line with their values, so that two nodes of the same
+// version with the same digest run the same configuration
+func configDigest(cliCtx *cli.Context) common.Hash {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, name := range cliCtx.FlagNames() {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	hasher := sha256.New()
+	for _, name := range names {
+		value := cliCtx.Value(name)
+		if secretFlags[name] {
+			value = "<redacted>"
+		}
+		fmt.Fprintf(hasher, "%s=%v\n", name, value)
+	}
+	return common.BytesToHash(hasher.Sum(nil))
+}
+
+// enabledFeatures returns the optional features which are turned on by the flags
+func enabledFeatures(cliCtx *cli.Context) []string {
+	features := make([]string, 0)
+	for feature, enabled := range map[string]bool{
+		"archive":            cliCtx.Bool(cmd.ArchiveFlag.Name),
+		"checkpoint-publish": len(cliCtx.StringSlice(cmd.CheckpointEndpointsFlag.Name)) > 0,
+		"checkpoint-sync":    cliCtx.String(cmd.CheckpointShardRootFlag.Name) != "",
+		"confirmation-ack":   cliCtx.Bool(cmd.ConfirmationAckFlag.Name),
+		"db-inmemory":        cliCtx.Bool(cmd.DBInMemoryFlag.Name),
+		"grpc":               cliCtx.Bool(cmd.GRPCEnabledFlag.Name),
+		"hooks":              cliCtx.String(cmd.HooksConfigFlag.Name) != "",
+		"http-rest":          cliCtx.Bool(cmd.HTTPRESTFlag.Name),
+		"idle-mode":          cliCtx.Duration(cmd.IdleAfterFlag.Name) > 0,
+		"maintenance":        cliCtx.String(cmd.MaintenanceConfigFlag.Name) != "",
+		"metrics":            cliCtx.Bool(cmd.MetricsEnabledFlag.Name),
+		"reconcile":          cliCtx.Duration(cmd.ReconcileIntervalFlag.Name) > 0,
+		"rpc-jwt":            cliCtx.String(cmd.RPCJWTSecretFlag.Name) != "",
+		"rpc-rate-limit":     cliCtx.Float64(cmd.RPCRateLimitFlag.Name) > 0,
+		"sql-sink":           cliCtx.String(cmd.SQLSinkDSNFlag.Name) != "",
+		"stats":              cliCtx.Bool(cmd.StatsEnabledFlag.Name),
+		"task-queue":         cliCtx.Duration(cmd.TaskQueueIntervalFlag.Name) > 0,
+		"vanguard-tls":       cliCtx.String(cmd.VanguardTLSCertFlag.Name) != "" || cliCtx.String(cmd.VanguardTLSCAFlag.Name) != "",
+		"ws-compression":     cliCtx.Bool(cmd.WSCompressionFlag.Name),
+	} {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// listenAddresses returns the addresses which the servers of the node listen on
+func (o *OrchestratorNode) listenAddresses(cliCtx *cli.Context) map[string]string {
+	addresses := make(map[string]string)
+	var rpcService *rpc.Service
+	if err := o.services.FetchService(&rpcService); err == nil {
+		if endpoint := rpcService.HTTPEndpoint(); endpoint != "" {
+			addresses["http"] = endpoint
+		}
+		if endpoint := rpcService.WSEndpoint(); endpoint != "" {
+			addresses["ws"] = endpoint
+		}
+		if endpoint := rpcService.GRPCEndpoint(); endpoint != "" {
+			addresses["grpc"] = endpoint
+		}
+	}
+	if ipcPath := cliCtx.String(cmd.IPCPathFlag.Name); ipcPath != "" {
+		addresses["ipc"] = fileutil.IpcEndpoint(filepath.Join(ipcPath, cmd.DefaultIpcPath), "")
+	}
+	if cliCtx.Bool(cmd.MetricsEnabledFlag.Name) || cliCtx.Bool(cmd.StatsEnabledFlag.Name) {
+		addresses["metrics"] = net.JoinHostPort(cliCtx.String(cmd.MetricsListenAddrFlag.Name),
+			strconv.Itoa(cliCtx.Int(cmd.MetricsPortFlag.Name)))
+	}
+	return addresses
+}
@@ -0,0 +1,33 @@
+package node
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/cmd"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestConfigDigest(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", 0)
+		set.String(cmd.PandoraRPCEndpoint.Name, "", "")
+		set.String(cmd.VanguardGRPCTokenFlag.Name, "", "")
+		set.Bool(cmd.ArchiveFlag.Name, false, "")
+		set.Bool(cmd.HTTPRESTFlag.Name, false, "")
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	digest := configDigest(newContext("--pandora-rpc-endpoint", "ws://127.0.0.1:8546", "--archive"))
+	// flag order and secret values do not change the digest
+	assert.Equal(t, digest, configDigest(newContext("--archive", "--pandora-rpc-endpoint", "ws://127.0.0.1:8546")))
+	assert.Equal(t, configDigest(newContext("--vanguard-grpc-token", "secret")),
+		configDigest(newContext("--vanguard-grpc-token", "other")))
+	assert.NotEqual(t, digest, configDigest(newContext("--pandora-rpc-endpoint", "ws://127.0.0.1:8547", "--archive")))
+
+	assert.DeepEqual(t, []string{"archive"}, enabledFeatures(newContext("--archive")))
+	assert.DeepEqual(t, []string{"archive", "http-rest"}, enabledFeatures(newContext("--http.rest", "--archive")))
+}
@@ -0,0 +1,78 @@
+package pandorachain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// blockTimeout is the maximum time to wait for pandora node to return a block
+var blockTimeout = 10 * time.Second
+
+// BlockByHash returns the full pandora block with its transactions as the pandora node encodes it. The block
+// hash is checked, so a node on another fork can't attach a different body to a verified header.
+func (s *Service) BlockByHash(ctx context.Context, hash common.Hash) (json.RawMessage, error) {
+	s.processingLock.RLock()
+	client := s.rpcClient
+	s.processingLock.RUnlock()
+	if client == nil {
+		return nil, errNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, blockTimeout)
+	defer cancel()
+
+	var block json.RawMessage
+	if err := client.CallContext(ctx, &block, "eth_getBlockByHash", hash, true); err != nil {
+		return nil, errors.Wrap(err, "could not retrieve block from pandora node")
+	}
+	if len(block) == 0 || string(block) == "null" {
+		return nil, errors.Errorf("pandora node does not have block %s", hash.Hex())
+	}
+
+	var header struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := json.Unmarshal(block, &header); err != nil {
+		return nil, errors.Wrap(err, "could not decode block from pandora node")
+	}
+	if header.Hash != hash {
+		return nil, errors.Errorf("pandora node returned block %s instead of %s", header.Hash.Hex(), hash.Hex())
+	}
+	return block, nil
+}
+
+// CanonicalStatus returns whether the pandora node has the block and whether the block is on its canonical chain
+func (s *Service) CanonicalStatus(ctx context.Context, hash common.Hash) (bool, bool, error) {
+	s.processingLock.RLock()
+	client := s.rpcClient
+	s.processingLock.RUnlock()
+	if client == nil {
+		return false, false, errNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, blockTimeout)
+	defer cancel()
+
+	var block *struct {
+		Number hexutil.Uint64 `json:"number"`
+	}
+	if err := client.CallContext(ctx, &block, "eth_getBlockByHash", hash, false); err != nil {
+		return false, false, errors.Wrap(err, "could not retrieve block from pandora node")
+	}
+	if block == nil {
+		return false, false, nil
+	}
+
+	var canonical *struct {
+		Hash common.Hash `json:"hash"`
+	}
+	if err := client.CallContext(ctx, &canonical, "eth_getBlockByNumber", block.Number, false); err != nil {
+		return true, false, errors.Wrap(err, "could not retrieve canonical block from pandora node")
+	}
+	return true, canonical != nil && canonical.Hash == hash, nil
+}
@@ -0,0 +1,77 @@
+package pandorachain
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestService_BlockByHash(t *testing.T) {
+	ctx := context.Background()
+	server, panService := SetupInProcServer(t)
+	defer server.Stop()
+	pandoraService := SetupPandoraSvc(ctx, t, DialInProcClient(server))
+
+	hash := common.HexToHash("0x1")
+	_, err := pandoraService.BlockByHash(ctx, hash)
+	assert.ErrorContains(t, errNotConnected.Error(), err)
+
+	pandoraService.rpcClient, err = pandoraService.dialRPCFn(pandoraService.endpoint)
+	require.NoError(t, err)
+	defer pandoraService.rpcClient.Close()
+
+	_, err = pandoraService.BlockByHash(ctx, hash)
+	assert.ErrorContains(t, "pandora node does not have block", err)
+
+	panService.blocks[hash] = map[string]interface{}{"hash": hash, "transactions": []string{"0xabc"}}
+	block, err := pandoraService.BlockByHash(ctx, hash)
+	require.NoError(t, err)
+	var decoded struct {
+		Hash         common.Hash `json:"hash"`
+		Transactions []string    `json:"transactions"`
+	}
+	require.NoError(t, json.Unmarshal(block, &decoded))
+	assert.Equal(t, hash, decoded.Hash)
+	assert.DeepEqual(t, []string{"0xabc"}, decoded.Transactions)
+
+	// body of another block is never attached
+	panService.blocks[hash] = map[string]interface{}{"hash": common.HexToHash("0x2")}
+	_, err = pandoraService.BlockByHash(ctx, hash)
+	assert.ErrorContains(t, "instead of", err)
+}
+
+func TestService_CanonicalStatus(t *testing.T) {
+	ctx := context.Background()
+	server, panService := SetupInProcServer(t)
+	defer server.Stop()
+	pandoraService := SetupPandoraSvc(ctx, t, DialInProcClient(server))
+
+	var err error
+	pandoraService.rpcClient, err = pandoraService.dialRPCFn(pandoraService.endpoint)
+	require.NoError(t, err)
+	defer pandoraService.rpcClient.Close()
+
+	hash := common.HexToHash("0x1")
+	known, canonical, err := pandoraService.CanonicalStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, false, known)
+	assert.Equal(t, false, canonical)
+
+	panService.blocks[hash] = map[string]interface{}{"hash": hash, "number": hexutil.Uint64(5)}
+	panService.canonical[5] = map[string]interface{}{"hash": common.HexToHash("0x2")}
+	known, canonical, err = pandoraService.CanonicalStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, true, known)
+	assert.Equal(t, false, canonical)
+
+	panService.canonical[5] = panService.blocks[hash]
+	known, canonical, err = pandoraService.CanonicalStatus(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, true, known)
+	assert.Equal(t, true, canonical)
+}
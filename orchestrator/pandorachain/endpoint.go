@@ -0,0 +1,132 @@
+package pandorachain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// identityTimeout is the maximum time to wait for pandora node to report its network identity
+var identityTimeout = 10 * time.Second
+
+var (
+	errChainIDMismatch     = errors.New("pandora node reports different chain id")
+	errGenesisHashMismatch = errors.New("pandora node reports different genesis hash")
+)
+
+// networkIdentity returns the chain id and genesis hash reported by pandora node
+func networkIdentity(ctx context.Context, client *rpc.Client) (*big.Int, common.Hash, error) {
+	ctx, cancel := context.WithTimeout(ctx, identityTimeout)
+	defer cancel()
+
+	var chainID hexutil.Big
+	if err := client.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return nil, common.Hash{}, errors.Wrap(err, "could not retrieve chain id from pandora node")
+	}
+	var genesis *eth1Types.Header
+	if err := client.CallContext(ctx, &genesis, "eth_getBlockByNumber", hexutil.EncodeUint64(0), false); err != nil {
+		return nil, common.Hash{}, errors.Wrap(err, "could not retrieve genesis header from pandora node")
+	}
+	if genesis == nil {
+		return nil, common.Hash{}, errors.New("pandora node does not have genesis header")
+	}
+	return chainID.ToInt(), genesis.Hash(), nil
+}
+
+// verifyNetworkIdentity checks the network identity of the given pandora node against the pinned one. The identity of
+// the first node is pinned into db, so a node which does not report its identity is refused while nothing is pinned.
+// When strict is false, such nodes are accepted once an identity is pinned.
+func (s *Service) verifyNetworkIdentity(client *rpc.Client, strict bool) error {
+	pinned, err := s.db.PandoraChainIdentity()
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve pinned pandora network identity")
+	}
+
+	chainID, genesisHash, err := networkIdentity(s.ctx, client)
+	if err != nil {
+		if strict || pinned == nil {
+			return err
+		}
+		log.WithError(err).Warn("Could not retrieve network identity from pandora node, skipping identity check")
+		return nil
+	}
+
+	// the chain id of the network preset applies before any identity is pinned
+	network := params.OrchestratorNetworkConfig()
+	if network.PandoraChainID != nil && chainID.Cmp(network.PandoraChainID) != 0 {
+		return errors.Wrapf(errChainIDMismatch, "network %s expects %v, got %v", network.Name,
+			network.PandoraChainID, chainID)
+	}
+
+	if pinned == nil {
+		if err := s.db.SavePandoraChainIdentity(&types.PandoraChainIdentity{
+			ChainID:     chainID,
+			GenesisHash: genesisHash,
+		}); err != nil {
+			return errors.Wrap(err, "could not pin pandora network identity")
+		}
+		log.WithField("chainID", chainID).WithField("genesisHash", genesisHash).Info("Pinned pandora network identity")
+		return nil
+	}
+
+	if chainID.Cmp(pinned.ChainID) != 0 {
+		return errors.Wrapf(errChainIDMismatch, "expected %v, got %v", pinned.ChainID, chainID)
+	}
+	if genesisHash != pinned.GenesisHash {
+		return errors.Wrapf(errGenesisHashMismatch, "expected %v, got %v", pinned.GenesisHash, genesisHash)
+	}
+	return nil
+}
+
+// SetEndpoint switches the service to a different pandora node. Existing subscription is torn down and the
+// regular re-subscription routine resumes it on the new node from the latest verified header. The node becomes the
+// primary endpoint, so failover starts after it and fails back to it instead of the node which was used before.
+func (s *Service) SetEndpoint(endpoint string) error {
+	s.failoverLock.Lock()
+	defer s.failoverLock.Unlock()
+
+	if err := s.switchEndpoint(endpoint); err != nil {
+		return err
+	}
+	if len(s.failoverEndpoints) > 0 {
+		s.failoverEndpoints = promoteEndpoint(s.failoverEndpoints, endpoint)
+	}
+	return nil
+}
+
+// switchEndpoint moves the subscription to the pandora node without changing the failover order
+func (s *Service) switchEndpoint(endpoint string) error {
+	rpcClient, err := s.dialRPCFn(endpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not dial new pandora endpoint")
+	}
+	if err := s.verifyNetworkIdentity(rpcClient, true); err != nil {
+		rpcClient.Close()
+		return err
+	}
+
+	s.processingLock.Lock()
+	oldClient, oldSub := s.rpcClient, s.conInfoSub
+	s.rpcClient = rpcClient
+	s.endpoint = endpoint
+	s.conInfoSub = nil
+	s.processingLock.Unlock()
+
+	// unsubscribing closes the subscription error channel, so the run loop re-subscribes with the new client
+	if oldSub != nil {
+		oldSub.Unsubscribe()
+	}
+	if oldClient != nil {
+		oldClient.Close()
+	}
+	log.WithField("endpoint", endpoint).Info("Switched pandora endpoint")
+	return nil
+}
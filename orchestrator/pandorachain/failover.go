@@ -0,0 +1,83 @@
+package pandorachain
+
+import (
+	"context"
+)
+
+// SetFallbackEndpoints sets the pandora nodes which the service fails over to when the used node is unreachable.
+// The primary endpoint stays the first candidate, so the service can fail back to it.
+func (s *Service) SetFallbackEndpoints(endpoints []string) {
+	s.failoverLock.Lock()
+	defer s.failoverLock.Unlock()
+	s.failoverEndpoints = promoteEndpoint(endpoints, s.Endpoint())
+}
+
+// promoteEndpoint returns the endpoints with primary as the first one. The former primary and the fallbacks stay
+// candidates in their order.
+func promoteEndpoint(endpoints []string, primary string) []string {
+	promoted := make([]string, 0, len(endpoints)+1)
+	promoted = append(promoted, primary)
+	for _, endpoint := range endpoints {
+		if endpoint != primary {
+			promoted = append(promoted, endpoint)
+		}
+	}
+	return promoted
+}
+
+// failoverCandidates returns the endpoints in failover order, starting after the given endpoint
+func failoverCandidates(endpoints []string, current string) []string {
+	start := 0
+	for i, endpoint := range endpoints {
+		if endpoint == current {
+			start = i + 1
+			break
+		}
+	}
+	candidates := make([]string, 0, len(endpoints))
+	for i := range endpoints {
+		if endpoint := endpoints[(start+i)%len(endpoints)]; endpoint != current {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	return candidates
+}
+
+// failover moves the service to the next healthy pandora node. It returns true when the service switched to a node
+// which answered the probe, the subscription is then resumed on it from the latest verified header.
+func (s *Service) failover() bool {
+	s.failoverLock.Lock()
+	defer s.failoverLock.Unlock()
+
+	if len(s.failoverEndpoints) < 2 {
+		return false
+	}
+	current := s.Endpoint()
+	for _, endpoint := range failoverCandidates(s.failoverEndpoints, current) {
+		ctx, cancel := context.WithTimeout(s.ctx, identityTimeout)
+		_, _, err := s.ProbeEndpoint(ctx, endpoint)
+		cancel()
+		if err != nil {
+			log.WithError(err).WithField("endpoint", endpoint).Warn("Pandora fallback node is not healthy")
+			continue
+		}
+		if err := s.switchEndpoint(endpoint); err != nil {
+			log.WithError(err).WithField("endpoint", endpoint).Warn("Could not fail over to pandora node")
+			continue
+		}
+		failoverCounter.Inc(1)
+		log.WithField("from", current).WithField("to", endpoint).Warn("Failed over to next pandora node")
+		return true
+	}
+	return false
+}
+
+// connectOrFailover connects and subscribes to the used pandora node. When it fails, the service moves to the next
+// healthy fallback node and subscribes there instead of waiting for the used node to come back.
+func (s *Service) connectOrFailover() error {
+	err := s.connectToChain()
+	if err == nil || !s.failover() {
+		return err
+	}
+	return s.connectToChain()
+}
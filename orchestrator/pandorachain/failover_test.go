@@ -0,0 +1,26 @@
+package pandorachain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestFailoverCandidates(t *testing.T) {
+	endpoints := []string{"ws://a:8546", "ws://b:8546", "ws://c:8546"}
+	assert.DeepEqual(t, []string{"ws://b:8546", "ws://c:8546"}, failoverCandidates(endpoints, "ws://a:8546"))
+	assert.DeepEqual(t, []string{"ws://c:8546", "ws://a:8546"}, failoverCandidates(endpoints, "ws://b:8546"))
+	assert.DeepEqual(t, []string{"ws://a:8546", "ws://b:8546"}, failoverCandidates(endpoints, "ws://c:8546"))
+	// endpoint which is set outside the candidates starts from the primary one
+	assert.DeepEqual(t, []string{"ws://a:8546", "ws://b:8546", "ws://c:8546"},
+		failoverCandidates(endpoints, "ws://d:8546"))
+}
+
+func TestPromoteEndpoint(t *testing.T) {
+	endpoints := []string{"ws://a:8546", "ws://b:8546", "ws://c:8546"}
+	assert.DeepEqual(t, []string{"ws://b:8546", "ws://a:8546", "ws://c:8546"}, promoteEndpoint(endpoints, "ws://b:8546"))
+	// endpoint which is set outside the candidates becomes the primary and keeps the others as fallbacks
+	promoted := promoteEndpoint(endpoints, "ws://d:8546")
+	assert.DeepEqual(t, []string{"ws://d:8546", "ws://a:8546", "ws://b:8546", "ws://c:8546"}, promoted)
+	assert.DeepEqual(t, []string{"ws://a:8546", "ws://b:8546", "ws://c:8546"}, failoverCandidates(promoted, "ws://d:8546"))
+}
@@ -2,8 +2,11 @@ package pandorachain
 
 import (
 	"context"
+	"time"
+
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
@@ -17,11 +20,21 @@ func (s *Service) OnNewPendingHeader(ctx context.Context, header *eth1Types.Head
 		return err
 	}
 
+	if err := chaos.Inject(chaos.PandoraHeaderPoint); err != nil {
+		log.WithError(err).WithField("slot", panExtraDataWithSig.Slot).Warn("Dropping pandora header")
+		return nil
+	}
+
 	log.WithField("slot", panExtraDataWithSig.Slot).
 		WithField("blockNumber", header.Number.Uint64()).
 		WithField("headerHash", header.Hash()).
 		Info("New pandora header info has arrived")
 
+	receivedHeadersCounter.Inc(1)
+	if delay := time.Since(time.Unix(int64(header.Time), 0)); delay > 0 {
+		headerDelayHistogram.Update(delay.Milliseconds())
+	}
+
 	s.pandoraHeaderInfoFeed.Send(&types.PandoraHeaderInfo{
 		Header: header,
 		Slot:   panExtraDataWithSig.Slot,
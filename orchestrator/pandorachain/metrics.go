@@ -0,0 +1,21 @@
+package pandorachain
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// receivedHeadersCounter is the number of received pending pandora headers
+	receivedHeadersCounter = metrics.NewRegisteredCounter("orc_pandora_headers_total", nil)
+	// subscriptionErrorsCounter is the number of times the pending header subscription failed
+	subscriptionErrorsCounter = metrics.NewRegisteredCounter("orc_pandora_subscription_errors_total", nil)
+	// droppedHeadersCounter is the number of queued pending headers which are dropped by drop-oldest policy
+	droppedHeadersCounter = metrics.NewRegisteredCounter("orc_pandora_dropped_headers_total", nil)
+	// headerQueueOverflowsCounter is the number of times the subscription is renewed because of a full queue
+	headerQueueOverflowsCounter = metrics.NewRegisteredCounter("orc_pandora_header_queue_overflows_total", nil)
+	// failoverCounter is the number of times the service moved to a fallback pandora node
+	failoverCounter = metrics.NewRegisteredCounter("orc_pandora_failovers_total", nil)
+	// pendingHeaderQueueDepth is the number of received pending headers which wait to be processed
+	pendingHeaderQueueDepth = metrics.NewRegisteredGauge("orc_pandora_pending_header_queue_depth", nil)
+	// headerDelayHistogram is the time in milliseconds from the pandora header time until the header is received
+	headerDelayHistogram = metrics.NewRegisteredHistogram("orc_pandora_header_delay_ms", nil,
+		metrics.NewExpDecaySample(1028, 0.015))
+)
@@ -0,0 +1,69 @@
+package pandorachain
+
+import (
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+)
+
+// OverflowPolicy decides what happens when pending headers are received faster than they are processed
+type OverflowPolicy string
+
+const (
+	// ResubscribeOnOverflow drops the subscription and subscribes again from the latest verified header, so that
+	// no header is lost
+	ResubscribeOnOverflow OverflowPolicy = "resubscribe"
+	// DropOldestOnOverflow keeps the subscription and drops the oldest queued header
+	DropOldestOnOverflow OverflowPolicy = "drop-oldest"
+)
+
+// DefaultPendingHeaderBuffer is the number of received pending headers which are queued for processing
+const DefaultPendingHeaderBuffer = 1024
+
+var errPendingHeaderOverflow = errors.New("pending pandora header queue is full")
+
+// ParseOverflowPolicy returns the policy of the given name
+func ParseOverflowPolicy(name string) (OverflowPolicy, error) {
+	switch policy := OverflowPolicy(name); policy {
+	case ResubscribeOnOverflow, DropOldestOnOverflow:
+		return policy, nil
+	default:
+		return "", errors.Errorf("unknown pending header overflow policy %q", name)
+	}
+}
+
+// Option configures the pandora chain service
+type Option func(*Service)
+
+// WithPendingHeaderBuffer bounds the queue of received pending headers and sets what happens when it is full
+func WithPendingHeaderBuffer(size int, policy OverflowPolicy) Option {
+	return func(s *Service) {
+		if size > 0 {
+			s.pendingHeaderBuffer = size
+		}
+		s.overflowPolicy = policy
+	}
+}
+
+// enqueuePendingHeader queues the received header. It returns false when the queue is full and the subscription
+// has to be renewed.
+func (s *Service) enqueuePendingHeader(queue chan *eth1Types.Header, header *eth1Types.Header) bool {
+	for {
+		select {
+		case queue <- header:
+			pendingHeaderQueueDepth.Update(int64(len(queue)))
+			return true
+		default:
+		}
+		if s.overflowPolicy != DropOldestOnOverflow {
+			headerQueueOverflowsCounter.Inc(1)
+			return false
+		}
+		select {
+		case dropped := <-queue:
+			droppedHeadersCounter.Inc(1)
+			log.WithField("blockNumber", dropped.Number).WithField("headerHash", dropped.Hash()).
+				Warn("Pending pandora header queue is full, dropped the oldest header")
+		default:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pandorachain
+
+import (
+	"testing"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestService_EnqueuePendingHeader(t *testing.T) {
+	first, second, third := testutil.NewEth1Header(1), testutil.NewEth1Header(2), testutil.NewEth1Header(3)
+
+	// resubscribe keeps the queued headers and reports the overflow
+	svc := &Service{}
+	WithPendingHeaderBuffer(2, ResubscribeOnOverflow)(svc)
+	queue := make(chan *eth1Types.Header, svc.pendingHeaderBuffer)
+	assert.Equal(t, true, svc.enqueuePendingHeader(queue, first))
+	assert.Equal(t, true, svc.enqueuePendingHeader(queue, second))
+	assert.Equal(t, false, svc.enqueuePendingHeader(queue, third))
+	assert.Equal(t, first, <-queue)
+	assert.Equal(t, second, <-queue)
+
+	// drop-oldest keeps the subscription and the newest headers
+	svc = &Service{}
+	WithPendingHeaderBuffer(2, DropOldestOnOverflow)(svc)
+	queue = make(chan *eth1Types.Header, svc.pendingHeaderBuffer)
+	assert.Equal(t, true, svc.enqueuePendingHeader(queue, first))
+	assert.Equal(t, true, svc.enqueuePendingHeader(queue, second))
+	assert.Equal(t, true, svc.enqueuePendingHeader(queue, third))
+	assert.Equal(t, second, <-queue)
+	assert.Equal(t, third, <-queue)
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	policy, err := ParseOverflowPolicy("drop-oldest")
+	require.NoError(t, err)
+	assert.Equal(t, DropOldestOnOverflow, policy)
+
+	_, err = ParseOverflowPolicy("block")
+	assert.ErrorContains(t, "unknown pending header overflow policy", err)
+}
@@ -0,0 +1,33 @@
+package pandorachain
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Endpoint returns the pandora node which the service is currently using
+func (s *Service) Endpoint() string {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.endpoint
+}
+
+// ProbeEndpoint returns the latest block number of the given pandora node and the round trip of the request.
+// Dialing is not included in the round trip.
+func (s *Service) ProbeEndpoint(ctx context.Context, endpoint string) (uint64, time.Duration, error) {
+	client, err := s.dialRPCFn(endpoint)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not dial pandora endpoint")
+	}
+	defer client.Close()
+
+	var head hexutil.Uint64
+	start := time.Now()
+	if err := client.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		return 0, 0, errors.Wrap(err, "could not retrieve block number from pandora endpoint")
+	}
+	return uint64(head), time.Since(start), nil
+}
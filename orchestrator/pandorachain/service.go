@@ -10,12 +10,33 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/circuitbreaker"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
 // time to wait before trying to reconnect.
 var reConPeriod = 2 * time.Second
 
+// restart budget of pandora connection. After breakerThreshold failures in breakerWindow, reconnection
+// backoff grows up to maxReConPeriod.
+var (
+	breakerThreshold = 5
+	breakerWindow    = time.Minute
+	maxReConPeriod   = time.Minute
+	reConJitter      = 0.2
+)
+
+// newBreaker creates circuit breaker for a pandora connection or subscription
+func newBreaker(name string) *circuitbreaker.Breaker {
+	return circuitbreaker.New(name, circuitbreaker.Config{
+		Threshold:  breakerThreshold,
+		Window:     breakerWindow,
+		MinBackoff: reConPeriod,
+		MaxBackoff: maxReConPeriod,
+		Jitter:     reConJitter,
+	})
+}
+
 // DialRPCFn dials to the given endpoint
 type DialRPCFn func(endpoint string) (*rpc.Client, error)
 
@@ -49,6 +70,19 @@ type Service struct {
 
 	scope                 event.SubscriptionScope
 	pandoraHeaderInfoFeed event.Feed
+
+	breaker *circuitbreaker.Breaker
+
+	// failoverEndpoints are the primary and fallback pandora nodes, in failover order
+	failoverLock      sync.Mutex
+	failoverEndpoints []string
+
+	// filtered subscriptions which are tracked and renewed independently
+	subscriptions *subscriptionManager
+
+	// pending headers are queued up to pendingHeaderBuffer, overflowPolicy applies beyond it
+	pendingHeaderBuffer int
+	overflowPolicy      OverflowPolicy
 }
 
 // NewService creates new service with pandora ws or ipc endpoint, pandora service namespace and db
@@ -59,11 +93,12 @@ func NewService(
 	db db.Database,
 	cache cache.PandoraHeaderCache,
 	dialRPCFn DialRPCFn,
+	opts ...Option,
 ) (*Service, error) {
 
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
-	return &Service{
+	svc := &Service{
 		ctx:             ctx,
 		cancel:          cancel,
 		endpoint:        endpoint,
@@ -73,7 +108,16 @@ func NewService(
 		conDisconnect:   make(chan struct{}),
 		db:              db,
 		cache:           cache,
-	}, nil
+		breaker:         newBreaker("pandora chain connection"),
+		subscriptions:   newSubscriptionManager(),
+
+		pendingHeaderBuffer: DefaultPendingHeaderBuffer,
+		overflowPolicy:      ResubscribeOnOverflow,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc, nil
 }
 
 // Start a consensus info fetcher service's main event loop.
@@ -108,6 +152,10 @@ func (s *Service) Status() error {
 	if !s.isRunning {
 		return nil
 	}
+	// degraded status after too many connection failures
+	if err := s.breaker.Err(); err != nil {
+		return err
+	}
 	// get error from run function
 	if s.runError != nil {
 		return s.runError
@@ -127,34 +175,36 @@ func (s *Service) closeClients() {
 func (s *Service) waitForConnection() {
 	log.Debug("Waiting for the connection")
 	var err error
-	if err = s.connectToChain(); err == nil {
+	if err = s.connectOrFailover(); err == nil {
 		log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
 		s.connected = true
+		s.breaker.Success()
 		return
 	}
 	log.WithError(err).Warn("Could not connect or subscribe to pandora chain")
 	s.runError = err
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
+	s.breaker.Failure(err)
 
 	for {
-		select {
-		case <-ticker.C:
-			log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
-			var errConnect error
-			if errConnect = s.connectToChain(); errConnect != nil {
-				log.WithError(errConnect).Warn("Could not connect or subscribe to pandora chain")
-				s.runError = errConnect
-				continue
-			}
-			s.connected = true
-			s.runError = nil
-			log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
-			return
-		case <-s.ctx.Done():
+		// backoff grows while the breaker is open instead of hot-looping reconnects
+		if err := s.breaker.Wait(s.ctx); err != nil {
 			log.Info("Received cancelled context, closing existing pandora client connection service")
 			return
 		}
+		log.WithField("endpoint", s.endpoint).Debug("Dialing pandora node")
+		var errConnect error
+		if errConnect = s.connectOrFailover(); errConnect != nil {
+			s.breaker.Failure(errConnect)
+			log.WithError(errConnect).WithField("backoff", s.breaker.Backoff()).
+				Warn("Could not connect or subscribe to pandora chain")
+			s.runError = errConnect
+			continue
+		}
+		s.connected = true
+		s.runError = nil
+		s.breaker.Success()
+		log.WithField("endpoint", s.endpoint).Info("Connected and subscribed to pandora chain")
+		return
 	}
 }
 
@@ -201,6 +251,11 @@ func (s *Service) connectToChain() error {
 		if err != nil {
 			return err
 		}
+		// refuse to connect with a node which belongs to another network
+		if err := s.verifyNetworkIdentity(panRPCClient, false); err != nil {
+			panRPCClient.Close()
+			return err
+		}
 		s.rpcClient = panRPCClient
 	}
 
@@ -215,8 +270,12 @@ func (s *Service) connectToChain() error {
 func (s *Service) retryToConnectAndSubscribe(err error) {
 	s.runError = err
 	s.connected = false
+	s.subscriptions.trackPrimary(nil, nil, err)
+	s.breaker.Failure(err)
 	// Back off for a while before resuming dialing the pandora node.
-	time.Sleep(reConPeriod)
+	if err := s.breaker.Wait(s.ctx); err != nil {
+		return
+	}
 	go s.waitForConnection()
 	// Reset run error in the event of a successful connection.
 	s.runError = nil
@@ -229,11 +288,12 @@ func (s *Service) subscribe() error {
 		FromBlockHash: latestSavedHeaderHash,
 	}
 
-	log.WithField("finalizedSlot", s.db.LatestSavedVerifiedSlot()).WithField("panHeaderHash", filter.FromBlockHash).
+	log.WithField("verifiedSlot", s.db.LatestSavedVerifiedSlot()).WithField("panHeaderHash", filter.FromBlockHash).
 		Debug("Start subscribing to pandora client for pending headers")
 
 	// subscribe to pandora client for pending headers
 	sub, err := s.SubscribePendingHeaders(s.ctx, filter, s.namespace, s.rpcClient)
+	s.subscriptions.trackPrimary(filter, sub, err)
 	if err != nil {
 		log.WithError(err).Warn("Could not subscribe to pandora client for new pending headers")
 		return err
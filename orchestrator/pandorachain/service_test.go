@@ -2,6 +2,7 @@ package pandorachain
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/pkg/errors"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -51,3 +52,25 @@ func Test_PandoraSvc_RetrySub(t *testing.T) {
 	hook.Reset()
 	assert.NoError(t, panSvc.Stop())
 }
+
+// Test_PandoraSvc_VerifyNetworkIdentity checks that a node which does not report its identity is refused until an
+// identity is pinned
+func Test_PandoraSvc_VerifyNetworkIdentity(t *testing.T) {
+	ctx := context.Background()
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+	silentServer := rpc.NewServer()
+	defer silentServer.Stop()
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	silentClient := rpc.DialInProc(silentServer)
+	defer silentClient.Close()
+	assert.ErrorContains(t, "could not retrieve chain id", panSvc.verifyNetworkIdentity(silentClient, false))
+
+	client := rpc.DialInProc(inProcServer)
+	defer client.Close()
+	assert.NoError(t, panSvc.verifyNetworkIdentity(client, false))
+	// identity is pinned now, so only the strict check refuses the silent node
+	assert.NoError(t, panSvc.verifyNetworkIdentity(silentClient, false))
+	assert.ErrorContains(t, "could not retrieve chain id", panSvc.verifyNetworkIdentity(silentClient, true))
+}
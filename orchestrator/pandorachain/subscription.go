@@ -5,6 +5,7 @@ import (
 
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 )
@@ -27,23 +28,57 @@ func (s *Service) SubscribePendingHeaders(
 	}
 	log.WithField("filterCriteria", crit).Info("subscribed to pandora chain for pending block headers")
 
+	// received headers are queued up to the buffer size, so a slow handler does not grow the client buffer
+	queue := make(chan *eth1Types.Header, s.pendingHeaderBuffer)
+	// stopped is closed when the reader exits, failed is closed when the handler fails
+	stopped, failed := make(chan struct{}), make(chan struct{})
+
 	// Start up a dispatcher to feed into the callback
 	go func() {
 		for {
 			select {
-			case newPendingHeader := <-ch:
+			case newPendingHeader := <-queue:
+				pendingHeaderQueueDepth.Update(int64(len(queue)))
 				// dispatch newPendingHeader to handler
-				err = s.OnNewPendingHeader(ctx, newPendingHeader)
-				if nil != err {
+				if err := s.OnNewPendingHeader(ctx, newPendingHeader); err != nil {
 					log.WithError(err).Error("Failed to process the pending pandora header")
+					subscriptionErrorsCounter.Inc(1)
+					close(failed)
 					s.conInfoSubErrCh <- errPandoraHeaderProcessing
 					return
 				}
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
+	// Start up a reader to drain the subscription into the queue
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case newPendingHeader := <-ch:
+				if err := chaos.Inject(chaos.PandoraStreamPoint); err != nil {
+					log.WithError(err).Warn("Breaking pandora pending header subscription")
+					s.conInfoSubErrCh <- err
+					return
+				}
+				if !s.enqueuePendingHeader(queue, newPendingHeader) {
+					log.WithField("buffer", s.pendingHeaderBuffer).
+						Warn("Pending pandora header queue is full, subscribing again from latest verified header")
+					sub.Unsubscribe()
+					s.conInfoSubErrCh <- errPendingHeaderOverflow
+					return
+				}
+			case <-failed:
+				return
 			case <-s.conDisconnect:
 				log.Info("Received re-org event, exiting pandora pending block subscription!")
 				return
 			case err := <-sub.Err():
 				log.WithError(err).Debug("Got subscription error")
+				subscriptionErrorsCounter.Inc(1)
 				s.conInfoSubErrCh <- err
 				return
 			case <-ctx.Done():
@@ -0,0 +1,253 @@
+package pandorachain
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/circuitbreaker"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// primarySubscriptionID identifies the default pending header subscription which is renewed by re-connecting
+const primarySubscriptionID = "pending-headers"
+
+var (
+	errSubscriptionExists   = errors.New("subscription already exists")
+	errSubscriptionNotFound = errors.New("subscription not found")
+	errNotConnected         = errors.New("pandora client is not connected")
+)
+
+// SubscriptionStatus reports the state of a filtered pandora subscription
+type SubscriptionStatus struct {
+	ID           string                            `json:"id"`
+	Filter       *types.PandoraPendingHeaderFilter `json:"filter"`
+	Active       bool                              `json:"active"`
+	Renewals     uint64                            `json:"renewals"`
+	LastError    string                            `json:"lastError,omitempty"`
+	SubscribedAt time.Time                         `json:"subscribedAt"`
+}
+
+// managedSubscription is a filtered subscription which is renewed independently from the others
+type managedSubscription struct {
+	id           string
+	filter       *types.PandoraPendingHeaderFilter
+	sub          *rpc.ClientSubscription
+	active       bool
+	renewing     bool
+	renewals     uint64
+	lastErr      error
+	subscribedAt time.Time
+	breaker      *circuitbreaker.Breaker
+	stop         chan struct{}
+}
+
+// subscriptionManager tracks all filtered pandora subscriptions of the service
+type subscriptionManager struct {
+	lock sync.Mutex
+	subs map[string]*managedSubscription
+}
+
+func newSubscriptionManager() *subscriptionManager {
+	return &subscriptionManager{subs: make(map[string]*managedSubscription)}
+}
+
+// status
+func (ms *managedSubscription) status() *SubscriptionStatus {
+	status := &SubscriptionStatus{
+		ID:           ms.id,
+		Filter:       ms.filter,
+		Active:       ms.active,
+		Renewals:     ms.renewals,
+		SubscribedAt: ms.subscribedAt,
+	}
+	if ms.lastErr != nil {
+		status.LastError = ms.lastErr.Error()
+	}
+	return status
+}
+
+// trackPrimary records the state of the default pending header subscription
+func (m *subscriptionManager) trackPrimary(filter *types.PandoraPendingHeaderFilter, sub *rpc.ClientSubscription, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	ms, ok := m.subs[primarySubscriptionID]
+	if !ok {
+		ms = &managedSubscription{id: primarySubscriptionID}
+		m.subs[primarySubscriptionID] = ms
+	}
+	if err != nil {
+		ms.active = false
+		ms.lastErr = err
+		return
+	}
+	if ms.sub != nil {
+		ms.renewals++
+	}
+	ms.filter = filter
+	ms.sub = sub
+	ms.active = true
+	ms.subscribedAt = time.Now()
+}
+
+// Subscriptions reports every filtered subscription including the default one
+func (s *Service) Subscriptions() []*SubscriptionStatus {
+	s.subscriptions.lock.Lock()
+	defer s.subscriptions.lock.Unlock()
+
+	statuses := make([]*SubscriptionStatus, 0, len(s.subscriptions.subs))
+	for _, ms := range s.subscriptions.subs {
+		statuses = append(statuses, ms.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses
+}
+
+// AddSubscriptions instantiates a batch of filtered pending header subscriptions. Every subscription is renewed
+// independently when it fails. Subscriptions which can not be created now are retried in background.
+func (s *Service) AddSubscriptions(filters map[string]*types.PandoraPendingHeaderFilter) error {
+	s.subscriptions.lock.Lock()
+	added := make([]*managedSubscription, 0, len(filters))
+	for id := range filters {
+		if _, ok := s.subscriptions.subs[id]; ok || id == primarySubscriptionID {
+			s.subscriptions.lock.Unlock()
+			return errors.Wrap(errSubscriptionExists, id)
+		}
+	}
+	for id, filter := range filters {
+		ms := &managedSubscription{
+			id:      id,
+			filter:  filter,
+			breaker: newBreaker("pandora subscription " + id),
+			stop:    make(chan struct{}),
+		}
+		s.subscriptions.subs[id] = ms
+		added = append(added, ms)
+	}
+	s.subscriptions.lock.Unlock()
+
+	for _, ms := range added {
+		if err := s.startSubscription(ms); err != nil {
+			log.WithError(err).WithField("subscriptionId", ms.id).Warn("Could not create filtered pandora subscription")
+			s.renewSubscription(ms, err)
+		}
+	}
+	return nil
+}
+
+// RemoveSubscription stops and forgets the filtered subscription
+func (s *Service) RemoveSubscription(id string) error {
+	s.subscriptions.lock.Lock()
+	ms, ok := s.subscriptions.subs[id]
+	if !ok || id == primarySubscriptionID {
+		s.subscriptions.lock.Unlock()
+		return errors.Wrap(errSubscriptionNotFound, id)
+	}
+	delete(s.subscriptions.subs, id)
+	sub := ms.sub
+	s.subscriptions.lock.Unlock()
+
+	close(ms.stop)
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+	log.WithField("subscriptionId", id).Info("Removed filtered pandora subscription")
+	return nil
+}
+
+// startSubscription subscribes with the current rpc client and dispatches headers to the regular handler
+func (s *Service) startSubscription(ms *managedSubscription) error {
+	s.processingLock.RLock()
+	client := s.rpcClient
+	s.processingLock.RUnlock()
+	if client == nil {
+		return errNotConnected
+	}
+
+	ch := make(chan *eth1Types.Header)
+	sub, err := client.Subscribe(s.ctx, s.namespace, ch, "newPendingBlockHeaders", ms.filter)
+	if err != nil {
+		return err
+	}
+
+	s.subscriptions.lock.Lock()
+	ms.sub = sub
+	ms.active = true
+	ms.renewing = false
+	ms.lastErr = nil
+	ms.subscribedAt = time.Now()
+	s.subscriptions.lock.Unlock()
+	ms.breaker.Success()
+	log.WithField("subscriptionId", ms.id).WithField("filterCriteria", ms.filter).
+		Info("Subscribed to pandora chain with filtered subscription")
+
+	go func() {
+		for {
+			select {
+			case header := <-ch:
+				if err := s.OnNewPendingHeader(s.ctx, header); err != nil {
+					log.WithError(err).WithField("subscriptionId", ms.id).Error("Failed to process the pending pandora header")
+					sub.Unsubscribe()
+					s.renewSubscription(ms, errPandoraHeaderProcessing)
+					return
+				}
+			case err := <-sub.Err():
+				s.renewSubscription(ms, err)
+				return
+			case <-ms.stop:
+				return
+			case <-s.ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// renewSubscription re-creates only the failed subscription, starting from the latest verified header
+func (s *Service) renewSubscription(ms *managedSubscription, err error) {
+	s.subscriptions.lock.Lock()
+	if ms.renewing {
+		s.subscriptions.lock.Unlock()
+		return
+	}
+	ms.renewing = true
+	ms.active = false
+	ms.lastErr = err
+	s.subscriptions.lock.Unlock()
+	ms.breaker.Failure(err)
+
+	go func() {
+		for {
+			select {
+			case <-ms.stop:
+				return
+			default:
+			}
+			if err := ms.breaker.Wait(s.ctx); err != nil {
+				return
+			}
+			s.subscriptions.lock.Lock()
+			ms.filter = &types.PandoraPendingHeaderFilter{FromBlockHash: s.db.LatestVerifiedHeaderHash()}
+			s.subscriptions.lock.Unlock()
+
+			if err := s.startSubscription(ms); err != nil {
+				s.subscriptions.lock.Lock()
+				ms.lastErr = err
+				s.subscriptions.lock.Unlock()
+				ms.breaker.Failure(err)
+				log.WithError(err).WithField("subscriptionId", ms.id).Debug("Could not renew filtered pandora subscription")
+				continue
+			}
+			s.subscriptions.lock.Lock()
+			ms.renewals++
+			s.subscriptions.lock.Unlock()
+			log.WithField("subscriptionId", ms.id).Info("Renewed filtered pandora subscription")
+			return
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package pandorachain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// Test_PandoraSvc_AddRemoveSubscriptions checks that filtered subscriptions are tracked independently
+func Test_PandoraSvc_AddRemoveSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	inProcServer, _ := SetupInProcServer(t)
+	defer inProcServer.Stop()
+
+	panSvc := SetupPandoraSvc(ctx, t, DialInProcClient(inProcServer))
+	panSvc.rpcClient = rpc.DialInProc(inProcServer)
+
+	filter := &types.PandoraPendingHeaderFilter{FromBlockHash: common.HexToHash("0x34")}
+	require.NoError(t, panSvc.AddSubscriptions(map[string]*types.PandoraPendingHeaderFilter{
+		"shard-0": filter,
+		"shard-1": filter,
+	}))
+	assert.ErrorContains(t, errSubscriptionExists.Error(), panSvc.AddSubscriptions(
+		map[string]*types.PandoraPendingHeaderFilter{"shard-1": filter}))
+
+	statuses := panSvc.Subscriptions()
+	require.Equal(t, 2, len(statuses))
+	assert.Equal(t, "shard-0", statuses[0].ID)
+	assert.Equal(t, true, statuses[0].Active)
+	assert.Equal(t, "shard-1", statuses[1].ID)
+	assert.Equal(t, true, statuses[1].Active)
+
+	require.NoError(t, panSvc.RemoveSubscription("shard-0"))
+	assert.ErrorContains(t, errSubscriptionNotFound.Error(), panSvc.RemoveSubscription("shard-0"))
+	statuses = panSvc.Subscriptions()
+	require.Equal(t, 1, len(statuses))
+	assert.Equal(t, "shard-1", statuses[0].ID)
+}
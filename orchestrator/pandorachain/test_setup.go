@@ -2,14 +2,19 @@ package pandorachain
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"math/big"
 	"testing"
 )
 
@@ -17,6 +22,23 @@ import (
 type pandoraChainService struct {
 	unsubscribed    chan string
 	pendingHeaderCh chan *eth1Types.Header
+	blocks          map[common.Hash]map[string]interface{}
+	canonical       map[uint64]map[string]interface{}
+}
+
+// GetBlockByHash
+func (s *pandoraChainService) GetBlockByHash(hash common.Hash, fullTx bool) map[string]interface{} {
+	return s.blocks[hash]
+}
+
+// GetBlockByNumber
+func (s *pandoraChainService) GetBlockByNumber(number hexutil.Uint64, fullTx bool) map[string]interface{} {
+	return s.canonical[uint64(number)]
+}
+
+// ChainId
+func (s *pandoraChainService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1))
 }
 
 // Unsubscribe
@@ -67,7 +89,19 @@ func SetupInProcServer(t *testing.T) (*rpc.Server, *pandoraChainService) {
 	panService := &pandoraChainService{
 		unsubscribed:    make(chan string),
 		pendingHeaderCh: make(chan *eth1Types.Header),
+		blocks:          make(map[common.Hash]map[string]interface{}),
+		canonical:       make(map[uint64]map[string]interface{}),
+	}
+	// genesis header is served for the network identity check
+	genesis, err := json.Marshal(testutil.NewEth1Header(0))
+	if err != nil {
+		panic(err)
+	}
+	var genesisFields map[string]interface{}
+	if err := json.Unmarshal(genesis, &genesisFields); err != nil {
+		panic(err)
 	}
+	panService.canonical[0] = genesisFields
 	if err := server.RegisterName("eth", panService); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,197 @@
+// Package pruner periodically removes verified slots and consensus infos which are older than the configured number
+// of finalized epochs, so that the db does not grow unbounded.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/idle"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// Database is the db which is pruned
+type Database interface {
+	db.RetentionDB
+	LatestLatestFinalizedEpoch() uint64
+}
+
+type Config struct {
+	DB Database
+	// RetentionEpochs is the number of finalized epochs whose verified slots are kept. Zero keeps every slot.
+	RetentionEpochs uint64
+	// ConsensusInfoRetentionEpochs is the number of finalized epochs whose consensus infos are kept. Zero keeps every
+	// consensus info.
+	ConsensusInfoRetentionEpochs uint64
+	Interval                     time.Duration
+	// Tasks retries failed prunes across restarts. Failed prunes are only retried at the next interval when it is nil.
+	Tasks taskqueue.Queue
+	// Idle skips pruning while the chains are idle. It is optional.
+	Idle idle.Detector
+}
+
+// Service prunes verified slots and consensus infos which fall out of the retention
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	db                           Database
+	retentionEpochs              uint64
+	consensusInfoRetentionEpochs uint64
+	interval                     time.Duration
+	tasks                        taskqueue.Queue
+	idle                         idle.Detector
+	// prunedBefore is the slot which the last pruning removed slots before
+	prunedBefore uint64
+	// prunedEpochsBefore is the epoch which the last pruning removed consensus infos before
+	prunedEpochsBefore uint64
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.RetentionEpochs == 0 && cfg.ConsensusInfoRetentionEpochs == 0 {
+		return nil, errors.New("db retention must be at least one epoch")
+	}
+	if cfg.Interval <= 0 {
+		return nil, errors.New("db pruning interval must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	s := &Service{
+		ctx:                          ctx,
+		cancel:                       cancel,
+		db:                           cfg.DB,
+		retentionEpochs:              cfg.RetentionEpochs,
+		consensusInfoRetentionEpochs: cfg.ConsensusInfoRetentionEpochs,
+		interval:                     cfg.Interval,
+		tasks:                        cfg.Tasks,
+		idle:                         cfg.Idle,
+	}
+	if s.tasks != nil {
+		s.tasks.Handle(taskqueue.PruneTask, s.pruneTask)
+	}
+	return s, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start pruner service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("retentionEpochs", s.retentionEpochs).
+		WithField("consensusInfoRetentionEpochs", s.consensusInfoRetentionEpochs).WithField("interval", s.interval).
+		Info("Started pruner service")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	return nil
+}
+
+// run
+func (s *Service) run() {
+	s.prune()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// finalization does not advance while the chains are idle
+			if s.idle != nil && s.idle.Idle() {
+				continue
+			}
+			s.prune()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing pruner service")
+			return
+		}
+	}
+}
+
+// prune removes verified slots and consensus infos which fall out of their retention
+func (s *Service) prune() {
+	s.pruneVerifiedSlots()
+	s.pruneConsensusInfos()
+}
+
+// pruneVerifiedSlots removes verified slots before the first slot of the oldest retained finalized epoch
+func (s *Service) pruneVerifiedSlots() {
+	finalizedEpoch := s.db.LatestLatestFinalizedEpoch()
+	if s.retentionEpochs == 0 || finalizedEpoch <= s.retentionEpochs {
+		return
+	}
+	beforeSlot := (finalizedEpoch - s.retentionEpochs) * params.SlotsPerEpoch
+	if beforeSlot <= s.prunedBefore {
+		return
+	}
+
+	removed, err := s.db.PruneVerifiedSlots(beforeSlot)
+	if err != nil {
+		log.WithError(err).WithField("beforeSlot", beforeSlot).Error("Failed to prune verified slots")
+		s.deferPrune(beforeSlot)
+		return
+	}
+	s.prunedBefore = beforeSlot
+	log.WithField("beforeSlot", beforeSlot).WithField("pruned", removed).Debug("Pruned verified slots")
+}
+
+// pruneConsensusInfos removes consensus infos before the oldest retained finalized epoch. Failed prunes are retried
+// at the next interval.
+func (s *Service) pruneConsensusInfos() {
+	finalizedEpoch := s.db.LatestLatestFinalizedEpoch()
+	if s.consensusInfoRetentionEpochs == 0 || finalizedEpoch <= s.consensusInfoRetentionEpochs {
+		return
+	}
+	beforeEpoch := finalizedEpoch - s.consensusInfoRetentionEpochs
+	if beforeEpoch <= s.prunedEpochsBefore {
+		return
+	}
+
+	removed, err := s.db.PruneConsensusInfos(beforeEpoch)
+	if err != nil {
+		log.WithError(err).WithField("beforeEpoch", beforeEpoch).Error("Failed to prune consensus infos")
+		return
+	}
+	s.prunedEpochsBefore = beforeEpoch
+	log.WithField("beforeEpoch", beforeEpoch).WithField("pruned", removed).Debug("Pruned consensus infos")
+}
+
+// deferPrune hands the failed prune over to the task queue, so that it is retried even after a restart
+func (s *Service) deferPrune(beforeSlot uint64) {
+	if s.tasks == nil {
+		return
+	}
+	if err := s.tasks.Enqueue(taskqueue.PruneTask, 0, beforeSlot, 0); err != nil {
+		log.WithError(err).WithField("beforeSlot", beforeSlot).Error("Failed to defer pruning of verified slots")
+		return
+	}
+	s.prunedBefore = beforeSlot
+}
+
+// pruneTask removes the verified slots before the task's ToSlot
+func (s *Service) pruneTask(ctx context.Context, task *types.DeferredTask) error {
+	removed, err := s.db.PruneVerifiedSlots(task.ToSlot)
+	if err != nil {
+		return err
+	}
+	log.WithField("beforeSlot", task.ToSlot).WithField("pruned", removed).Debug("Pruned verified slots of deferred task")
+	return nil
+}
@@ -0,0 +1,113 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockDB struct {
+	finalizedEpoch     uint64
+	prunedBefore       []uint64
+	prunedEpochsBefore []uint64
+	err                error
+}
+
+func (m *mockDB) PruneConsensusInfos(beforeEpoch uint64) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.prunedEpochsBefore = append(m.prunedEpochsBefore, beforeEpoch)
+	return 0, nil
+}
+
+func (m *mockDB) PruneVerifiedSlots(beforeSlot uint64) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	m.prunedBefore = append(m.prunedBefore, beforeSlot)
+	return 0, nil
+}
+
+type mockQueue struct {
+	handlers map[string]taskqueue.Handler
+	tasks    []*types.DeferredTask
+}
+
+func (m *mockQueue) Handle(kind string, handler taskqueue.Handler) {
+	m.handlers[kind] = handler
+}
+
+func (m *mockQueue) Enqueue(kind string, fromSlot, toSlot uint64, delay time.Duration) error {
+	m.tasks = append(m.tasks, &types.DeferredTask{Kind: kind, FromSlot: fromSlot, ToSlot: toSlot})
+	return nil
+}
+
+func (m *mockDB) LatestLatestFinalizedEpoch() uint64 {
+	return m.finalizedEpoch
+}
+
+func TestService_Prune(t *testing.T) {
+	db := &mockDB{finalizedEpoch: 2}
+	s, err := NewService(context.Background(), &Config{DB: db, RetentionEpochs: 2, Interval: time.Minute})
+	require.NoError(t, err)
+
+	// nothing is pruned until finalized epochs exceed the retention
+	s.prune()
+	assert.Equal(t, 0, len(db.prunedBefore))
+
+	db.finalizedEpoch = 5
+	s.prune()
+	// pruning is skipped until finalized epoch advances
+	s.prune()
+	db.finalizedEpoch = 6
+	s.prune()
+	assert.DeepEqual(t, []uint64{96, 128}, db.prunedBefore)
+	assert.Equal(t, 0, len(db.prunedEpochsBefore))
+}
+
+func TestService_PruneConsensusInfos(t *testing.T) {
+	db := &mockDB{finalizedEpoch: 3}
+	s, err := NewService(context.Background(), &Config{DB: db, ConsensusInfoRetentionEpochs: 3, Interval: time.Minute})
+	require.NoError(t, err)
+
+	s.prune()
+	db.finalizedEpoch = 10
+	s.prune()
+	s.prune()
+	assert.DeepEqual(t, []uint64{7}, db.prunedEpochsBefore)
+	// verified slots are kept without their retention
+	assert.Equal(t, 0, len(db.prunedBefore))
+
+	_, err = NewService(context.Background(), &Config{DB: db, Interval: time.Minute})
+	assert.ErrorContains(t, "db retention must be at least one epoch", err)
+}
+
+func TestService_DeferFailedPrune(t *testing.T) {
+	db := &mockDB{finalizedEpoch: 5, err: errors.New("db is busy")}
+	queue := &mockQueue{handlers: make(map[string]taskqueue.Handler)}
+	s, err := NewService(context.Background(), &Config{DB: db, RetentionEpochs: 2, Interval: time.Minute, Tasks: queue})
+	require.NoError(t, err)
+
+	// failed prune is queued once and not retried by the interval
+	s.prune()
+	s.prune()
+	require.Equal(t, 1, len(queue.tasks))
+	assert.Equal(t, taskqueue.PruneTask, queue.tasks[0].Kind)
+	assert.Equal(t, uint64(96), queue.tasks[0].ToSlot)
+
+	db.err = nil
+	require.NoError(t, queue.handlers[taskqueue.PruneTask](context.Background(), queue.tasks[0]))
+	assert.DeepEqual(t, []uint64{96}, db.prunedBefore)
+}
+
+func TestNewService_InvalidConfig(t *testing.T) {
+	_, err := NewService(context.Background(), &Config{DB: &mockDB{}, Interval: time.Minute})
+	assert.ErrorContains(t, "at least one epoch", err)
+}
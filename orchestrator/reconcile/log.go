@@ -0,0 +1,5 @@
+package reconcile
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "reconcile")
@@ -0,0 +1,14 @@
+package reconcile
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// sampledSlotsCounter is the number of slots which are compared with pandora
+	sampledSlotsCounter = metrics.NewRegisteredCounter("orc_reconcile_sampled_slots_total", nil)
+	// missingBlocksCounter is the number of verified slots whose block pandora does not have
+	missingBlocksCounter = metrics.NewRegisteredCounter("orc_reconcile_missing_blocks_total", nil)
+	// nonCanonicalBlocksCounter is the number of verified slots whose block is not canonical in pandora
+	nonCanonicalBlocksCounter = metrics.NewRegisteredCounter("orc_reconcile_noncanonical_blocks_total", nil)
+	// canonicalInvalidBlocksCounter is the number of invalid slots whose block is canonical in pandora
+	canonicalInvalidBlocksCounter = metrics.NewRegisteredCounter("orc_reconcile_canonical_invalid_blocks_total", nil)
+)
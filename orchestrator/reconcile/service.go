@@ -0,0 +1,248 @@
+// Package reconcile periodically compares the confirmations of recent slots with the view of pandora node, so that
+// undelivered confirmations are reported before they surface as a stalled chain.
+package reconcile
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/idle"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sampleWindow is the number of recent slots which are sampled from
+	sampleWindow = 4 * params.SlotsPerEpoch
+	// settleSlots is the number of the newest slots which are skipped, since pandora may not have applied their
+	// confirmations yet
+	settleSlots = 2
+)
+
+// Discrepancy kinds
+const (
+	// MissingBlock means a slot is verified but pandora does not have its block
+	MissingBlock = "missing"
+	// NonCanonicalBlock means a slot is verified but its block is not on the canonical chain of pandora
+	NonCanonicalBlock = "non-canonical"
+	// CanonicalInvalidBlock means a slot is invalid but its block is on the canonical chain of pandora
+	CanonicalInvalidBlock = "canonical-invalid"
+)
+
+// PandoraChain is the view of pandora node
+type PandoraChain interface {
+	CanonicalStatus(ctx context.Context, hash common.Hash) (bool, bool, error)
+}
+
+type Config struct {
+	VerifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
+	InvalidSlotInfoDB  db.ROnlyInvalidSlotInfoDB
+	PandoraChain       PandoraChain
+	Interval           time.Duration
+	// SampleSize is the number of slots which are compared in every run
+	SampleSize int
+	// Idle skips reconciliation while the chains are idle. It is optional.
+	Idle idle.Detector
+}
+
+// Discrepancy is a slot whose confirmation does not match the view of pandora
+type Discrepancy struct {
+	Slot   uint64
+	Hash   common.Hash
+	Status types.Status
+	Kind   string
+}
+
+// Service samples recent slots and compares their confirmations with pandora
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	verifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
+	invalidSlotInfoDB  db.ROnlyInvalidSlotInfoDB
+	pandoraChain       PandoraChain
+	interval           time.Duration
+	sampleSize         int
+	idle               idle.Detector
+	rand               *rand.Rand
+
+	lock     sync.Mutex
+	runError error
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("reconciliation interval must be positive")
+	}
+	if cfg.SampleSize <= 0 {
+		return nil, errors.New("reconciliation sample size must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:                ctx,
+		cancel:             cancel,
+		verifiedSlotInfoDB: cfg.VerifiedSlotInfoDB,
+		invalidSlotInfoDB:  cfg.InvalidSlotInfoDB,
+		pandoraChain:       cfg.PandoraChain,
+		interval:           cfg.Interval,
+		sampleSize:         cfg.SampleSize,
+		idle:               cfg.Idle,
+		rand:               rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start reconciliation service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("interval", s.interval).WithField("sampleSize", s.sampleSize).
+		Info("Started reconciliation of slot statuses against pandora")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status returns error when the last reconciliation found discrepancies
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.runError
+}
+
+// run
+func (s *Service) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// the sampled slots do not change while the chains are idle
+			if s.idle != nil && s.idle.Idle() {
+				continue
+			}
+			s.reconcile()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing reconciliation service")
+			return
+		}
+	}
+}
+
+// reconcile compares the sampled slots with pandora and reports discrepancies
+func (s *Service) reconcile() {
+	discrepancies, err := s.compare(s.sample())
+	if err != nil {
+		log.WithError(err).Warn("Failed to reconcile slot statuses against pandora")
+		return
+	}
+
+	for _, d := range discrepancies {
+		switch d.Kind {
+		case MissingBlock:
+			missingBlocksCounter.Inc(1)
+		case NonCanonicalBlock:
+			nonCanonicalBlocksCounter.Inc(1)
+		case CanonicalInvalidBlock:
+			canonicalInvalidBlocksCounter.Inc(1)
+		}
+		log.WithField("slot", d.Slot).WithField("hash", d.Hash).WithField("status", d.Status).
+			WithField("kind", d.Kind).Warn("Slot status does not match pandora")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.runError = nil
+	if len(discrepancies) > 0 {
+		s.runError = errors.Errorf("%d sampled slots do not match pandora", len(discrepancies))
+	}
+}
+
+// sample returns distinct random slots of the recent window in ascending order
+func (s *Service) sample() []uint64 {
+	latestSlot := s.verifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	if latestSlot <= settleSlots {
+		return nil
+	}
+	toSlot := latestSlot - settleSlots
+	var fromSlot uint64
+	if toSlot > sampleWindow {
+		fromSlot = toSlot - sampleWindow
+	}
+
+	span := int(toSlot - fromSlot + 1)
+	size := s.sampleSize
+	if size > span {
+		size = span
+	}
+	slots := make([]uint64, 0, size)
+	for _, offset := range s.rand.Perm(span)[:size] {
+		slots = append(slots, fromSlot+uint64(offset))
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+	return slots
+}
+
+// compare returns the discrepancies of the given slots. Slots which are neither verified nor invalid are skipped.
+func (s *Service) compare(slots []uint64) ([]*Discrepancy, error) {
+	discrepancies := make([]*Discrepancy, 0)
+	for _, slot := range slots {
+		status := types.Verified
+		slotInfo, err := s.verifiedSlotInfoDB.VerifiedSlotInfo(slot)
+		if err != nil {
+			return nil, err
+		}
+		if slotInfo == nil {
+			status = types.Invalid
+			if slotInfo, err = s.invalidSlotInfoDB.InvalidSlotInfo(slot); err != nil {
+				return nil, err
+			}
+		}
+		if slotInfo == nil {
+			continue
+		}
+
+		known, canonical, err := s.pandoraChain.CanonicalStatus(s.ctx, slotInfo.PandoraHeaderHash)
+		if err != nil {
+			return nil, err
+		}
+		sampledSlotsCounter.Inc(1)
+
+		kind := ""
+		switch {
+		case status == types.Verified && !known:
+			kind = MissingBlock
+		case status == types.Verified && !canonical:
+			kind = NonCanonicalBlock
+		case status == types.Invalid && canonical:
+			kind = CanonicalInvalidBlock
+		}
+		if kind != "" {
+			discrepancies = append(discrepancies, &Discrepancy{
+				Slot:   slot,
+				Hash:   slotInfo.PandoraHeaderHash,
+				Status: status,
+				Kind:   kind,
+			})
+		}
+	}
+	return discrepancies, nil
+}
@@ -0,0 +1,65 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockPandoraChain struct {
+	known     map[common.Hash]bool
+	canonical map[common.Hash]bool
+}
+
+func (m *mockPandoraChain) CanonicalStatus(ctx context.Context, hash common.Hash) (bool, bool, error) {
+	return m.known[hash], m.canonical[hash], nil
+}
+
+func TestService_Compare(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	pandora := &mockPandoraChain{known: make(map[common.Hash]bool), canonical: make(map[common.Hash]bool)}
+
+	hash := func(slot uint64) common.Hash {
+		return common.BytesToHash([]byte{byte(slot)})
+	}
+	for slot := uint64(1); slot <= 4; slot++ {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{PandoraHeaderHash: hash(slot)}))
+	}
+	require.NoError(t, db.SaveInvalidSlotInfo(5, &types.SlotInfo{PandoraHeaderHash: hash(5)}))
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 4))
+
+	// slot 1 matches, slot 2 is missing, slot 3 is not canonical and invalid slot 5 is canonical
+	pandora.known[hash(1)], pandora.canonical[hash(1)] = true, true
+	pandora.known[hash(3)] = true
+	pandora.known[hash(4)], pandora.canonical[hash(4)] = true, true
+	pandora.known[hash(5)], pandora.canonical[hash(5)] = true, true
+
+	s, err := NewService(ctx, &Config{
+		VerifiedSlotInfoDB: db,
+		InvalidSlotInfoDB:  db,
+		PandoraChain:       pandora,
+		Interval:           time.Minute,
+		SampleSize:         8,
+	})
+	require.NoError(t, err)
+
+	discrepancies, err := s.compare([]uint64{1, 2, 3, 4, 5, 6})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(discrepancies))
+	assert.DeepEqual(t, &Discrepancy{Slot: 2, Hash: hash(2), Status: types.Verified, Kind: MissingBlock}, discrepancies[0])
+	assert.DeepEqual(t, &Discrepancy{Slot: 3, Hash: hash(3), Status: types.Verified, Kind: NonCanonicalBlock}, discrepancies[1])
+	assert.DeepEqual(t, &Discrepancy{Slot: 5, Hash: hash(5), Status: types.Invalid, Kind: CanonicalInvalidBlock}, discrepancies[2])
+
+	// the newest slots are not sampled
+	assert.DeepEqual(t, []uint64{0, 1, 2}, s.sample())
+
+	s.reconcile()
+	assert.ErrorContains(t, "do not match pandora", s.Status())
+}
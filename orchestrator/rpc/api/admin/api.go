@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// maxRepublishRange is the highest number of slots which are republished by a single request
+const maxRepublishRange = 1024
+
+var (
+	errEndpointNotSupported = errors.New("endpoint switching is not supported")
+	errProbingDisabled      = errors.New("endpoint probing is disabled")
+	errRepublishDisabled    = errors.New("confirmation republishing is not supported")
+)
+
+// EndpointSwitcher is implemented by chain services which can be moved to a different node at runtime
+type EndpointSwitcher interface {
+	SetEndpoint(endpoint string) error
+}
+
+// EndpointScorer reports the latest probe results of the configured chain endpoints
+type EndpointScorer interface {
+	Scores() []*types.EndpointScore
+}
+
+// ConfirmationRepublisher sends the confirmations of verified slots to the subscribers again
+type ConfirmationRepublisher interface {
+	RepublishConfirmation(slot uint64) (*types.SlotInfoWithStatus, error)
+	RepublishConfirmations(fromSlot, toSlot uint64) ([]*types.SlotInfoWithStatus, error)
+}
+
+// PrivateAdminAPI is the collection of administrative API methods exposed only over a secure RPC channel.
+type PrivateAdminAPI struct {
+	pandoraService  EndpointSwitcher
+	vanguardService EndpointSwitcher
+	endpointScorer  EndpointScorer
+	republisher     ConfirmationRepublisher
+}
+
+// NewPrivateAdminAPI creates a new API definition for the private admin methods of the orchestrator.
+func NewPrivateAdminAPI(
+	pandoraService, vanguardService EndpointSwitcher,
+	endpointScorer EndpointScorer,
+	republisher ConfirmationRepublisher,
+) *PrivateAdminAPI {
+	return &PrivateAdminAPI{
+		pandoraService:  pandoraService,
+		vanguardService: vanguardService,
+		endpointScorer:  endpointScorer,
+		republisher:     republisher,
+	}
+}
+
+// SetPandoraEndpoint moves pandora subscription to the given node without restarting orchestrator
+func (api *PrivateAdminAPI) SetPandoraEndpoint(ctx context.Context, url string) (bool, error) {
+	if api.pandoraService == nil {
+		return false, errEndpointNotSupported
+	}
+	if err := api.pandoraService.SetEndpoint(url); err != nil {
+		log.WithError(err).WithField("endpoint", url).Error("Failed to switch pandora endpoint")
+		return false, err
+	}
+	return true, nil
+}
+
+// SetVanguardEndpoint moves vanguard subscriptions to the given node without restarting orchestrator
+func (api *PrivateAdminAPI) SetVanguardEndpoint(ctx context.Context, addr string) (bool, error) {
+	if api.vanguardService == nil {
+		return false, errEndpointNotSupported
+	}
+	if err := api.vanguardService.SetEndpoint(addr); err != nil {
+		log.WithError(err).WithField("endpoint", addr).Error("Failed to switch vanguard endpoint")
+		return false, err
+	}
+	return true, nil
+}
+
+// EndpointScores returns latency, head lag and score of every probed chain endpoint, so that a degraded
+// upstream can be spotted
+func (api *PrivateAdminAPI) EndpointScores(ctx context.Context) ([]*types.EndpointScore, error) {
+	if api.endpointScorer == nil {
+		return nil, errProbingDisabled
+	}
+	return api.endpointScorer.Scores(), nil
+}
+
+// RepublishConfirmation sends the confirmation of the verified slot to the subscribers again, so that a pandora node
+// which missed the original event is not stuck waiting for it
+func (api *PrivateAdminAPI) RepublishConfirmation(ctx context.Context, slot uint64) (*types.SlotInfoWithStatus, error) {
+	if api.republisher == nil {
+		return nil, errRepublishDisabled
+	}
+	return api.republisher.RepublishConfirmation(slot)
+}
+
+// RepublishConfirmations sends the confirmations of the verified slots of [fromSlot, toSlot] to the subscribers
+// again. Slots which are not verified are skipped.
+func (api *PrivateAdminAPI) RepublishConfirmations(
+	ctx context.Context,
+	fromSlot uint64,
+	toSlot uint64,
+) ([]*types.SlotInfoWithStatus, error) {
+	if api.republisher == nil {
+		return nil, errRepublishDisabled
+	}
+	if fromSlot > toSlot {
+		return nil, errors.Errorf("invalid slot range [%d, %d]", fromSlot, toSlot)
+	}
+	if toSlot-fromSlot >= maxRepublishRange {
+		return nil, errors.Errorf("slot range exceeds %d slots", maxRepublishRange)
+	}
+	return api.republisher.RepublishConfirmations(fromSlot, toSlot)
+}
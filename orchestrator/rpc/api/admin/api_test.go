@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockSwitcher struct {
+	endpoint string
+	err      error
+}
+
+func (m *mockSwitcher) SetEndpoint(endpoint string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.endpoint = endpoint
+	return nil
+}
+
+func TestPrivateAdminAPI_SetEndpoints(t *testing.T) {
+	pandora := &mockSwitcher{}
+	vanguard := &mockSwitcher{}
+	api := NewPrivateAdminAPI(pandora, vanguard, nil, nil)
+
+	ok, err := api.SetPandoraEndpoint(context.Background(), "ws://127.0.0.1:8546")
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "ws://127.0.0.1:8546", pandora.endpoint)
+
+	ok, err = api.SetVanguardEndpoint(context.Background(), "127.0.0.1:4001")
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "127.0.0.1:4001", vanguard.endpoint)
+}
+
+func TestPrivateAdminAPI_SetEndpoint_Failure(t *testing.T) {
+	pandora := &mockSwitcher{err: errors.New("chain id mismatch")}
+	api := NewPrivateAdminAPI(pandora, nil, nil, nil)
+
+	ok, err := api.SetPandoraEndpoint(context.Background(), "ws://127.0.0.1:8546")
+	assert.ErrorContains(t, "chain id mismatch", err)
+	assert.Equal(t, false, ok)
+
+	_, err = api.SetVanguardEndpoint(context.Background(), "127.0.0.1:4001")
+	assert.ErrorContains(t, errEndpointNotSupported.Error(), err)
+}
+
+type mockScorer struct {
+	scores []*types.EndpointScore
+}
+
+func (m *mockScorer) Scores() []*types.EndpointScore {
+	return m.scores
+}
+
+func TestPrivateAdminAPI_EndpointScores(t *testing.T) {
+	api := NewPrivateAdminAPI(nil, nil, nil, nil)
+	_, err := api.EndpointScores(context.Background())
+	assert.ErrorContains(t, errProbingDisabled.Error(), err)
+
+	scores := []*types.EndpointScore{{Chain: "pandora", Endpoint: "ws://127.0.0.1:8546", Selected: true, Healthy: true}}
+	api = NewPrivateAdminAPI(nil, nil, &mockScorer{scores: scores}, nil)
+	retrieved, err := api.EndpointScores(context.Background())
+	require.NoError(t, err)
+	assert.DeepEqual(t, scores, retrieved)
+}
+
+type mockRepublisher struct {
+	ranges [][2]uint64
+}
+
+func (m *mockRepublisher) RepublishConfirmation(slot uint64) (*types.SlotInfoWithStatus, error) {
+	if slot > 10 {
+		return nil, errors.New("slot is not verified")
+	}
+	return &types.SlotInfoWithStatus{Slot: slot, Status: types.Verified}, nil
+}
+
+func (m *mockRepublisher) RepublishConfirmations(fromSlot, toSlot uint64) ([]*types.SlotInfoWithStatus, error) {
+	m.ranges = append(m.ranges, [2]uint64{fromSlot, toSlot})
+	return nil, nil
+}
+
+func TestPrivateAdminAPI_RepublishConfirmation(t *testing.T) {
+	ctx := context.Background()
+	api := NewPrivateAdminAPI(nil, nil, nil, nil)
+	_, err := api.RepublishConfirmation(ctx, 1)
+	assert.ErrorContains(t, errRepublishDisabled.Error(), err)
+
+	republisher := &mockRepublisher{}
+	api = NewPrivateAdminAPI(nil, nil, nil, republisher)
+	status, err := api.RepublishConfirmation(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), status.Slot)
+	_, err = api.RepublishConfirmation(ctx, 11)
+	assert.ErrorContains(t, "slot is not verified", err)
+
+	_, err = api.RepublishConfirmations(ctx, 10, 5)
+	assert.ErrorContains(t, "invalid slot range", err)
+	_, err = api.RepublishConfirmations(ctx, 0, maxRepublishRange)
+	assert.ErrorContains(t, "slot range exceeds", err)
+	_, err = api.RepublishConfirmations(ctx, 0, maxRepublishRange-1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][2]uint64{{0, maxRepublishRange - 1}}, republisher.ranges)
+}
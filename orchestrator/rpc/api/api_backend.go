@@ -2,7 +2,14 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	log "github.com/sirupsen/logrus"
@@ -11,25 +18,124 @@ import (
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/accumulator"
+	"github.com/lukso-network/lukso-orchestrator/shared/identity"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 )
 
-var ErrHeaderHashMisMatch = errors.New("header hash mismatched")
+// confirmationRetryDelay gives pandora time to resubscribe before undelivered confirmations are republished
+const confirmationRetryDelay = 30 * time.Second
+
+// maxShardDisagreements is the maximum number of disagreements returned in one query
+const maxShardDisagreements = 256
+
+// MaxReorgHistoryPage is the maximum number of reorgs returned in one reorg history query
+const MaxReorgHistoryPage = 256
+
+// MaxArchiveRange is the maximum number of slots returned in one archive range query
+const MaxArchiveRange = 4096
+
+// MaxEpochInfoPage is the maximum number of epoch infos returned in one query
+const MaxEpochInfoPage = 1024
+
+// MaxVerifiedSlotPage is the maximum number of slots scanned in one verified slot query
+const MaxVerifiedSlotPage = 4096
+
+// MaxVerifyHeaders is the maximum number of headers checked in one header verification request
+const MaxVerifyHeaders = 256
+
+var (
+	ErrHeaderHashMisMatch      = errors.New("header hash mismatched")
+	ErrConfirmationAckDisabled = errors.New("confirmation acknowledgement is not enabled")
+	ErrUnknownConsumer         = errors.New("unknown confirmation consumer")
+	ErrIdentityDisabled        = errors.New("orchestrator identity is not configured")
+	ErrArchiveDisabled         = errors.New("orchestrator is not running in archive mode")
+	ErrPayloadUnavailable      = errors.New("pandora block retrieval is not configured")
+	ErrLifetimeStatsDisabled   = errors.New("lifetime stats are not enabled")
+	ErrHeaderVerifyDisabled    = errors.New("header verification is not enabled")
+	ErrJournalDisabled         = errors.New("confirmation journal is not enabled")
+	ErrEpochInfoPruned         = db.ErrEpochInfoPruned
+)
+
+// PayloadFetcher fetches full pandora blocks from the execution node
+type PayloadFetcher interface {
+	BlockByHash(ctx context.Context, hash common.Hash) (json.RawMessage, error)
+}
+
+// ConfirmationJournal serves the confirmations which were published to a consumer
+type ConfirmationJournal interface {
+	Backlog(consumer string, fromSlot uint64) ([]*types.JournaledConfirmation, error)
+}
 
 type Backend struct {
 	// feed
 	ConsensusInfoFeed    iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	EpochSummaryFeed     conIface.EpochSummaryFeed
+	ShardConflictFeed    conIface.ShardConflictFeed
+
+	// LifetimeStats reports the counters of all runs of the node
+	LifetimeStats conIface.LifetimeStatsProvider
+
+	// HeaderVerifier checks pandora headers of external callers without storing them
+	HeaderVerifier conIface.HeaderVerifier
 
 	// db reference
 	ConsensusInfoDB    db.ROnlyConsensusInfoDB
 	VerifiedSlotInfoDB db.ROnlyVerifiedSlotInfoDB
 	InvalidSlotInfoDB  db.ROnlyInvalidSlotInfoDB
+	ConfirmationAckDB  db.ConfirmationAckDB
+	AccumulatorDB      db.ROnlyAccumulatorDB
+	EpochSummaryDB     db.ROnlyEpochSummaryDB
+	ReorgHistoryDB     db.ROnlyReorgHistoryDB
 
 	// cache reference
 	VanguardPendingShardingCache cache.VanguardShardCache
 	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+
+	// Identity signs exported proofs, nil when orchestrator has no identity key
+	Identity identity.Signer
+
+	// PayloadFetcher attaches pandora block bodies to verified slots on request
+	PayloadFetcher PayloadFetcher
+
+	// Tasks republishes the confirmations which could not be delivered to pandora, nil disables it
+	Tasks taskqueue.Queue
+
+	// Journal keeps the published confirmations per consumer, nil disables it
+	Journal ConfirmationJournal
+
+	// confirmation acknowledgement
+	ConfirmationAckEnabled bool
+	// ConfirmationConsumers are the named pandora nodes whose acknowledgements are tracked independently.
+	// Consumer which does not pass its name uses the default high-water mark.
+	ConfirmationConsumers []string
+	ackLock               sync.Mutex
+
+	// startupReport is set once the node has started all services
+	startupLock   sync.RWMutex
+	startupReport *types.StartupReport
+
+	// proofTree caches the accumulator nodes so proofs are not rebuilt from all leaves on every request
+	proofLock sync.Mutex
+	proofTree *accumulator.Tree
+}
+
+// SetStartupReport sets the report of the node setup which is served by the status API
+func (backend *Backend) SetStartupReport(report *types.StartupReport) {
+	backend.startupLock.Lock()
+	defer backend.startupLock.Unlock()
+	backend.startupReport = report
+}
+
+// StartupReport returns the report of the node setup. Returns nil until the node has started.
+func (backend *Backend) StartupReport() *types.StartupReport {
+	backend.startupLock.RLock()
+	defer backend.startupLock.RUnlock()
+	return backend.startupReport
 }
 
 func (backend *Backend) SubscribeNewEpochEvent(ch chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription {
@@ -40,8 +146,25 @@ func (backend *Backend) SubscribeNewVerifiedSlotInfoEvent(ch chan<- *types.SlotI
 	return backend.VerifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(ch)
 }
 
-func (backend *Backend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*types.MinimalEpochConsensusInfoV2, error) {
-	consensusInfosV2, err := backend.ConsensusInfoDB.ConsensusInfos(fromEpoch)
+func (backend *Backend) SubscribeEpochSummaryEvent(ch chan<- *types.EpochSummary) event.Subscription {
+	return backend.EpochSummaryFeed.SubscribeEpochSummaryEvent(ch)
+}
+
+func (backend *Backend) SubscribeShardConflictEvent(ch chan<- *types.ShardConflict) event.Subscription {
+	return backend.ShardConflictFeed.SubscribeShardConflictEvent(ch)
+}
+
+// ConsensusInfoByEpochRange returns at most limit consecutive epoch infos from the given epoch. Limit is capped at
+// MaxEpochInfoPage, so a request from epoch zero never loads every epoch at once. Requests which start before the
+// earliest epoch fail with ErrEpochInfoPruned, so that clients restart from the available history.
+func (backend *Backend) ConsensusInfoByEpochRange(fromEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error) {
+	if err := backend.CheckPrunedEpoch(fromEpoch); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > MaxEpochInfoPage {
+		limit = MaxEpochInfoPage
+	}
+	consensusInfosV2, err := backend.ConsensusInfoDB.ConsensusInfoRange(fromEpoch, lastOfWindow(fromEpoch, limit))
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +177,422 @@ func (backend *Backend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*types.Mi
 	return epochInfos, nil
 }
 
-func (backend *Backend) VerifiedSlotInfos(fromSlot uint64) map[uint64]*types.SlotInfo {
-	slotInfos, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfos(fromSlot)
+// EpochInfoPage returns a page of epoch infos from the given epoch with the first epoch of the next page
+func (backend *Backend) EpochInfoPage(fromEpoch uint64, limit int) (*types.EpochInfoPage, error) {
+	epochInfos, err := backend.ConsensusInfoByEpochRange(fromEpoch, limit)
+	if err != nil {
+		return nil, err
+	}
+	page := &types.EpochInfoPage{EpochInfos: epochInfos}
+	if len(epochInfos) > 0 {
+		if last := epochInfos[len(epochInfos)-1].Epoch; last < backend.LatestEpoch() {
+			next := last + 1
+			page.Next = &next
+		}
+	}
+	return page, nil
+}
+
+// EarliestEpoch returns the first epoch whose epoch info is not pruned
+func (backend *Backend) EarliestEpoch() uint64 {
+	return backend.ConsensusInfoDB.EarliestConsensusInfoEpoch()
+}
+
+// CheckPrunedEpoch returns ErrEpochInfoPruned with the earliest epoch when the epoch info is pruned
+func (backend *Backend) CheckPrunedEpoch(epoch uint64) error {
+	if earliestEpoch := backend.EarliestEpoch(); epoch < earliestEpoch {
+		return fmt.Errorf("%w: epoch %d is before the earliest epoch %d", ErrEpochInfoPruned, epoch, earliestEpoch)
+	}
+	return nil
+}
+
+// lastOfWindow returns the last item of the window of size items from the first one
+func lastOfWindow(first uint64, size int) uint64 {
+	last := first + uint64(size) - 1
+	if last < first {
+		return math.MaxUint64
+	}
+	return last
+}
+
+// EpochInfo returns stored epoch info with the vanguard block from which the proposer list is derived
+func (backend *Backend) EpochInfo(ctx context.Context, epoch uint64) (*types.EpochInfoWithSource, error) {
+	if err := backend.CheckPrunedEpoch(epoch); err != nil {
+		return nil, err
+	}
+	epochInfo, err := backend.ConsensusInfoDB.ConsensusInfo(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if epochInfo == nil {
+		return nil, fmt.Errorf("epoch info not found for epoch %d", epoch)
+	}
+	source, err := backend.ConsensusInfoDB.ConsensusInfoSource(epoch)
+	if err != nil {
+		return nil, err
+	}
+	return &types.EpochInfoWithSource{
+		MinimalEpochConsensusInfo: epochInfo,
+		Source:                    source,
+	}, nil
+}
+
+// ProposerForSlot returns the public key of the expected proposer of the slot from the stored epoch info
+func (backend *Backend) ProposerForSlot(ctx context.Context, slot uint64) (*types.SlotProposer, error) {
+	epoch := slot / params.SlotsPerEpoch
+	epochInfo, err := backend.ConsensusInfoDB.ConsensusInfo(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+	if epochInfo == nil {
+		return nil, fmt.Errorf("epoch info not found for epoch %d", epoch)
+	}
+	return slotProposer(slot, epochInfo.Epoch, epochInfo.ValidatorList)
+}
+
+// slotProposer picks the proposer of the slot from the proposer list of its epoch
+func slotProposer(slot uint64, epoch uint64, validatorList []string) (*types.SlotProposer, error) {
+	index := slot % params.SlotsPerEpoch
+	if index >= uint64(len(validatorList)) {
+		return nil, fmt.Errorf("proposer list of epoch %d has no proposer for slot %d", epoch, slot)
+	}
+	return &types.SlotProposer{
+		Slot:      slot,
+		Epoch:     epoch,
+		PublicKey: validatorList[index],
+	}, nil
+}
+
+// EpochSummary returns the stored summary of the given epoch
+func (backend *Backend) EpochSummary(epoch uint64) (*types.EpochSummary, error) {
+	summary, err := backend.EpochSummaryDB.EpochSummary(epoch)
+	if err != nil {
+		return nil, err
+	}
+	if summary == nil {
+		return nil, fmt.Errorf("epoch summary not found for epoch %d", epoch)
+	}
+	return summary, nil
+}
+
+// ShardDisagreements returns stored sharding info disagreements starting from the given slot
+func (backend *Backend) ShardDisagreements(fromSlot uint64, limit int) ([]*types.ShardDisagreement, error) {
+	if limit <= 0 || limit > maxShardDisagreements {
+		limit = maxShardDisagreements
+	}
+	return backend.InvalidSlotInfoDB.ShardDisagreements(fromSlot, limit)
+}
+
+// ReorgHistory returns a page of at most limit stored reorgs starting from the reorg of the given slot and id
+func (backend *Backend) ReorgHistory(fromSlot, fromId uint64, limit int) (*types.ReorgHistoryPage, error) {
+	if limit <= 0 || limit > MaxReorgHistoryPage {
+		limit = MaxReorgHistoryPage
+	}
+	// reading one more reorg tells where the next page starts
+	records, err := backend.ReorgHistoryDB.ReorgHistoryFrom(fromSlot, fromId, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &types.ReorgHistoryPage{Reorgs: records}
+	if len(records) > limit {
+		next := records[limit]
+		page.Reorgs = records[:limit]
+		page.Next = &types.ReorgCursor{Slot: next.Slot, Id: next.Id}
+	}
+	return page, nil
+}
+
+// ShardEquivocations returns stored evidences of proposers which signed conflicting shard infos
+func (backend *Backend) ShardEquivocations(fromSlot uint64, limit int) ([]*types.ShardEquivocation, error) {
+	if limit <= 0 || limit > maxShardDisagreements {
+		limit = maxShardDisagreements
+	}
+	return backend.InvalidSlotInfoDB.ShardEquivocations(fromSlot, limit)
+}
+
+// ShardConflicts returns stored conflicts of verified slots which received a different pandora header
+func (backend *Backend) ShardConflicts(fromSlot uint64, limit int) ([]*types.ShardConflict, error) {
+	if limit <= 0 || limit > maxShardDisagreements {
+		limit = maxShardDisagreements
+	}
+	return backend.InvalidSlotInfoDB.ShardConflicts(fromSlot, limit)
+}
+
+// AccumulatorStep returns the verified-chain accumulator leaf and root right after the given slot was appended
+func (backend *Backend) AccumulatorStep(slot uint64) (*types.AccumulatorStep, error) {
+	step, err := backend.AccumulatorDB.AccumulatorStep(slot)
+	if err != nil {
+		return nil, err
+	}
+	if step == nil {
+		return nil, fmt.Errorf("accumulator step not found for slot %d", slot)
+	}
+	return step, nil
+}
+
+// AccumulatorProof returns merkle inclusion proof of the given slot against the latest verified-chain accumulator root
+func (backend *Backend) AccumulatorProof(slot uint64) (*types.AccumulatorProof, error) {
+	step, err := backend.AccumulatorStep(slot)
+	if err != nil {
+		return nil, err
+	}
+	latestStep, err := backend.AccumulatorDB.LatestAccumulatorStep()
+	if err != nil {
+		return nil, err
+	}
+	if latestStep == nil || latestStep.LeafIndex < step.LeafIndex {
+		return nil, fmt.Errorf("accumulator is behind of slot %d", slot)
+	}
+	proof, err := backend.accumulatorProof(latestStep, step.LeafIndex)
+	if err != nil {
+		return nil, err
+	}
+	return &types.AccumulatorProof{
+		AccumulatorStep: *step,
+		AccumulatorRoot: latestStep.Root,
+		AccumulatorSize: latestStep.LeafIndex + 1,
+		Proof:           proof,
+	}, nil
+}
+
+// accumulatorProof serves the proof from the cached tree. The tree is extended with the leaves which were appended
+// since the last request and rebuilt only when the accumulator was reverted underneath it.
+func (backend *Backend) accumulatorProof(latestStep *types.AccumulatorStep, leafIndex uint64) ([]common.Hash, error) {
+	backend.proofLock.Lock()
+	defer backend.proofLock.Unlock()
+
+	size := latestStep.LeafIndex + 1
+	tree := backend.proofTree
+	if tree != nil && tree.Size() <= size {
+		leaves, err := backend.AccumulatorDB.AccumulatorLeafRange(tree.Size(), size-tree.Size())
+		if err != nil {
+			return nil, err
+		}
+		for _, leaf := range leaves {
+			if err := tree.Append(leaf); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if tree == nil || tree.Size() != size || tree.Root() != latestStep.Root {
+		leaves, err := backend.AccumulatorDB.AccumulatorLeaves(size)
+		if err != nil {
+			return nil, err
+		}
+		if tree, err = accumulator.NewTree(leaves); err != nil {
+			return nil, err
+		}
+		if tree.Root() != latestStep.Root {
+			backend.proofTree = nil
+			return nil, fmt.Errorf("accumulator leaves do not match the root of step %d", latestStep.LeafIndex)
+		}
+	}
+	backend.proofTree = tree
+	return tree.Proof(leafIndex)
+}
+
+// SignedAccumulatorProof returns the inclusion proof of the slot signed by the orchestrator identity
+func (backend *Backend) SignedAccumulatorProof(ctx context.Context, slot uint64) (*types.SignedAccumulatorProof, error) {
+	if backend.Identity == nil {
+		return nil, ErrIdentityDisabled
+	}
+	proof, err := backend.AccumulatorProof(slot)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := backend.Identity.Sign(ctx, proof.SigningData())
+	if err != nil {
+		return nil, err
+	}
+	return &types.SignedAccumulatorProof{
+		AccumulatorProof: proof,
+		Signer:           backend.Identity.Address(),
+		Signature:        signature,
+	}, nil
+}
+
+// Lifetime returns verified and invalid slots, reorgs and uptime over all runs of the node
+func (backend *Backend) Lifetime() (*types.LifetimeStats, error) {
+	if backend.LifetimeStats == nil {
+		return nil, ErrLifetimeStatsDisabled
+	}
+	stats := backend.LifetimeStats.LifetimeStats()
+	if stats == nil {
+		return nil, ErrLifetimeStatsDisabled
+	}
+	return stats, nil
+}
+
+// VerifyHeaders checks the pandora headers against the vanguard shard infos of their slots. Number of headers is
+// capped by MaxVerifyHeaders.
+func (backend *Backend) VerifyHeaders(headers []*eth1Types.Header) ([]*types.HeaderVerification, error) {
+	if backend.HeaderVerifier == nil {
+		return nil, ErrHeaderVerifyDisabled
+	}
+	if len(headers) > MaxVerifyHeaders {
+		return nil, fmt.Errorf("too many headers, at most %d headers are verified at once", MaxVerifyHeaders)
+	}
+	for i, header := range headers {
+		if header == nil {
+			return nil, fmt.Errorf("header %d is empty", i)
+		}
+	}
+	return backend.HeaderVerifier.VerifyHeaders(headers), nil
+}
+
+// IdentityAddress returns the address of orchestrator identity key
+func (backend *Backend) IdentityAddress() (common.Address, error) {
+	if backend.Identity == nil {
+		return common.Address{}, ErrIdentityDisabled
+	}
+	return backend.Identity.Address(), nil
+}
+
+// VerifiedSlotRange returns verified slots of [fromSlot, toSlot] in order. Range is capped by MaxArchiveRange.
+func (backend *Backend) VerifiedSlotRange(fromSlot, toSlot uint64) ([]*types.SlotHeaderStatus, error) {
+	if !backend.VerifiedSlotInfoDB.IsArchive() {
+		return nil, ErrArchiveDisabled
+	}
+	if toSlot < fromSlot {
+		return nil, fmt.Errorf("invalid slot range [%d, %d]", fromSlot, toSlot)
+	}
+	if toSlot-fromSlot >= MaxArchiveRange {
+		toSlot = fromSlot + MaxArchiveRange - 1
+	}
+	slotInfos, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfoRange(fromSlot, toSlot)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]*types.SlotHeaderStatus, 0, len(slotInfos))
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		if slotInfo := slotInfos[slot]; slotInfo != nil {
+			slots = append(slots, backend.verifiedSlotHeader(slot, slotInfo))
+		}
+	}
+	return slots, nil
+}
+
+// VerifiedSlotByHash returns the verified slot of a pandora header hash or vanguard block hash
+func (backend *Backend) VerifiedSlotByHash(hash common.Hash) (*types.SlotHeaderStatus, error) {
+	if !backend.VerifiedSlotInfoDB.IsArchive() {
+		return nil, ErrArchiveDisabled
+	}
+	slot, found, err := backend.VerifiedSlotInfoDB.SlotByPandoraHeaderHash(hash)
+	if err == nil && !found {
+		slot, found, err = backend.VerifiedSlotInfoDB.SlotByVanguardBlockHash(hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("verified slot not found for hash %s", hash.Hex())
+	}
+	slotInfo, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, fmt.Errorf("verified slot info not found for slot %d", slot)
+	}
+	return backend.verifiedSlotHeader(slot, slotInfo), nil
+}
+
+// VerifiedSlotByBlockNumber returns the verified slot of a pandora block number
+func (backend *Backend) VerifiedSlotByBlockNumber(number uint64) (*types.SlotHeaderStatus, error) {
+	slot, found, err := backend.VerifiedSlotInfoDB.SlotByPandoraBlockNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("verified slot not found for pandora block number %d", number)
+	}
+	slotInfo, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, fmt.Errorf("verified slot info not found for slot %d", slot)
+	}
+	return backend.verifiedSlotHeader(slot, slotInfo), nil
+}
+
+// VerifiedSlot returns the verified slot. When withPayload is set, the full pandora block of the slot is fetched
+// from the execution node and attached.
+func (backend *Backend) VerifiedSlot(ctx context.Context, slot uint64, withPayload bool) (*types.SlotHeaderWithPayload, error) {
+	slotInfo, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, fmt.Errorf("verified slot info not found for slot %d", slot)
+	}
+	verifiedSlot := &types.SlotHeaderWithPayload{SlotHeaderStatus: backend.verifiedSlotHeader(slot, slotInfo)}
+	if !withPayload {
+		return verifiedSlot, nil
+	}
+
+	if backend.PayloadFetcher == nil {
+		return nil, ErrPayloadUnavailable
+	}
+	payload, err := backend.PayloadFetcher.BlockByHash(ctx, slotInfo.PandoraHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	verifiedSlot.Payload = payload
+	return verifiedSlot, nil
+}
+
+// verifiedSlotHeader
+func (backend *Backend) verifiedSlotHeader(slot uint64, slotInfo *types.SlotInfo) *types.SlotHeaderStatus {
+	header := &types.SlotHeaderStatus{
+		Slot:              slot,
+		PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+		VanguardBlockRoot: slotInfo.VanguardBlockHash,
+		Status:            types.Verified,
+	}
+	if backend.AccumulatorDB == nil {
+		return header
+	}
+	if step, err := backend.AccumulatorStep(slot); err == nil && step != nil {
+		id := step.LeafIndex
+		header.StepId = &id
+	}
+	return header
+}
+
+// VerifiedSlotInfos returns the verified slot infos of the window of limit slots from the given slot. Limit is capped
+// at MaxVerifiedSlotPage, so a request from slot zero never loads the whole chain at once.
+func (backend *Backend) VerifiedSlotInfos(fromSlot uint64, limit int) map[uint64]*types.SlotInfo {
+	if limit <= 0 || limit > MaxVerifiedSlotPage {
+		limit = MaxVerifiedSlotPage
+	}
+	return backend.VerifiedSlotInfoRange(fromSlot, lastOfWindow(fromSlot, limit))
+}
+
+// VerifiedSlotPage returns the verified slots of the window of limit slots from the given slot with the first slot of
+// the next window
+func (backend *Backend) VerifiedSlotPage(fromSlot uint64, limit int) *types.VerifiedSlotPage {
+	if limit <= 0 || limit > MaxVerifiedSlotPage {
+		limit = MaxVerifiedSlotPage
+	}
+	toSlot := lastOfWindow(fromSlot, limit)
+	slotInfos := backend.VerifiedSlotInfos(fromSlot, limit)
+
+	page := &types.VerifiedSlotPage{Slots: make([]*types.SlotHeaderStatus, 0, len(slotInfos))}
+	for slot := range slotInfos {
+		page.Slots = append(page.Slots, backend.verifiedSlotHeader(slot, slotInfos[slot]))
+	}
+	sort.Slice(page.Slots, func(i, j int) bool { return page.Slots[i].Slot < page.Slots[j].Slot })
+	if toSlot < backend.LatestVerifiedSlot() {
+		next := toSlot + 1
+		page.Next = &next
+	}
+	return page
+}
+
+// VerifiedSlotInfoRange returns verified slot infos of [fromSlot, toSlot]
+func (backend *Backend) VerifiedSlotInfoRange(fromSlot, toSlot uint64) map[uint64]*types.SlotInfo {
+	slotInfos, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfoRange(fromSlot, toSlot)
 	if err != nil {
 		return nil
 	}
@@ -82,8 +619,13 @@ func (backend *Backend) LatestFinalizedSlot() uint64 {
 	return backend.VerifiedSlotInfoDB.LatestLatestFinalizedSlot()
 }
 
-// GetSlotStatus
-func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool) types.Status {
+func (backend *Backend) LatestFinalizedEpoch() uint64 {
+	return backend.VerifiedSlotInfoDB.LatestLatestFinalizedEpoch()
+}
+
+// GetSlotStatus returns the verification status of the slot. Verified slots at or below the latest finalized slot are
+// reported as Finalized only when withFinalized is set, so callers which don't know the status keep getting Verified.
+func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool, withFinalized bool) types.Status {
 	// by default if nothing is found then return skipped
 	status := types.Pending
 
@@ -117,7 +659,11 @@ func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash com
 		}
 
 		status = types.Verified
-		logPrinter(types.Verified)
+		// pandora can drop reorg protection of the block once vanguard has finalized the slot
+		if withFinalized && slot <= backend.LatestFinalizedSlot() {
+			status = types.Finalized
+		}
+		logPrinter(status)
 		return status
 	}
 
@@ -130,3 +676,81 @@ func (backend *Backend) GetSlotStatus(ctx context.Context, slot uint64, hash com
 	logPrinter(status)
 	return status
 }
+
+// CheckConsumer returns error when the consumer name is not configured. Empty name is the default consumer.
+func (backend *Backend) CheckConsumer(consumer string) error {
+	if consumer == "" {
+		return nil
+	}
+	for _, configured := range backend.ConfirmationConsumers {
+		if configured == consumer {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownConsumer, consumer)
+}
+
+// AckConfirmedSlot moves the acknowledgement high-water mark of the consumer to the given slot. Acks which are
+// lower than the current high-water mark are ignored because pandora may re-ack after reconnecting.
+func (backend *Backend) AckConfirmedSlot(slot uint64, consumer string) error {
+	if !backend.ConfirmationAckEnabled {
+		return ErrConfirmationAckDisabled
+	}
+	if err := backend.CheckConsumer(consumer); err != nil {
+		return err
+	}
+	latestVerifiedSlot := backend.VerifiedSlotInfoDB.LatestSavedVerifiedSlot()
+	if slot > latestVerifiedSlot {
+		return fmt.Errorf("acked slot %d is ahead of latest verified slot %d", slot, latestVerifiedSlot)
+	}
+
+	backend.ackLock.Lock()
+	defer backend.ackLock.Unlock()
+
+	if slot <= backend.ackedSlot(consumer) {
+		return nil
+	}
+	if consumer == "" {
+		return backend.ConfirmationAckDB.SaveLatestAckedSlot(slot)
+	}
+	return backend.ConfirmationAckDB.SaveConsumerAckedSlot(consumer, slot)
+}
+
+// LatestAckedSlot returns the acknowledgement high-water mark of the consumer and whether ack tracking is enabled
+func (backend *Backend) LatestAckedSlot(consumer string) (uint64, bool) {
+	if !backend.ConfirmationAckEnabled {
+		return 0, false
+	}
+	return backend.ackedSlot(consumer), true
+}
+
+// DeferConfirmations queues the republishing of the confirmations of [fromSlot, toSlot] which could not be
+// delivered to pandora. Acknowledging consumers get them retransmitted when they subscribe again, so nothing is queued.
+func (backend *Backend) DeferConfirmations(fromSlot, toSlot uint64) {
+	if backend.Tasks == nil || backend.ConfirmationAckEnabled {
+		return
+	}
+	if err := backend.Tasks.Enqueue(taskqueue.RepublishTask, fromSlot, toSlot, confirmationRetryDelay); err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).
+			Error("Failed to defer undelivered confirmations")
+	}
+}
+
+// JournaledConfirmations returns the journaled confirmations of the consumer from the given slot
+func (backend *Backend) JournaledConfirmations(consumer string, fromSlot uint64) ([]*types.JournaledConfirmation, error) {
+	if backend.Journal == nil {
+		return nil, ErrJournalDisabled
+	}
+	if err := backend.CheckConsumer(consumer); err != nil {
+		return nil, err
+	}
+	return backend.Journal.Backlog(consumer, fromSlot)
+}
+
+// ackedSlot
+func (backend *Backend) ackedSlot(consumer string) uint64 {
+	if consumer == "" {
+		return backend.ConfirmationAckDB.LatestAckedSlot()
+	}
+	return backend.ConfirmationAckDB.ConsumerAckedSlot(consumer)
+}
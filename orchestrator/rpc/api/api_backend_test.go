@@ -1 +1,116 @@
 package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/accumulator"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestBackend_AccumulatorProof(t *testing.T) {
+	db := testDB.SetupDB(t)
+	backend := &Backend{AccumulatorDB: db}
+	acc := new(accumulator.Accumulator)
+	accumulate := func(slot uint64, seed byte) {
+		leaf := common.BytesToHash([]byte{seed, byte(slot)})
+		require.NoError(t, acc.Append(leaf))
+		require.NoError(t, db.SaveAccumulatorStep(&types.AccumulatorStep{
+			Slot:      slot,
+			LeafIndex: acc.Size() - 1,
+			Leaf:      leaf,
+			Root:      acc.Root(),
+		}))
+	}
+	verify := func(slot uint64) {
+		proof, err := backend.AccumulatorProof(slot)
+		require.NoError(t, err)
+		assert.Equal(t, acc.Root(), proof.AccumulatorRoot)
+		assert.Equal(t, true, accumulator.VerifyProof(proof.AccumulatorRoot, proof.Leaf, proof.LeafIndex, proof.Proof))
+	}
+
+	for slot := uint64(1); slot <= 5; slot++ {
+		accumulate(slot, 1)
+	}
+	verify(3)
+
+	// cached tree is extended with the new leaves
+	for slot := uint64(6); slot <= 9; slot++ {
+		accumulate(slot, 1)
+	}
+	verify(2)
+	verify(9)
+	assert.Equal(t, uint64(9), backend.proofTree.Size())
+
+	// reverted accumulator rebuilds the cached tree
+	require.NoError(t, db.RemoveRangeVerifiedInfo(4, 9))
+	leaves, err := db.AccumulatorLeaves(3)
+	require.NoError(t, err)
+	acc, err = accumulator.New(leaves)
+	require.NoError(t, err)
+	for slot := uint64(4); slot <= 10; slot++ {
+		accumulate(slot, 2)
+	}
+	verify(4)
+	verify(10)
+	assert.Equal(t, uint64(10), backend.proofTree.Size())
+}
+
+func TestBackend_Pages(t *testing.T) {
+	db := testDB.SetupDB(t)
+	backend := &Backend{ConsensusInfoDB: db, VerifiedSlotInfoDB: db, ReorgHistoryDB: db}
+	ctx := context.Background()
+	for epoch := uint64(0); epoch < 5; epoch++ {
+		require.NoError(t, db.SaveConsensusInfo(ctx, testutil.NewMinimalConsensusInfo(epoch).ConvertToEpochInfo()))
+	}
+	require.NoError(t, db.SaveLatestEpoch(ctx, 4))
+	for _, slot := range []uint64{1, 2, 5, 9} {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)})}))
+	}
+	require.NoError(t, db.SaveLatestVerifiedSlot(ctx, 9))
+
+	epochPage, err := backend.EpochInfoPage(1, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(epochPage.EpochInfos))
+	assert.Equal(t, uint64(1), epochPage.EpochInfos[0].Epoch)
+	require.NotNil(t, epochPage.Next)
+	assert.Equal(t, uint64(4), *epochPage.Next)
+	epochPage, err = backend.EpochInfoPage(4, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(epochPage.EpochInfos))
+	assert.Equal(t, (*uint64)(nil), epochPage.Next)
+
+	// limit is a window of slots, skipped slots are not returned
+	slotPage := backend.VerifiedSlotPage(1, 5)
+	require.Equal(t, 3, len(slotPage.Slots))
+	assert.DeepEqual(t, []uint64{1, 2, 5}, []uint64{slotPage.Slots[0].Slot, slotPage.Slots[1].Slot, slotPage.Slots[2].Slot})
+	require.NotNil(t, slotPage.Next)
+	assert.Equal(t, uint64(6), *slotPage.Next)
+	slotPage = backend.VerifiedSlotPage(6, 5)
+	require.Equal(t, 1, len(slotPage.Slots))
+	assert.Equal(t, uint64(9), slotPage.Slots[0].Slot)
+	assert.Equal(t, (*uint64)(nil), slotPage.Next)
+
+	// zero limit is capped
+	assert.Equal(t, 4, len(backend.VerifiedSlotInfos(0, 0)))
+
+	// page of reorgs ends between the reorgs of a slot
+	for _, slot := range []uint64{3, 7, 7} {
+		require.NoError(t, db.SaveReorg(&types.ReorgRecord{Slot: slot}))
+	}
+	reorgPage, err := backend.ReorgHistory(0, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(reorgPage.Reorgs))
+	require.NotNil(t, reorgPage.Next)
+	assert.Equal(t, uint64(7), reorgPage.Next.Slot)
+	reorgPage, err = backend.ReorgHistory(reorgPage.Next.Slot, reorgPage.Next.Id, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reorgPage.Reorgs))
+	assert.Equal(t, uint64(3), reorgPage.Reorgs[0].Id)
+	assert.Equal(t, (*types.ReorgCursor)(nil), reorgPage.Next)
+}
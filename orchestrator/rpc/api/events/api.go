@@ -16,15 +16,45 @@ import (
 var lastSendEpoch uint64
 
 type Backend interface {
-	ConsensusInfoByEpochRange(fromEpoch uint64) ([]*generalTypes.MinimalEpochConsensusInfoV2, error)
+	ConsensusInfoByEpochRange(fromEpoch uint64, limit int) ([]*generalTypes.MinimalEpochConsensusInfoV2, error)
+	EpochInfoPage(fromEpoch uint64, limit int) (*generalTypes.EpochInfoPage, error)
 	SubscribeNewEpochEvent(chan<- *generalTypes.MinimalEpochConsensusInfoV2) event.Subscription
-	GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool) generalTypes.Status
+	GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool, withFinalized bool) generalTypes.Status
 	LatestEpoch() uint64
+	CheckPrunedEpoch(epoch uint64) error
+	EpochInfo(ctx context.Context, epoch uint64) (*generalTypes.EpochInfoWithSource, error)
+	ProposerForSlot(ctx context.Context, slot uint64) (*generalTypes.SlotProposer, error)
 	SubscribeNewVerifiedSlotInfoEvent(chan<- *generalTypes.SlotInfoWithStatus) event.Subscription
-	VerifiedSlotInfos(fromSlot uint64) map[uint64]*generalTypes.SlotInfo
+	SubscribeEpochSummaryEvent(chan<- *generalTypes.EpochSummary) event.Subscription
+	EpochSummary(epoch uint64) (*generalTypes.EpochSummary, error)
+	Lifetime() (*generalTypes.LifetimeStats, error)
+	VerifyHeaders(headers []*eth1Types.Header) ([]*generalTypes.HeaderVerification, error)
+	VerifiedSlotPage(fromSlot uint64, limit int) *generalTypes.VerifiedSlotPage
+	VerifiedSlotInfoRange(fromSlot, toSlot uint64) map[uint64]*generalTypes.SlotInfo
 	LatestVerifiedSlot() uint64
 	PendingPandoraHeaders() []*eth1Types.Header
 	LatestFinalizedSlot() uint64
+	LatestFinalizedEpoch() uint64
+	CheckConsumer(consumer string) error
+	AckConfirmedSlot(slot uint64, consumer string) error
+	LatestAckedSlot(consumer string) (uint64, bool)
+	DeferConfirmations(fromSlot, toSlot uint64)
+	JournaledConfirmations(consumer string, fromSlot uint64) ([]*generalTypes.JournaledConfirmation, error)
+	AccumulatorStep(slot uint64) (*generalTypes.AccumulatorStep, error)
+	AccumulatorProof(slot uint64) (*generalTypes.AccumulatorProof, error)
+	ShardDisagreements(fromSlot uint64, limit int) ([]*generalTypes.ShardDisagreement, error)
+	ShardEquivocations(fromSlot uint64, limit int) ([]*generalTypes.ShardEquivocation, error)
+	ShardConflicts(fromSlot uint64, limit int) ([]*generalTypes.ShardConflict, error)
+	SubscribeShardConflictEvent(chan<- *generalTypes.ShardConflict) event.Subscription
+	ReorgHistory(fromSlot, fromId uint64, limit int) (*generalTypes.ReorgHistoryPage, error)
+	HeadAtSlotTime(ctx context.Context, timestamp uint64) (*generalTypes.HistoricalSlot, error)
+	ShardInfoAsOf(stepId uint64, slot uint64) (*generalTypes.HistoricalSlot, error)
+	SignedAccumulatorProof(ctx context.Context, slot uint64) (*generalTypes.SignedAccumulatorProof, error)
+	IdentityAddress() (common.Address, error)
+	VerifiedSlotRange(fromSlot, toSlot uint64) ([]*generalTypes.SlotHeaderStatus, error)
+	VerifiedSlotByHash(hash common.Hash) (*generalTypes.SlotHeaderStatus, error)
+	VerifiedSlotByBlockNumber(number uint64) (*generalTypes.SlotHeaderStatus, error)
+	VerifiedSlot(ctx context.Context, slot uint64, withPayload bool) (*generalTypes.SlotHeaderWithPayload, error)
 }
 
 // PublicFilterAPI offers support to create and manage filters. This will allow external clients to retrieve various
@@ -56,10 +86,12 @@ func NewPublicFilterAPI(backend Backend, timeout time.Duration) *PublicFilterAPI
 	return api
 }
 
-// ConfirmPanBlockHashes should be used to get the confirmation about known state of Pandora block hashes
+// ConfirmPanBlockHashes should be used to get the confirmation about known state of Pandora block hashes. Verified
+// blocks which vanguard has finalized are reported as Finalized only when finalized is true.
 func (api *PublicFilterAPI) ConfirmPanBlockHashes(
 	ctx context.Context,
 	requests []*BlockHash,
+	finalized *bool,
 ) ([]*BlockStatus, error) {
 	if len(requests) < 1 {
 		err := fmt.Errorf("invalid request")
@@ -67,7 +99,7 @@ func (api *PublicFilterAPI) ConfirmPanBlockHashes(
 	}
 	res := make([]*BlockStatus, 0)
 	for _, req := range requests {
-		status := api.backend.GetSlotStatus(ctx, req.Slot, req.Hash, true)
+		status := api.backend.GetSlotStatus(ctx, req.Slot, req.Hash, true, finalized != nil && *finalized)
 		log.WithField("slot", req.Slot).WithField("status", status).WithField(
 			"api", "ConfirmPanBlockHashes").Debug("status of the requested slot")
 		hash := req.Hash
@@ -82,10 +114,12 @@ func (api *PublicFilterAPI) ConfirmPanBlockHashes(
 	return res, nil
 }
 
-// ConfirmVanBlockHashes should be used to get the confirmation about known state of Vanguard block hashes
+// ConfirmVanBlockHashes should be used to get the confirmation about known state of Vanguard block hashes. Verified
+// blocks which vanguard has finalized are reported as Finalized only when finalized is true.
 func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	ctx context.Context,
 	requests []*BlockHash,
+	finalized *bool,
 ) (response []*BlockStatus, err error) {
 	if len(requests) < 1 {
 		err := fmt.Errorf("invalid request")
@@ -93,7 +127,7 @@ func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	}
 	res := make([]*BlockStatus, 0)
 	for _, req := range requests {
-		status := api.backend.GetSlotStatus(ctx, req.Slot, req.Hash, false)
+		status := api.backend.GetSlotStatus(ctx, req.Slot, req.Hash, false, finalized != nil && *finalized)
 		log.WithField("slot", req.Slot).WithField("status", status).WithField(
 			"api", "ConfirmVanBlockHashes").Debug("Status of the requested slot")
 		hash := req.Hash
@@ -108,22 +142,256 @@ func (api *PublicFilterAPI) ConfirmVanBlockHashes(
 	return res, nil
 }
 
-// MinimalConsensusInfo
+// GetEpochInfo returns the stored epoch info of the given epoch along with the vanguard block root and state root
+// which the proposer list is derived from. Source is null when orchestrator did not observe the dependent block, and
+// it is marked as unverified when it is inferred from the vanguard block stream.
+func (api *PublicFilterAPI) GetEpochInfo(ctx context.Context, epoch uint64) (*generalTypes.EpochInfoWithSource, error) {
+	epochInfo, err := api.backend.EpochInfo(ctx, epoch)
+	if err != nil {
+		log.WithError(err).WithField("epoch", epoch).Debug("Failed to retrieve epoch info")
+		return nil, err
+	}
+	return epochInfo, nil
+}
+
+// GetEpochInfos returns a page of stored epoch infos starting from the given epoch. Limit is capped by the
+// orchestrator, so clients continue from the next epoch of the page until it is null.
+func (api *PublicFilterAPI) GetEpochInfos(ctx context.Context, fromEpoch uint64, limit int) (*generalTypes.EpochInfoPage, error) {
+	page, err := api.backend.EpochInfoPage(fromEpoch, limit)
+	if err != nil {
+		log.WithError(err).WithField("fromEpoch", fromEpoch).Debug("Failed to retrieve epoch infos")
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetVerifiedSlots returns the verified slots of a window of limit slots starting from the given slot. Limit is
+// capped by the orchestrator, so clients continue from the next slot of the page until it is null.
+func (api *PublicFilterAPI) GetVerifiedSlots(ctx context.Context, fromSlot uint64, limit int) (*generalTypes.VerifiedSlotPage, error) {
+	return api.backend.VerifiedSlotPage(fromSlot, limit), nil
+}
+
+// GetProposerForSlot returns the public key of the validator which should have sealed the shard header of the slot.
+// It is derived from the stored epoch info, so it fails for epochs which orchestrator did not receive.
+func (api *PublicFilterAPI) GetProposerForSlot(ctx context.Context, slot uint64) (*generalTypes.SlotProposer, error) {
+	proposer, err := api.backend.ProposerForSlot(ctx, slot)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Failed to retrieve slot proposer")
+		return nil, err
+	}
+	return proposer, nil
+}
+
+// GetEpochSummary returns verified, invalid and skipped slot counts, reorgs and average confirmation latency of
+// the given epoch. Epoch is summarized once the first slot of a later epoch is processed.
+func (api *PublicFilterAPI) GetEpochSummary(ctx context.Context, epoch uint64) (*generalTypes.EpochSummary, error) {
+	summary, err := api.backend.EpochSummary(epoch)
+	if err != nil {
+		log.WithError(err).WithField("epoch", epoch).Debug("Failed to retrieve epoch summary")
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetLifetimeStats returns verified and invalid slots, reorgs and uptime in seconds over all runs of the node.
+// Counters survive restarts.
+func (api *PublicFilterAPI) GetLifetimeStats(ctx context.Context) (*generalTypes.LifetimeStats, error) {
+	return api.backend.Lifetime()
+}
+
+// GetAccumulatorStep returns the verified-chain accumulator leaf and root right after the given slot was verified
+func (api *PublicFilterAPI) GetAccumulatorStep(ctx context.Context, slot uint64) (*generalTypes.AccumulatorStep, error) {
+	step, err := api.backend.AccumulatorStep(slot)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Failed to retrieve accumulator step")
+		return nil, err
+	}
+	return step, nil
+}
+
+// GetInclusionProof returns merkle proof which proves that the verified slot info of the given slot is
+// included in the latest verified-chain accumulator root
+func (api *PublicFilterAPI) GetInclusionProof(ctx context.Context, slot uint64) (*generalTypes.AccumulatorProof, error) {
+	proof, err := api.backend.AccumulatorProof(slot)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Failed to build accumulator inclusion proof")
+		return nil, err
+	}
+	return proof, nil
+}
+
+// GetSignedInclusionProof returns the inclusion proof of the slot signed by the orchestrator identity key,
+// so it can be authenticated by consumers which know the orchestrator address
+func (api *PublicFilterAPI) GetSignedInclusionProof(ctx context.Context, slot uint64) (*generalTypes.SignedAccumulatorProof, error) {
+	proof, err := api.backend.SignedAccumulatorProof(ctx, slot)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Failed to build signed accumulator inclusion proof")
+		return nil, err
+	}
+	return proof, nil
+}
+
+// GetIdentity returns the address of orchestrator identity key which signs exported proofs
+func (api *PublicFilterAPI) GetIdentity(ctx context.Context) (common.Address, error) {
+	return api.backend.IdentityAddress()
+}
+
+// VerifyHeaders runs the sharding info verification of the orchestrator over the given pandora headers without
+// storing anything and returns the result of every header in order. A header is pending until the vanguard shard
+// info of its slot is received.
+func (api *PublicFilterAPI) VerifyHeaders(
+	ctx context.Context,
+	headers []*eth1Types.Header,
+) ([]*generalTypes.HeaderVerification, error) {
+	results, err := api.backend.VerifyHeaders(headers)
+	if err != nil {
+		log.WithError(err).WithField("headers", len(headers)).Debug("Failed to verify headers")
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetVerifiedSlotRange returns verified slots of [fromSlot, toSlot] in order. Only archive orchestrator serves it
+// and the range is capped, so bulk exporters should continue from the last returned slot.
+func (api *PublicFilterAPI) GetVerifiedSlotRange(ctx context.Context, fromSlot uint64, toSlot uint64) ([]*generalTypes.SlotHeaderStatus, error) {
+	slots, err := api.backend.VerifiedSlotRange(fromSlot, toSlot)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).Debug("Failed to retrieve verified slot range")
+		return nil, err
+	}
+	return slots, nil
+}
+
+// GetVerifiedSlotByHash returns the verified slot of the given pandora header hash or vanguard block hash.
+// Only archive orchestrator serves it.
+func (api *PublicFilterAPI) GetVerifiedSlotByHash(ctx context.Context, hash common.Hash) (*generalTypes.SlotHeaderStatus, error) {
+	slot, err := api.backend.VerifiedSlotByHash(hash)
+	if err != nil {
+		log.WithError(err).WithField("hash", hash).Debug("Failed to retrieve verified slot by hash")
+		return nil, err
+	}
+	return slot, nil
+}
+
+// GetVerifiedSlotByBlockNumber returns the verified slot of the given pandora block number. Numbers above a reorg
+// point are dropped when the verified chain is reverted, so the result always belongs to the current verified chain.
+func (api *PublicFilterAPI) GetVerifiedSlotByBlockNumber(ctx context.Context, number uint64) (*generalTypes.SlotHeaderStatus, error) {
+	slot, err := api.backend.VerifiedSlotByBlockNumber(number)
+	if err != nil {
+		log.WithError(err).WithField("blockNumber", number).Debug("Failed to retrieve verified slot by block number")
+		return nil, err
+	}
+	return slot, nil
+}
+
+// GetVerifiedSlot returns the verified slot. When withPayload is true, the full pandora block of the slot is
+// fetched from the execution node and returned with it, so explorers get verification and payload in one call.
+func (api *PublicFilterAPI) GetVerifiedSlot(ctx context.Context, slot uint64, withPayload *bool) (*generalTypes.SlotHeaderWithPayload, error) {
+	verifiedSlot, err := api.backend.VerifiedSlot(ctx, slot, withPayload != nil && *withPayload)
+	if err != nil {
+		log.WithError(err).WithField("slot", slot).Debug("Failed to retrieve verified slot")
+		return nil, err
+	}
+	return verifiedSlot, nil
+}
+
+// GetShardDisagreements returns pandora and vanguard sides of the slots whose sharding info did not match,
+// starting from the given slot. Limit is capped by the orchestrator.
+func (api *PublicFilterAPI) GetShardDisagreements(ctx context.Context, fromSlot uint64, limit int) ([]*generalTypes.ShardDisagreement, error) {
+	disagreements, err := api.backend.ShardDisagreements(fromSlot, limit)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).Debug("Failed to retrieve shard disagreements")
+		return nil, err
+	}
+	return disagreements, nil
+}
+
+// GetShardEquivocations returns evidences of proposers which signed conflicting shard infos for the same slot,
+// collected across all configured vanguard nodes, starting from the given slot. Limit is capped by the orchestrator.
+func (api *PublicFilterAPI) GetShardEquivocations(ctx context.Context, fromSlot uint64, limit int) ([]*generalTypes.ShardEquivocation, error) {
+	equivocations, err := api.backend.ShardEquivocations(fromSlot, limit)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).Debug("Failed to retrieve shard equivocations")
+		return nil, err
+	}
+	return equivocations, nil
+}
+
+// GetShardConflicts returns the verified slots which received a different pandora header without a vanguard reorg,
+// starting from the given slot. The conflicting shard infos were refused. Limit is capped by the orchestrator.
+func (api *PublicFilterAPI) GetShardConflicts(ctx context.Context, fromSlot uint64, limit int) ([]*generalTypes.ShardConflict, error) {
+	conflicts, err := api.backend.ShardConflicts(fromSlot, limit)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).Debug("Failed to retrieve shard conflicts")
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// GetReorgHistory returns a page of the reorgs which orchestrator detected starting from the given slot, with the
+// verified head they reverted and whether the revert succeeded. It is targeted at debugging chain splits. Limit is
+// capped by the orchestrator, so clients continue from the slot and id of the next reorg of the page until it is null.
+func (api *PublicFilterAPI) GetReorgHistory(
+	ctx context.Context,
+	fromSlot uint64,
+	limit int,
+	fromId *uint64,
+) (*generalTypes.ReorgHistoryPage, error) {
+	var id uint64
+	if fromId != nil {
+		id = *fromId
+	}
+	page, err := api.backend.ReorgHistory(fromSlot, id, limit)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).Debug("Failed to retrieve reorg history")
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetHeadAtSlotTime returns the verified head which orchestrator considered current at the given unix timestamp,
+// so that post-incident analysis can tell which confirmations pandora was given at the time. replacedBy is set when
+// the head of that time was reverted by a later reorg.
+func (api *PublicFilterAPI) GetHeadAtSlotTime(ctx context.Context, timestamp uint64) (*generalTypes.HistoricalSlot, error) {
+	head, err := api.backend.HeadAtSlotTime(ctx, timestamp)
+	if err != nil {
+		log.WithError(err).WithField("timestamp", timestamp).Debug("Failed to retrieve head at slot time")
+		return nil, err
+	}
+	return head, nil
+}
+
+// GetShardInfoAsOf returns the verified entry of the slot at the given accumulator step. replacedBy is set when
+// the entry of that step was reverted by a later reorg.
+func (api *PublicFilterAPI) GetShardInfoAsOf(ctx context.Context, stepId uint64, slot uint64) (*generalTypes.HistoricalSlot, error) {
+	historical, err := api.backend.ShardInfoAsOf(stepId, slot)
+	if err != nil {
+		log.WithError(err).WithField("stepId", stepId).WithField("slot", slot).
+			Debug("Failed to retrieve shard info as of step")
+		return nil, err
+	}
+	return historical, nil
+}
+
+// epochInfoBatchSize is the number of epoch infos which are read from db at once while sending the history
+const epochInfoBatchSize = 256
+
+// MinimalConsensusInfo streams the epoch infos from the requested epoch. A subscription from a pruned epoch fails, so
+// that pandora does not miss the epoch infos which it asked for.
 func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedEpoch uint64) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	if err := api.backend.CheckPrunedEpoch(requestedEpoch); err != nil {
+		log.WithError(err).WithField("requestedEpoch", requestedEpoch).Warn("Requested epoch infos are pruned")
+		return &rpc.Subscription{}, err
+	}
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
 
-		batchSender := func(start, end uint64) error {
-			epochInfos, err := api.backend.ConsensusInfoByEpochRange(start)
-			if err != nil {
-				log.WithError(err).Error("Some epoch infos are missing in db.")
-				return errors.Wrap(err, "Missing epoch infos in db. Could not send over stream.")
-			}
+		sendEpochInfos := func(epochInfos []*generalTypes.MinimalEpochConsensusInfoV2, start, end uint64) error {
 			latestFinalizedSlot := api.backend.LatestFinalizedSlot()
 			for _, ei := range epochInfos {
 				if err := notifier.Notify(rpcSub.ID, &generalTypes.MinimalEpochConsensusInfoV2{
@@ -145,6 +413,25 @@ func (api *PublicFilterAPI) MinimalConsensusInfo(ctx context.Context, requestedE
 			return nil
 		}
 
+		batchSender := func(start, end uint64) error {
+			// history is read in pages, so a subscription from an early epoch does not load every epoch at once
+			for pageStart := start; pageStart <= api.backend.LatestEpoch(); {
+				epochInfos, err := api.backend.ConsensusInfoByEpochRange(pageStart, epochInfoBatchSize)
+				if err != nil {
+					log.WithError(err).Error("Some epoch infos are missing in db.")
+					return errors.Wrap(err, "Missing epoch infos in db. Could not send over stream.")
+				}
+				if err := sendEpochInfos(epochInfos, start, end); err != nil {
+					return err
+				}
+				if len(epochInfos) < epochInfoBatchSize {
+					break
+				}
+				pageStart = epochInfos[len(epochInfos)-1].Epoch + 1
+			}
+			return nil
+		}
+
 		startEpoch := requestedEpoch
 		endEpoch := api.backend.LatestEpoch()
 		if startEpoch <= endEpoch {
@@ -2,9 +2,11 @@ package events
 
 import (
 	"context"
+	"errors"
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"time"
 )
@@ -16,15 +18,32 @@ var (
 type MockBackend struct {
 	ConsensusInfoFeed    event.Feed
 	verifiedSlotInfoFeed event.Feed
+	EpochSummaryFeed     event.Feed
+	ShardConflictFeed    event.Feed
 
 	ConsensusInfos    []*eventTypes.MinimalEpochConsensusInfoV2
 	verifiedSlotInfos map[uint64]*eventTypes.SlotInfo
 	CurEpoch          uint64
+	AckEnabled        bool
+	AckedSlot         uint64
+	EpochSummaries    map[uint64]*eventTypes.EpochSummary
+	LifetimeStats     *eventTypes.LifetimeStats
+	// HeaderStatuses are the verification results of the pandora header hashes
+	HeaderStatuses map[common.Hash]eventTypes.Status
+	// AccumulatorSteps are keyed by slot
+	AccumulatorSteps map[uint64]*eventTypes.AccumulatorStep
+
+	// ConsumerAckedSlots is keyed by consumer name, only "standby" consumer is configured
+	ConsumerAckedSlots map[string]uint64
+	// DeferredConfirmations are the slot ranges of undelivered confirmations
+	DeferredConfirmations [][2]uint64
+	// EarliestEpoch is the first epoch whose epoch info is not pruned
+	EarliestEpoch uint64
 }
 
 var _ Backend = &MockBackend{}
 
-func (b *MockBackend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*eventTypes.MinimalEpochConsensusInfoV2, error) {
+func (b *MockBackend) ConsensusInfoByEpochRange(fromEpoch uint64, limit int) ([]*eventTypes.MinimalEpochConsensusInfoV2, error) {
 	consensusInfos := make([]*eventTypes.MinimalEpochConsensusInfoV2, 0)
 	for _, consensusInfo := range b.ConsensusInfos {
 		consensusInfos = append(consensusInfos, consensusInfo)
@@ -32,6 +51,14 @@ func (b *MockBackend) ConsensusInfoByEpochRange(fromEpoch uint64) ([]*eventTypes
 	return consensusInfos, nil
 }
 
+func (b *MockBackend) EpochInfoPage(fromEpoch uint64, limit int) (*eventTypes.EpochInfoPage, error) {
+	consensusInfos, err := b.ConsensusInfoByEpochRange(fromEpoch, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &eventTypes.EpochInfoPage{EpochInfos: consensusInfos}, nil
+}
+
 func (b *MockBackend) SubscribeNewEpochEvent(ch chan<- *eventTypes.MinimalEpochConsensusInfoV2) event.Subscription {
 	return b.ConsensusInfoFeed.Subscribe(ch)
 }
@@ -40,22 +67,101 @@ func (b *MockBackend) SubscribeNewVerifiedSlotInfoEvent(ch chan<- *eventTypes.Sl
 	return b.verifiedSlotInfoFeed.Subscribe(ch)
 }
 
-func (mb *MockBackend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestType bool) eventTypes.Status {
+func (b *MockBackend) SubscribeEpochSummaryEvent(ch chan<- *eventTypes.EpochSummary) event.Subscription {
+	return b.EpochSummaryFeed.Subscribe(ch)
+}
+
+func (b *MockBackend) SubscribeShardConflictEvent(ch chan<- *eventTypes.ShardConflict) event.Subscription {
+	return b.ShardConflictFeed.Subscribe(ch)
+}
+
+func (mb *MockBackend) EpochSummary(epoch uint64) (*eventTypes.EpochSummary, error) {
+	if summary, ok := mb.EpochSummaries[epoch]; ok {
+		return summary, nil
+	}
+	return nil, errors.New("epoch summary not found")
+}
+
+func (mb *MockBackend) Lifetime() (*eventTypes.LifetimeStats, error) {
+	if mb.LifetimeStats == nil {
+		return nil, errors.New("lifetime stats are not enabled")
+	}
+	return mb.LifetimeStats, nil
+}
+
+func (mb *MockBackend) VerifyHeaders(headers []*eth1Types.Header) ([]*eventTypes.HeaderVerification, error) {
+	results := make([]*eventTypes.HeaderVerification, len(headers))
+	for i, header := range headers {
+		status, ok := mb.HeaderStatuses[header.Hash()]
+		if !ok {
+			status = eventTypes.Pending
+		}
+		results[i] = &eventTypes.HeaderVerification{Hash: header.Hash(), Status: status}
+	}
+	return results, nil
+}
+
+func (mb *MockBackend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestType bool, withFinalized bool) eventTypes.Status {
 	return eventTypes.Pending
 }
 
+func (mb *MockBackend) EpochInfo(ctx context.Context, epoch uint64) (*eventTypes.EpochInfoWithSource, error) {
+	for _, consensusInfo := range mb.ConsensusInfos {
+		if consensusInfo.Epoch == epoch {
+			return &eventTypes.EpochInfoWithSource{MinimalEpochConsensusInfo: consensusInfo.ConvertToEpochInfo()}, nil
+		}
+	}
+	return nil, errors.New("epoch info not found")
+}
+
+func (mb *MockBackend) ProposerForSlot(ctx context.Context, slot uint64) (*eventTypes.SlotProposer, error) {
+	epochInfo, err := mb.EpochInfo(ctx, slot/params.SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+	index := slot % params.SlotsPerEpoch
+	if index >= uint64(len(epochInfo.ValidatorList)) {
+		return nil, errors.New("proposer not found")
+	}
+	return &eventTypes.SlotProposer{Slot: slot, Epoch: epochInfo.Epoch, PublicKey: epochInfo.ValidatorList[index]}, nil
+}
+
 func (mb *MockBackend) LatestEpoch() uint64 {
 	return 100
 }
 
+func (mb *MockBackend) CheckPrunedEpoch(epoch uint64) error {
+	if epoch < mb.EarliestEpoch {
+		return errors.New("epoch info is pruned")
+	}
+	return nil
+}
+
 func (mb *MockBackend) PendingPandoraHeaders() []*eth1Types.Header {
 	return nil
 }
 
-func (mb *MockBackend) VerifiedSlotInfos(fromSlot uint64) map[uint64]*eventTypes.SlotInfo {
+func (mb *MockBackend) VerifiedSlotPage(fromSlot uint64, limit int) *eventTypes.VerifiedSlotPage {
+	page := &eventTypes.VerifiedSlotPage{Slots: make([]*eventTypes.SlotHeaderStatus, 0)}
+	for slot, slotInfo := range mb.verifiedSlotInfos {
+		if slot >= fromSlot {
+			page.Slots = append(page.Slots, &eventTypes.SlotHeaderStatus{
+				Slot:              slot,
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+				VanguardBlockRoot: slotInfo.VanguardBlockHash,
+				Status:            eventTypes.Verified,
+			})
+		}
+	}
+	return page
+}
+
+func (mb *MockBackend) VerifiedSlotInfoRange(fromSlot, toSlot uint64) map[uint64]*eventTypes.SlotInfo {
 	slotInfos := make(map[uint64]*eventTypes.SlotInfo)
 	for slot, slotInfo := range mb.verifiedSlotInfos {
-		slotInfos[slot] = slotInfo
+		if slot >= fromSlot && slot <= toSlot {
+			slotInfos[slot] = slotInfo
+		}
 	}
 	return slotInfos
 }
@@ -67,3 +173,101 @@ func (mb *MockBackend) LatestVerifiedSlot() uint64 {
 func (mb *MockBackend) LatestFinalizedSlot() uint64 {
 	return 100
 }
+
+func (mb *MockBackend) LatestFinalizedEpoch() uint64 {
+	return 3
+}
+
+func (mb *MockBackend) CheckConsumer(consumer string) error {
+	if consumer == "" || consumer == "standby" {
+		return nil
+	}
+	return errors.New("unknown confirmation consumer")
+}
+
+func (mb *MockBackend) AckConfirmedSlot(slot uint64, consumer string) error {
+	if consumer != "" {
+		if slot > mb.ConsumerAckedSlots[consumer] {
+			mb.ConsumerAckedSlots[consumer] = slot
+		}
+		return nil
+	}
+	if slot > mb.AckedSlot {
+		mb.AckedSlot = slot
+	}
+	return nil
+}
+
+func (mb *MockBackend) LatestAckedSlot(consumer string) (uint64, bool) {
+	if consumer != "" {
+		return mb.ConsumerAckedSlots[consumer], mb.AckEnabled
+	}
+	return mb.AckedSlot, mb.AckEnabled
+}
+
+func (mb *MockBackend) DeferConfirmations(fromSlot, toSlot uint64) {
+	mb.DeferredConfirmations = append(mb.DeferredConfirmations, [2]uint64{fromSlot, toSlot})
+}
+
+func (mb *MockBackend) JournaledConfirmations(consumer string, fromSlot uint64) ([]*eventTypes.JournaledConfirmation, error) {
+	return nil, nil
+}
+
+func (mb *MockBackend) AccumulatorStep(slot uint64) (*eventTypes.AccumulatorStep, error) {
+	if step, ok := mb.AccumulatorSteps[slot]; ok {
+		return step, nil
+	}
+	return nil, errors.New("accumulator step not found")
+}
+
+func (mb *MockBackend) AccumulatorProof(slot uint64) (*eventTypes.AccumulatorProof, error) {
+	return nil, errors.New("accumulator step not found")
+}
+
+func (mb *MockBackend) SignedAccumulatorProof(ctx context.Context, slot uint64) (*eventTypes.SignedAccumulatorProof, error) {
+	return nil, errors.New("accumulator step not found")
+}
+
+func (mb *MockBackend) IdentityAddress() (common.Address, error) {
+	return common.Address{}, errors.New("orchestrator identity is not configured")
+}
+
+func (mb *MockBackend) VerifiedSlotRange(fromSlot, toSlot uint64) ([]*eventTypes.SlotHeaderStatus, error) {
+	return nil, errors.New("orchestrator is not running in archive mode")
+}
+
+func (mb *MockBackend) VerifiedSlotByHash(hash common.Hash) (*eventTypes.SlotHeaderStatus, error) {
+	return nil, errors.New("orchestrator is not running in archive mode")
+}
+
+func (mb *MockBackend) VerifiedSlotByBlockNumber(number uint64) (*eventTypes.SlotHeaderStatus, error) {
+	return nil, errors.New("verified slot not found")
+}
+
+func (mb *MockBackend) VerifiedSlot(ctx context.Context, slot uint64, withPayload bool) (*eventTypes.SlotHeaderWithPayload, error) {
+	return nil, errors.New("verified slot info not found")
+}
+
+func (mb *MockBackend) ShardEquivocations(fromSlot uint64, limit int) ([]*eventTypes.ShardEquivocation, error) {
+	return []*eventTypes.ShardEquivocation{}, nil
+}
+
+func (mb *MockBackend) ShardConflicts(fromSlot uint64, limit int) ([]*eventTypes.ShardConflict, error) {
+	return []*eventTypes.ShardConflict{}, nil
+}
+
+func (mb *MockBackend) ReorgHistory(fromSlot, fromId uint64, limit int) (*eventTypes.ReorgHistoryPage, error) {
+	return &eventTypes.ReorgHistoryPage{Reorgs: []*eventTypes.ReorgRecord{}}, nil
+}
+
+func (mb *MockBackend) HeadAtSlotTime(ctx context.Context, timestamp uint64) (*eventTypes.HistoricalSlot, error) {
+	return nil, errors.New("no verified slot")
+}
+
+func (mb *MockBackend) ShardInfoAsOf(stepId uint64, slot uint64) (*eventTypes.HistoricalSlot, error) {
+	return nil, errors.New("no verified slot")
+}
+
+func (mb *MockBackend) ShardDisagreements(fromSlot uint64, limit int) ([]*eventTypes.ShardDisagreement, error) {
+	return []*eventTypes.ShardDisagreement{}, nil
+}
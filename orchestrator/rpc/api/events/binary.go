@@ -0,0 +1,139 @@
+package events
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// JSONEncoding sends the notifications of a subscription as JSON objects
+	JSONEncoding = "json"
+	// BinaryEncoding sends the notifications of a subscription as SSZ encoded bytes. WebSocket connections write
+	// them as binary frames, other transports carry the same bytes hex-encoded in the JSON notification.
+	BinaryEncoding = "binary"
+)
+
+const (
+	// blockStatusSize is the SSZ size of a block status: hash, status code and finalized slot
+	blockStatusSize = common.HashLength + 1 + 8
+	// blockRetractionSize is the SSZ size of a block retraction: hash, status code, slot and replacedBy hash, which
+	// is zero when it is not known
+	blockRetractionSize = common.HashLength + 1 + 8 + common.HashLength
+)
+
+// statusCodes are the SSZ codes of the statuses, the order must never change
+var statusCodes = []generalTypes.Status{
+	generalTypes.Unknown,
+	generalTypes.Pending,
+	generalTypes.Verified,
+	generalTypes.Invalid,
+	generalTypes.Skipped,
+	generalTypes.Retracted,
+	generalTypes.Finalized,
+}
+
+// binarySubscriptions are the ids of the subscriptions whose notifications are binary encoded
+var binarySubscriptions sync.Map
+
+// ConfirmationStreamOptions are the options which a pandora node negotiates per confirmation stream subscription
+type ConfirmationStreamOptions struct {
+	// Encoding of the notifications, JSONEncoding when it is empty
+	Encoding string `json:"encoding"`
+	// Finalized sends a Finalized status for every verified block once vanguard finalizes its slot. Pandora nodes
+	// which don't know the status leave it unset and only get Verified.
+	Finalized bool `json:"finalized"`
+}
+
+// parseStreamOptions validates the options of a subscription, nil options select the defaults
+func parseStreamOptions(options *ConfirmationStreamOptions) (*ConfirmationStreamOptions, error) {
+	if options == nil {
+		return &ConfirmationStreamOptions{Encoding: JSONEncoding}, nil
+	}
+	parsed := *options
+	switch parsed.Encoding {
+	case "":
+		parsed.Encoding = JSONEncoding
+	case JSONEncoding, BinaryEncoding:
+	default:
+		return nil, errors.Errorf("unsupported encoding %q, use %q or %q", parsed.Encoding, JSONEncoding, BinaryEncoding)
+	}
+	return &parsed, nil
+}
+
+// IsBinarySubscription reports whether the notifications of the subscription are SSZ encoded bytes
+func IsBinarySubscription(id rpc.ID) bool {
+	_, ok := binarySubscriptions.Load(id)
+	return ok
+}
+
+// confirmationNotifier sends the block statuses and retractions of a confirmation stream in the negotiated encoding
+type confirmationNotifier struct {
+	notifier *rpc.Notifier
+	id       rpc.ID
+	binary   bool
+}
+
+// newConfirmationNotifier registers the subscription as binary when binary encoding is negotiated. close must be
+// called when the subscription ends.
+func newConfirmationNotifier(notifier *rpc.Notifier, id rpc.ID, options *ConfirmationStreamOptions) *confirmationNotifier {
+	isBinary := options.Encoding == BinaryEncoding
+	if isBinary {
+		binarySubscriptions.Store(id, struct{}{})
+	}
+	return &confirmationNotifier{notifier: notifier, id: id, binary: isBinary}
+}
+
+func (n *confirmationNotifier) close() {
+	binarySubscriptions.Delete(n.id)
+}
+
+func (n *confirmationNotifier) notifyStatus(status *generalTypes.BlockStatus) error {
+	if n.binary {
+		return n.notifier.Notify(n.id, hexutil.Bytes(encodeBlockStatus(status)))
+	}
+	return n.notifier.Notify(n.id, status)
+}
+
+func (n *confirmationNotifier) notifyRetraction(retraction *generalTypes.BlockRetraction) error {
+	if n.binary {
+		return n.notifier.Notify(n.id, hexutil.Bytes(encodeBlockRetraction(retraction)))
+	}
+	return n.notifier.Notify(n.id, retraction)
+}
+
+// statusCode returns the SSZ code of the status, unknown statuses are encoded as Unknown
+func statusCode(status generalTypes.Status) byte {
+	for code, s := range statusCodes {
+		if s == status {
+			return byte(code)
+		}
+	}
+	return 0
+}
+
+// encodeBlockStatus returns the SSZ encoding of the block status
+func encodeBlockStatus(status *generalTypes.BlockStatus) []byte {
+	enc := make([]byte, blockStatusSize)
+	copy(enc, status.Hash.Bytes())
+	enc[common.HashLength] = statusCode(status.Status)
+	binary.LittleEndian.PutUint64(enc[common.HashLength+1:], status.FinalizedSlot)
+	return enc
+}
+
+// encodeBlockRetraction returns the SSZ encoding of the block retraction
+func encodeBlockRetraction(retraction *generalTypes.BlockRetraction) []byte {
+	enc := make([]byte, blockRetractionSize)
+	copy(enc, retraction.Hash.Bytes())
+	enc[common.HashLength] = statusCode(retraction.Status)
+	binary.LittleEndian.PutUint64(enc[common.HashLength+1:], retraction.Slot)
+	if retraction.ReplacedBy != nil {
+		copy(enc[common.HashLength+9:], retraction.ReplacedBy.Bytes())
+	}
+	return enc
+}
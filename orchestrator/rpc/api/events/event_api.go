@@ -2,30 +2,54 @@ package events
 
 import (
 	"context"
+	"math"
 
 	"github.com/ethereum/go-ethereum/rpc"
 	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 )
 
-// SteamConfirmedPanBlockHashes
+// SteamConfirmedPanBlockHashes streams confirmations to every subscribed pandora node. A node which passes a
+// configured consumer name gets its own acknowledgement tracking, so a standby node is kept in sync with the primary.
+// When a reorg orphans verified blocks, a retraction with the hash, slot and replacedBy of every orphaned block is
+// sent on the same stream. Options negotiate the encoding of the notifications of this subscription and whether
+// Finalized statuses are sent.
 func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 	ctx context.Context,
 	request *BlockHash,
+	consumer *string,
+	options *ConfirmationStreamOptions,
 ) (*rpc.Subscription, error) {
 
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	consumerName := parseConsumer(consumer)
+	if err := api.backend.CheckConsumer(consumerName); err != nil {
+		return &rpc.Subscription{}, err
+	}
+	streamOptions, err := parseStreamOptions(options)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
 	rpcSub := notifier.CreateSubscription()
+	confirmations := newConfirmationNotifier(notifier, rpcSub.ID, streamOptions)
 
 	go func() {
+		defer confirmations.close()
 
 		batchSender := func(start, end uint64) error {
-			slotInfos := api.backend.VerifiedSlotInfos(start)
-
+			var slotInfos map[uint64]*generalTypes.SlotInfo
 			for i := start; i <= end; i++ {
+				// history is read in pages, so a subscription from an early slot does not load the whole chain at once
+				if (i-start)%exportBatchSize == 0 {
+					pageEnd := end
+					if end-i >= exportBatchSize {
+						pageEnd = i + exportBatchSize - 1
+					}
+					slotInfos = api.backend.VerifiedSlotInfoRange(i, pageEnd)
+				}
 				log.WithField("slot", i).WithField("slotInfo", slotInfos[i]).Debug("sending verifiedInfo to pandora batchsender")
 				if slotInfos[i] == nil {
 					// invalid slot requested. maybe slot 0.
@@ -38,11 +62,12 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 					FinalizedSlot: api.backend.LatestFinalizedSlot(),
 				}
 				log.WithField("info", *sendingInfo).Debug("Sending pendingness status to pandora")
-				if err := notifier.Notify(rpcSub.ID, sendingInfo); err != nil {
+				if err := confirmations.notifyStatus(sendingInfo); err != nil {
 					log.WithField("start", start).
 						WithField("end", end).
 						WithError(err).
 						Error("Failed to notify verified slot info. Could not send over stream.")
+					api.backend.DeferConfirmations(i, end)
 					return errors.Wrap(err, "Failed to notify verified slot info. Could not send over stream")
 				}
 			}
@@ -50,6 +75,13 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 		}
 
 		startSlot := request.Slot
+		// pandora may have lost confirmations which were sent but never processed before disconnecting.
+		// So retransmit everything after the acknowledgement high-water mark.
+		if ackedSlot, enabled := api.backend.LatestAckedSlot(consumerName); enabled && ackedSlot+1 < startSlot {
+			log.WithField("requestedSlot", startSlot).WithField("ackedSlot", ackedSlot).
+				WithField("consumer", consumerName).Info("Retransmitting unacknowledged confirmations to pandora")
+			startSlot = ackedSlot + 1
+		}
 		endSlot := api.backend.LatestVerifiedSlot()
 		log.WithField("startSlot", startSlot).WithField("endSlot", endSlot).
 			Debug("received information from pandora")
@@ -80,15 +112,36 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 					}
 				}
 
-				if err := notifier.Notify(rpcSub.ID, &generalTypes.BlockStatus{
+				if slotInfoWithStatus.Status == generalTypes.Finalized && !streamOptions.Finalized {
+					continue
+				}
+				if slotInfoWithStatus.Status == generalTypes.Retracted {
+					if err := confirmations.notifyRetraction(&generalTypes.BlockRetraction{
+						Hash:       slotInfoWithStatus.PandoraHeaderHash,
+						Slot:       slotInfoWithStatus.Slot,
+						Status:     generalTypes.Retracted,
+						ReplacedBy: slotInfoWithStatus.ReplacedBy,
+					}); err != nil {
+						log.WithField("hash", slotInfoWithStatus.PandoraHeaderHash).
+							Error("Failed to notify block retraction. Could not send over stream.")
+						return
+					}
+					continue
+				}
+
+				if err := confirmations.notifyStatus(&generalTypes.BlockStatus{
 					Hash:          slotInfoWithStatus.PandoraHeaderHash,
 					Status:        slotInfoWithStatus.Status,
 					FinalizedSlot: api.backend.LatestFinalizedSlot(),
 				}); err != nil {
 					log.WithField("hash", slotInfoWithStatus.PandoraHeaderHash).
 						Error("Failed to notify slot info status. Could not send over stream.")
+					if slotInfoWithStatus.Status == generalTypes.Verified {
+						api.backend.DeferConfirmations(slotInfoWithStatus.Slot, slotInfoWithStatus.Slot)
+					}
 					return
 				}
+				updateAckLag(api.backend, consumerName)
 			case <-rpcSub.Err():
 				log.Info("Unsubscribing registered subscriber from SteamConfirmedPanBlockHashes")
 				verifiedSlotInfoSub.Unsubscribe()
@@ -103,3 +156,382 @@ func (api *PublicFilterAPI) SteamConfirmedPanBlockHashes(
 
 	return rpcSub, nil
 }
+
+// AckConfirmedPanBlockHashes should be called by pandora when all the confirmations till the given slot are processed.
+// Named consumers pass the same name which they subscribed with.
+func (api *PublicFilterAPI) AckConfirmedPanBlockHashes(ctx context.Context, slot uint64, consumer *string) error {
+	consumerName := parseConsumer(consumer)
+	if err := api.backend.AckConfirmedSlot(slot, consumerName); err != nil {
+		log.WithError(err).WithField("slot", slot).WithField("consumer", consumerName).Warn("Failed to acknowledge confirmed slot")
+		return err
+	}
+	updateAckLag(api.backend, consumerName)
+	log.WithField("slot", slot).WithField("consumer", consumerName).Debug("Pandora acknowledged confirmations")
+	return nil
+}
+
+// GetJournaledConfirmations returns the confirmations and retractions which were published to the consumer from
+// the given slot, in the order they were published. A pandora node which reconnects after a long outage applies
+// them instead of reconciling the status of every slot. The journal is bounded by size and age, so a consumer
+// which finds a gap has to fall back to slot status reconciliation.
+func (api *PublicFilterAPI) GetJournaledConfirmations(
+	ctx context.Context,
+	fromSlot uint64,
+	consumer *string,
+) ([]*generalTypes.JournaledConfirmation, error) {
+	consumerName := parseConsumer(consumer)
+	backlog, err := api.backend.JournaledConfirmations(consumerName, fromSlot)
+	if err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).WithField("consumer", consumerName).
+			Debug("Failed to retrieve journaled confirmations")
+		return nil, err
+	}
+	return backlog, nil
+}
+
+// parseConsumer returns the name of the optional consumer argument. Empty name is the default consumer.
+func parseConsumer(consumer *string) string {
+	if consumer == nil {
+		return ""
+	}
+	return *consumer
+}
+
+// SlotHeaders streams only (slot, panHeaderHash, vanBlockRoot, status, stepId) tuples without shard payloads.
+// It is targeted at wallets and light services which only need confirmation bits.
+func (api *PublicFilterAPI) SlotHeaders(ctx context.Context, fromSlot uint64) (*rpc.Subscription, error) {
+	return api.slotHeaders(ctx, fromSlot, math.MaxUint64)
+}
+
+// SlotHeadersWindow streams the same tuples as SlotHeaders but only for slots of [fromSlot, toSlot]. Once the
+// verified chain reaches toSlot, a null notification marks the window as delivered and the stream stops, so
+// backfill jobs know when to unsubscribe.
+func (api *PublicFilterAPI) SlotHeadersWindow(ctx context.Context, fromSlot uint64, toSlot uint64) (*rpc.Subscription, error) {
+	if toSlot < fromSlot {
+		return &rpc.Subscription{}, errors.Errorf("invalid slot window [%d, %d]", fromSlot, toSlot)
+	}
+	return api.slotHeaders(ctx, fromSlot, toSlot)
+}
+
+// SlotHeadersResume continues a SlotHeaders stream right after the slot of the resume token which was delivered
+// last, so a reconnecting client gets neither duplicate nor missed confirmations.
+func (api *PublicFilterAPI) SlotHeadersResume(ctx context.Context, token string) (*rpc.Subscription, error) {
+	fromSlot, err := resumeSlot(api.backend, token)
+	if err != nil {
+		return &rpc.Subscription{}, err
+	}
+	return api.slotHeaders(ctx, fromSlot, math.MaxUint64)
+}
+
+// slotHeaders streams slot header tuples of [fromSlot, toSlot]
+func (api *PublicFilterAPI) slotHeaders(ctx context.Context, fromSlot uint64, toSlot uint64) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	// completeWindow notifies the end of the window
+	completeWindow := func() {
+		if err := notifier.Notify(rpcSub.ID, nil); err != nil {
+			log.WithField("toSlot", toSlot).WithError(err).Error("Failed to notify end of slot window")
+			return
+		}
+		log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).Debug("Delivered slot header window")
+	}
+
+	go func() {
+		// subscribing before sending historical tuples so that no verified slot or retraction is missed in between.
+		// The subscription is drained into a queue, so the event loop is not blocked while history is replayed.
+		slotInfoCh := make(chan *generalTypes.SlotInfoWithStatus, 1)
+		verifiedSlotInfoSub := api.events.SubscribeVerifiedSlotInfo(slotInfoCh)
+		defer verifiedSlotInfoSub.Unsubscribe()
+		queue := newSlotInfoQueue()
+		done := make(chan struct{})
+		defer close(done)
+		go queue.drain(slotInfoCh, done)
+
+		endSlot := api.backend.LatestVerifiedSlot()
+		if fromSlot <= endSlot {
+			lastSlot := endSlot
+			if toSlot < lastSlot {
+				lastSlot = toSlot
+			}
+			// history is read in pages, so a subscription from an early slot does not load the whole chain at once
+			for pageStart := fromSlot; ; {
+				pageEnd := lastSlot
+				if lastSlot-pageStart >= exportBatchSize {
+					pageEnd = pageStart + exportBatchSize - 1
+				}
+				slotInfos := api.backend.VerifiedSlotInfoRange(pageStart, pageEnd)
+				for slot := pageStart; slot <= pageEnd; slot++ {
+					slotInfo := slotInfos[slot]
+					if slotInfo == nil {
+						continue
+					}
+					if err := notifier.Notify(rpcSub.ID, &generalTypes.SlotHeaderStatus{
+						Slot:              slot,
+						PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+						VanguardBlockRoot: slotInfo.VanguardBlockHash,
+						Status:            generalTypes.Verified,
+						StepId:            stepId(api.backend, slot),
+						ResumeToken:       newResumeToken(api.backend, slot),
+					}); err != nil {
+						log.WithField("slot", slot).WithError(err).
+							Error("Failed to notify slot header status. Could not send over stream.")
+						return
+					}
+				}
+				if pageEnd == lastSlot {
+					break
+				}
+				pageStart = pageEnd + 1
+			}
+		}
+		if endSlot >= toSlot {
+			completeWindow()
+			return
+		}
+
+		for {
+			select {
+			case <-queue.ready:
+				slotInfos, err := queue.take()
+				if err != nil {
+					log.WithField("fromSlot", fromSlot).WithError(err).Error("Dropping slot header subscriber")
+					return
+				}
+				for _, slotInfoWithStatus := range slotInfos {
+					if slotInfoWithStatus.Slot < fromSlot {
+						continue
+					}
+					retracted := slotInfoWithStatus.Status == generalTypes.Retracted
+					if retracted {
+						// retraction does not advance the window
+						if slotInfoWithStatus.Slot > toSlot {
+							continue
+						}
+						// the slot is verified again on the new chain, so it must not be skipped as already sent
+						if slotInfoWithStatus.Slot <= endSlot {
+							endSlot = slotInfoWithStatus.Slot - 1
+						}
+					} else if slotInfoWithStatus.Slot > toSlot {
+						// verified chain is already beyond the window
+						completeWindow()
+						return
+					}
+					// already sent while sending historical tuples
+					if slotInfoWithStatus.Status == generalTypes.Verified && slotInfoWithStatus.Slot <= endSlot {
+						continue
+					}
+					header := &generalTypes.SlotHeaderStatus{
+						Slot:              slotInfoWithStatus.Slot,
+						PandoraHeaderHash: slotInfoWithStatus.PandoraHeaderHash,
+						VanguardBlockRoot: slotInfoWithStatus.VanguardBlockHash,
+						Status:            slotInfoWithStatus.Status,
+						StepId:            slotInfoWithStatus.StepId,
+					}
+					if header.Status == generalTypes.Verified && header.StepId != nil {
+						header.ResumeToken = newResumeToken(api.backend, header.Slot)
+					}
+					if err := notifier.Notify(rpcSub.ID, header); err != nil {
+						log.WithField("slot", slotInfoWithStatus.Slot).WithError(err).
+							Error("Failed to notify slot header status. Could not send over stream.")
+						return
+					}
+					if !retracted && slotInfoWithStatus.Slot == toSlot {
+						completeWindow()
+						return
+					}
+				}
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from SlotHeaders")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from SlotHeaders")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// stepId returns the position of the slot in the verified chain or nil when the slot is not accumulated
+func stepId(backend Backend, slot uint64) *uint64 {
+	step, err := backend.AccumulatorStep(slot)
+	if err != nil || step == nil {
+		return nil
+	}
+	id := step.LeafIndex
+	return &id
+}
+
+// exportBatchSize is the number of slots which are read from db at once while exporting
+const exportBatchSize = 1024
+
+// ExportVerifiedSlots streams every verified slot of [fromSlot, toSlot] in order without following new slots.
+// It is targeted at explorers which copy the full verification history from an archive orchestrator.
+func (api *PublicFilterAPI) ExportVerifiedSlots(ctx context.Context, fromSlot uint64, toSlot uint64) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if toSlot < fromSlot {
+		return &rpc.Subscription{}, errors.Errorf("invalid slot range [%d, %d]", fromSlot, toSlot)
+	}
+	// fail early when archive mode is disabled
+	if _, err := api.backend.VerifiedSlotRange(fromSlot, fromSlot); err != nil {
+		return &rpc.Subscription{}, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		for batchStart := fromSlot; batchStart <= toSlot; {
+			select {
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from ExportVerifiedSlots")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from ExportVerifiedSlots")
+				return
+			default:
+			}
+
+			batchEnd := toSlot
+			if toSlot-batchStart >= exportBatchSize {
+				batchEnd = batchStart + exportBatchSize - 1
+			}
+			slots, err := api.backend.VerifiedSlotRange(batchStart, batchEnd)
+			if err != nil {
+				log.WithError(err).WithField("fromSlot", batchStart).Error("Failed to export verified slots")
+				return
+			}
+			for _, slot := range slots {
+				if err := notifier.Notify(rpcSub.ID, slot); err != nil {
+					log.WithField("slot", slot.Slot).WithError(err).
+						Error("Failed to notify verified slot. Could not send over stream.")
+					return
+				}
+			}
+			if batchEnd == toSlot {
+				break
+			}
+			batchStart = batchEnd + 1
+		}
+		log.WithField("fromSlot", fromSlot).WithField("toSlot", toSlot).Debug("Exported verified slots")
+	}()
+
+	return rpcSub, nil
+}
+
+// EpochSummaries streams the summary of every epoch which is finished after subscribing. It is targeted at
+// dashboards, which can read earlier epochs with GetEpochSummary.
+func (api *PublicFilterAPI) EpochSummaries(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		summaryCh := make(chan *generalTypes.EpochSummary, 1)
+		summarySub := api.backend.SubscribeEpochSummaryEvent(summaryCh)
+		defer summarySub.Unsubscribe()
+
+		for {
+			select {
+			case summary := <-summaryCh:
+				if err := notifier.Notify(rpcSub.ID, summary); err != nil {
+					log.WithField("epoch", summary.Epoch).WithError(err).
+						Error("Failed to notify epoch summary. Could not send over stream.")
+					return
+				}
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from EpochSummaries")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from EpochSummaries")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// ShardConflicts alerts about every shard info which is refused after subscribing, because its slot is already
+// verified with a different pandora header. Operators can read earlier conflicts with GetShardConflicts.
+func (api *PublicFilterAPI) ShardConflicts(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		conflictCh := make(chan *generalTypes.ShardConflict, 1)
+		conflictSub := api.backend.SubscribeShardConflictEvent(conflictCh)
+		defer conflictSub.Unsubscribe()
+
+		for {
+			select {
+			case conflict := <-conflictCh:
+				if err := notifier.Notify(rpcSub.ID, conflict); err != nil {
+					log.WithField("slot", conflict.Slot).WithError(err).
+						Error("Failed to notify shard conflict. Could not send over stream.")
+					return
+				}
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from ShardConflicts")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from ShardConflicts")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// VerifiedSlotInfo streams the verification status of every slot which is processed after subscribing. Unlike
+// SlotHeaders it sends no history, so explorers and monitoring tools can follow confirmations in real time.
+func (api *PublicFilterAPI) VerifiedSlotInfo(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		slotInfoCh := make(chan *generalTypes.SlotInfoWithStatus)
+		verifiedSlotInfoSub := api.events.SubscribeVerifiedSlotInfo(slotInfoCh)
+		defer verifiedSlotInfoSub.Unsubscribe()
+
+		for {
+			select {
+			case slotInfoWithStatus := <-slotInfoCh:
+				if err := notifier.Notify(rpcSub.ID, &generalTypes.SlotHeaderStatus{
+					Slot:              slotInfoWithStatus.Slot,
+					PandoraHeaderHash: slotInfoWithStatus.PandoraHeaderHash,
+					VanguardBlockRoot: slotInfoWithStatus.VanguardBlockHash,
+					Status:            slotInfoWithStatus.Status,
+					StepId:            slotInfoWithStatus.StepId,
+				}); err != nil {
+					log.WithField("slot", slotInfoWithStatus.Slot).WithError(err).
+						Error("Failed to notify verified slot info. Could not send over stream.")
+					return
+				}
+			case <-rpcSub.Err():
+				log.Info("Unsubscribing registered subscriber from VerifiedSlotInfo")
+				return
+			case <-notifier.Closed():
+				log.Info("Closing notifier. Unsubscribing registered subscriber from VerifiedSlotInfo")
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
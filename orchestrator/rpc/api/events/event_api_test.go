@@ -0,0 +1,235 @@
+package events
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// TestPublicFilterAPI_SlotHeadersWindow checks that the window subscription ends with a null notification
+// once every slot of the window is delivered
+func TestPublicFilterAPI_SlotHeadersWindow(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.verifiedSlotInfos = make(map[uint64]*eventTypes.SlotInfo)
+	for slot := uint64(1); slot <= 5; slot++ {
+		backend.verifiedSlotInfos[slot] = &eventTypes.SlotInfo{
+			VanguardBlockHash: common.BigToHash(common.Big1),
+			PandoraHeaderHash: common.BigToHash(common.Big2),
+		}
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	headers := make(chan *eventTypes.SlotHeaderStatus)
+	sub, err := client.Subscribe(ctx, "orc", headers, "slotHeadersWindow", 2, 4)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	receive := func() *eventTypes.SlotHeaderStatus {
+		select {
+		case header := <-headers:
+			return header
+		case <-ctx.Done():
+			t.Fatal("slot header window is not delivered")
+			return nil
+		}
+	}
+	for slot := uint64(2); slot <= 4; slot++ {
+		header := receive()
+		require.NotNil(t, header)
+		assert.Equal(t, slot, header.Slot)
+		assert.Equal(t, eventTypes.Verified, header.Status)
+	}
+	// end of window
+	assert.Equal(t, (*eventTypes.SlotHeaderStatus)(nil), receive())
+
+	_, err = client.Subscribe(ctx, "orc", headers, "slotHeadersWindow", 4, 2)
+	assert.ErrorContains(t, "invalid slot window", err)
+}
+
+// TestPublicFilterAPI_EpochSummaries
+func TestPublicFilterAPI_EpochSummaries(t *testing.T) {
+	backend, eventApi := setup(t)
+	summary := &eventTypes.EpochSummary{Epoch: 7, VerifiedSlots: 30, SkippedSlots: 2, AverageConfirmationLatency: 420}
+	backend.EpochSummaries = map[uint64]*eventTypes.EpochSummary{7: summary}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var stored *eventTypes.EpochSummary
+	require.NoError(t, client.CallContext(ctx, &stored, "orc_getEpochSummary", 7))
+	assert.DeepEqual(t, summary, stored)
+	assert.ErrorContains(t, "epoch summary not found", client.CallContext(ctx, &stored, "orc_getEpochSummary", 8))
+
+	summaries := make(chan *eventTypes.EpochSummary)
+	sub, err := client.Subscribe(ctx, "orc", summaries, "epochSummaries")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// subscription is installed by the stream go routine
+	for backend.EpochSummaryFeed.Send(summary) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case received := <-summaries:
+		assert.DeepEqual(t, summary, received)
+	case <-ctx.Done():
+		t.Fatal("epoch summary is not streamed")
+	}
+}
+
+// TestPublicFilterAPI_SlotHeadersResume checks that a resumed stream continues right after the token slot and
+// resends the token slot when its step was rewritten
+func TestPublicFilterAPI_SlotHeadersResume(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.verifiedSlotInfos = make(map[uint64]*eventTypes.SlotInfo)
+	backend.AccumulatorSteps = make(map[uint64]*eventTypes.AccumulatorStep)
+	for slot := uint64(1); slot <= 5; slot++ {
+		backend.verifiedSlotInfos[slot] = &eventTypes.SlotInfo{
+			VanguardBlockHash: common.BigToHash(common.Big1),
+			PandoraHeaderHash: common.BigToHash(common.Big2),
+		}
+		backend.AccumulatorSteps[slot] = &eventTypes.AccumulatorStep{
+			Slot:      slot,
+			LeafIndex: slot - 1,
+			Leaf:      common.BigToHash(new(big.Int).SetUint64(slot)),
+		}
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receive := func(headers chan *eventTypes.SlotHeaderStatus) *eventTypes.SlotHeaderStatus {
+		select {
+		case header := <-headers:
+			return header
+		case <-ctx.Done():
+			t.Fatal("slot header is not delivered")
+			return nil
+		}
+	}
+
+	headers := make(chan *eventTypes.SlotHeaderStatus)
+	sub, err := client.Subscribe(ctx, "orc", headers, "slotHeadersWindow", 1, 2)
+	require.NoError(t, err)
+	receive(headers)
+	header := receive(headers)
+	require.NotNil(t, header)
+	assert.NotEqual(t, "", header.ResumeToken)
+	sub.Unsubscribe()
+
+	headers = make(chan *eventTypes.SlotHeaderStatus)
+	sub, err = client.Subscribe(ctx, "orc", headers, "slotHeadersResume", header.ResumeToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), receive(headers).Slot)
+	sub.Unsubscribe()
+
+	// slot 2 is rewritten by a reorg so it is delivered again
+	backend.AccumulatorSteps[2].Leaf = common.BigToHash(common.Big3)
+	headers = make(chan *eventTypes.SlotHeaderStatus)
+	sub, err = client.Subscribe(ctx, "orc", headers, "slotHeadersResume", header.ResumeToken)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), receive(headers).Slot)
+	sub.Unsubscribe()
+
+	_, err = client.Subscribe(ctx, "orc", headers, "slotHeadersResume", "not-a-token")
+	assert.ErrorContains(t, errInvalidResumeToken.Error(), err)
+}
+
+// TestPublicFilterAPI_VerifiedSlotInfo checks that live slot statuses are streamed to external subscribers
+func TestPublicFilterAPI_VerifiedSlotInfo(t *testing.T) {
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	headers := make(chan *eventTypes.SlotHeaderStatus)
+	sub, err := client.Subscribe(ctx, "orc", headers, "verifiedSlotInfo")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// keeps sending until the subscription is installed in the event system
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:   7,
+				Status: eventTypes.Invalid,
+			})
+		case header := <-headers:
+			assert.Equal(t, uint64(7), header.Slot)
+			assert.Equal(t, eventTypes.Invalid, header.Status)
+			return
+		case <-ctx.Done():
+			t.Fatal("verified slot info is not delivered")
+		}
+	}
+}
+
+// TestPublicFilterAPI_GetProposerForSlot checks that the proposer is picked from the stored epoch info of the slot
+func TestPublicFilterAPI_GetProposerForSlot(t *testing.T) {
+	backend, eventApi := setup(t)
+	proposerKey := "0x" + strings.Repeat("ab", 48)
+	backend.ConsensusInfos[2].ValidatorList[5] = proposerKey
+
+	proposer, err := eventApi.GetProposerForSlot(context.Background(), 2*params.SlotsPerEpoch+5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), proposer.Epoch)
+	assert.Equal(t, proposerKey, proposer.PublicKey)
+
+	_, err = eventApi.GetProposerForSlot(context.Background(), 100*params.SlotsPerEpoch)
+	assert.ErrorContains(t, "epoch info not found", err)
+}
+
+// TestSlotInfoQueue checks that slot infos are taken in order of arrival and that a subscriber which falls too far
+// behind is dropped
+func TestSlotInfoQueue(t *testing.T) {
+	queue := newSlotInfoQueue()
+	for slot := uint64(0); slot < maxQueuedSlotInfos; slot++ {
+		queue.push(&eventTypes.SlotInfoWithStatus{Slot: slot})
+	}
+	<-queue.ready
+	slotInfos, err := queue.take()
+	require.NoError(t, err)
+	require.Equal(t, maxQueuedSlotInfos, len(slotInfos))
+	assert.Equal(t, uint64(maxQueuedSlotInfos-1), slotInfos[maxQueuedSlotInfos-1].Slot)
+
+	for slot := uint64(0); slot <= maxQueuedSlotInfos; slot++ {
+		queue.push(&eventTypes.SlotInfoWithStatus{Slot: slot})
+	}
+	<-queue.ready
+	_, err = queue.take()
+	assert.ErrorContains(t, errSlotInfoQueueFull.Error(), err)
+}
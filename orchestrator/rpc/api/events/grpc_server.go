@@ -0,0 +1,174 @@
+package events
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	orcpb "github.com/lukso-network/lukso-orchestrator/shared/proto/orchestrator/v1"
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// OrchestratorServer serves the verified shard infos over gRPC. It shares the backend and the event system of the
+// orc namespace, so both APIs send the same slots in the same order.
+type OrchestratorServer struct {
+	orcpb.UnimplementedOrchestratorServer
+
+	backend Backend
+	events  *EventSystem
+}
+
+// NewOrchestratorServer returns the gRPC server of the filter API
+func NewOrchestratorServer(api *PublicFilterAPI) *OrchestratorServer {
+	return &OrchestratorServer{backend: api.backend, events: api.events}
+}
+
+// StreamVerifiedShardInfo sends the stored verified shard infos from the requested slot and then follows the
+// verified chain like the SlotHeaders subscription. Finalized slots are only sent when the request opts in.
+func (s *OrchestratorServer) StreamVerifiedShardInfo(
+	req *orcpb.StreamVerifiedShardInfoRequest,
+	stream orcpb.Orchestrator_StreamVerifiedShardInfoServer,
+) error {
+	// subscribing before sending historical shard infos so that no verified slot or retraction is missed in between
+	slotInfoCh := make(chan *generalTypes.SlotInfoWithStatus, 1)
+	verifiedSlotInfoSub := s.events.SubscribeVerifiedSlotInfo(slotInfoCh)
+	defer verifiedSlotInfoSub.Unsubscribe()
+	queue := newSlotInfoQueue()
+	done := make(chan struct{})
+	defer close(done)
+	go queue.drain(slotInfoCh, done)
+
+	// without a from slot nothing is replayed, but every slot which is verified or retracted later is sent
+	fromSlot := req.GetFromSlot()
+	endSlot := s.backend.LatestVerifiedSlot()
+	if req.FromSlot != nil {
+		if err := s.sendHistory(stream, fromSlot, endSlot); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-queue.ready:
+			slotInfos, err := queue.take()
+			if err != nil {
+				log.WithField("fromSlot", fromSlot).WithError(err).Error("Dropping gRPC verified shard info stream")
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			for _, slotInfoWithStatus := range slotInfos {
+				if slotInfoWithStatus.Slot < fromSlot {
+					continue
+				}
+				if slotInfoWithStatus.Status == generalTypes.Finalized && !req.Finalized {
+					continue
+				}
+				if slotInfoWithStatus.Status == generalTypes.Retracted {
+					// the slot is verified again on the new chain, so it must not be skipped as already sent
+					if slotInfoWithStatus.Slot <= endSlot {
+						endSlot = slotInfoWithStatus.Slot - 1
+					}
+				} else if slotInfoWithStatus.Status == generalTypes.Verified && slotInfoWithStatus.Slot <= endSlot {
+					// already sent while sending historical shard infos
+					continue
+				}
+				if err := stream.Send(verifiedShardInfo(slotInfoWithStatus)); err != nil {
+					log.WithField("slot", slotInfoWithStatus.Slot).WithError(err).
+						Debug("Failed to send verified shard info over gRPC stream")
+					return err
+				}
+			}
+		case <-stream.Context().Done():
+			log.Info("Closing gRPC verified shard info stream")
+			return nil
+		}
+	}
+}
+
+// sendHistory sends the stored verified shard infos of [fromSlot, endSlot]. They are read in pages, so a stream from
+// an early slot does not load the whole chain at once.
+func (s *OrchestratorServer) sendHistory(
+	stream orcpb.Orchestrator_StreamVerifiedShardInfoServer,
+	fromSlot uint64,
+	endSlot uint64,
+) error {
+	for pageStart := fromSlot; pageStart <= endSlot; {
+		pageEnd := endSlot
+		if endSlot-pageStart >= exportBatchSize {
+			pageEnd = pageStart + exportBatchSize - 1
+		}
+		slotInfos := s.backend.VerifiedSlotInfoRange(pageStart, pageEnd)
+		for slot := pageStart; slot <= pageEnd; slot++ {
+			slotInfo := slotInfos[slot]
+			if slotInfo == nil {
+				continue
+			}
+			if err := stream.Send(&orcpb.VerifiedShardInfo{
+				Slot:              slot,
+				VanguardBlockHash: slotInfo.VanguardBlockHash.Bytes(),
+				PandoraHeaderHash: slotInfo.PandoraHeaderHash.Bytes(),
+				Status:            orcpb.Status_STATUS_VERIFIED,
+				StepId:            stepId(s.backend, slot),
+			}); err != nil {
+				log.WithField("slot", slot).WithError(err).Debug("Failed to send verified shard info over gRPC stream")
+				return err
+			}
+		}
+		if pageEnd == endSlot {
+			break
+		}
+		pageStart = pageEnd + 1
+	}
+	return nil
+}
+
+// GetLatestFinalized returns the latest slot and epoch which vanguard has finalized
+func (s *OrchestratorServer) GetLatestFinalized(ctx context.Context, _ *emptypb.Empty) (*orcpb.LatestFinalized, error) {
+	return &orcpb.LatestFinalized{
+		Slot:  s.backend.LatestFinalizedSlot(),
+		Epoch: s.backend.LatestFinalizedEpoch(),
+	}, nil
+}
+
+// GetSlotStatus returns the verification status of the pandora header hash or vanguard block hash of the slot
+func (s *OrchestratorServer) GetSlotStatus(ctx context.Context, req *orcpb.SlotStatusRequest) (*orcpb.SlotStatus, error) {
+	if len(req.Hash) != common.HashLength {
+		return nil, status.Errorf(codes.InvalidArgument, "hash has %d bytes, want %d", len(req.Hash), common.HashLength)
+	}
+	var requestFrom bool
+	switch req.Chain {
+	case orcpb.Chain_CHAIN_PANDORA:
+		requestFrom = true
+	case orcpb.Chain_CHAIN_VANGUARD:
+		requestFrom = false
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain %d", req.Chain)
+	}
+	hash := common.BytesToHash(req.Hash)
+	return &orcpb.SlotStatus{
+		Slot:   req.Slot,
+		Hash:   hash.Bytes(),
+		Status: protoStatus(s.backend.GetSlotStatus(ctx, req.Slot, hash, requestFrom, req.Finalized)),
+	}, nil
+}
+
+// verifiedShardInfo converts a verified slot info event into its protobuf message
+func verifiedShardInfo(slotInfo *generalTypes.SlotInfoWithStatus) *orcpb.VerifiedShardInfo {
+	shardInfo := &orcpb.VerifiedShardInfo{
+		Slot:              slotInfo.Slot,
+		VanguardBlockHash: slotInfo.VanguardBlockHash.Bytes(),
+		PandoraHeaderHash: slotInfo.PandoraHeaderHash.Bytes(),
+		Status:            protoStatus(slotInfo.Status),
+		StepId:            slotInfo.StepId,
+	}
+	if slotInfo.ReplacedBy != nil {
+		shardInfo.ReplacedBy = slotInfo.ReplacedBy.Bytes()
+	}
+	return shardInfo
+}
+
+// protoStatus returns the protobuf status, which has the same codes as the binary confirmation stream
+func protoStatus(slotStatus generalTypes.Status) orcpb.Status {
+	return orcpb.Status(statusCode(slotStatus))
+}
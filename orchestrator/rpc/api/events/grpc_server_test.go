@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	orcpb "github.com/lukso-network/lukso-orchestrator/shared/proto/orchestrator/v1"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"google.golang.org/grpc"
+)
+
+// shardInfoStream collects the shard infos which the server sends over a stream
+type shardInfoStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	shardInfos chan *orcpb.VerifiedShardInfo
+}
+
+func (s *shardInfoStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *shardInfoStream) Send(shardInfo *orcpb.VerifiedShardInfo) error {
+	select {
+	case s.shardInfos <- shardInfo:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func TestOrchestratorServer_StreamVerifiedShardInfo(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.verifiedSlotInfos = make(map[uint64]*eventTypes.SlotInfo)
+	for slot := uint64(98); slot <= 100; slot++ {
+		backend.verifiedSlotInfos[slot] = &eventTypes.SlotInfo{
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot)}),
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot), 1}),
+		}
+	}
+	server := NewOrchestratorServer(eventApi)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream := &shardInfoStream{ctx: ctx, shardInfos: make(chan *orcpb.VerifiedShardInfo, 16)}
+	fromSlot := uint64(99)
+	// the stream ends when the test cancels its context
+	go server.StreamVerifiedShardInfo(&orcpb.StreamVerifiedShardInfoRequest{FromSlot: &fromSlot}, stream)
+
+	receive := func() *orcpb.VerifiedShardInfo {
+		select {
+		case shardInfo := <-stream.shardInfos:
+			return shardInfo
+		case <-ctx.Done():
+			t.Fatal("verified shard info is not delivered")
+			return nil
+		}
+	}
+	for slot := uint64(99); slot <= 100; slot++ {
+		shardInfo := receive()
+		assert.Equal(t, slot, shardInfo.Slot)
+		assert.Equal(t, orcpb.Status_STATUS_VERIFIED, shardInfo.Status)
+		assert.DeepEqual(t, backend.verifiedSlotInfos[slot].PandoraHeaderHash.Bytes(), shardInfo.PandoraHeaderHash)
+	}
+
+	replacedBy := common.HexToHash("0x99")
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// keeps sending until the subscription is installed in the event system
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:       100,
+				ReplacedBy: &replacedBy,
+				Status:     eventTypes.Retracted,
+			})
+		case shardInfo := <-stream.shardInfos:
+			assert.Equal(t, uint64(100), shardInfo.Slot)
+			assert.Equal(t, orcpb.Status_STATUS_RETRACTED, shardInfo.Status)
+			assert.DeepEqual(t, replacedBy.Bytes(), shardInfo.ReplacedBy)
+			return
+		case <-ctx.Done():
+			t.Fatal("retraction is not delivered")
+		}
+	}
+}
+
+func TestProtoStatus(t *testing.T) {
+	assert.Equal(t, orcpb.Status_STATUS_UNKNOWN, protoStatus(eventTypes.Unknown))
+	assert.Equal(t, orcpb.Status_STATUS_VERIFIED, protoStatus(eventTypes.Verified))
+	assert.Equal(t, orcpb.Status_STATUS_FINALIZED, protoStatus(eventTypes.Finalized))
+	require.Equal(t, len(statusCodes), len(orcpb.Status_name))
+}
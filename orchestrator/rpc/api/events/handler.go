@@ -113,6 +113,7 @@ func (sub *Subscription) Unsubscribe() {
 			case sub.es.uninstall <- sub.f:
 				break uninstallLoop
 			case <-sub.f.consensusInfo:
+			case <-sub.f.slotInfo:
 			}
 		}
 
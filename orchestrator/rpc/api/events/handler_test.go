@@ -1,8 +1,13 @@
 package events
 
 import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
 	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
 	eventTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
 	"testing"
 	"time"
@@ -96,3 +101,201 @@ func Test_MinimalConsensusInfo_With_Future_Epoch(t *testing.T) {
 
 	<-subscriber.Err()
 }
+
+// Test_AckConfirmedPanBlockHashes checks that acknowledgements only move the high-water mark forward
+func Test_AckConfirmedPanBlockHashes(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.AckEnabled = true
+
+	assert.NoError(t, eventApi.AckConfirmedPanBlockHashes(context.Background(), 10, nil))
+	assert.NoError(t, eventApi.AckConfirmedPanBlockHashes(context.Background(), 5, nil))
+
+	ackedSlot, enabled := backend.LatestAckedSlot("")
+	assert.Equal(t, true, enabled)
+	assert.Equal(t, uint64(10), ackedSlot)
+}
+
+// Test_SteamConfirmedPanBlockHashes_Retraction checks that orphaned blocks are retracted on the confirmation stream
+func Test_SteamConfirmedPanBlockHashes_Retraction(t *testing.T) {
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	retractions := make(chan *eventTypes.BlockRetraction)
+	sub, err := client.Subscribe(ctx, "orc", retractions, "steamConfirmedPanBlockHashes", &BlockHash{Slot: 1})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	replacedBy := common.HexToHash("0x99")
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// keeps sending until the subscription is installed in the event system
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:              9,
+				PandoraHeaderHash: common.HexToHash("0x92"),
+				ReplacedBy:        &replacedBy,
+				Status:            eventTypes.Retracted,
+			})
+		case retraction := <-retractions:
+			assert.Equal(t, uint64(9), retraction.Slot)
+			assert.Equal(t, common.HexToHash("0x92"), retraction.Hash)
+			assert.Equal(t, eventTypes.Retracted, retraction.Status)
+			require.NotNil(t, retraction.ReplacedBy)
+			assert.Equal(t, replacedBy, *retraction.ReplacedBy)
+			return
+		case <-ctx.Done():
+			t.Fatal("block retraction is not delivered")
+		}
+	}
+}
+
+// Test_AckConfirmedPanBlockHashes_Consumers checks that named consumers keep their own high-water mark
+func Test_AckConfirmedPanBlockHashes_Consumers(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.AckEnabled = true
+	backend.ConsumerAckedSlots = make(map[string]uint64)
+
+	standby, unknown := "standby", "unknown"
+	assert.NoError(t, eventApi.AckConfirmedPanBlockHashes(context.Background(), 10, nil))
+	assert.NoError(t, eventApi.AckConfirmedPanBlockHashes(context.Background(), 4, &standby))
+	assert.ErrorContains(t, "unknown confirmation consumer", eventApi.AckConfirmedPanBlockHashes(context.Background(), 4, &unknown))
+
+	ackedSlot, _ := backend.LatestAckedSlot("")
+	assert.Equal(t, uint64(10), ackedSlot)
+	ackedSlot, _ = backend.LatestAckedSlot(standby)
+	assert.Equal(t, uint64(4), ackedSlot)
+}
+
+// Test_SteamConfirmedPanBlockHashes_Binary checks that a subscription which negotiated binary encoding receives SSZ
+// encoded block statuses
+func Test_SteamConfirmedPanBlockHashes_Binary(t *testing.T) {
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	statuses := make(chan hexutil.Bytes)
+	_, err := client.Subscribe(ctx, "orc", statuses, "steamConfirmedPanBlockHashes", &BlockHash{Slot: 1}, nil,
+		&ConfirmationStreamOptions{Encoding: "protobuf"})
+	assert.ErrorContains(t, "unsupported encoding", err)
+
+	sub, err := client.Subscribe(ctx, "orc", statuses, "steamConfirmedPanBlockHashes", &BlockHash{Slot: 1}, nil,
+		&ConfirmationStreamOptions{Encoding: BinaryEncoding})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// keeps sending until the subscription is installed in the event system
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:              9,
+				PandoraHeaderHash: common.HexToHash("0x92"),
+				Status:            eventTypes.Verified,
+			})
+		case status := <-statuses:
+			expected := encodeBlockStatus(&eventTypes.BlockStatus{
+				Hash:          common.HexToHash("0x92"),
+				Status:        eventTypes.Verified,
+				FinalizedSlot: backend.LatestFinalizedSlot(),
+			})
+			assert.Equal(t, blockStatusSize, len(status))
+			assert.DeepEqual(t, expected, []byte(status))
+			assert.Equal(t, statusCode(eventTypes.Verified), status[common.HashLength])
+			return
+		case <-ctx.Done():
+			t.Fatal("binary block status is not delivered")
+		}
+	}
+}
+
+// Test_SteamConfirmedPanBlockHashes_Finalized checks that only subscriptions which opted in receive Finalized statuses
+func Test_SteamConfirmedPanBlockHashes_Finalized(t *testing.T) {
+	backend, eventApi := setup(t)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	statuses := make(chan *eventTypes.BlockStatus)
+	sub, err := client.Subscribe(ctx, "orc", statuses, "steamConfirmedPanBlockHashes", &BlockHash{Slot: 1})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	finalizedStatuses := make(chan *eventTypes.BlockStatus)
+	finalizedSub, err := client.Subscribe(ctx, "orc", finalizedStatuses, "steamConfirmedPanBlockHashes",
+		&BlockHash{Slot: 1}, nil, &ConfirmationStreamOptions{Finalized: true})
+	require.NoError(t, err)
+	defer finalizedSub.Unsubscribe()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	var verified, finalized bool
+	for !verified || !finalized {
+		select {
+		case <-ticker.C:
+			// keeps sending until the subscriptions are installed in the event system
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:              8,
+				PandoraHeaderHash: common.HexToHash("0x82"),
+				Status:            eventTypes.Finalized,
+			})
+			backend.verifiedSlotInfoFeed.Send(&eventTypes.SlotInfoWithStatus{
+				Slot:              9,
+				PandoraHeaderHash: common.HexToHash("0x92"),
+				Status:            eventTypes.Verified,
+			})
+		case status := <-statuses:
+			assert.Equal(t, eventTypes.Verified, status.Status)
+			verified = true
+		case status := <-finalizedStatuses:
+			if status.Status == eventTypes.Finalized {
+				assert.Equal(t, common.HexToHash("0x82"), status.Hash)
+				finalized = true
+			}
+		case <-ctx.Done():
+			t.Fatal("block statuses are not delivered")
+		}
+	}
+}
+
+// Test_MinimalConsensusInfo_Pruned checks that a subscription from a pruned epoch fails instead of skipping epochs
+func Test_MinimalConsensusInfo_Pruned(t *testing.T) {
+	backend, eventApi := setup(t)
+	backend.EarliestEpoch = 2
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(t, server.RegisterName("orc", eventApi))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	consensusInfos := make(chan *eventTypes.MinimalEpochConsensusInfoV2)
+	_, err := client.Subscribe(ctx, "orc", consensusInfos, "minimalConsensusInfo", 1)
+	assert.ErrorContains(t, "epoch info is pruned", err)
+
+	sub, err := client.Subscribe(ctx, "orc", consensusInfos, "minimalConsensusInfo", 2)
+	require.NoError(t, err)
+	sub.Unsubscribe()
+}
@@ -0,0 +1,31 @@
+package events
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// confirmationAckLagGauge is the number of verified slots which are not acknowledged by pandora yet
+	confirmationAckLagGauge = metrics.NewRegisteredGauge("orc_confirmation_ack_lag", nil)
+)
+
+// ackLagGauge returns the ack lag gauge of the consumer. Named consumers get their own gauge.
+func ackLagGauge(consumer string) metrics.Gauge {
+	if consumer == "" {
+		return confirmationAckLagGauge
+	}
+	return metrics.GetOrRegisterGauge("orc_confirmation_ack_lag_"+consumer, nil)
+}
+
+// updateAckLag refreshes the ack lag metric of the consumer from the latest verified slot and the acknowledgement
+// high-water mark
+func updateAckLag(backend Backend, consumer string) {
+	ackedSlot, enabled := backend.LatestAckedSlot(consumer)
+	if !enabled {
+		return
+	}
+	latestVerifiedSlot := backend.LatestVerifiedSlot()
+	if ackedSlot >= latestVerifiedSlot {
+		ackLagGauge(consumer).Update(0)
+		return
+	}
+	ackLagGauge(consumer).Update(int64(latestVerifiedSlot - ackedSlot))
+}
@@ -0,0 +1,76 @@
+package events
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	resumeTokenVersion = byte(1)
+	// resumeTokenLen is version + step id + slot + leaf
+	resumeTokenLen = 1 + 8 + 8 + common.HashLength
+)
+
+var errInvalidResumeToken = errors.New("invalid resume token")
+
+// resumeToken is the position of a delivered slot header in the verified chain. Clients only see its opaque encoding.
+type resumeToken struct {
+	stepId uint64
+	slot   uint64
+	leaf   common.Hash
+}
+
+// newResumeToken returns the encoded token of the accumulated slot or an empty string when the slot has no step
+func newResumeToken(backend Backend, slot uint64) string {
+	step, err := backend.AccumulatorStep(slot)
+	if err != nil || step == nil {
+		return ""
+	}
+	return encodeResumeToken(&resumeToken{stepId: step.LeafIndex, slot: step.Slot, leaf: step.Leaf})
+}
+
+func encodeResumeToken(token *resumeToken) string {
+	enc := make([]byte, resumeTokenLen)
+	enc[0] = resumeTokenVersion
+	binary.BigEndian.PutUint64(enc[1:9], token.stepId)
+	binary.BigEndian.PutUint64(enc[9:17], token.slot)
+	copy(enc[17:], token.leaf.Bytes())
+	return base64.RawURLEncoding.EncodeToString(enc)
+}
+
+func decodeResumeToken(token string) (*resumeToken, error) {
+	enc, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(enc) != resumeTokenLen || enc[0] != resumeTokenVersion {
+		return nil, errInvalidResumeToken
+	}
+	return &resumeToken{
+		stepId: binary.BigEndian.Uint64(enc[1:9]),
+		slot:   binary.BigEndian.Uint64(enc[9:17]),
+		leaf:   common.BytesToHash(enc[17:]),
+	}, nil
+}
+
+// resumeSlot maps the token to its step and returns the first slot which the client has not seen yet. When the
+// step of the token was rewritten by a reorg, the token slot itself is sent again so the client gets the new header.
+func resumeSlot(backend Backend, token string) (uint64, error) {
+	decoded, err := decodeResumeToken(token)
+	if err != nil {
+		return 0, err
+	}
+	step, err := backend.AccumulatorStep(decoded.slot)
+	if err != nil || step == nil || !sameStep(step, decoded) {
+		log.WithField("slot", decoded.slot).WithField("stepId", decoded.stepId).
+			Debug("Resume token step is not in the verified chain anymore, resending from token slot")
+		return decoded.slot, nil
+	}
+	return decoded.slot + 1, nil
+}
+
+func sameStep(step *generalTypes.AccumulatorStep, token *resumeToken) bool {
+	return step.LeafIndex == token.stepId && bytes.Equal(step.Leaf.Bytes(), token.leaf.Bytes())
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"sync"
+
+	generalTypes "github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// maxQueuedSlotInfos is the number of slot infos a stream may fall behind before it is dropped
+const maxQueuedSlotInfos = 4096
+
+var errSlotInfoQueueFull = errors.New("subscriber is too slow, verified slot info queue is full")
+
+// slotInfoQueue drains a verified slot info subscription into memory, so that a stream which is replaying
+// history or writing to a slow connection never blocks the event loop and with it the consensus loop.
+type slotInfoQueue struct {
+	lock  sync.Mutex
+	items []*generalTypes.SlotInfoWithStatus
+	full  bool
+	ready chan struct{} // signalled when items are pushed
+}
+
+func newSlotInfoQueue() *slotInfoQueue {
+	return &slotInfoQueue{ready: make(chan struct{}, 1)}
+}
+
+// drain moves slot infos from the subscription channel into the queue until done is closed
+func (q *slotInfoQueue) drain(slotInfoCh <-chan *generalTypes.SlotInfoWithStatus, done <-chan struct{}) {
+	for {
+		select {
+		case slotInfo := <-slotInfoCh:
+			q.push(slotInfo)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (q *slotInfoQueue) push(slotInfo *generalTypes.SlotInfoWithStatus) {
+	q.lock.Lock()
+	if len(q.items) >= maxQueuedSlotInfos {
+		q.full = true
+	} else {
+		q.items = append(q.items, slotInfo)
+	}
+	q.lock.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take returns every queued slot info in order of arrival. It fails once a slot info was dropped.
+func (q *slotInfoQueue) take() ([]*generalTypes.SlotInfoWithStatus, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.full {
+		return nil, errSlotInfoQueueFull
+	}
+	items := q.items
+	q.items = nil
+	return items, nil
+}
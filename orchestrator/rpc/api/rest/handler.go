@@ -0,0 +1,265 @@
+// Package rest mirrors the read-only part of the orc RPC namespace as a plain JSON-over-HTTP API for the tooling
+// which can not speak the go-ethereum RPC protocol.
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PathPrefix is the path which the REST API is served under
+	PathPrefix = "/api/v1/"
+	// defaultLimit is the page size when the request does not give one
+	defaultLimit = 100
+	// maxLimit is the maximum page size
+	maxLimit = 1000
+	// slotScanWindow is the number of slots which are scanned for a page of verified slots
+	slotScanWindow = 4096
+)
+
+var (
+	errMethodNotAllowed = errors.New("method not allowed")
+	errNotStarted       = errors.New("node has not started yet")
+)
+
+// Backend is the part of the events backend which is served by the REST API
+type Backend interface {
+	ConsensusInfoByEpochRange(fromEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error)
+	VerifiedSlotInfos(fromSlot uint64, limit int) map[uint64]*types.SlotInfo
+	LatestVerifiedSlot() uint64
+	GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool, withFinalized bool) types.Status
+	StartupReport() *types.StartupReport
+}
+
+// Page is a paginated response. Next is the from parameter of the next page and it is nil on the last page.
+type Page struct {
+	Data interface{} `json:"data"`
+	Next *uint64     `json:"next"`
+}
+
+// VerifiedSlot is a verified slot with its vanguard block hash and pandora header hash
+type VerifiedSlot struct {
+	Slot              uint64      `json:"slot"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+}
+
+// SlotStatus is the verification status of the slot and hash
+type SlotStatus struct {
+	Slot   uint64       `json:"slot"`
+	Hash   common.Hash  `json:"hash"`
+	Status types.Status `json:"status"`
+}
+
+// LatestVerifiedSlot is the response of the latest verified slot
+type LatestVerifiedSlot struct {
+	Slot uint64 `json:"slot"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler serves the REST API:
+//
+//	GET /api/v1/epochs?from=<epoch>&limit=<n>
+//	GET /api/v1/slots/verified?from=<slot>&limit=<n>
+//	GET /api/v1/slots/latest
+//	GET /api/v1/slots/<slot>/status?hash=<hash>&chain=<pandora|vanguard>&finalized=<bool>
+//	GET /api/v1/status/startup
+type Handler struct {
+	backend Backend
+}
+
+// NewHandler
+func NewHandler(backend Backend) *Handler {
+	return &Handler{backend: backend}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "epochs":
+		h.epochs(w, r)
+	case len(parts) == 2 && parts[0] == "slots" && parts[1] == "verified":
+		h.verifiedSlots(w, r)
+	case len(parts) == 2 && parts[0] == "slots" && parts[1] == "latest":
+		writeJSON(w, &LatestVerifiedSlot{Slot: h.backend.LatestVerifiedSlot()})
+	case len(parts) == 3 && parts[0] == "slots" && parts[2] == "status":
+		h.slotStatus(w, r, parts[1])
+	case len(parts) == 2 && parts[0] == "status" && parts[1] == "startup":
+		h.startupReport(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// epochs serves the stored epoch infos from the given epoch
+func (h *Handler) epochs(w http.ResponseWriter, r *http.Request) {
+	from, limit, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// one more epoch tells whether there is a next page
+	epochInfos, err := h.backend.ConsensusInfoByEpochRange(from, limit+1)
+	if errors.Is(err, api.ErrEpochInfoPruned) {
+		writeError(w, http.StatusGone, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	page := &Page{Data: epochInfos}
+	if len(epochInfos) > limit {
+		page.Data = epochInfos[:limit]
+		next := epochInfos[limit].Epoch
+		page.Next = &next
+	}
+	writeJSON(w, page)
+}
+
+// verifiedSlots serves the verified slots from the given slot in ascending order
+func (h *Handler) verifiedSlots(w http.ResponseWriter, r *http.Request) {
+	from, limit, err := pagination(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// verified slots are sparse, so a window of slotScanWindow slots is scanned for the page
+	slotInfos := h.backend.VerifiedSlotInfos(from, slotScanWindow)
+
+	slots := make([]uint64, 0, len(slotInfos))
+	for slot := range slotInfos {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	page := &Page{}
+	if len(slots) > limit {
+		next := slots[limit]
+		page.Next = &next
+		slots = slots[:limit]
+	} else if next := from + slotScanWindow; next > from && next <= h.backend.LatestVerifiedSlot() {
+		page.Next = &next
+	}
+	verifiedSlots := make([]*VerifiedSlot, len(slots))
+	for i, slot := range slots {
+		verifiedSlots[i] = &VerifiedSlot{
+			Slot:              slot,
+			VanguardBlockHash: slotInfos[slot].VanguardBlockHash,
+			PandoraHeaderHash: slotInfos[slot].PandoraHeaderHash,
+		}
+	}
+	page.Data = verifiedSlots
+	writeJSON(w, page)
+}
+
+// slotStatus serves the verification status of the pandora header hash or vanguard block hash of the slot
+func (h *Handler) slotStatus(w http.ResponseWriter, r *http.Request, slotParam string) {
+	slot, err := strconv.ParseUint(slotParam, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errors.Errorf("invalid slot %q", slotParam))
+		return
+	}
+	query := r.URL.Query()
+	hashParam := query.Get("hash")
+	if hashParam == "" {
+		writeError(w, http.StatusBadRequest, errors.New("hash is required"))
+		return
+	}
+
+	var requestFrom bool
+	switch chain := query.Get("chain"); chain {
+	case "", "pandora":
+		requestFrom = true
+	case "vanguard":
+		requestFrom = false
+	default:
+		writeError(w, http.StatusBadRequest, errors.Errorf("invalid chain %q, expected pandora or vanguard", chain))
+		return
+	}
+
+	// verified slots which vanguard has finalized are reported as Finalized only on request
+	var withFinalized bool
+	if finalizedParam := query.Get("finalized"); finalizedParam != "" {
+		if withFinalized, err = strconv.ParseBool(finalizedParam); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Errorf("invalid finalized %q", finalizedParam))
+			return
+		}
+	}
+
+	hash := common.HexToHash(hashParam)
+	writeJSON(w, &SlotStatus{
+		Slot:   slot,
+		Hash:   hash,
+		Status: h.backend.GetSlotStatus(r.Context(), slot, hash, requestFrom, withFinalized),
+	})
+}
+
+// startupReport serves the setup of the node which was reported when it started
+func (h *Handler) startupReport(w http.ResponseWriter) {
+	report := h.backend.StartupReport()
+	if report == nil {
+		writeError(w, http.StatusServiceUnavailable, errNotStarted)
+		return
+	}
+	writeJSON(w, report)
+}
+
+// pagination parses the from and limit query parameters
+func pagination(r *http.Request) (uint64, int, error) {
+	query := r.URL.Query()
+
+	var from uint64
+	if fromParam := query.Get("from"); fromParam != "" {
+		var err error
+		if from, err = strconv.ParseUint(fromParam, 10, 64); err != nil {
+			return 0, 0, errors.Errorf("invalid from %q", fromParam)
+		}
+	}
+
+	limit := defaultLimit
+	if limitParam := query.Get("limit"); limitParam != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit < 1 {
+			return 0, 0, errors.Errorf("invalid limit %q", limitParam)
+		}
+		if limit > maxLimit {
+			limit = maxLimit
+		}
+	}
+	return from, limit, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Debug("Failed to write REST response")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&errorResponse{Error: err.Error()}); err != nil {
+		log.WithError(err).Debug("Failed to write REST error response")
+	}
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type mockBackend struct {
+	epochInfos    []*types.MinimalEpochConsensusInfoV2
+	earliestEpoch uint64
+	slotInfos     map[uint64]*types.SlotInfo
+	report        *types.StartupReport
+}
+
+func (b *mockBackend) ConsensusInfoByEpochRange(fromEpoch uint64, limit int) ([]*types.MinimalEpochConsensusInfoV2, error) {
+	if fromEpoch < b.earliestEpoch {
+		return nil, api.ErrEpochInfoPruned
+	}
+	epochInfos := make([]*types.MinimalEpochConsensusInfoV2, 0)
+	for _, epochInfo := range b.epochInfos {
+		if epochInfo.Epoch >= fromEpoch {
+			epochInfos = append(epochInfos, epochInfo)
+		}
+	}
+	return epochInfos, nil
+}
+
+func (b *mockBackend) VerifiedSlotInfos(fromSlot uint64, limit int) map[uint64]*types.SlotInfo {
+	slotInfos := make(map[uint64]*types.SlotInfo)
+	for slot, slotInfo := range b.slotInfos {
+		if slot >= fromSlot {
+			slotInfos[slot] = slotInfo
+		}
+	}
+	return slotInfos
+}
+
+func (b *mockBackend) LatestVerifiedSlot() uint64 {
+	return 7
+}
+
+func (b *mockBackend) GetSlotStatus(ctx context.Context, slot uint64, hash common.Hash, requestFrom bool, withFinalized bool) types.Status {
+	if slotInfo, ok := b.slotInfos[slot]; ok && requestFrom && slotInfo.PandoraHeaderHash == hash {
+		if withFinalized {
+			return types.Finalized
+		}
+		return types.Verified
+	}
+	return types.Invalid
+}
+
+func (b *mockBackend) StartupReport() *types.StartupReport {
+	return b.report
+}
+
+func get(h http.Handler, url string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	return rec
+}
+
+func TestHandler_Pagination(t *testing.T) {
+	backend := &mockBackend{slotInfos: make(map[uint64]*types.SlotInfo)}
+	for i := uint64(0); i < 5; i++ {
+		backend.epochInfos = append(backend.epochInfos, &types.MinimalEpochConsensusInfoV2{Epoch: i})
+		backend.slotInfos[i*2] = &types.SlotInfo{PandoraHeaderHash: common.BytesToHash([]byte{byte(i)})}
+	}
+	h := NewHandler(backend)
+
+	rec := get(h, "/api/v1/epochs?from=1&limit=3")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var epochs struct {
+		Data []*types.MinimalEpochConsensusInfoV2
+		Next *uint64
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&epochs))
+	assert.Equal(t, 3, len(epochs.Data))
+	assert.Equal(t, uint64(1), epochs.Data[0].Epoch)
+	require.NotNil(t, epochs.Next)
+	assert.Equal(t, uint64(4), *epochs.Next)
+
+	rec = get(h, "/api/v1/slots/verified?from=3&limit=2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var slots struct {
+		Data []*VerifiedSlot
+		Next *uint64
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&slots))
+	assert.DeepEqual(t, []uint64{4, 6}, []uint64{slots.Data[0].Slot, slots.Data[1].Slot})
+	require.NotNil(t, slots.Next)
+	assert.Equal(t, uint64(8), *slots.Next)
+
+	rec = get(h, "/api/v1/slots/verified?from=8")
+	slots.Next = nil
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&slots))
+	assert.Equal(t, 1, len(slots.Data))
+	assert.Equal(t, (*uint64)(nil), slots.Next)
+
+	rec = get(h, "/api/v1/epochs?limit=0")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	backend.earliestEpoch = 2
+	rec = get(h, "/api/v1/epochs?from=1")
+	assert.Equal(t, http.StatusGone, rec.Code)
+}
+
+func TestHandler_SlotStatus(t *testing.T) {
+	hash := common.BytesToHash([]byte{1})
+	h := NewHandler(&mockBackend{slotInfos: map[uint64]*types.SlotInfo{3: {PandoraHeaderHash: hash}}})
+
+	rec := get(h, "/api/v1/slots/3/status?hash="+hash.Hex())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status SlotStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, types.Verified, status.Status)
+
+	rec = get(h, "/api/v1/slots/3/status?hash="+hash.Hex()+"&finalized=true")
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, types.Finalized, status.Status)
+
+	rec = get(h, "/api/v1/slots/3/status?hash="+hash.Hex()+"&chain=vanguard")
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, types.Invalid, status.Status)
+
+	rec = get(h, "/api/v1/slots/latest")
+	var latest LatestVerifiedSlot
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&latest))
+	assert.Equal(t, uint64(7), latest.Slot)
+
+	assert.Equal(t, http.StatusBadRequest, get(h, "/api/v1/slots/x/status?hash="+hash.Hex()).Code)
+	assert.Equal(t, http.StatusBadRequest, get(h, "/api/v1/slots/3/status").Code)
+	assert.Equal(t, http.StatusBadRequest, get(h, "/api/v1/slots/3/status?hash="+hash.Hex()+"&finalized=x").Code)
+	assert.Equal(t, http.StatusNotFound, get(h, "/api/v1/unknown").Code)
+}
+
+func TestHandler_StartupReport(t *testing.T) {
+	backend := &mockBackend{}
+	h := NewHandler(backend)
+	assert.Equal(t, http.StatusServiceUnavailable, get(h, "/api/v1/status/startup").Code)
+
+	backend.report = &types.StartupReport{
+		Version:         "v0.1.0",
+		SchemaVersion:   3,
+		Features:        []string{"archive"},
+		ListenAddresses: map[string]string{"http": "127.0.0.1:8545"},
+	}
+	rec := get(h, "/api/v1/status/startup")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var report types.StartupReport
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.DeepEqual(t, backend.report, &report)
+}
@@ -0,0 +1,5 @@
+package rest
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "rest")
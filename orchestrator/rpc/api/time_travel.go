@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+// HeadAtSlotTime returns the verified head which orchestrator considered current at the given unix timestamp. When
+// a later reorg reverted the head of that time, the reverted head is returned when it is known, otherwise the
+// latest entry which survived the reorg.
+func (backend *Backend) HeadAtSlotTime(ctx context.Context, timestamp uint64) (*types.HistoricalSlot, error) {
+	slot, err := backend.slotAtTime(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	reorgs, err := backend.ReorgHistoryDB.ReorgHistory(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first reorg after the timestamp which reverted the chain below the slot rewrote the head of that time
+	var replacedBy *types.ReorgRecord
+	for _, reorg := range reorgs {
+		if reorg.DetectedAt <= int64(timestamp) || reorg.RevertSlot >= slot {
+			continue
+		}
+		if replacedBy == nil || reorg.DetectedAt < replacedBy.DetectedAt {
+			replacedBy = reorg
+		}
+	}
+	if replacedBy != nil && replacedBy.OldHeadSlot <= slot {
+		return &types.HistoricalSlot{
+			Slot:              replacedBy.OldHeadSlot,
+			PandoraHeaderHash: replacedBy.OldHeadHash,
+			ReplacedBy:        replacedBy,
+		}, nil
+	}
+
+	upToSlot := slot
+	if replacedBy != nil {
+		upToSlot = replacedBy.RevertSlot
+	}
+	headSlot, slotInfo, err := backend.VerifiedSlotInfoDB.SeekSlotInfo(upToSlot)
+	if err != nil {
+		return nil, err
+	}
+	if slotInfo == nil {
+		return nil, fmt.Errorf("no verified slot at or before slot %d", upToSlot)
+	}
+	return &types.HistoricalSlot{
+		Slot:              headSlot,
+		PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+		VanguardBlockRoot: slotInfo.VanguardBlockHash,
+		StepId:            backend.stepId(headSlot),
+		ReplacedBy:        replacedBy,
+	}, nil
+}
+
+// ShardInfoAsOf returns the verified entry of the slot at the given accumulator step. When the step was reverted by
+// a reorg, the reorg is returned along with the reverted pandora header hash when it was the head at that time.
+func (backend *Backend) ShardInfoAsOf(stepId uint64, slot uint64) (*types.HistoricalSlot, error) {
+	slotInfo, err := backend.VerifiedSlotInfoDB.VerifiedSlotInfo(slot)
+	if err != nil {
+		return nil, err
+	}
+	if current := backend.stepId(slot); slotInfo != nil && current != nil && *current == stepId {
+		return &types.HistoricalSlot{
+			Slot:              slot,
+			PandoraHeaderHash: slotInfo.PandoraHeaderHash,
+			VanguardBlockRoot: slotInfo.VanguardBlockHash,
+			StepId:            current,
+		}, nil
+	}
+
+	reorgs, err := backend.ReorgHistoryDB.ReorgHistory(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	// the first reorg which reverted the chain below both the slot and the step replaced the entry
+	var replacedBy *types.ReorgRecord
+	for _, reorg := range reorgs {
+		if reorg.RevertSlot >= slot || (reorg.NewParentStepId != nil && *reorg.NewParentStepId >= stepId) {
+			continue
+		}
+		if replacedBy == nil || reorg.DetectedAt < replacedBy.DetectedAt {
+			replacedBy = reorg
+		}
+	}
+	if replacedBy == nil {
+		return nil, fmt.Errorf("slot %d is not verified at step %d", slot, stepId)
+	}
+	historical := &types.HistoricalSlot{Slot: slot, StepId: &stepId, ReplacedBy: replacedBy}
+	if replacedBy.OldHeadSlot == slot {
+		historical.PandoraHeaderHash = replacedBy.OldHeadHash
+	}
+	return historical, nil
+}
+
+// slotAtTime returns the slot of the unix timestamp derived from the latest epoch info
+func (backend *Backend) slotAtTime(ctx context.Context, timestamp uint64) (uint64, error) {
+	epoch := backend.ConsensusInfoDB.LatestSavedEpoch()
+	epochInfo, err := backend.ConsensusInfoDB.ConsensusInfo(ctx, epoch)
+	if err != nil {
+		return 0, err
+	}
+	if epochInfo == nil {
+		return 0, fmt.Errorf("epoch info not found for epoch %d", epoch)
+	}
+	// slot time duration of the epoch info is given in seconds
+	secondsPerSlot := uint64(epochInfo.SlotTimeDuration)
+	if secondsPerSlot == 0 {
+		secondsPerSlot = params.SecondsPerSlot
+	}
+	epochSlot := epochInfo.Epoch * params.SlotsPerEpoch
+	if timestamp >= epochInfo.EpochStartTime {
+		return epochSlot + (timestamp-epochInfo.EpochStartTime)/secondsPerSlot, nil
+	}
+	slotsBefore := (epochInfo.EpochStartTime - timestamp + secondsPerSlot - 1) / secondsPerSlot
+	if slotsBefore > epochSlot {
+		return 0, nil
+	}
+	return epochSlot - slotsBefore, nil
+}
+
+// stepId returns the accumulator step of the slot or nil when the slot is not accumulated
+func (backend *Backend) stepId(slot uint64) *uint64 {
+	step, err := backend.AccumulatorDB.AccumulatorStep(slot)
+	if err != nil || step == nil {
+		return nil
+	}
+	id := step.LeafIndex
+	return &id
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestBackend_TimeTravel(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	require.NoError(t, db.SaveConsensusInfo(ctx, &types.MinimalEpochConsensusInfo{
+		Epoch:            0,
+		EpochStartTime:   1000,
+		SlotTimeDuration: 6,
+	}))
+	require.NoError(t, db.SaveLatestEpoch(ctx, 0))
+	for slot := uint64(1); slot <= 10; slot++ {
+		require.NoError(t, db.SaveVerifiedSlotInfo(slot, &types.SlotInfo{
+			PandoraHeaderHash: common.BytesToHash([]byte{byte(slot)}),
+			VanguardBlockHash: common.BytesToHash([]byte{byte(slot), 1}),
+		}))
+		require.NoError(t, db.SaveAccumulatorStep(&types.AccumulatorStep{Slot: slot, LeafIndex: slot - 1}))
+	}
+	// reorg at the time of slot 20 reverted the chain of slots 5-8 to slot 4
+	revertedHead := common.HexToHash("0xdead")
+	parentStep := uint64(3)
+	require.NoError(t, db.SaveReorg(&types.ReorgRecord{
+		Slot:            20,
+		VanParentHash:   []byte{1},
+		PanParentHash:   []byte{2},
+		OldHeadSlot:     8,
+		OldHeadHash:     revertedHead,
+		RevertSlot:      4,
+		NewParentStepId: &parentStep,
+		Depth:           4,
+		DetectedAt:      1120,
+		ResolvedAt:      1120,
+	}))
+	backend := &Backend{
+		ConsensusInfoDB:    db,
+		VerifiedSlotInfoDB: db,
+		AccumulatorDB:      db,
+		ReorgHistoryDB:     db,
+	}
+
+	// head of slot 6 was reverted, slot 4 is the latest entry which survived
+	head, err := backend.HeadAtSlotTime(ctx, 1036)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), head.Slot)
+	require.NotNil(t, head.ReplacedBy)
+
+	// reverted head is known once the timestamp is past it
+	head, err = backend.HeadAtSlotTime(ctx, 1054)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), head.Slot)
+	assert.Equal(t, revertedHead, head.PandoraHeaderHash)
+
+	// after the reorg the current chain is returned
+	head, err = backend.HeadAtSlotTime(ctx, 1200)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), head.Slot)
+	assert.Equal(t, (*types.ReorgRecord)(nil), head.ReplacedBy)
+	require.NotNil(t, head.StepId)
+	assert.Equal(t, uint64(9), *head.StepId)
+
+	historical, err := backend.ShardInfoAsOf(5, 6)
+	require.NoError(t, err)
+	assert.Equal(t, common.BytesToHash([]byte{6}), historical.PandoraHeaderHash)
+	assert.Equal(t, (*types.ReorgRecord)(nil), historical.ReplacedBy)
+
+	historical, err = backend.ShardInfoAsOf(99, 8)
+	require.NoError(t, err)
+	assert.Equal(t, revertedHead, historical.PandoraHeaderHash)
+	require.NotNil(t, historical.ReplacedBy)
+
+	_, err = backend.ShardInfoAsOf(99, 2)
+	assert.ErrorContains(t, "slot 2 is not verified at step 99", err)
+}
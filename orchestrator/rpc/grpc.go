@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	orcpb "github.com/lukso-network/lukso-orchestrator/shared/proto/orchestrator/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcServer serves the orchestrator gRPC API next to the JSON-RPC servers
+type grpcServer struct {
+	mu       sync.Mutex
+	server   *grpc.Server
+	listener net.Listener
+}
+
+// start listens on the address and serves the orchestrator gRPC API with the filter API of the node
+func (g *grpcServer) start(host string, port int, filterAPI *events.PublicFilterAPI, auth *grpcAuth) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.listener != nil {
+		return nil // already running
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	g.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.unaryInterceptor),
+		grpc.ChainStreamInterceptor(auth.streamInterceptor),
+	)
+	orcpb.RegisterOrchestratorServer(g.server, events.NewOrchestratorServer(filterAPI))
+	g.listener = listener
+	go func(server *grpc.Server) {
+		if err := server.Serve(listener); err != nil {
+			log.WithError(err).Error("gRPC server stopped serving")
+		}
+	}(g.server)
+
+	log.WithField("endpoint", listener.Addr()).Info("gRPC server started")
+	return nil
+}
+
+// stop closes the listener and every open stream
+func (g *grpcServer) stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.listener == nil {
+		return // not running
+	}
+	g.server.Stop()
+	log.WithField("endpoint", g.listener.Addr()).Info("gRPC server stopped")
+	g.server, g.listener = nil, nil
+}
+
+// listenAddr returns the address which the server listens on or an empty string when it is not running
+func (g *grpcServer) listenAddr() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.listener == nil {
+		return ""
+	}
+	return g.listener.Addr().String()
+}
+
+// grpcAuth applies the token authentication and the rate limit of the JSON-RPC servers to gRPC calls. The gRPC API
+// is read-only, so a token is only required with JWTScopeAll.
+type grpcAuth struct {
+	secret  []byte
+	scope   string
+	limiter *rateLimitHandler
+}
+
+// newGRPCAuth returns the interceptors of the gRPC server. Without secret and rate, calls are passed through.
+func newGRPCAuth(secret []byte, scope string, limit rateLimit) *grpcAuth {
+	auth := &grpcAuth{secret: secret, scope: scope}
+	if limit.enabled() {
+		// gRPC clients keep their connection, but the buckets are kept per client address like for HTTP-RPC, so a
+		// client can't get a fresh bucket by reconnecting
+		auth.limiter = &rateLimitHandler{
+			limit:     limit,
+			buckets:   make(map[string]*tokenBucket),
+			lastSweep: time.Now(),
+		}
+	}
+	return auth
+}
+
+func (a *grpcAuth) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := a.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *grpcAuth) streamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := a.check(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// check authenticates the call and takes a token from the bucket of the client
+func (a *grpcAuth) check(ctx context.Context) error {
+	if len(a.secret) > 0 && a.scope == JWTScopeAll {
+		var auth string
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+			auth = md.Get("authorization")[0]
+		}
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return status.Error(codes.Unauthenticated, "missing token")
+		}
+		if err := verifyJWT(a.secret, strings.TrimPrefix(auth, "Bearer "), time.Now()); err != nil {
+			return status.Error(codes.Unauthenticated, err.Error())
+		}
+	}
+	if a.limiter != nil {
+		var remoteAddr string
+		if p, ok := peer.FromContext(ctx); ok {
+			remoteAddr = p.Addr.String()
+		}
+		if !a.limiter.bucket(remoteAddr).allow() {
+			rateLimitedCounter.Inc(1)
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	orcpb "github.com/lukso-network/lukso-orchestrator/shared/proto/orchestrator/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func startGRPC(t *testing.T, auth *grpcAuth) orcpb.OrchestratorClient {
+	server := &grpcServer{}
+	filterAPI := events.NewPublicFilterAPI(&events.MockBackend{}, time.Minute)
+	require.NoError(t, server.start("127.0.0.1", 0, filterAPI, auth))
+	t.Cleanup(server.stop)
+
+	conn, err := grpc.Dial(server.listenAddr(), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return orcpb.NewOrchestratorClient(conn)
+}
+
+func TestGRPCServer(t *testing.T) {
+	client := startGRPC(t, newGRPCAuth(nil, "", rateLimit{}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	finalized, err := client.GetLatestFinalized(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), finalized.Slot)
+	assert.Equal(t, uint64(3), finalized.Epoch)
+
+	slotStatus, err := client.GetSlotStatus(ctx, &orcpb.SlotStatusRequest{Slot: 101, Hash: make([]byte, 32)})
+	require.NoError(t, err)
+	assert.Equal(t, orcpb.Status_STATUS_PENDING, slotStatus.Status)
+
+	_, err = client.GetSlotStatus(ctx, &orcpb.SlotStatusRequest{Slot: 101, Hash: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCServer_Auth(t *testing.T) {
+	client := startGRPC(t, newGRPCAuth(testJWTSecret, JWTScopeAll, rateLimit{rate: 1, burst: 1}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.GetLatestFinalized(ctx, &emptypb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+issueJWT(testJWTSecret, "HS256", time.Now()))
+	_, err = client.GetLatestFinalized(authCtx, &emptypb.Empty{})
+	require.NoError(t, err)
+
+	// burst is spent by the first authenticated call
+	_, err = client.GetLatestFinalized(authCtx, &emptypb.Empty{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
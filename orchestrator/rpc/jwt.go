@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// JWTScopeAll requires a token for every HTTP-RPC and WS-RPC request
+	JWTScopeAll = "all"
+	// JWTScopeMutating requires a token only for state-mutating and subscription methods
+	JWTScopeMutating = "mutating"
+
+	jwtSecretLength = 32
+	// jwtIssuedAtTolerance is how far the iat claim of a token may lie away from the local time
+	jwtIssuedAtTolerance = 60 * time.Second
+	// jwtMaxBodyLength limits the request bodies which are inspected for mutating methods
+	jwtMaxBodyLength = 5 * 1024 * 1024
+)
+
+// mutatingNamespaces are the namespaces whose methods all change the state of the orchestrator
+var mutatingNamespaces = []string{"admin_", "chaos_"}
+
+// mutatingMethods are the methods outside of mutatingNamespaces which change the state of the orchestrator
+var mutatingMethods = map[string]bool{
+	"orc_ackConfirmedPanBlockHashes": true,
+}
+
+// LoadJWTSecret reads the hex encoded shared secret from the given file, like the engine API of execution clients
+func LoadJWTSecret(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read jwt secret: %v", err)
+	}
+	hexSecret := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	secret, err := hex.DecodeString(hexSecret)
+	if err != nil {
+		return nil, fmt.Errorf("jwt secret is not hex encoded: %v", err)
+	}
+	if len(secret) != jwtSecretLength {
+		return nil, fmt.Errorf("jwt secret has %d bytes, want %d", len(secret), jwtSecretLength)
+	}
+	return secret, nil
+}
+
+// ValidJWTScope reports whether scope is a known authentication scope
+func ValidJWTScope(scope string) bool {
+	return scope == JWTScopeAll || scope == JWTScopeMutating
+}
+
+// jwtHandler rejects requests without a valid HS256 bearer token. With the mutating scope, requests which
+// only call read-only methods are served without a token.
+type jwtHandler struct {
+	secret []byte
+	scope  string
+	next   http.Handler
+}
+
+// newJWTHandler wraps next with token authentication. Without secret, next is returned unchanged.
+func newJWTHandler(secret []byte, scope string, next http.Handler) http.Handler {
+	if len(secret) == 0 {
+		return next
+	}
+	return &jwtHandler{secret: secret, scope: scope, next: next}
+}
+
+// ServeHTTP implements http.Handler
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.requiresAuth(w, r) {
+		if err := h.authenticate(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// requiresAuth reports whether the request must carry a token. The request body is restored after inspection.
+func (h *jwtHandler) requiresAuth(w http.ResponseWriter, r *http.Request) bool {
+	if h.scope != JWTScopeMutating {
+		return true
+	}
+	// subscriptions are only served over websocket, so every websocket connection needs a token
+	if isWebsocket(r) {
+		return true
+	}
+	if r.Method != http.MethodPost || r.Body == nil {
+		return false
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, jwtMaxBodyLength))
+	if err != nil {
+		return true
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return callsMutatingMethod(body)
+}
+
+// authenticate checks the bearer token of the request
+func (h *jwtHandler) authenticate(r *http.Request) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return fmt.Errorf("missing token")
+	}
+	return verifyJWT(h.secret, strings.TrimPrefix(auth, "Bearer "), time.Now())
+}
+
+// verifyJWT checks the HS256 signature of token and that its iat claim lies within jwtIssuedAtTolerance of now
+func verifyJWT(secret []byte, token string, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed token")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("unsupported signing algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed token signature")
+	}
+	if !hmac.Equal(signature, signJWT(secret, parts[0]+"."+parts[1])) {
+		return fmt.Errorf("invalid token signature")
+	}
+	var claims struct {
+		IssuedAt *int64 `json:"iat"`
+	}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.IssuedAt == nil {
+		return fmt.Errorf("missing issued-at claim")
+	}
+	drift := now.Sub(time.Unix(*claims.IssuedAt, 0))
+	if drift > jwtIssuedAtTolerance || drift < -jwtIssuedAtTolerance {
+		return fmt.Errorf("stale token")
+	}
+	return nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("malformed token")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed token")
+	}
+	return nil
+}
+
+func signJWT(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+// callsMutatingMethod reports whether the JSON-RPC request or batch calls an admin, subscription or other
+// state-mutating method. Bodies which cannot be decoded are treated as mutating.
+func callsMutatingMethod(body []byte) bool {
+	type call struct {
+		Method string `json:"method"`
+	}
+	var calls []call
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return true
+		}
+	} else {
+		var single call
+		if err := json.Unmarshal(body, &single); err != nil {
+			return true
+		}
+		calls = append(calls, single)
+	}
+	for _, c := range calls {
+		if isMutatingMethod(c.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMutatingMethod(method string) bool {
+	if mutatingMethods[method] || strings.HasSuffix(method, "_subscribe") || strings.HasSuffix(method, "_unsubscribe") {
+		return true
+	}
+	for _, namespace := range mutatingNamespaces {
+		if strings.HasPrefix(method, namespace) {
+			return true
+		}
+	}
+	return false
+}
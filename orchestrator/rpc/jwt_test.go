@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = bytes.Repeat([]byte{0x42}, jwtSecretLength)
+
+// issueJWT returns a token signed with secret which was issued at iat
+func issueJWT(secret []byte, alg string, iat time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"alg":%q,"typ":"JWT"}`, alg)))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, iat.Unix())))
+	signature := base64.RawURLEncoding.EncodeToString(signJWT(secret, header+"."+claims))
+	return header + "." + claims + "." + signature
+}
+
+func TestLoadJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	secret, err := LoadJWTSecret(write("prefixed", "0x4242424242424242424242424242424242424242424242424242424242424242\n"))
+	require.NoError(t, err)
+	assert.Equal(t, testJWTSecret, secret)
+
+	_, err = LoadJWTSecret(write("short", "4242"))
+	assert.Error(t, err)
+	_, err = LoadJWTSecret(write("nothex", "zz"))
+	assert.Error(t, err)
+	_, err = LoadJWTSecret(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestVerifyJWT(t *testing.T) {
+	now := time.Now()
+	assert.NoError(t, verifyJWT(testJWTSecret, issueJWT(testJWTSecret, "HS256", now), now))
+	assert.NoError(t, verifyJWT(testJWTSecret, issueJWT(testJWTSecret, "HS256", now.Add(-30*time.Second)), now))
+	assert.Error(t, verifyJWT(testJWTSecret, issueJWT(testJWTSecret, "HS256", now.Add(-2*time.Minute)), now))
+	assert.Error(t, verifyJWT(testJWTSecret, issueJWT(testJWTSecret, "HS256", now.Add(2*time.Minute)), now))
+	assert.Error(t, verifyJWT(testJWTSecret, issueJWT(bytes.Repeat([]byte{1}, jwtSecretLength), "HS256", now), now))
+	assert.Error(t, verifyJWT(testJWTSecret, issueJWT(testJWTSecret, "none", now), now))
+	assert.Error(t, verifyJWT(testJWTSecret, "not.a.token", now))
+}
+
+func TestCallsMutatingMethod(t *testing.T) {
+	tests := []struct {
+		body     string
+		mutating bool
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"orc_minimalConsensusInfo","params":[1]}`, false},
+		{`{"jsonrpc":"2.0","id":1,"method":"orc_ackConfirmedPanBlockHashes","params":[]}`, true},
+		{`{"jsonrpc":"2.0","id":1,"method":"admin_setPandoraEndpoint","params":[]}`, true},
+		{`{"jsonrpc":"2.0","id":1,"method":"chaos_dropVanguardStream","params":[]}`, true},
+		{`{"jsonrpc":"2.0","id":1,"method":"orc_subscribe","params":["verifiedSlotInfo"]}`, true},
+		{`[{"method":"rpc_modules"},{"method":"orc_minimalConsensusInfo"}]`, false},
+		{`[{"method":"rpc_modules"},{"method":"admin_endpointScores"}]`, true},
+		{`{"method":`, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.mutating, callsMutatingMethod([]byte(tt.body)), tt.body)
+	}
+}
+
+// TestJWTHandler makes sure the http server rejects requests without a valid token according to the scope.
+func TestJWTHandler(t *testing.T) {
+	token := "Bearer " + issueJWT(testJWTSecret, "HS256", time.Now())
+
+	all := createAndStartServer(t, &httpConfig{jwtSecret: testJWTSecret, jwtScope: JWTScopeAll}, true,
+		&wsConfig{Origins: []string{"*"}, jwtSecret: testJWTSecret})
+	defer all.stop()
+	url := "http://" + all.listenAddr()
+
+	assert.Equal(t, http.StatusUnauthorized, rpcRequest(t, url).StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, rpcRequest(t, url, "Authorization", "Bearer garbage").StatusCode)
+	assert.Equal(t, http.StatusOK, rpcRequest(t, url, "Authorization", token).StatusCode)
+
+	wsURL := "ws://" + all.listenAddr()
+	assert.Error(t, wsRequest(t, wsURL, ""))
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Authorization": []string{token}})
+	require.NoError(t, err)
+	conn.Close()
+
+	mutating := createAndStartServer(t, &httpConfig{jwtSecret: testJWTSecret, jwtScope: JWTScopeMutating}, false, &wsConfig{})
+	defer mutating.stop()
+	url = "http://" + mutating.listenAddr()
+
+	// read-only queries stay open
+	assert.Equal(t, http.StatusOK, rpcRequest(t, url).StatusCode)
+
+	admin := `{"jsonrpc":"2.0","id":1,"method":"admin_setPandoraEndpoint","params":["http://127.0.0.1:8545"]}`
+	resp, err := http.Post(url, "application/json", bytes.NewReader([]byte(admin)))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader([]byte(admin)))
+	require.NoError(t, err)
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("Authorization", token)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	// the request reaches the rpc server, which does not serve the admin namespace here
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,8 @@
+package rpc
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// rateLimitedCounter is the number of RPC requests which were rejected or delayed by the rate limiter
+	rateLimitedCounter = metrics.NewRegisteredCounter("orc_rpc_rate_limited_total", nil)
+)
@@ -0,0 +1,211 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimitSweepInterval is how often the idle clients are dropped from the http rate limiter
+	rateLimitSweepInterval = time.Minute
+	// rateLimitMaxBodyLength limits the request bodies which are inspected for the number of calls
+	rateLimitMaxBodyLength = 5 * 1024 * 1024
+)
+
+// rateLimit is the token bucket configuration of every connection. Zero rate disables rate limiting.
+type rateLimit struct {
+	// rate is the number of requests per second which refill the bucket
+	rate float64
+	// burst is the capacity of the bucket
+	burst int
+}
+
+func (l rateLimit) enabled() bool {
+	return l.rate > 0
+}
+
+// tokenBucket allows burst requests at once and rate requests per second after the bucket is drained
+type tokenBucket struct {
+	lock   sync.Mutex
+	limit  rateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit rateLimit) *tokenBucket {
+	if limit.burst < 1 {
+		limit.burst = 1
+	}
+	return &tokenBucket{limit: limit, tokens: float64(limit.burst), last: time.Now()}
+}
+
+// refill adds the tokens earned since the last call. The caller must hold b.lock.
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.rate
+	if b.tokens > float64(b.limit.burst) {
+		b.tokens = float64(b.limit.burst)
+	}
+	b.last = now
+}
+
+// allow takes a token and reports whether there was one
+func (b *tokenBucket) allow() bool {
+	return b.allowN(1)
+}
+
+// allowN takes n tokens and reports whether there were enough. Nothing is taken when there were not.
+func (b *tokenBucket) allowN(n int) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill(time.Now())
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// reserve takes a token and returns how long the caller must wait until the token is earned
+func (b *tokenBucket) reserve() time.Duration {
+	return b.reserveN(1)
+}
+
+// reserveN takes n tokens and returns how long the caller must wait until the tokens are earned
+func (b *tokenBucket) reserveN(n int) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill(time.Now())
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.limit.rate * float64(time.Second))
+}
+
+// full reports whether the bucket is refilled, so the client has been idle long enough to forget it
+func (b *tokenBucket) full() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.refill(time.Now())
+	return b.tokens >= float64(b.limit.burst)
+}
+
+// rateLimitHandler rejects the HTTP requests of clients which drained their token bucket. HTTP requests do not
+// keep a connection, so the buckets are kept per client address. A JSON-RPC batch takes a token for every call in it.
+type rateLimitHandler struct {
+	limit rateLimit
+	next  http.Handler
+
+	lock      sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// newRateLimitHandler wraps next with rate limiting. Without rate, next is returned unchanged.
+func newRateLimitHandler(limit rateLimit, next http.Handler) http.Handler {
+	if !limit.enabled() {
+		return next
+	}
+	return &rateLimitHandler{
+		limit:     limit,
+		next:      next,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	calls := 1
+	if r.Method == http.MethodPost && r.Body != nil {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, rateLimitMaxBodyLength))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		calls = rpcCallCount(body)
+	}
+	if calls > h.limit.burst && calls > 1 {
+		// the bucket never holds enough tokens for the batch
+		rateLimitedCounter.Inc(1)
+		http.Error(w, "batch exceeds rate limit burst", http.StatusTooManyRequests)
+		return
+	}
+	if !h.bucket(r.RemoteAddr).allowN(calls) {
+		rateLimitedCounter.Inc(1)
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// bucket returns the token bucket of the client address and drops the buckets of idle clients
+func (h *rateLimitHandler) bucket(remoteAddr string) *tokenBucket {
+	client, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		client = remoteAddr
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if now := time.Now(); now.Sub(h.lastSweep) >= rateLimitSweepInterval {
+		for key, bucket := range h.buckets {
+			if bucket.full() {
+				delete(h.buckets, key)
+			}
+		}
+		h.lastSweep = now
+	}
+	bucket, ok := h.buckets[client]
+	if !ok {
+		bucket = newTokenBucket(h.limit)
+		h.buckets[client] = bucket
+	}
+	return bucket
+}
+
+// rateLimitedRead delays every message read from a websocket connection until the connection has earned a token for
+// every call in it, so a client which sends too fast is slowed down instead of being disconnected.
+func rateLimitedRead(limit rateLimit, read func(v interface{}) error) func(v interface{}) error {
+	if !limit.enabled() {
+		return read
+	}
+	bucket := newTokenBucket(limit)
+	return func(v interface{}) error {
+		if err := read(v); err != nil {
+			return err
+		}
+		calls := 1
+		if msg, ok := v.(*json.RawMessage); ok {
+			calls = rpcCallCount(*msg)
+		}
+		if wait := bucket.reserveN(calls); wait > 0 {
+			rateLimitedCounter.Inc(1)
+			time.Sleep(wait)
+		}
+		return nil
+	}
+}
+
+// rpcCallCount returns the number of calls in a JSON-RPC message. A message which is not a batch counts as one call.
+func rpcCallCount(msg []byte) int {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) == 0 || msg[0] != '[' {
+		return 1
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil || len(batch) == 0 {
+		return 1
+	}
+	return len(batch)
+}
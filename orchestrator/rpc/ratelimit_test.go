@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenBucket makes sure the bucket allows the burst at once and refills at the configured rate.
+func TestTokenBucket(t *testing.T) {
+	bucket := newTokenBucket(rateLimit{rate: 10, burst: 2})
+	assert.True(t, bucket.allow())
+	assert.True(t, bucket.allow())
+	assert.False(t, bucket.allow())
+	assert.False(t, bucket.full())
+
+	wait := bucket.reserve()
+	assert.True(t, wait > 0 && wait <= 100*time.Millisecond)
+
+	bucket.last = bucket.last.Add(-time.Second)
+	assert.True(t, bucket.full())
+	assert.True(t, bucket.allow())
+}
+
+// TestRateLimitHandler makes sure the http server rejects requests of a client which drained its bucket.
+func TestRateLimitHandler(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{rateLimit: rateLimit{rate: 0.01, burst: 2}}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	assert.Equal(t, http.StatusOK, rpcRequest(t, url).StatusCode)
+	assert.Equal(t, http.StatusOK, rpcRequest(t, url).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, rpcRequest(t, url).StatusCode)
+
+	unlimited := createAndStartServer(t, &httpConfig{}, false, &wsConfig{})
+	defer unlimited.stop()
+	url = "http://" + unlimited.listenAddr()
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, rpcRequest(t, url).StatusCode)
+	}
+}
+
+// TestRateLimitHandler_Batch makes sure every call of a batch takes a token.
+func TestRateLimitHandler_Batch(t *testing.T) {
+	srv := createAndStartServer(t, &httpConfig{rateLimit: rateLimit{rate: 0.01, burst: 3}}, false, &wsConfig{})
+	defer srv.stop()
+	url := "http://" + srv.listenAddr()
+
+	batch := func(calls int) int {
+		msgs := make([]string, calls)
+		for i := range msgs {
+			msgs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"rpc_modules","params":[]}`, i)
+		}
+		resp, err := http.Post(url, "application/json", strings.NewReader("["+strings.Join(msgs, ",")+"]"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	// the batch which exceeds the burst is rejected without taking tokens
+	assert.Equal(t, http.StatusTooManyRequests, batch(4))
+	assert.Equal(t, http.StatusOK, batch(2))
+	assert.Equal(t, http.StatusTooManyRequests, batch(2))
+	assert.Equal(t, http.StatusOK, rpcRequest(t, url).StatusCode)
+}
+
+func TestRPCCallCount(t *testing.T) {
+	assert.Equal(t, 1, rpcCallCount([]byte(`{"jsonrpc":"2.0","id":1,"method":"rpc_modules"}`)))
+	assert.Equal(t, 2, rpcCallCount([]byte(` [{"id":1},{"id":2}]`)))
+	assert.Equal(t, 1, rpcCallCount([]byte(`[`)))
+	assert.Equal(t, 1, rpcCallCount(nil))
+}
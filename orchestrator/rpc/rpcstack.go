@@ -3,15 +3,18 @@ package rpc
 import (
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -24,6 +27,11 @@ type httpConfig struct {
 	CorsAllowedOrigins []string
 	Vhosts             []string
 	prefix             string // path prefix on which to mount http handler
+	// jwtSecret enables token authentication of the requests within jwtScope
+	jwtSecret []byte
+	jwtScope  string
+	// rateLimit limits the requests of every client
+	rateLimit rateLimit
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
@@ -31,6 +39,13 @@ type wsConfig struct {
 	Origins []string
 	Modules []string
 	prefix  string // path prefix on which to mount ws handler
+	// Compression enables permessage-deflate negotiation with CompressionLevel
+	Compression      bool
+	CompressionLevel int
+	// jwtSecret enables token authentication of the websocket connections
+	jwtSecret []byte
+	// rateLimit limits the messages of every connection
+	rateLimit rateLimit
 }
 
 type rpcHandler struct {
@@ -59,6 +74,8 @@ type httpServer struct {
 	endpoint string
 	host     string
 	port     int
+	// portRetries is the number of following ports which are tried when the configured one is occupied
+	portRetries int
 
 	handlerNames map[string]string
 }
@@ -86,6 +103,39 @@ func (h *httpServer) setListenAddr(host string, port int) error {
 	return nil
 }
 
+// setPortRetries configures how many following ports are tried when the configured port is already in use.
+func (h *httpServer) setPortRetries(retries int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.portRetries = retries
+}
+
+// listen opens the listener on the configured endpoint. When the port is occupied, the next ports are tried
+// and the endpoint is moved to the first free one. The caller must hold h.mu.
+func (h *httpServer) listen() (net.Listener, error) {
+	listener, err := net.Listen("tcp", h.endpoint)
+	// port 0 picks a free port anyway
+	if err == nil || h.port == 0 || !errors.Is(err, syscall.EADDRINUSE) {
+		return listener, err
+	}
+
+	for port := h.port + 1; port <= h.port+h.portRetries && port <= math.MaxUint16; port++ {
+		endpoint := fmt.Sprintf("%s:%d", h.host, port)
+		fallback, fallbackErr := net.Listen("tcp", endpoint)
+		if fallbackErr == nil {
+			log.WithField("occupiedEndpoint", h.endpoint).WithField("endpoint", endpoint).
+				Warn("RPC port is already in use, listening on the next free port")
+			h.port, h.endpoint = port, endpoint
+			return fallback, nil
+		}
+		if !errors.Is(fallbackErr, syscall.EADDRINUSE) {
+			return nil, fallbackErr
+		}
+	}
+	return nil, err
+}
+
 // listenAddr returns the listening address of the server.
 func (h *httpServer) listenAddr() string {
 	h.mu.Lock()
@@ -116,7 +166,7 @@ func (h *httpServer) start() error {
 	}
 
 	// Start the server.
-	listener, err := net.Listen("tcp", h.endpoint)
+	listener, err := h.listen()
 	if err != nil {
 		// If the server fails to start, we need to clear out the RPC and WS
 		// configuration so they can be configured another time.
@@ -251,6 +301,15 @@ func (h *httpServer) doStop() {
 	h.server, h.listener = nil, nil
 }
 
+// registerHandler mounts the handler on the path next to JSON-RPC. Handlers are served only while JSON-RPC over
+// HTTP is enabled.
+func (h *httpServer) registerHandler(name, path string, handler http.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.mux.Handle(path, handler)
+	h.handlerNames[path] = name
+}
+
 // enableRPC turns on JSON-RPC over HTTP on the server.
 func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	h.mu.Lock()
@@ -266,8 +325,9 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 		return err
 	}
 	h.httpConfig = config
+	handler := newRateLimitHandler(config.rateLimit, newJWTHandler(config.jwtSecret, config.jwtScope, srv))
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
+		Handler: NewHTTPHandlerStack(handler, config.CorsAllowedOrigins, config.Vhosts),
 		server:  srv,
 	})
 	return nil
@@ -297,9 +357,18 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if err := RegisterApisFromWhitelist(apis, config.Modules, srv, false); err != nil {
 		return err
 	}
+	handler := srv.WebsocketHandler(config.Origins)
+	if config.Compression || config.rateLimit.enabled() {
+		if config.Compression {
+			if err := validateCompressionLevel(config.CompressionLevel); err != nil {
+				return err
+			}
+		}
+		handler = newWebsocketHandler(srv, config)
+	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
+		Handler: newJWTHandler(config.jwtSecret, JWTScopeAll, handler),
 		server:  srv,
 	})
 	return nil
@@ -3,6 +3,7 @@ package rpc
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -141,6 +142,69 @@ func TestWebsocketOrigins(t *testing.T) {
 	}
 }
 
+// TestWebsocketCompression makes sure permessage-deflate is negotiated only when it is enabled.
+func TestWebsocketCompression(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: []string{"*"}, Compression: enabled, CompressionLevel: 1})
+		dialer := websocket.Dialer{EnableCompression: true}
+		conn, resp, err := dialer.Dial("ws://"+srv.listenAddr(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, enabled, strings.Contains(resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate"))
+
+		assert.NoError(t, conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "rpc_modules", "params": []interface{}{}}))
+		var response map[string]interface{}
+		assert.NoError(t, conn.ReadJSON(&response))
+		assert.Contains(t, response, "result")
+		conn.Close()
+		srv.stop()
+	}
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.Error(t, srv.enableWS(nil, wsConfig{Compression: true, CompressionLevel: 10}))
+}
+
+// TestBinaryFrame makes sure only the notifications of binary subscriptions are turned into binary frames.
+func TestBinaryFrame(t *testing.T) {
+	isBinary := func(id rpc.ID) bool { return id == "0x1" }
+	notification := func(id string) []byte {
+		return []byte(`{"jsonrpc":"2.0","method":"orc_subscription","params":{"subscription":"` + id + `","result":"0xabcd"}}`)
+	}
+
+	frame, ok := binaryFrame(notification("0x1"), isBinary)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{3, '0', 'x', '1', 0xab, 0xcd}, frame)
+
+	_, ok = binaryFrame(notification("0x2"), isBinary)
+	assert.False(t, ok)
+	_, ok = binaryFrame([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`), isBinary)
+	assert.False(t, ok)
+}
+
+// TestPortRetries makes sure the server moves to the next free port only when retries are configured.
+func TestPortRetries(t *testing.T) {
+	occupied, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	defer occupied.Close()
+	port := occupied.Addr().(*net.TCPAddr).Port
+
+	srv := newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, srv.enableRPC(nil, httpConfig{}))
+	assert.NoError(t, srv.setListenAddr("localhost", port))
+	assert.Error(t, srv.start())
+
+	srv = newHTTPServer(rpc.DefaultHTTPTimeouts)
+	assert.NoError(t, srv.enableRPC(nil, httpConfig{}))
+	assert.NoError(t, srv.setListenAddr("localhost", port))
+	srv.setPortRetries(10)
+	assert.NoError(t, srv.start())
+	defer srv.stop()
+
+	_, listenPort, err := net.SplitHostPort(srv.listenAddr())
+	assert.NoError(t, err)
+	assert.NotEqual(t, strconv.Itoa(port), listenPort)
+	assert.Equal(t, http.StatusOK, rpcRequest(t, "http://"+srv.listenAddr()).StatusCode)
+}
+
 // TestIsWebsocket tests if an incoming websocket upgrade request is handled properly.
 func TestIsWebsocket(t *testing.T) {
 	r, _ := http.NewRequest("GET", "/", nil)
@@ -7,8 +7,14 @@ import (
 	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/admin"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/rest"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
+	"github.com/lukso-network/lukso-orchestrator/shared/identity"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"sync"
 	"time"
 )
@@ -17,9 +23,23 @@ import (
 type Config struct {
 	ConsensusInfoFeed            iface.ConsensusInfoFeed
 	VerifiedSlotInfoFeed         conIface.VerifiedSlotInfoFeed
+	EpochSummaryFeed             conIface.EpochSummaryFeed
+	ShardConflictFeed            conIface.ShardConflictFeed
+	LifetimeStats                conIface.LifetimeStatsProvider
+	HeaderVerifier               conIface.HeaderVerifier
 	Db                           db.Database
 	VanguardPendingShardingCache cache.VanguardShardCache
 	PandoraPendingHeaderCache    cache.PandoraHeaderCache
+	ConfirmationAckEnabled       bool
+	ConfirmationConsumers        []string
+	PandoraEndpointSwitcher      admin.EndpointSwitcher
+	VanguardEndpointSwitcher     admin.EndpointSwitcher
+	EndpointScorer               admin.EndpointScorer
+	ConfirmationRepublisher      admin.ConfirmationRepublisher
+	Identity                     identity.Signer
+	PayloadFetcher               api.PayloadFetcher
+	Tasks                        taskqueue.Queue
+	ConfirmationJournal          api.ConfirmationJournal
 	// ipc config
 	IPCPath string
 	// http config
@@ -31,12 +51,31 @@ type Config struct {
 	HTTPModules      []string
 	HTTPTimeouts     rpc.HTTPTimeouts
 	HTTPPathPrefix   string
+	// HTTPREST serves the read-only events API as JSON under /api/v1/ of the HTTP server
+	HTTPREST bool
 	// WebSocket config
 	WSEnable     bool
 	WSHost       string
 	WSPort       int
 	WSPathPrefix string
 	WSOrigins    []string
+	// gRPC config
+	GRPCEnable bool
+	GRPCHost   string
+	GRPCPort   int
+	// PortRetries is the number of following ports which HTTP and WS servers try when their port is in use
+	PortRetries int
+	// WSCompression negotiates permessage-deflate with the websocket clients which support it
+	WSCompression      bool
+	WSCompressionLevel int
+	// JWTSecret authenticates HTTP-RPC and WS-RPC requests with engine API style tokens when it is set
+	JWTSecret []byte
+	// JWTScope selects which requests need a token, JWTScopeAll or JWTScopeMutating
+	JWTScope string
+	// RateLimit is the number of requests per second which every HTTP-RPC client and WS-RPC connection may send
+	// after its burst is spent. Zero disables rate limiting.
+	RateLimit float64
+	RateBurst int
 }
 
 // Service defining an RPC server for a orchestrator node.
@@ -50,11 +89,13 @@ type Service struct {
 
 	backend       *api.Backend
 	config        *Config
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	filterAPI     *events.PublicFilterAPI // orc namespace which is shared by JSON-RPC and gRPC
+	rpcAPIs       []rpc.API               // List of APIs currently provided by the node
+	http          *httpServer             //
+	ws            *httpServer             //
+	ipc           *ipcServer              // Stores information about the ipc http server
+	grpc          *grpcServer             // Serves the orchestrator gRPC API
+	inprocHandler *rpc.Server             // In-process RPC request handler to process the API requests
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -76,13 +117,29 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			PandoraPendingHeaderCache:    cfg.PandoraPendingHeaderCache,
 			VanguardPendingShardingCache: cfg.VanguardPendingShardingCache,
 			VerifiedSlotInfoFeed:         cfg.VerifiedSlotInfoFeed,
+			EpochSummaryFeed:             cfg.EpochSummaryFeed,
+			ShardConflictFeed:            cfg.ShardConflictFeed,
+			LifetimeStats:                cfg.LifetimeStats,
+			HeaderVerifier:               cfg.HeaderVerifier,
+			ConfirmationAckDB:            cfg.Db,
+			AccumulatorDB:                cfg.Db,
+			EpochSummaryDB:               cfg.Db,
+			ReorgHistoryDB:               cfg.Db,
+			ConfirmationAckEnabled:       cfg.ConfirmationAckEnabled,
+			ConfirmationConsumers:        cfg.ConfirmationConsumers,
+			Identity:                     cfg.Identity,
+			PayloadFetcher:               cfg.PayloadFetcher,
+			Tasks:                        cfg.Tasks,
+			Journal:                      cfg.ConfirmationJournal,
 		},
 	}
 	// Configure RPC servers.
+	service.filterAPI = events.NewPublicFilterAPI(service.backend, 5*time.Minute)
 	service.rpcAPIs = service.APIs()
 	service.http = newHTTPServer(rpc.DefaultHTTPTimeouts)
 	service.ws = newHTTPServer(rpc.DefaultHTTPTimeouts)
 	service.ipc = newIPCServer(service.config.IPCPath)
+	service.grpc = &grpcServer{}
 
 	return service, nil
 }
@@ -150,6 +207,9 @@ func (s *Service) startRPC() error {
 			Vhosts:             nil,
 			Modules:            nil,
 			prefix:             "",
+			jwtSecret:          s.config.JWTSecret,
+			jwtScope:           s.config.JWTScope,
+			rateLimit:          s.rateLimit(),
 		}
 		if err := s.http.setListenAddr(s.config.HTTPHost, s.config.HTTPPort); err != nil {
 			return err
@@ -157,6 +217,11 @@ func (s *Service) startRPC() error {
 		if err := s.http.enableRPC(s.rpcAPIs, config); err != nil {
 			return err
 		}
+		if s.config.HTTPREST {
+			restHandler := newRateLimitHandler(s.rateLimit(),
+				newJWTHandler(s.config.JWTSecret, s.config.JWTScope, rest.NewHandler(s.backend)))
+			s.http.registerHandler("rest", rest.PathPrefix, restHandler)
+		}
 	}
 
 	// Configure WebSocket.
@@ -166,6 +231,11 @@ func (s *Service) startRPC() error {
 			Modules: nil,
 			Origins: []string{"*"},
 			prefix:  "",
+
+			Compression:      s.config.WSCompression,
+			CompressionLevel: s.config.WSCompressionLevel,
+			jwtSecret:        s.config.JWTSecret,
+			rateLimit:        s.rateLimit(),
 		}
 		if err := server.setListenAddr(s.config.WSHost, s.config.WSPort); err != nil {
 			return err
@@ -175,6 +245,16 @@ func (s *Service) startRPC() error {
 		}
 	}
 
+	// Configure gRPC.
+	if s.config.GRPCEnable && s.config.GRPCHost != "" {
+		auth := newGRPCAuth(s.config.JWTSecret, s.config.JWTScope, s.rateLimit())
+		if err := s.grpc.start(s.config.GRPCHost, s.config.GRPCPort, s.filterAPI, auth); err != nil {
+			return err
+		}
+	}
+
+	s.http.setPortRetries(s.config.PortRetries)
+	s.ws.setPortRetries(s.config.PortRetries)
 	if err := s.http.start(); err != nil {
 		return err
 	}
@@ -186,6 +266,11 @@ func (s *Service) startRPC() error {
 	return nil
 }
 
+// rateLimit returns the token bucket configuration of the HTTP-RPC clients and WS-RPC connections
+func (s *Service) rateLimit() rateLimit {
+	return rateLimit{rate: s.config.RateLimit, burst: s.config.RateBurst}
+}
+
 // startInProc registers all RPC APIs on the inproc server.
 func (s *Service) startInProc() error {
 	for _, api := range s.rpcAPIs {
@@ -196,6 +281,36 @@ func (s *Service) startInProc() error {
 	return nil
 }
 
+// SetStartupReport sets the report of the node setup which is served by the status API
+func (s *Service) SetStartupReport(report *types.StartupReport) {
+	s.backend.SetStartupReport(report)
+}
+
+// HTTPEndpoint returns the address which HTTP-RPC server listens on. It differs from the configured one when
+// the configured port was occupied.
+func (s *Service) HTTPEndpoint() string {
+	if !s.config.HTTPEnable {
+		return ""
+	}
+	return s.http.listenAddr()
+}
+
+// WSEndpoint returns the address which WS-RPC server listens on
+func (s *Service) WSEndpoint() string {
+	if !s.config.WSEnable {
+		return ""
+	}
+	return s.wsServerForPort(s.config.WSPort).listenAddr()
+}
+
+// GRPCEndpoint returns the address which the gRPC server listens on
+func (s *Service) GRPCEndpoint() string {
+	if !s.config.GRPCEnable {
+		return ""
+	}
+	return s.grpc.listenAddr()
+}
+
 func (s *Service) wsServerForPort(port int) *httpServer {
 	if s.config.HTTPHost == "" || s.http.port == port {
 		return s.http
@@ -207,6 +322,7 @@ func (s *Service) stopRPC() {
 	s.http.stop()
 	s.ws.stop()
 	s.ipc.stop()
+	s.grpc.stop()
 	s.stopInProc()
 }
 
@@ -221,13 +337,24 @@ func (s *Service) Wait() {
 }
 
 func (s *Service) APIs() []rpc.API {
+	adminAPI := admin.NewPrivateAdminAPI(
+		s.config.PandoraEndpointSwitcher, s.config.VanguardEndpointSwitcher, s.config.EndpointScorer,
+		s.config.ConfirmationRepublisher)
 	// Append all the local APIs and return
-	return []rpc.API{
+	apis := []rpc.API{
 		{
 			Namespace: "orc",
 			Version:   "1.0",
-			Service:   events.NewPublicFilterAPI(s.backend, 5*time.Minute),
+			Service:   s.filterAPI,
 			Public:    true,
 		},
+		{
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   adminAPI,
+			Public:    false,
+		},
 	}
+	// chaos namespace is only served by the builds which are made with the chaos tag
+	return append(apis, chaos.APIs()...)
 }
@@ -28,12 +28,10 @@ func setup(t *testing.T) (*Config, error) {
 	consensusSvr := consensus.New(
 		context.Background(),
 		&consensus.Config{
-			orchestratorDB,
-			orchestratorDB,
-			cache.NewVanShardInfoCache(1 << 10),
-			cache.NewPanHeaderCache(),
-			nil,
-			nil,
+			VerifiedSlotInfoDB:           orchestratorDB,
+			InvalidSlotInfoDB:            orchestratorDB,
+			VanguardPendingShardingCache: cache.NewVanShardInfoCache(1 << 10),
+			PandoraPendingHeaderCache:    cache.NewPanHeaderCache(),
 		})
 
 	return &Config{
@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/rpc/api/events"
+)
+
+const (
+	wsReadBuffer       = 1024
+	wsWriteBuffer      = 1024
+	wsPingInterval     = 60 * time.Second
+	wsPingWriteTimeout = 5 * time.Second
+	wsMessageSizeLimit = 15 * 1024 * 1024
+
+	// wsSubscriptionSuffix is the method suffix of the notifications of a subscription
+	wsSubscriptionSuffix = "_subscription"
+)
+
+var wsBufferPool = new(sync.Pool)
+
+// newWebsocketHandler serves JSON-RPC over WebSocket like rpc.Server.WebsocketHandler does, but also negotiates
+// permessage-deflate with the clients which offer it when compression is enabled, and limits the rate of the
+// messages of every connection. Clients without compression support are served uncompressed frames. Notifications of
+// the subscriptions which negotiated binary encoding are written as binary frames.
+func newWebsocketHandler(srv *rpc.Server, config wsConfig) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:    wsReadBuffer,
+		WriteBufferSize:   wsWriteBuffer,
+		WriteBufferPool:   wsBufferPool,
+		CheckOrigin:       wsHandshakeValidator(config.Origins),
+		EnableCompression: config.Compression,
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Debug("WebSocket upgrade failed")
+			return
+		}
+		if config.Compression {
+			if err := conn.SetCompressionLevel(config.CompressionLevel); err != nil {
+				log.WithError(err).WithField("level", config.CompressionLevel).Warn("Invalid WebSocket compression level")
+			}
+		}
+		conn.SetReadLimit(wsMessageSizeLimit)
+
+		done := make(chan struct{})
+		go wsPingLoop(conn, done)
+		srv.ServeCodec(rpc.NewFuncCodec(conn, wsWrite(conn, events.IsBinarySubscription), rateLimitedRead(config.rateLimit, conn.ReadJSON)), 0)
+		close(done)
+	})
+}
+
+// wsWrite writes the notifications of binary subscriptions as binary frames and every other message as a text frame
+func wsWrite(conn *websocket.Conn, isBinary func(id rpc.ID) bool) func(v interface{}) error {
+	return func(v interface{}) error {
+		enc, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if frame, ok := binaryFrame(enc, isBinary); ok {
+			return conn.WriteMessage(websocket.BinaryMessage, frame)
+		}
+		return conn.WriteMessage(websocket.TextMessage, enc)
+	}
+}
+
+// binaryFrame returns the binary frame of a notification of a binary subscription. The frame is the length of the
+// subscription id as a single byte, the subscription id and the SSZ encoded result of the notification.
+func binaryFrame(enc []byte, isBinary func(id rpc.ID) bool) ([]byte, bool) {
+	if !bytes.Contains(enc, []byte(wsSubscriptionSuffix)) {
+		return nil, false
+	}
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription rpc.ID        `json:"subscription"`
+			Result       hexutil.Bytes `json:"result"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(enc, &notification); err != nil {
+		return nil, false
+	}
+	id := notification.Params.Subscription
+	if !strings.HasSuffix(notification.Method, wsSubscriptionSuffix) || len(id) > math.MaxUint8 || !isBinary(id) {
+		return nil, false
+	}
+	frame := make([]byte, 0, 1+len(id)+len(notification.Params.Result))
+	frame = append(frame, byte(len(id)))
+	frame = append(frame, id...)
+	return append(frame, notification.Params.Result...), true
+}
+
+// wsPingLoop keeps idle connections alive through proxies until done is closed
+func wsPingLoop(conn *websocket.Conn, done chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsPingWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// wsHandshakeValidator returns a handler that verifies the origin during the
+// websocket upgrade process. When a '*' is specified as an allowed origins all
+// connections are accepted.
+func wsHandshakeValidator(allowedOrigins []string) func(*http.Request) bool {
+	origins := make([]string, 0, len(allowedOrigins))
+	allowAllOrigins := false
+
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAllOrigins = true
+		}
+		if origin != "" {
+			origins = append(origins, strings.ToLower(origin))
+		}
+	}
+	// allow localhost if no allowedOrigins are specified.
+	if len(origins) == 0 {
+		origins = append(origins, "http://localhost")
+		if hostname, err := os.Hostname(); err == nil {
+			origins = append(origins, "http://"+strings.ToLower(hostname))
+		}
+	}
+
+	return func(req *http.Request) bool {
+		// Skip origin verification if no Origin header is present. The origin check
+		// is supposed to protect against browser based attacks. Browsers always set
+		// Origin. Non-browser software can put anything in origin and checking it doesn't
+		// provide additional security.
+		if _, ok := req.Header["Origin"]; !ok {
+			return true
+		}
+		// Verify origin against allow list.
+		origin := strings.ToLower(req.Header.Get("Origin"))
+		if allowAllOrigins || originIsAllowed(origins, origin) {
+			return true
+		}
+		log.WithField("origin", origin).Warn("Rejected WebSocket connection")
+		return false
+	}
+}
+
+// originIsAllowed
+func originIsAllowed(allowedOrigins []string, browserOrigin string) bool {
+	for _, origin := range allowedOrigins {
+		if ruleAllowsOrigin(origin, browserOrigin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleAllowsOrigin
+func ruleAllowsOrigin(allowedOrigin string, browserOrigin string) bool {
+	allowedScheme, allowedHostname, allowedPort, err := parseOriginURL(allowedOrigin)
+	if err != nil {
+		log.WithError(err).WithField("allowedOrigin", allowedOrigin).Warn("Error parsing allowed origin specification")
+		return false
+	}
+	browserScheme, browserHostname, browserPort, err := parseOriginURL(browserOrigin)
+	if err != nil {
+		log.WithError(err).WithField("browserOrigin", browserOrigin).Warn("Error parsing browser 'Origin' field")
+		return false
+	}
+	if allowedScheme != "" && allowedScheme != browserScheme {
+		return false
+	}
+	if allowedHostname != "" && allowedHostname != browserHostname {
+		return false
+	}
+	if allowedPort != "" && allowedPort != browserPort {
+		return false
+	}
+	return true
+}
+
+// parseOriginURL
+func parseOriginURL(origin string) (string, string, string, error) {
+	parsedURL, err := url.Parse(strings.ToLower(origin))
+	if err != nil {
+		return "", "", "", err
+	}
+	var scheme, hostname, port string
+	if strings.Contains(origin, "://") {
+		scheme = parsedURL.Scheme
+		hostname = parsedURL.Hostname()
+		port = parsedURL.Port()
+	} else {
+		scheme = ""
+		hostname = parsedURL.Scheme
+		port = parsedURL.Opaque
+		if hostname == "" {
+			hostname = origin
+		}
+	}
+	return scheme, hostname, port, nil
+}
+
+// validateCompressionLevel
+func validateCompressionLevel(level int) error {
+	if level < flate.HuffmanOnly || level > flate.BestCompression {
+		return fmt.Errorf("invalid websocket compression level %d", level)
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+// +build postgres
+
+package sqlsink
+
+// The postgres driver is linked only into the builds which are made with the postgres tag
+import _ "github.com/lib/pq"
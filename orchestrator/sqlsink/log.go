@@ -0,0 +1,5 @@
+package sqlsink
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "sqlsink")
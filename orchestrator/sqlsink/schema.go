@@ -0,0 +1,99 @@
+package sqlsink
+
+import (
+	"fmt"
+	"strings"
+)
+
+// schema creates the tables of the sink. Every table has a dedup key, so rows which are written again after a
+// failed flush or a restart are ignored.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS orc_slots (
+		slot                BIGINT      NOT NULL,
+		vanguard_block_hash BYTEA       NOT NULL,
+		pandora_header_hash BYTEA       NOT NULL,
+		status              TEXT        NOT NULL,
+		step_id             BIGINT,
+		recorded_at         TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (slot, vanguard_block_hash, pandora_header_hash, status)
+	)`,
+	`CREATE TABLE IF NOT EXISTS orc_reorgs (
+		new_slot        BIGINT      NOT NULL,
+		van_parent_hash BYTEA       NOT NULL,
+		pan_parent_hash BYTEA       NOT NULL,
+		recorded_at     TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (new_slot, van_parent_hash, pan_parent_hash)
+	)`,
+	`CREATE TABLE IF NOT EXISTS orc_epoch_summaries (
+		epoch                       BIGINT      NOT NULL PRIMARY KEY,
+		verified_slots              BIGINT      NOT NULL,
+		invalid_slots               BIGINT      NOT NULL,
+		skipped_slots               BIGINT      NOT NULL,
+		reorgs                      BIGINT      NOT NULL,
+		avg_confirmation_latency_ms BIGINT      NOT NULL,
+		recorded_at                 TIMESTAMPTZ NOT NULL
+	)`,
+}
+
+// columnKind is the go type which values of a column are created with
+type columnKind int
+
+const (
+	intColumn columnKind = iota
+	bytesColumn
+	textColumn
+	timeColumn
+)
+
+// table describes the columns which rows of a table are inserted with
+type table struct {
+	name    string
+	columns []string
+	kinds   []columnKind
+}
+
+var (
+	slotsTable = &table{
+		name:    "orc_slots",
+		columns: []string{"slot", "vanguard_block_hash", "pandora_header_hash", "status", "step_id", "recorded_at"},
+		kinds:   []columnKind{intColumn, bytesColumn, bytesColumn, textColumn, intColumn, timeColumn},
+	}
+	reorgsTable = &table{
+		name:    "orc_reorgs",
+		columns: []string{"new_slot", "van_parent_hash", "pan_parent_hash", "recorded_at"},
+		kinds:   []columnKind{intColumn, bytesColumn, bytesColumn, timeColumn},
+	}
+	epochSummariesTable = &table{
+		name: "orc_epoch_summaries",
+		columns: []string{"epoch", "verified_slots", "invalid_slots", "skipped_slots", "reorgs",
+			"avg_confirmation_latency_ms", "recorded_at"},
+		kinds: []columnKind{intColumn, intColumn, intColumn, intColumn, intColumn, intColumn, timeColumn},
+	}
+
+	// tables are flushed in this order
+	tables = []*table{slotsTable, reorgsTable, epochSummariesTable}
+)
+
+// insertQuery returns a single multi row insert of the given rows which skips already stored rows
+func (t *table) insertQuery(rows [][]interface{}) (string, []interface{}) {
+	var query strings.Builder
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES ", t.name, strings.Join(t.columns, ", "))
+
+	args := make([]interface{}, 0, len(rows)*len(t.columns))
+	for i, row := range rows {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for j := range row {
+			if j > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, row[j])
+			fmt.Fprintf(&query, "$%d", len(args))
+		}
+		query.WriteString(")")
+	}
+	query.WriteString(" ON CONFLICT DO NOTHING")
+	return query.String(), args
+}
@@ -0,0 +1,421 @@
+package sqlsink
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultDriver is the database/sql driver name of postgres
+	DefaultDriver = "postgres"
+	// DirName is the directory of the spill files inside the data directory
+	DirName = "sqlsink"
+	// maxPendingRows bounds memory use while the database is unreachable. Rows beyond it are spilled to disk.
+	maxPendingRows = 1 << 16
+	// flushTimeout bounds a flush, so an unreachable database does not hold the pending rows forever
+	flushTimeout = 30 * time.Second
+)
+
+// ReorgFeed
+type ReorgFeed interface {
+	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
+}
+
+// execer is the part of *sql.DB which the sink writes through
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+type Config struct {
+	// Driver is the database/sql driver name and DSN is its data source name
+	Driver        string
+	DSN           string
+	BatchSize     int
+	FlushInterval time.Duration
+	// SpillDir keeps the rows which do not fit in memory while the database is unreachable
+	SpillDir string
+
+	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	ReorgFeed            ReorgFeed
+	EpochSummaryFeed     conIface.EpochSummaryFeed
+}
+
+// Service mirrors verified and invalid slots, reorgs and epoch summaries into relational tables. Rows are
+// written in batches and kept until they are stored, so every row is written at least once. Rows which do not fit
+// in memory are spilled to disk. Dedup keys of the tables drop the rows which are written twice.
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	db            *sql.DB
+	exec          execer
+	batchSize     int
+	flushInterval time.Duration
+
+	verifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	reorgFeed            ReorgFeed
+	epochSummaryFeed     conIface.EpochSummaryFeed
+
+	lock sync.Mutex
+	// pending rows of every table in arrival order
+	pending map[*table][][]interface{}
+	// spills keep the rows of every table beyond maxPendingRows, rows are kept in memory only when it is nil
+	spills map[*table]*spill
+
+	// flushLock serializes the flushes, flushCh requests a flush from the flush loop
+	flushLock sync.Mutex
+	flushCh   chan struct{}
+}
+
+// NewService opens the database and creates the sink tables when they don't exist
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.BatchSize <= 0 {
+		return nil, errors.New("sql sink batch size must be positive")
+	}
+	if cfg.FlushInterval <= 0 {
+		return nil, errors.New("sql sink flush interval must be positive")
+	}
+	if err := fileutil.MkdirAll(cfg.SpillDir); err != nil {
+		return nil, errors.Wrap(err, "could not create sql sink spill directory")
+	}
+	driver := cfg.Driver
+	if driver == "" {
+		driver = DefaultDriver
+	}
+	db, err := sql.Open(driver, cfg.DSN)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open %s database. Orchestrator must be built with the %s tag", driver, driver)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	s := newService(ctx, cancel, db, cfg)
+	s.db = db
+	if err := s.openSpills(cfg.SpillDir); err != nil {
+		cancel()
+		db.Close()
+		return nil, errors.Wrap(err, "could not open sql sink spill files")
+	}
+	if err := s.createSchema(); err != nil {
+		cancel()
+		db.Close()
+		return nil, errors.Wrap(err, "could not create sql sink schema")
+	}
+	return s, nil
+}
+
+// newService
+func newService(ctx context.Context, cancel context.CancelFunc, exec execer, cfg *Config) *Service {
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		exec:                 exec,
+		batchSize:            cfg.BatchSize,
+		flushInterval:        cfg.FlushInterval,
+		verifiedSlotInfoFeed: cfg.VerifiedSlotInfoFeed,
+		reorgFeed:            cfg.ReorgFeed,
+		epochSummaryFeed:     cfg.EpochSummaryFeed,
+		pending:              make(map[*table][][]interface{}),
+		flushCh:              make(chan struct{}, 1),
+	}
+}
+
+// openSpills opens the spill file of every table. Rows which were spilled before a restart are flushed first.
+func (s *Service) openSpills(dir string) error {
+	s.spills = make(map[*table]*spill, len(tables))
+	for _, t := range tables {
+		sp, err := openSpill(dir, t)
+		if err != nil {
+			return err
+		}
+		if sp.rows > 0 {
+			log.WithField("table", t.name).WithField("rows", sp.rows).Info("Resuming spilled rows of sql sink")
+		}
+		s.spills[t] = sp
+	}
+	return nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start sql sink when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.flushLoop()
+	go s.run()
+	// spilled rows of a previous run are stored without waiting for new rows
+	s.requestFlush()
+	log.WithField("batchSize", s.batchSize).WithField("flushInterval", s.flushInterval).Info("Started sql sink")
+}
+
+// Stop flushes the pending rows and closes the database. Rows which can not be flushed are spilled to disk, so they
+// are stored after the next start.
+func (s *Service) Stop() error {
+	// cancelling stops a running flush, which holds the flush lock
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.isRunning = false
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := s.flush(ctx); err != nil {
+		log.WithError(err).Warn("Failed to flush pending rows of sql sink, spilling them to disk")
+		if err := s.spillPending(); err != nil {
+			log.WithError(err).Error("Failed to spill pending rows of sql sink")
+		}
+	}
+	if s.db != nil {
+		return s.db.Close()
+	}
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	if !s.isRunning {
+		return nil
+	}
+	return s.runError
+}
+
+// createSchema
+func (s *Service) createSchema() error {
+	for _, statement := range schema {
+		if _, err := s.exec.ExecContext(s.ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run collects rows from the feeds and flushes them when a batch is full or the flush interval passed
+func (s *Service) run() {
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 1)
+	slotInfoSub := s.verifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer slotInfoSub.Unsubscribe()
+
+	reorgCh := make(chan *types.Reorg, 1)
+	reorgSub := s.reorgFeed.SubscribeShutdownSignalEvent(reorgCh)
+	defer reorgSub.Unsubscribe()
+
+	summaryCh := make(chan *types.EpochSummary, 1)
+	summarySub := s.epochSummaryFeed.SubscribeEpochSummaryEvent(summaryCh)
+	defer summarySub.Unsubscribe()
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		var full bool
+		select {
+		case slotInfo := <-slotInfoCh:
+			full = s.add(slotsTable, slotRow(slotInfo, time.Now()))
+		case reorg := <-reorgCh:
+			full = s.add(reorgsTable, reorgRow(reorg, time.Now()))
+		case summary := <-summaryCh:
+			full = s.add(epochSummariesTable, epochSummaryRow(summary, time.Now()))
+		case <-ticker.C:
+			full = true
+		case err := <-slotInfoSub.Err():
+			log.WithError(err).Error("Verified slot info subscription of sql sink is closed")
+			s.runError = err
+			return
+		case err := <-reorgSub.Err():
+			log.WithError(err).Error("Reorg subscription of sql sink is closed")
+			s.runError = err
+			return
+		case err := <-summarySub.Err():
+			log.WithError(err).Error("Epoch summary subscription of sql sink is closed")
+			s.runError = err
+			return
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing sql sink")
+			return
+		}
+
+		if full {
+			s.requestFlush()
+		}
+	}
+}
+
+// requestFlush wakes up the flush loop without waiting for it, a flush which is already requested is not repeated
+func (s *Service) requestFlush() {
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// flushLoop flushes the pending rows when a flush is requested. Flushes run apart from the feed loop, so a slow or
+// unreachable database never blocks the senders of the feeds.
+func (s *Service) flushLoop() {
+	for {
+		select {
+		case <-s.flushCh:
+			ctx, cancel := context.WithTimeout(s.ctx, flushTimeout)
+			if err := s.flush(ctx); err != nil {
+				log.WithError(err).Warn("Failed to flush sql sink, retrying at next flush")
+			}
+			cancel()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// add queues the row and returns true when the batch of the table is full. Once maxPendingRows are pending, rows are
+// spilled to disk until the spilled rows are loaded back, which keeps the rows of a table in order.
+func (s *Service) add(t *table, row []interface{}) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if sp := s.spills[t]; sp != nil && (sp.rows > 0 || len(s.pending[t]) >= maxPendingRows) {
+		if sp.rows == 0 {
+			log.WithField("table", t.name).Warn("Sql sink is too far behind, spilling rows to disk")
+		}
+		err := sp.append([][]interface{}{row})
+		if err == nil {
+			return true
+		}
+		// the row is kept in memory rather than lost
+		log.WithError(err).WithField("table", t.name).Error("Failed to spill row of sql sink")
+	}
+	s.pending[t] = append(s.pending[t], row)
+	return len(s.pending[t]) >= s.batchSize
+}
+
+// flush writes the pending rows of every table in batches. Rows stay pending until their batch is stored. The
+// database is written without holding the lock of the pending rows, so rows are added while a flush waits.
+func (s *Service) flush(ctx context.Context) error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+
+	for _, t := range tables {
+		for {
+			batch, err := s.nextBatch(t)
+			if err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				break
+			}
+			query, args := t.insertQuery(batch)
+			if _, err := s.exec.ExecContext(ctx, query, args...); err != nil {
+				return errors.Wrapf(err, "could not insert %d rows into %s", len(batch), t.name)
+			}
+			// rows are only added at the end, so the batch is still at the head of the pending rows
+			s.lock.Lock()
+			s.pending[t] = s.pending[t][len(batch):]
+			s.lock.Unlock()
+		}
+	}
+	return nil
+}
+
+// nextBatch returns the oldest pending rows of the table. Spilled rows are loaded once the rows in memory are
+// stored, and the spill file is removed once all of its rows are stored.
+func (s *Service) nextBatch(t *table) ([][]interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.pending[t]) == 0 {
+		sp := s.spills[t]
+		if sp == nil {
+			return nil, nil
+		}
+		if sp.rows == 0 {
+			return nil, sp.clear()
+		}
+		rows, err := sp.load(maxPendingRows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not load spilled rows of %s", t.name)
+		}
+		s.pending[t] = rows
+	}
+	size := s.batchSize
+	if size > len(s.pending[t]) {
+		size = len(s.pending[t])
+	}
+	return s.pending[t][:size], nil
+}
+
+// spillPending writes the rows which are pending in memory to the spill files. Rows of a table may be stored out of
+// order then, which the dedup keys make harmless.
+func (s *Service) spillPending() error {
+	s.flushLock.Lock()
+	defer s.flushLock.Unlock()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, t := range tables {
+		sp := s.spills[t]
+		if sp == nil || len(s.pending[t]) == 0 {
+			continue
+		}
+		if err := sp.append(s.pending[t]); err != nil {
+			return errors.Wrapf(err, "could not spill %d rows of %s", len(s.pending[t]), t.name)
+		}
+		s.pending[t] = nil
+	}
+	return nil
+}
+
+// slotRow
+func slotRow(slotInfo *types.SlotInfoWithStatus, now time.Time) []interface{} {
+	var stepId interface{}
+	if slotInfo.StepId != nil {
+		stepId = int64(*slotInfo.StepId)
+	}
+	return []interface{}{
+		int64(slotInfo.Slot),
+		slotInfo.VanguardBlockHash.Bytes(),
+		slotInfo.PandoraHeaderHash.Bytes(),
+		string(slotInfo.Status),
+		stepId,
+		now.UTC(),
+	}
+}
+
+// reorgRow
+func reorgRow(reorg *types.Reorg, now time.Time) []interface{} {
+	return []interface{}{
+		int64(reorg.NewSlot),
+		nonNilBytes(reorg.VanParentHash),
+		nonNilBytes(reorg.PanParentHash),
+		now.UTC(),
+	}
+}
+
+// nonNilBytes keeps missing hashes out of NULL, since hashes are part of the dedup keys
+func nonNilBytes(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
+// epochSummaryRow
+func epochSummaryRow(summary *types.EpochSummary, now time.Time) []interface{} {
+	return []interface{}{
+		int64(summary.Epoch),
+		int64(summary.VerifiedSlots),
+		int64(summary.InvalidSlots),
+		int64(summary.SkippedSlots),
+		int64(summary.Reorgs),
+		int64(summary.AverageConfirmationLatency),
+		now.UTC(),
+	}
+}
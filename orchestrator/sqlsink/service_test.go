@@ -0,0 +1,126 @@
+package sqlsink
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+type execCall struct {
+	query string
+	args  []interface{}
+}
+
+type mockExecer struct {
+	calls []*execCall
+	err   error
+}
+
+func (m *mockExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.calls = append(m.calls, &execCall{query: query, args: args})
+	return nil, nil
+}
+
+func TestTable_InsertQuery(t *testing.T) {
+	query, args := reorgsTable.insertQuery([][]interface{}{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	assert.Equal(t, "INSERT INTO orc_reorgs (new_slot, van_parent_hash, pan_parent_hash, recorded_at) "+
+		"VALUES ($1, $2, $3, $4), ($5, $6, $7, $8) ON CONFLICT DO NOTHING", query)
+	assert.DeepEqual(t, []interface{}{1, 2, 3, 4, 5, 6, 7, 8}, args)
+}
+
+func TestService_Flush(t *testing.T) {
+	exec := &mockExecer{err: errors.New("connection refused")}
+	s := newService(context.Background(), nil, exec, &Config{BatchSize: 2, FlushInterval: time.Second})
+
+	now := time.Now()
+	stepId := uint64(7)
+	for slot := uint64(1); slot <= 3; slot++ {
+		s.add(slotsTable, slotRow(&types.SlotInfoWithStatus{
+			Slot:              slot,
+			VanguardBlockHash: common.HexToHash("0x1"),
+			PandoraHeaderHash: common.HexToHash("0x2"),
+			StepId:            &stepId,
+			Status:            types.Verified,
+		}, now))
+	}
+	assert.Equal(t, false, s.add(reorgsTable, reorgRow(&types.Reorg{NewSlot: 2}, now)))
+
+	// rows stay pending while database is unreachable
+	assert.ErrorContains(t, "connection refused", s.flush(context.Background()))
+	assert.Equal(t, 3, len(s.pending[slotsTable]))
+
+	exec.err = nil
+	require.NoError(t, s.flush(context.Background()))
+	// slots are written in two batches, then the reorg
+	assert.Equal(t, 3, len(exec.calls))
+	assert.Equal(t, 12, len(exec.calls[0].args))
+	assert.Equal(t, 6, len(exec.calls[1].args))
+	assert.DeepEqual(t, []byte{}, exec.calls[2].args[1])
+	assert.Equal(t, 0, len(s.pending[slotsTable]))
+	assert.Equal(t, 0, len(s.pending[reorgsTable]))
+}
+
+func TestService_Spill(t *testing.T) {
+	exec := &mockExecer{err: errors.New("connection refused")}
+	s := newService(context.Background(), nil, exec, &Config{BatchSize: 2, FlushInterval: time.Second})
+	dir := t.TempDir()
+	require.NoError(t, s.openSpills(dir))
+
+	now := time.Unix(100, 0).UTC()
+	s.pending[slotsTable] = make([][]interface{}, maxPendingRows)
+	for i := range s.pending[slotsTable] {
+		s.pending[slotsTable][i] = slotRow(&types.SlotInfoWithStatus{Slot: uint64(i), Status: types.Verified}, now)
+	}
+	// rows beyond the limit are spilled rather than dropped
+	stepId := uint64(7)
+	spilled := slotRow(&types.SlotInfoWithStatus{
+		Slot:              uint64(maxPendingRows),
+		VanguardBlockHash: common.HexToHash("0x1"),
+		PandoraHeaderHash: common.HexToHash("0x2"),
+		StepId:            &stepId,
+		Status:            types.Verified,
+	}, now)
+	assert.Equal(t, true, s.add(slotsTable, spilled))
+	assert.Equal(t, maxPendingRows, len(s.pending[slotsTable]))
+	assert.Equal(t, 1, s.spills[slotsTable].rows)
+
+	// spilled rows survive a restart
+	require.NoError(t, s.spillPending())
+	restarted := newService(context.Background(), nil, exec, &Config{BatchSize: maxPendingRows, FlushInterval: time.Second})
+	require.NoError(t, restarted.openSpills(dir))
+	assert.Equal(t, maxPendingRows+1, restarted.spills[slotsTable].rows)
+
+	exec.err = nil
+	require.NoError(t, restarted.flush(context.Background()))
+	assert.Equal(t, 2, len(exec.calls))
+	// the spilled row is loaded with the values it was created with
+	assert.DeepEqual(t, spilled, exec.calls[0].args[:len(spilled)])
+	assert.Equal(t, 0, restarted.spills[slotsTable].rows)
+	assert.Equal(t, 0, len(restarted.pending[slotsTable]))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(entries))
+}
+
+func TestEpochSummaryRow(t *testing.T) {
+	row := epochSummaryRow(&types.EpochSummary{
+		Epoch:                      3,
+		VerifiedSlots:              30,
+		InvalidSlots:               1,
+		SkippedSlots:               1,
+		Reorgs:                     2,
+		AverageConfirmationLatency: 420,
+	}, time.Unix(0, 0))
+	assert.DeepEqual(t, []interface{}{int64(3), int64(30), int64(1), int64(1), int64(2), int64(420), time.Unix(0, 0).UTC()}, row)
+}
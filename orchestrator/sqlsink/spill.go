@@ -0,0 +1,174 @@
+package sqlsink
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/pkg/errors"
+)
+
+// spill keeps the rows of a table on disk while too many rows are pending in memory. Rows are appended as JSON
+// lines and are loaded back in order. The file is removed only after every row of it is stored, so spilled rows
+// which are not stored yet are exported again after a restart.
+type spill struct {
+	table *table
+	path  string
+	// offset is the position of the first row which is not loaded yet and rows is the number of rows after it
+	offset int64
+	rows   int
+}
+
+// openSpill opens the spill file of the table, rows which were spilled before a restart are loaded again
+func openSpill(dir string, t *table) (*spill, error) {
+	sp := &spill{table: t, path: filepath.Join(dir, t.name+".spill")}
+	if !fileutil.FileExists(sp.path) {
+		return sp, nil
+	}
+	f, err := os.Open(sp.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		_, err := reader.ReadBytes('\n')
+		// a torn last line is not a row
+		if err == nil {
+			sp.rows++
+		}
+		if err == io.EOF {
+			return sp, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// append writes the rows to the end of the spill file and syncs it
+func (sp *spill) append(rows [][]interface{}) error {
+	f, err := os.OpenFile(sp.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, params.OrchestratorIoConfig().ReadWritePermissions)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(f)
+	for _, row := range rows {
+		enc, err := json.Marshal(row)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		writer.Write(enc)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	sp.rows += len(rows)
+	return nil
+}
+
+// load returns up to limit rows which are not loaded yet
+func (sp *spill) load(limit int) ([][]interface{}, error) {
+	f, err := os.Open(sp.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(sp.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(f)
+	rows := make([][]interface{}, 0)
+	for len(rows) < limit && sp.rows > 0 {
+		line, err := reader.ReadBytes('\n')
+		// a torn last line is not a row yet
+		if err != nil {
+			return rows, nil
+		}
+		sp.offset += int64(len(line))
+		sp.rows--
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		row, err := sp.table.decodeRow(line)
+		if err != nil {
+			log.WithError(err).WithField("table", sp.table.name).Error("Dropping unreadable spilled row of sql sink")
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// clear removes the spill file once every row of it is loaded and stored
+func (sp *spill) clear() error {
+	if sp.rows > 0 || sp.offset == 0 {
+		return nil
+	}
+	if err := os.Remove(sp.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	sp.offset = 0
+	return nil
+}
+
+// decodeRow decodes a spilled row into the values which the row was created with
+func (t *table) decodeRow(enc []byte) ([]interface{}, error) {
+	var values []json.RawMessage
+	if err := json.Unmarshal(enc, &values); err != nil {
+		return nil, err
+	}
+	if len(values) != len(t.kinds) {
+		return nil, errors.Errorf("spilled row has %d values, %s has %d columns", len(values), t.name, len(t.kinds))
+	}
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		if bytes.Equal(value, []byte("null")) {
+			continue
+		}
+		var err error
+		switch t.kinds[i] {
+		case intColumn:
+			var v int64
+			err = json.Unmarshal(value, &v)
+			row[i] = v
+		case bytesColumn:
+			var v []byte
+			err = json.Unmarshal(value, &v)
+			if v == nil {
+				v = []byte{}
+			}
+			row[i] = v
+		case textColumn:
+			var v string
+			err = json.Unmarshal(value, &v)
+			row[i] = v
+		case timeColumn:
+			var v time.Time
+			err = json.Unmarshal(value, &v)
+			row[i] = v.UTC()
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode column %s of %s", t.columns[i], t.name)
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,5 @@
+package stats
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "stats")
@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"sync"
+	"time"
+)
+
+// Point is a single bucket of a time series
+type Point struct {
+	// Time is the start of the bucket in unix seconds
+	Time  int64 `json:"time"`
+	Value int64 `json:"value"`
+}
+
+// series keeps the most recent buckets of a time series in a ring buffer. Buckets are aligned to the bucket width,
+// so series of different services line up on the same dashboard.
+type series struct {
+	lock   sync.Mutex
+	width  time.Duration
+	points []Point
+	// head is the index of the newest bucket
+	head int
+}
+
+func newSeries(width time.Duration, size int) *series {
+	return &series{
+		width:  width,
+		points: make([]Point, size),
+	}
+}
+
+// add adds the value to the bucket of the given time
+func (s *series) add(at time.Time, value int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p := s.bucket(at)
+	if p == nil {
+		return
+	}
+	p.Value += value
+}
+
+// observe keeps the maximum of the observed values in the bucket of the given time
+func (s *series) observe(at time.Time, value int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	p := s.bucket(at)
+	if p == nil {
+		return
+	}
+	if value > p.Value {
+		p.Value = value
+	}
+}
+
+// bucket returns the bucket of the given time. It advances the ring when the time is beyond the newest bucket and
+// returns nil when the bucket is already dropped from the ring.
+func (s *series) bucket(at time.Time) *Point {
+	start := at.Truncate(s.width).Unix()
+	newest := s.points[s.head].Time
+	step := int64(s.width / time.Second)
+
+	if newest == 0 {
+		s.points[s.head] = Point{Time: start}
+		return &s.points[s.head]
+	}
+	if start > newest {
+		// zero the skipped buckets, so quiet periods show up as zero instead of stale values
+		missed := (start - newest) / step
+		if missed > int64(len(s.points)) {
+			missed = int64(len(s.points))
+		}
+		for i := missed - 1; i >= 0; i-- {
+			s.head = (s.head + 1) % len(s.points)
+			s.points[s.head] = Point{Time: start - i*step}
+		}
+		return &s.points[s.head]
+	}
+
+	back := (newest - start) / step
+	if back >= int64(len(s.points)) {
+		return nil
+	}
+	idx := (s.head - int(back) + len(s.points)) % len(s.points)
+	if s.points[idx].Time != start {
+		return nil
+	}
+	return &s.points[idx]
+}
+
+// snapshot returns the buckets from the oldest to the newest one up to the given time. Buckets between the newest
+// bucket and the given time are reported as zero.
+func (s *series) snapshot(now time.Time) []Point {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.bucket(now)
+	points := make([]Point, 0, len(s.points))
+	for i := 1; i <= len(s.points); i++ {
+		p := s.points[(s.head+i)%len(s.points)]
+		if p.Time == 0 {
+			continue
+		}
+		points = append(points, p)
+	}
+	return points
+}
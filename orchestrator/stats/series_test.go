@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestSeries_Buckets(t *testing.T) {
+	start := time.Unix(3600600, 0)
+	s := newSeries(time.Minute, 3)
+
+	s.add(start, 1)
+	s.add(start.Add(30*time.Second), 2)
+	s.add(start.Add(time.Minute), 5)
+	assert.DeepEqual(t, []Point{{Time: 3600600, Value: 3}, {Time: 3600660, Value: 5}}, s.snapshot(start.Add(time.Minute)))
+
+	// quiet minutes are reported as zero and the oldest bucket is dropped
+	s.add(start.Add(3*time.Minute), 1)
+	assert.DeepEqual(t, []Point{{Time: 3600660, Value: 5}, {Time: 3600720, Value: 0}, {Time: 3600780, Value: 1}},
+		s.snapshot(start.Add(3*time.Minute)))
+
+	// values of dropped buckets are ignored
+	s.add(start, 10)
+	assert.DeepEqual(t, []Point{{Time: 3600720, Value: 0}, {Time: 3600780, Value: 1}, {Time: 3600840, Value: 0}},
+		s.snapshot(start.Add(4*time.Minute)))
+
+	// a gap longer than the ring resets every bucket
+	assert.DeepEqual(t, []Point{{Time: 3601260, Value: 0}, {Time: 3601320, Value: 0}, {Time: 3601380, Value: 0}},
+		s.snapshot(start.Add(13*time.Minute)))
+}
+
+func TestSeries_Observe(t *testing.T) {
+	start := time.Unix(3600600, 0)
+	s := newSeries(time.Minute, 2)
+
+	s.observe(start, 4)
+	s.observe(start.Add(10*time.Second), 2)
+	s.observe(start.Add(time.Minute), 1)
+	assert.DeepEqual(t, []Point{{Time: 3600600, Value: 4}, {Time: 3600660, Value: 1}}, s.snapshot(start.Add(time.Minute)))
+}
+
+func TestService_Stats(t *testing.T) {
+	now := time.Unix(3601200, 0)
+	s := NewService(context.Background(), &Config{})
+	s.now = func() time.Time { return now }
+
+	s.epochInfo = &types.MinimalEpochConsensusInfoV2{Epoch: 2, EpochStartTime: 3601140, SlotTimeDuration: 6}
+	s.onSlotInfo(&types.SlotInfoWithStatus{Slot: 70, Status: types.Verified})
+	s.onSlotInfo(&types.SlotInfoWithStatus{Slot: 71, Status: types.Invalid})
+	s.sampleLag()
+
+	stats := s.Stats()
+	assert.Equal(t, uint64(74), stats.HeadSlot)
+	assert.Equal(t, uint64(70), stats.LatestVerifiedSlot)
+	assert.DeepEqual(t, []Point{{Time: 3601200, Value: 1}}, stats.VerifiedPerMinute)
+	assert.DeepEqual(t, []Point{{Time: 3601200, Value: 1}}, stats.InvalidPerMinute)
+	assert.DeepEqual(t, []Point{{Time: 3601200, Value: 4}}, stats.Lag)
+	assert.DeepEqual(t, []Point{{Time: 3600000, Value: 0}}, stats.ReorgsPerHour)
+}
@@ -0,0 +1,256 @@
+// Package stats aggregates verifications, reorgs and the verification lag into pre-bucketed time series, so
+// dashboards can be built from a single JSON endpoint without running prometheus.
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	conIface "github.com/lukso-network/lukso-orchestrator/orchestrator/consensus/iface"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+const (
+	// minuteBuckets is the number of kept per-minute buckets
+	minuteBuckets = 60
+	// hourBuckets is the number of kept per-hour buckets
+	hourBuckets = 24
+	// lagSampleInterval is the interval which the verification lag is sampled with
+	lagSampleInterval = params.SecondsPerSlot * time.Second
+)
+
+// ReorgFeed
+type ReorgFeed interface {
+	SubscribeShutdownSignalEvent(chan<- *types.Reorg) event.Subscription
+}
+
+// ConsensusInfoFeed
+type ConsensusInfoFeed interface {
+	SubscribeMinConsensusInfoEvent(chan<- *types.MinimalEpochConsensusInfoV2) event.Subscription
+}
+
+type Config struct {
+	VerifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	ReorgFeed            ReorgFeed
+	ConsensusInfoFeed    ConsensusInfoFeed
+	// LifetimeStats reports the counters of all runs of the node. Lifetime counters are omitted when it is nil.
+	LifetimeStats conIface.LifetimeStatsProvider
+}
+
+// Stats is the response of the stats endpoint
+type Stats struct {
+	VerifiedPerMinute []Point `json:"verifiedPerMinute"`
+	InvalidPerMinute  []Point `json:"invalidPerMinute"`
+	ReorgsPerHour     []Point `json:"reorgsPerHour"`
+	// Lag is the highest number of slots which verification was behind the wall clock slot in every minute
+	Lag                []Point `json:"lag"`
+	LatestVerifiedSlot uint64  `json:"latestVerifiedSlot"`
+	HeadSlot           uint64  `json:"headSlot"`
+	// Lifetime counts slots, reorgs and uptime over all runs of the node
+	Lifetime *types.LifetimeStats `json:"lifetime,omitempty"`
+}
+
+// Service keeps the time series of the stats endpoint and serves them as JSON
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+	runError  error
+
+	verifiedSlotInfoFeed conIface.VerifiedSlotInfoFeed
+	reorgFeed            ReorgFeed
+	consensusInfoFeed    ConsensusInfoFeed
+	lifetimeStats        conIface.LifetimeStatsProvider
+
+	verified *series
+	invalid  *series
+	reorgs   *series
+	lag      *series
+
+	lock               sync.Mutex
+	latestVerifiedSlot uint64
+	epochInfo          *types.MinimalEpochConsensusInfoV2
+
+	now func() time.Time
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		verifiedSlotInfoFeed: cfg.VerifiedSlotInfoFeed,
+		reorgFeed:            cfg.ReorgFeed,
+		consensusInfoFeed:    cfg.ConsensusInfoFeed,
+		lifetimeStats:        cfg.LifetimeStats,
+		verified:             newSeries(time.Minute, minuteBuckets),
+		invalid:              newSeries(time.Minute, minuteBuckets),
+		reorgs:               newSeries(time.Hour, hourBuckets),
+		lag:                  newSeries(time.Minute, minuteBuckets),
+		now:                  time.Now,
+	}
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start stats service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.Info("Started stats service")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	if !s.isRunning {
+		return nil
+	}
+	return s.runError
+}
+
+// ServeHTTP writes the current stats as JSON
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Stats()); err != nil {
+		log.WithError(err).Debug("Failed to write stats response")
+	}
+}
+
+// Stats returns the time series up to now
+func (s *Service) Stats() *Stats {
+	now := s.now()
+	s.lock.Lock()
+	latestVerifiedSlot := s.latestVerifiedSlot
+	headSlot := s.headSlot(now)
+	s.lock.Unlock()
+
+	stats := &Stats{
+		VerifiedPerMinute:  s.verified.snapshot(now),
+		InvalidPerMinute:   s.invalid.snapshot(now),
+		ReorgsPerHour:      s.reorgs.snapshot(now),
+		Lag:                s.lag.snapshot(now),
+		LatestVerifiedSlot: latestVerifiedSlot,
+		HeadSlot:           headSlot,
+	}
+	if s.lifetimeStats != nil {
+		stats.Lifetime = s.lifetimeStats.LifetimeStats()
+	}
+	return stats
+}
+
+// run listens verified slot info, reorg and consensus info events and samples the verification lag
+func (s *Service) run() {
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 1)
+	slotInfoSub := s.verifiedSlotInfoFeed.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer slotInfoSub.Unsubscribe()
+
+	reorgCh := make(chan *types.Reorg, 1)
+	reorgSub := s.reorgFeed.SubscribeShutdownSignalEvent(reorgCh)
+	defer reorgSub.Unsubscribe()
+
+	epochInfoCh := make(chan *types.MinimalEpochConsensusInfoV2, 1)
+	epochInfoSub := s.consensusInfoFeed.SubscribeMinConsensusInfoEvent(epochInfoCh)
+	defer epochInfoSub.Unsubscribe()
+
+	ticker := time.NewTicker(lagSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case slotInfo := <-slotInfoCh:
+			s.onSlotInfo(slotInfo)
+		case <-reorgCh:
+			s.reorgs.add(s.now(), 1)
+		case epochInfo := <-epochInfoCh:
+			s.lock.Lock()
+			s.epochInfo = epochInfo
+			s.lock.Unlock()
+		case <-ticker.C:
+			s.sampleLag()
+		case err := <-slotInfoSub.Err():
+			log.WithError(err).Error("Verified slot info subscription of stats service is closed")
+			s.runError = err
+			return
+		case err := <-reorgSub.Err():
+			log.WithError(err).Error("Reorg subscription of stats service is closed")
+			s.runError = err
+			return
+		case err := <-epochInfoSub.Err():
+			log.WithError(err).Error("Consensus info subscription of stats service is closed")
+			s.runError = err
+			return
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing stats service")
+			return
+		}
+	}
+}
+
+func (s *Service) onSlotInfo(slotInfo *types.SlotInfoWithStatus) {
+	switch slotInfo.Status {
+	case types.Verified:
+		s.verified.add(s.now(), 1)
+		s.lock.Lock()
+		if slotInfo.Slot > s.latestVerifiedSlot {
+			s.latestVerifiedSlot = slotInfo.Slot
+		}
+		s.lock.Unlock()
+	case types.Invalid:
+		s.invalid.add(s.now(), 1)
+	}
+}
+
+// sampleLag records the distance between the wall clock slot and the latest verified slot
+func (s *Service) sampleLag() {
+	now := s.now()
+	s.lock.Lock()
+	headSlot := s.headSlot(now)
+	latestVerifiedSlot := s.latestVerifiedSlot
+	s.lock.Unlock()
+
+	if headSlot == 0 || headSlot < latestVerifiedSlot {
+		return
+	}
+	s.lag.observe(now, int64(headSlot-latestVerifiedSlot))
+}
+
+// headSlot returns the wall clock slot derived from the latest epoch info. It returns 0 when no epoch info is
+// received yet. Caller must hold the lock.
+func (s *Service) headSlot(now time.Time) uint64 {
+	if s.epochInfo == nil {
+		return 0
+	}
+	// slot time duration of the epoch info is given in seconds
+	secondsPerSlot := uint64(s.epochInfo.SlotTimeDuration)
+	if secondsPerSlot == 0 {
+		secondsPerSlot = params.SecondsPerSlot
+	}
+	slot := s.epochInfo.Epoch * params.SlotsPerEpoch
+	if unix := uint64(now.Unix()); unix > s.epochInfo.EpochStartTime {
+		slot += (unix - s.epochInfo.EpochStartTime) / secondsPerSlot
+	}
+	return slot
+}
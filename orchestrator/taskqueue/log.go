@@ -0,0 +1,5 @@
+package taskqueue
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "taskqueue")
@@ -0,0 +1,14 @@
+package taskqueue
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// queuedTasksGauge is the number of tasks in the queue
+	queuedTasksGauge = metrics.NewRegisteredGauge("orc_taskqueue_queued_tasks", nil)
+	// completedTasksCounter is the number of tasks which succeeded
+	completedTasksCounter = metrics.NewRegisteredCounter("orc_taskqueue_completed_tasks_total", nil)
+	// failedAttemptsCounter is the number of task runs which failed and were rescheduled
+	failedAttemptsCounter = metrics.NewRegisteredCounter("orc_taskqueue_failed_attempts_total", nil)
+	// abandonedTasksCounter is the number of tasks which were removed after the last allowed attempt
+	abandonedTasksCounter = metrics.NewRegisteredCounter("orc_taskqueue_abandoned_tasks_total", nil)
+)
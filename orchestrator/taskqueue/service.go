@@ -0,0 +1,225 @@
+// Package taskqueue runs deferred work from a queue which is persisted in db, so that retries of failed work survive
+// restarts instead of living only in goroutine state.
+package taskqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// kinds of deferred tasks
+const (
+	// PruneTask removes the verified slots before ToSlot
+	PruneTask = "prune-verified-slots"
+	// BackfillTask delivers the vanguard blocks after FromSlot up to the vanguard head
+	BackfillTask = "vanguard-backfill"
+	// RepublishTask sends the confirmations of the verified slots of [FromSlot, ToSlot] again
+	RepublishTask = "republish-confirmations"
+)
+
+// maxRetryDelay caps the exponential backoff of a failing task
+const maxRetryDelay = time.Hour
+
+// Handler runs the task. A returned error reschedules the task with backoff.
+type Handler func(ctx context.Context, task *types.DeferredTask) error
+
+// Queue is the task queue which services defer their failed work to
+type Queue interface {
+	Handle(kind string, handler Handler)
+	Enqueue(kind string, fromSlot, toSlot uint64, delay time.Duration) error
+}
+
+type Config struct {
+	DB db.DeferredTaskDB
+	// Interval is how often the queue is checked for due tasks
+	Interval time.Duration
+	// RetryDelay is the delay after the first failed attempt, it doubles with every further attempt
+	RetryDelay time.Duration
+	// MaxAttempts is the number of attempts after which a task is abandoned. Zero retries forever.
+	MaxAttempts uint64
+}
+
+// Service runs the due tasks of the queue with the handler of their kind
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	db          db.DeferredTaskDB
+	interval    time.Duration
+	retryDelay  time.Duration
+	maxAttempts uint64
+
+	lock     sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("task queue interval must be positive")
+	}
+	if cfg.RetryDelay <= 0 {
+		return nil, errors.New("task retry delay must be positive")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:         ctx,
+		cancel:      cancel,
+		db:          cfg.DB,
+		interval:    cfg.Interval,
+		retryDelay:  cfg.RetryDelay,
+		maxAttempts: cfg.MaxAttempts,
+		handlers:    make(map[string]Handler),
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start task queue service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("interval", s.interval).WithField("maxAttempts", s.maxAttempts).Info("Started task queue service")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status
+func (s *Service) Status() error {
+	return nil
+}
+
+// Handle registers the handler of the task kind. Tasks of a kind without handler stay in the queue, so that they
+// run once the feature which handles them is enabled again.
+func (s *Service) Handle(kind string, handler Handler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers[kind] = handler
+}
+
+// Enqueue persists a task of the given kind and slot range which runs after the delay
+func (s *Service) Enqueue(kind string, fromSlot, toSlot uint64, delay time.Duration) error {
+	now := time.Now()
+	task := &types.DeferredTask{
+		Kind:      kind,
+		FromSlot:  fromSlot,
+		ToSlot:    toSlot,
+		NotBefore: now.Add(delay).Unix(),
+		CreatedAt: now.Unix(),
+	}
+	if err := s.db.EnqueueTask(task); err != nil {
+		return errors.Wrapf(err, "could not enqueue %s task", kind)
+	}
+	log.WithField("id", task.Id).WithField("kind", kind).WithField("fromSlot", task.FromSlot).
+		WithField("toSlot", task.ToSlot).Debug("Enqueued deferred task")
+	return nil
+}
+
+// run
+func (s *Service) run() {
+	s.process()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.process()
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing task queue service")
+			return
+		}
+	}
+}
+
+// process runs every due task in the order they were enqueued
+func (s *Service) process() {
+	tasks, err := s.db.DeferredTasks()
+	if err != nil {
+		log.WithError(err).Error("Failed to load deferred tasks")
+		return
+	}
+	queuedTasksGauge.Update(int64(len(tasks)))
+
+	now := time.Now()
+	for _, task := range tasks {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if task.NotBefore > now.Unix() {
+			continue
+		}
+		s.lock.RLock()
+		handler, ok := s.handlers[task.Kind]
+		s.lock.RUnlock()
+		if !ok {
+			log.WithField("id", task.Id).WithField("kind", task.Kind).Trace("No handler for deferred task")
+			continue
+		}
+		s.runTask(handler, task, now)
+	}
+}
+
+// runTask removes the task when it succeeds or is abandoned, otherwise it is rescheduled with backoff
+func (s *Service) runTask(handler Handler, task *types.DeferredTask, now time.Time) {
+	logger := log.WithField("id", task.Id).WithField("kind", task.Kind).
+		WithField("fromSlot", task.FromSlot).WithField("toSlot", task.ToSlot)
+
+	runErr := handler(s.ctx, task)
+	if runErr == nil {
+		if err := s.db.DeleteTask(task.Id); err != nil {
+			logger.WithError(err).Error("Failed to remove completed deferred task")
+			return
+		}
+		completedTasksCounter.Inc(1)
+		logger.Debug("Completed deferred task")
+		return
+	}
+
+	task.Attempts++
+	task.LastError = runErr.Error()
+	if s.maxAttempts > 0 && task.Attempts >= s.maxAttempts {
+		if err := s.db.DeleteTask(task.Id); err != nil {
+			logger.WithError(err).Error("Failed to remove abandoned deferred task")
+			return
+		}
+		abandonedTasksCounter.Inc(1)
+		logger.WithError(runErr).WithField("attempts", task.Attempts).Error("Abandoned deferred task")
+		return
+	}
+	task.NotBefore = now.Add(s.backoff(task.Attempts)).Unix()
+	if err := s.db.SaveTask(task); err != nil {
+		logger.WithError(err).Error("Failed to reschedule deferred task")
+		return
+	}
+	failedAttemptsCounter.Inc(1)
+	logger.WithError(runErr).WithField("attempts", task.Attempts).Warn("Deferred task failed, rescheduled")
+}
+
+// backoff returns the delay after the given number of failed attempts
+func (s *Service) backoff(attempts uint64) time.Duration {
+	delay := s.retryDelay
+	for i := uint64(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,68 @@
+package taskqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+)
+
+func TestService_Process(t *testing.T) {
+	db := testDB.SetupDB(t)
+	s, err := NewService(context.Background(), &Config{
+		DB:          db,
+		Interval:    time.Minute,
+		RetryDelay:  time.Minute,
+		MaxAttempts: 2,
+	})
+	require.NoError(t, err)
+
+	var pruned []uint64
+	s.Handle(PruneTask, func(ctx context.Context, task *types.DeferredTask) error {
+		pruned = append(pruned, task.ToSlot)
+		return nil
+	})
+	s.Handle(RepublishTask, func(ctx context.Context, task *types.DeferredTask) error {
+		return errors.New("pandora is not subscribed")
+	})
+
+	require.NoError(t, s.Enqueue(PruneTask, 0, 64, 0))
+	require.NoError(t, s.Enqueue(RepublishTask, 10, 10, 0))
+	// tasks which are not due and tasks without handler stay in the queue
+	require.NoError(t, s.Enqueue(PruneTask, 200, 256, time.Hour))
+	require.NoError(t, s.Enqueue(BackfillTask, 300, 0, 0))
+
+	s.process()
+	assert.DeepEqual(t, []uint64{64}, pruned)
+	tasks, err := db.DeferredTasks()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(tasks))
+	assert.Equal(t, RepublishTask, tasks[0].Kind)
+	assert.Equal(t, uint64(1), tasks[0].Attempts)
+	assert.Equal(t, "pandora is not subscribed", tasks[0].LastError)
+	assert.Equal(t, true, tasks[0].NotBefore > time.Now().Unix())
+
+	// failing task is abandoned after the last allowed attempt
+	tasks[0].NotBefore = 0
+	require.NoError(t, db.SaveTask(tasks[0]))
+	s.process()
+	tasks, err = db.DeferredTasks()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(tasks))
+	assert.Equal(t, PruneTask, tasks[0].Kind)
+	assert.Equal(t, BackfillTask, tasks[1].Kind)
+}
+
+func TestService_Backoff(t *testing.T) {
+	s := &Service{retryDelay: 10 * time.Minute}
+	assert.Equal(t, 10*time.Minute, s.backoff(1))
+	assert.Equal(t, 20*time.Minute, s.backoff(2))
+	assert.Equal(t, 40*time.Minute, s.backoff(3))
+	assert.Equal(t, maxRetryDelay, s.backoff(4))
+	assert.Equal(t, maxRetryDelay, s.backoff(100))
+}
@@ -0,0 +1,300 @@
+// Package upstream probes the configured endpoints of a chain and moves the chain service to the endpoint
+// with the lowest latency and the freshest head.
+package upstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/idle"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// probeTimeout is the maximum time to wait for an endpoint to answer a probe
+var probeTimeout = 5 * time.Second
+
+// idleProbeFactor stretches the probe interval while the chains are idle. Endpoints are still probed, so that a
+// failed node is replaced even when the chains are idle.
+const idleProbeFactor = 10
+
+// latencyWeight is the weight of the latest round trip in the moving average of latency
+const latencyWeight = 0.3
+
+// Node is a chain service whose endpoint can be probed and switched at runtime
+type Node interface {
+	Endpoint() string
+	SetEndpoint(endpoint string) error
+	// ProbeEndpoint returns the head block number or slot of the endpoint and the round trip of the request
+	ProbeEndpoint(ctx context.Context, endpoint string) (uint64, time.Duration, error)
+}
+
+// Chain is a chain service together with its candidate endpoints
+type Chain struct {
+	Name      string
+	Node      Node
+	Endpoints []string
+	// LagPenalty is added to the score for every block or slot which the endpoint is behind the best head
+	LagPenalty time.Duration
+}
+
+type Config struct {
+	Chains   []*Chain
+	Interval time.Duration
+	// SwitchMargin is the minimum score improvement which moves the chain service to another endpoint
+	SwitchMargin time.Duration
+	// Idle stretches the probe interval while the chains are idle. It is optional.
+	Idle idle.Detector
+}
+
+// endpointState is the probe history of an endpoint
+type endpointState struct {
+	endpoint string
+	healthy  bool
+	latency  time.Duration
+	head     uint64
+	lag      uint64
+	score    time.Duration
+	err      error
+	probedAt time.Time
+}
+
+// Service periodically probes every candidate endpoint of the chains and selects the best one
+type Service struct {
+	isRunning bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	chains       []*Chain
+	interval     time.Duration
+	switchMargin time.Duration
+	idle         idle.Detector
+
+	lock   sync.Mutex
+	states map[string][]*endpointState
+}
+
+// NewService
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	if cfg.Interval <= 0 {
+		return nil, errors.New("endpoint probe interval must be positive")
+	}
+	states := make(map[string][]*endpointState, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		if chain.Node == nil || len(chain.Endpoints) == 0 {
+			return nil, errors.Errorf("%s chain has no endpoint to probe", chain.Name)
+		}
+		for _, endpoint := range chain.Endpoints {
+			states[chain.Name] = append(states[chain.Name], &endpointState{endpoint: endpoint})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		chains:       cfg.Chains,
+		interval:     cfg.Interval,
+		switchMargin: cfg.SwitchMargin,
+		idle:         cfg.Idle,
+		states:       states,
+	}, nil
+}
+
+// Start
+func (s *Service) Start() {
+	if s.isRunning {
+		log.Error("Attempted to start upstream service when it was already started")
+		return
+	}
+	s.isRunning = true
+	go s.run()
+	log.WithField("chains", len(s.chains)).WithField("interval", s.interval).Info("Started endpoint probing")
+}
+
+// Stop
+func (s *Service) Stop() error {
+	if s.cancel != nil {
+		defer s.cancel()
+	}
+	s.isRunning = false
+	return nil
+}
+
+// Status returns error when a chain has no healthy endpoint
+func (s *Service) Status() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, chain := range s.chains {
+		var lastErr error
+		healthy := false
+		for _, state := range s.states[chain.Name] {
+			healthy = healthy || state.healthy || state.probedAt.IsZero()
+			if state.err != nil {
+				lastErr = state.err
+			}
+		}
+		if !healthy {
+			return errors.Wrapf(lastErr, "none of %s endpoints is healthy", chain.Name)
+		}
+	}
+	return nil
+}
+
+// Scores returns the latest probe results of every endpoint
+func (s *Service) Scores() []*types.EndpointScore {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scores := make([]*types.EndpointScore, 0)
+	for _, chain := range s.chains {
+		selected := chain.Node.Endpoint()
+		for _, state := range s.states[chain.Name] {
+			score := &types.EndpointScore{
+				Chain:     chain.Name,
+				Endpoint:  state.endpoint,
+				Selected:  state.endpoint == selected,
+				Healthy:   state.healthy,
+				LatencyMs: state.latency.Milliseconds(),
+				Head:      state.head,
+				Lag:       state.lag,
+				Score:     state.score.Milliseconds(),
+				ProbedAt:  state.probedAt,
+			}
+			if state.err != nil {
+				score.Error = state.err.Error()
+			}
+			scores = append(scores, score)
+		}
+	}
+	return scores
+}
+
+// run
+func (s *Service) run() {
+	s.probeAll()
+	probedAt := time.Now()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if s.idle != nil && s.idle.Idle() && now.Sub(probedAt) < idleProbeFactor*s.interval {
+				continue
+			}
+			s.probeAll()
+			probedAt = now
+		case <-s.ctx.Done():
+			log.Info("Received cancelled context, closing upstream service")
+			return
+		}
+	}
+}
+
+// probeAll
+func (s *Service) probeAll() {
+	for _, chain := range s.chains {
+		s.probe(chain)
+		s.selectEndpoint(chain)
+	}
+}
+
+// probe probes every endpoint of the chain and scores them against the best head
+func (s *Service) probe(chain *Chain) {
+	s.lock.Lock()
+	states := s.states[chain.Name]
+	s.lock.Unlock()
+
+	type result struct {
+		head    uint64
+		latency time.Duration
+		err     error
+	}
+	results := make([]result, len(states))
+	var wg sync.WaitGroup
+	for i, state := range states {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(s.ctx, probeTimeout)
+			defer cancel()
+			head, latency, err := chain.Node.ProbeEndpoint(ctx, endpoint)
+			results[i] = result{head: head, latency: latency, err: err}
+		}(i, state.endpoint)
+	}
+	wg.Wait()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	var bestHead uint64
+	for i, state := range states {
+		state.probedAt = now
+		state.err = results[i].err
+		state.healthy = state.err == nil
+		if !state.healthy {
+			log.WithError(state.err).WithField("chain", chain.Name).WithField("endpoint", state.endpoint).
+				Debug("Endpoint probe failed")
+			continue
+		}
+		state.head = results[i].head
+		if state.latency == 0 {
+			state.latency = results[i].latency
+		} else {
+			state.latency = time.Duration((1-latencyWeight)*float64(state.latency) + latencyWeight*float64(results[i].latency))
+		}
+		if state.head > bestHead {
+			bestHead = state.head
+		}
+	}
+	for _, state := range states {
+		if !state.healthy {
+			continue
+		}
+		state.lag = bestHead - state.head
+		state.score = state.latency + time.Duration(state.lag)*chain.LagPenalty
+	}
+}
+
+// selectEndpoint moves the chain service to the best endpoint when the selected one is unhealthy or the best one
+// is better by more than the switch margin. A selected endpoint which is not a candidate is left alone, since it
+// has been chosen by the operator.
+func (s *Service) selectEndpoint(chain *Chain) {
+	selected := chain.Node.Endpoint()
+
+	s.lock.Lock()
+	var current, best *endpointState
+	for _, state := range s.states[chain.Name] {
+		if state.endpoint == selected {
+			current = state
+		}
+		if state.healthy && (best == nil || state.score < best.score) {
+			best = state
+		}
+	}
+	s.lock.Unlock()
+
+	if current == nil || best == nil || best == current {
+		return
+	}
+	if current.healthy && current.score-best.score <= s.switchMargin {
+		return
+	}
+
+	logger := log.WithField("chain", chain.Name).WithField("from", current.endpoint).WithField("to", best.endpoint).
+		WithField("fromScore", current.score).WithField("toScore", best.score)
+	if err := chain.Node.SetEndpoint(best.endpoint); err != nil {
+		logger.WithError(err).Error("Failed to switch to the best endpoint")
+		s.lock.Lock()
+		best.healthy = false
+		best.err = err
+		s.lock.Unlock()
+		return
+	}
+	logger.Info("Switched to the best endpoint")
+}
@@ -0,0 +1,103 @@
+package upstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+type probeResult struct {
+	head    uint64
+	latency time.Duration
+	err     error
+}
+
+type mockNode struct {
+	endpoint string
+	results  map[string]probeResult
+}
+
+func (m *mockNode) Endpoint() string { return m.endpoint }
+
+func (m *mockNode) SetEndpoint(endpoint string) error {
+	m.endpoint = endpoint
+	return nil
+}
+
+func (m *mockNode) ProbeEndpoint(ctx context.Context, endpoint string) (uint64, time.Duration, error) {
+	r := m.results[endpoint]
+	return r.head, r.latency, r.err
+}
+
+func setup(t *testing.T, node *mockNode) *Service {
+	s, err := NewService(context.Background(), &Config{
+		Chains: []*Chain{{
+			Name:       "pandora",
+			Node:       node,
+			Endpoints:  []string{"a", "b"},
+			LagPenalty: time.Second,
+		}},
+		Interval:     time.Minute,
+		SwitchMargin: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestService_SelectsFresherEndpoint(t *testing.T) {
+	node := &mockNode{endpoint: "a", results: map[string]probeResult{
+		"a": {head: 90, latency: 10 * time.Millisecond},
+		"b": {head: 100, latency: 50 * time.Millisecond},
+	}}
+	s := setup(t, node)
+
+	s.probeAll()
+	assert.Equal(t, "b", node.endpoint)
+
+	scores := s.Scores()
+	require.Equal(t, 2, len(scores))
+	assert.Equal(t, false, scores[0].Selected)
+	assert.Equal(t, uint64(10), scores[0].Lag)
+	assert.Equal(t, int64(10010), scores[0].Score)
+	assert.Equal(t, true, scores[1].Selected)
+	assert.Equal(t, int64(50), scores[1].Score)
+	require.NoError(t, s.Status())
+}
+
+func TestService_KeepsEndpointWithinMargin(t *testing.T) {
+	node := &mockNode{endpoint: "a", results: map[string]probeResult{
+		"a": {head: 100, latency: 80 * time.Millisecond},
+		"b": {head: 100, latency: 10 * time.Millisecond},
+	}}
+	s := setup(t, node)
+
+	s.probeAll()
+	assert.Equal(t, "a", node.endpoint)
+
+	// operator selected endpoint is left alone
+	node.endpoint = "c"
+	node.results["b"] = probeResult{head: 200, latency: time.Millisecond}
+	s.probeAll()
+	assert.Equal(t, "c", node.endpoint)
+}
+
+func TestService_LeavesUnhealthyEndpoint(t *testing.T) {
+	node := &mockNode{endpoint: "a", results: map[string]probeResult{
+		"a": {err: errors.New("connection refused")},
+		"b": {head: 100, latency: 10 * time.Millisecond},
+	}}
+	s := setup(t, node)
+
+	s.probeAll()
+	assert.Equal(t, "b", node.endpoint)
+	assert.Equal(t, "connection refused", s.Scores()[0].Error)
+	require.NoError(t, s.Status())
+
+	node.results["b"] = probeResult{err: errors.New("timeout")}
+	s.probeAll()
+	assert.ErrorContains(t, "none of pandora endpoints is healthy", s.Status())
+}
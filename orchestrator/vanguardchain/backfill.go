@@ -0,0 +1,130 @@
+package vanguardchain
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// backfillPageSize is the number of blocks which are requested from vanguard node at once
+const backfillPageSize = 64
+
+// backfillRetryDelay gives the live subscription time to settle before a failed backfill is retried
+const backfillRetryDelay = time.Minute
+
+// SetTaskQueue sets the task queue which failed backfills are deferred to
+func (s *Service) SetTaskQueue(tasks taskqueue.Queue) {
+	s.tasks = tasks
+	tasks.Handle(taskqueue.BackfillTask, s.backfillTask)
+}
+
+// backfill delivers the canonical vanguard blocks after the given slot up to the current head, so that the slots
+// which were produced while orchestrator was offline are verified before the live stream starts. Latest verified
+// slot is the cursor, so an interrupted backfill resumes from the last verified slot at next start.
+func (s *Service) backfill(ctx context.Context, fromSlot uint64) error {
+	head, err := s.beaconClient.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve vanguard chain head")
+	}
+	headSlot := uint64(head.HeadSlot)
+	if headSlot <= fromSlot {
+		return nil
+	}
+	log.WithField("fromSlot", fromSlot).WithField("headSlot", headSlot).Info("Backfilling missed vanguard blocks")
+
+	delivered := 0
+	for epoch := fromSlot / params.SlotsPerEpoch; epoch <= headSlot/params.SlotsPerEpoch; epoch++ {
+		containers, err := s.listBlocks(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		for _, container := range canonicalContainers(containers) {
+			block := container.Block.Block
+			if slot := uint64(block.Slot); slot <= fromSlot || slot > headSlot {
+				continue
+			}
+			// blocks of the finalized part of the chain must not move finalized info beyond the verified slots
+			blockInfo := &ethpb.StreamPendingBlockInfo{
+				Block:          block,
+				FinalizedSlot:  eth2Types.Slot(s.db.LatestLatestFinalizedSlot()),
+				FinalizedEpoch: eth2Types.Epoch(s.db.LatestLatestFinalizedEpoch()),
+			}
+			if block.Slot > head.FinalizedSlot {
+				blockInfo.FinalizedSlot = head.FinalizedSlot
+				blockInfo.FinalizedEpoch = head.FinalizedEpoch
+			}
+			if err := s.onNewPendingVanguardBlock(ctx, blockInfo); err != nil {
+				return errors.Wrapf(err, "could not process backfilled vanguard block of slot %d", block.Slot)
+			}
+			backfilledBlocksCounter.Inc(1)
+			delivered++
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	log.WithField("blocks", delivered).WithField("headSlot", headSlot).Info("Finished backfilling vanguard blocks")
+	return nil
+}
+
+// deferBackfill queues the backfill after the given slot, so that it is retried even after a restart
+func (s *Service) deferBackfill(fromSlot uint64) {
+	if s.tasks == nil {
+		return
+	}
+	if err := s.tasks.Enqueue(taskqueue.BackfillTask, fromSlot, fromSlot, backfillRetryDelay); err != nil {
+		log.WithError(err).WithField("fromSlot", fromSlot).Error("Failed to defer backfill of vanguard blocks")
+	}
+}
+
+// backfillTask runs the deferred backfill. Slots which were verified since the task was queued are skipped.
+func (s *Service) backfillTask(ctx context.Context, task *types.DeferredTask) error {
+	if !s.connectedVanguard {
+		return errors.New("vanguard node is not connected")
+	}
+	fromSlot := task.FromSlot
+	if verifiedSlot := s.db.LatestSavedVerifiedSlot(); verifiedSlot > fromSlot {
+		fromSlot = verifiedSlot
+	}
+	return s.backfill(ctx, fromSlot)
+}
+
+// listBlocks returns every block of the epoch which vanguard node knows
+func (s *Service) listBlocks(ctx context.Context, epoch uint64) ([]*ethpb.BeaconBlockContainer, error) {
+	containers := make([]*ethpb.BeaconBlockContainer, 0)
+	req := &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Epoch{Epoch: eth2Types.Epoch(epoch)},
+		PageSize:    backfillPageSize,
+	}
+	for {
+		resp, err := s.beaconClient.ListBlocks(ctx, req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list vanguard blocks of epoch %d", epoch)
+		}
+		containers = append(containers, resp.BlockContainers...)
+		if resp.NextPageToken == "" || len(resp.BlockContainers) == 0 {
+			return containers, nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
+}
+
+// canonicalContainers returns the canonical blocks ordered by slot
+func canonicalContainers(containers []*ethpb.BeaconBlockContainer) []*ethpb.BeaconBlockContainer {
+	canonical := make([]*ethpb.BeaconBlockContainer, 0, len(containers))
+	for _, container := range containers {
+		if container.Canonical && container.Block != nil && container.Block.Block != nil {
+			canonical = append(canonical, container)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool { return canonical[i].Block.Block.Slot < canonical[j].Block.Block.Slot })
+	return canonical
+}
@@ -0,0 +1,31 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+func TestCanonicalContainers(t *testing.T) {
+	container := func(slot eth2Types.Slot, canonical bool) *ethpb.BeaconBlockContainer {
+		return &ethpb.BeaconBlockContainer{
+			Block:     &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot}},
+			Canonical: canonical,
+		}
+	}
+	containers := []*ethpb.BeaconBlockContainer{
+		container(7, true),
+		container(5, false),
+		container(5, true),
+		{Canonical: true},
+		container(6, true),
+	}
+
+	canonical := canonicalContainers(containers)
+	assert.Equal(t, 3, len(canonical))
+	assert.Equal(t, eth2Types.Slot(5), canonical[0].Block.Block.Slot)
+	assert.Equal(t, eth2Types.Slot(6), canonical[1].Block.Block.Slot)
+	assert.Equal(t, eth2Types.Slot(7), canonical[2].Block.Block.Slot)
+}
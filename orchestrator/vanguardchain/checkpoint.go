@@ -0,0 +1,59 @@
+package vanguardchain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetCheckpoint makes the backfill start at the trusted checkpoint slot instead of the genesis of an empty db
+func (s *Service) SetCheckpoint(slot uint64) {
+	s.checkpointSlot = slot
+}
+
+// CheckpointShardInfo returns the shard info and the pandora block number of the canonical vanguard block of the
+// given slot. It fails unless the vanguard node has already finalized the slot.
+func (s *Service) CheckpointShardInfo(ctx context.Context, slot uint64) (*types.SlotInfo, uint64, error) {
+	if !s.connectedVanguard {
+		return nil, 0, errors.New("vanguard node is not connected")
+	}
+	head, err := s.beaconClient.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "could not retrieve vanguard chain head")
+	}
+	if uint64(head.FinalizedSlot) < slot {
+		return nil, 0, errors.Errorf("checkpoint slot %d is not finalized by vanguard node yet, finalized slot is %d",
+			slot, head.FinalizedSlot)
+	}
+
+	resp, err := s.beaconClient.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Slot{Slot: eth2Types.Slot(slot)},
+	})
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "could not list vanguard blocks of slot %d", slot)
+	}
+	containers := canonicalContainers(resp.BlockContainers)
+	if len(containers) == 0 {
+		return nil, 0, errors.Errorf("vanguard node has no canonical block at checkpoint slot %d", slot)
+	}
+	block := containers[0].Block.Block
+	blockHash, err := block.HashTreeRoot()
+	if err != nil {
+		return nil, 0, err
+	}
+	pandoraShards := wrapper.WrappedPhase0BeaconBlock(block).Body().PandoraShards()
+	if len(pandoraShards) < 1 {
+		return nil, 0, errors.New("invalid shard info length in vanguard block body")
+	}
+	slotInfo := &types.SlotInfo{
+		VanguardBlockHash: common.BytesToHash(blockHash[:]),
+		PandoraHeaderHash: common.BytesToHash(pandoraShards[0].Hash),
+	}
+	return slotInfo, pandoraShards[0].BlockNumber, nil
+}
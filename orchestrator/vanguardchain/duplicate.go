@@ -0,0 +1,47 @@
+package vanguardchain
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// recentRootsSize is the number of latest vanguard block roots which are remembered. It covers the blocks which are
+// replayed from the finalized slot when the pending block stream is restarted.
+const recentRootsSize = 1024
+
+var (
+	// duplicateBlocksCounter counts vanguard blocks which are dropped since they were already delivered
+	duplicateBlocksCounter = metrics.NewRegisteredCounter("orc_vanguard_duplicate_blocks_total", nil)
+)
+
+// recentRoots remembers the roots of the latest vanguard blocks, so that exact duplicates which the stream
+// delivers again after reconnects do not reach the consensus service
+type recentRoots struct {
+	cache *lru.Cache
+}
+
+func newRecentRoots(size int) (*recentRoots, error) {
+	cache, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &recentRoots{cache: cache}, nil
+}
+
+// seen returns true when the block root is already delivered, otherwise the root is remembered
+func (r *recentRoots) seen(root common.Hash) bool {
+	found, _ := r.cache.ContainsOrAdd(root, struct{}{})
+	return found
+}
+
+// forget removes the block root
+func (r *recentRoots) forget(root common.Hash) {
+	r.cache.Remove(root)
+}
+
+// purge forgets every root. It is used when the consensus service reverts its state, since the replayed blocks
+// must be delivered again.
+func (r *recentRoots) purge() {
+	r.cache.Purge()
+}
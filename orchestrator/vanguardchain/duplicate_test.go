@@ -0,0 +1,29 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestRecentRoots_Seen(t *testing.T) {
+	roots, err := newRecentRoots(2)
+	require.NoError(t, err)
+
+	first, second, third := common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")
+	assert.Equal(t, false, roots.seen(first))
+	assert.Equal(t, true, roots.seen(first))
+	assert.Equal(t, false, roots.seen(second))
+
+	// the oldest root is evicted
+	assert.Equal(t, false, roots.seen(third))
+	assert.Equal(t, false, roots.seen(first))
+
+	roots.forget(first)
+	assert.Equal(t, false, roots.seen(first))
+
+	roots.purge()
+	assert.Equal(t, false, roots.seen(third))
+}
@@ -0,0 +1,92 @@
+package vanguardchain
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// identityTimeout is the maximum time to wait for vanguard node to report its genesis
+var identityTimeout = 10 * time.Second
+
+var errGenesisValidatorsRootMismatch = errors.New("vanguard node reports different genesis validators root")
+
+// genesisValidatorsRoot returns the genesis validators root reported by vanguard node
+func genesisValidatorsRoot(ctx context.Context, nodeClient ethpb.NodeClient) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, identityTimeout)
+	defer cancel()
+
+	genesis, err := nodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve genesis from vanguard node")
+	}
+	return genesis.GenesisValidatorsRoot, nil
+}
+
+// verifyGenesisValidatorsRoot checks the genesis validators root of the given vanguard node against the pinned one.
+// The root of the first node is pinned into db.
+func (s *Service) verifyGenesisValidatorsRoot(nodeClient ethpb.NodeClient) error {
+	root, err := genesisValidatorsRoot(s.ctx, nodeClient)
+	if err != nil {
+		return err
+	}
+	pinned, err := s.db.VanguardGenesisValidatorsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve pinned genesis validators root")
+	}
+	if len(pinned) == 0 {
+		if err := s.db.SaveVanguardGenesisValidatorsRoot(root); err != nil {
+			return errors.Wrap(err, "could not pin genesis validators root")
+		}
+		log.WithField("genesisValidatorsRoot", hexutil.Encode(root)).Info("Pinned vanguard genesis validators root")
+		return nil
+	}
+	if !bytes.Equal(pinned, root) {
+		return errors.Wrapf(errGenesisValidatorsRootMismatch, "expected %s, got %s",
+			hexutil.Encode(pinned), hexutil.Encode(root))
+	}
+	return nil
+}
+
+// SetEndpoint switches the service to a different vanguard node. Closing the old connection makes running
+// streams to re-subscribe on the new connection from the latest finalized slot and epoch.
+func (s *Service) SetEndpoint(endpoint string) error {
+	conn, err := s.newConn(endpoint)
+	if err != nil {
+		return errors.Wrap(err, "could not dial new vanguard endpoint")
+	}
+	if conn == nil {
+		return errors.Errorf("invalid vanguard endpoint %s", endpoint)
+	}
+	nodeClient := ethpb.NewNodeClient(conn)
+	if err := s.verifyGenesisValidatorsRoot(nodeClient); err != nil {
+		closeConn(conn)
+		return err
+	}
+
+	s.processingLock.Lock()
+	oldConn := s.conn
+	s.conn = conn
+	s.beaconClient = ethpb.NewBeaconChainClient(conn)
+	s.nodeClient = nodeClient
+	s.vanGRPCEndpoint = endpoint
+	s.processingLock.Unlock()
+
+	if oldConn != nil {
+		closeConn(oldConn)
+	}
+	log.WithField("vanguardEndpoint", endpoint).Info("Switched vanguard endpoint")
+	return nil
+}
+
+func closeConn(conn *grpc.ClientConn) {
+	if err := conn.Close(); err != nil {
+		log.WithError(err).Debug("Failed to close vanguard connection")
+	}
+}
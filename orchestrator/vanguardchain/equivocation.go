@@ -0,0 +1,174 @@
+package vanguardchain
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
+)
+
+var (
+	// shardEquivocationCounter counts slots whose proposer signed different shard infos
+	shardEquivocationCounter = metrics.NewRegisteredCounter("orc_vanguard_shard_equivocations_total", nil)
+)
+
+// observedShard is the first shard info of a slot which is received from any vanguard node
+type observedShard struct {
+	proposerIndex uint64
+	side          *types.ShardEquivocationSide
+	// reported is true when an equivocation of the slot is already reported
+	reported bool
+}
+
+// equivocationDetector cross-checks shard infos of all vanguard feeds and catches proposers which
+// signed different shard infos for the same slot
+type equivocationDetector struct {
+	lock     sync.Mutex
+	observed map[uint64]*observedShard
+}
+
+func newEquivocationDetector() *equivocationDetector {
+	return &equivocationDetector{observed: make(map[uint64]*observedShard)}
+}
+
+// observe returns the evidence when the proposer of the slot already signed a different shard info
+func (d *equivocationDetector) observe(
+	source string,
+	slot uint64,
+	proposerIndex uint64,
+	blockRoot common.Hash,
+	shard *ethpb.PandoraShard,
+) *types.ShardEquivocation {
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	side := &types.ShardEquivocationSide{
+		Source:    source,
+		BlockRoot: blockRoot,
+		Shard:     types.NewShardInfoFields(shard),
+	}
+	observed, ok := d.observed[slot]
+	// a different proposer means another fork of the slot, not an equivocation
+	if !ok || observed.proposerIndex != proposerIndex {
+		d.observed[slot] = &observedShard{proposerIndex: proposerIndex, side: side}
+		return nil
+	}
+	if observed.reported || sameShard(observed.side.Shard, side.Shard) {
+		return nil
+	}
+	observed.reported = true
+	return &types.ShardEquivocation{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		First:         observed.side,
+		Second:        side,
+	}
+}
+
+// prune removes slots which are older than the given slot
+func (d *equivocationDetector) prune(slot uint64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for s := range d.observed {
+		if s < slot {
+			delete(d.observed, s)
+		}
+	}
+}
+
+// sameShard
+func sameShard(a, b *types.ShardInfoFields) bool {
+	return a.Hash == b.Hash && bytes.Equal(a.Signature, b.Signature)
+}
+
+// observeShard persists the evidence when the block's proposer equivocated. It only runs when more than
+// one vanguard node is configured.
+func (s *Service) observeShard(source string, block *ethpb.BeaconBlock, blockRoot [32]byte, shard *ethpb.PandoraShard) {
+	if s.equivocations == nil {
+		return
+	}
+	s.equivocations.prune(s.db.LatestLatestFinalizedSlot())
+	equivocation := s.equivocations.observe(source, uint64(block.Slot), uint64(block.ProposerIndex), blockRoot, shard)
+	if equivocation == nil {
+		return
+	}
+
+	shardEquivocationCounter.Inc(1)
+	log.WithField("slot", equivocation.Slot).WithField("proposerIndex", equivocation.ProposerIndex).
+		WithField("firstSource", equivocation.First.Source).WithField("secondSource", equivocation.Second.Source).
+		WithField("firstShardHash", equivocation.First.Shard.Hash).WithField("secondShardHash", equivocation.Second.Shard.Hash).
+		Error("Proposer signed conflicting shard infos for the same slot")
+	if err := s.db.SaveShardEquivocation(equivocation); err != nil {
+		log.WithError(err).WithField("slot", equivocation.Slot).Error("Failed to save shard equivocation evidence")
+	}
+}
+
+// subscribeFanInPendingBlocks streams blocks from a secondary vanguard node only to cross-check shard infos
+// of proposers. Blocks of secondary nodes are never verified.
+func (s *Service) subscribeFanInPendingBlocks(ctx context.Context, endpoint string) {
+	breaker := newBreaker("secondary vanguard block stream " + endpoint)
+	for {
+		if err := s.streamFanInPendingBlocks(ctx, endpoint); err != nil {
+			breaker.Failure(err)
+			log.WithError(err).WithField("vanguardEndpoint", endpoint).WithField("backoff", breaker.Backoff()).
+				Warn("Block stream of secondary vanguard node is broken, retrying")
+		}
+		if err := breaker.Wait(ctx); err != nil {
+			log.WithField("vanguardEndpoint", endpoint).
+				Info("Received cancelled context, closing secondary vanguard block subscription")
+			return
+		}
+	}
+}
+
+// streamFanInPendingBlocks
+func (s *Service) streamFanInPendingBlocks(ctx context.Context, endpoint string) error {
+	conn, err := s.newConn(endpoint)
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return errDialNil
+	}
+	defer conn.Close()
+
+	if err := s.verifyGenesisValidatorsRoot(ethpb.NewNodeClient(conn)); err != nil {
+		return err
+	}
+
+	fromSlot := s.db.LatestLatestFinalizedSlot()
+	stream, err := ethpb.NewBeaconChainClient(conn).StreamNewPendingBlocks(
+		ctx, &ethpb.StreamPendingBlocksRequest{FromSlot: eth2Types.Slot(fromSlot)})
+	if err != nil {
+		return err
+	}
+	log.WithField("vanguardEndpoint", endpoint).WithField("fromSlot", fromSlot).
+		Info("Successfully subscribed to blocks of secondary vanguard node")
+
+	for {
+		blockInfo, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if blockInfo == nil || blockInfo.Block == nil {
+			return errBlockInfoNil
+		}
+		blockRoot, err := blockInfo.Block.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		shards := wrapper.WrappedPhase0BeaconBlock(blockInfo.Block).Body().PandoraShards()
+		if len(shards) < 1 {
+			continue
+		}
+		s.observeShard(endpoint, blockInfo.Block, blockRoot, shards[0])
+	}
+}
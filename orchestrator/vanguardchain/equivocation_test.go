@@ -0,0 +1,36 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+func TestEquivocationDetector_Observe(t *testing.T) {
+	detector := newEquivocationDetector()
+	shard := &ethpb.PandoraShard{BlockNumber: 10, Hash: common.HexToHash("0x1").Bytes(), Signature: []byte{0x01}}
+	conflicting := &ethpb.PandoraShard{BlockNumber: 10, Hash: common.HexToHash("0x2").Bytes(), Signature: []byte{0x02}}
+
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("primary", 5, 3, common.HexToHash("0xa"), shard))
+	// same shard info from another node is a duplicate
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("secondary", 5, 3, common.HexToHash("0xa"), shard))
+	// another proposer of the same slot is a fork, not an equivocation
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("secondary", 6, 3, common.HexToHash("0xb"), shard))
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("primary", 6, 4, common.HexToHash("0xc"), conflicting))
+
+	equivocation := detector.observe("secondary", 5, 3, common.HexToHash("0xd"), conflicting)
+	assert.DeepEqual(t, &types.ShardEquivocation{
+		Slot:          5,
+		ProposerIndex: 3,
+		First:         &types.ShardEquivocationSide{Source: "primary", BlockRoot: common.HexToHash("0xa"), Shard: types.NewShardInfoFields(shard)},
+		Second:        &types.ShardEquivocationSide{Source: "secondary", BlockRoot: common.HexToHash("0xd"), Shard: types.NewShardInfoFields(conflicting)},
+	}, equivocation)
+	// evidence of a slot is reported once
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("secondary", 5, 3, common.HexToHash("0xd"), conflicting))
+
+	detector.prune(6)
+	assert.Equal(t, (*types.ShardEquivocation)(nil), detector.observe("secondary", 5, 3, common.HexToHash("0xd"), conflicting))
+}
@@ -0,0 +1,65 @@
+package vanguardchain
+
+import (
+	"context"
+)
+
+// SetFallbackEndpoints sets the vanguard nodes which the service fails over to when the used node is unreachable.
+// The primary endpoint stays the first candidate, so the service can fail back to it.
+func (s *Service) SetFallbackEndpoints(endpoints []string) {
+	s.failoverLock.Lock()
+	defer s.failoverLock.Unlock()
+	s.failoverEndpoints = append([]string{s.Endpoint()}, endpoints...)
+}
+
+// failoverCandidates returns the endpoints in failover order, starting after the given endpoint
+func failoverCandidates(endpoints []string, current string) []string {
+	start := 0
+	for i, endpoint := range endpoints {
+		if endpoint == current {
+			start = i + 1
+			break
+		}
+	}
+	candidates := make([]string, 0, len(endpoints))
+	for i := range endpoints {
+		if endpoint := endpoints[(start+i)%len(endpoints)]; endpoint != current {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	return candidates
+}
+
+// failover moves the service to the next healthy vanguard node. It returns true when the service uses a
+// reachable node afterwards, either because another stream already failed over or a candidate answered.
+func (s *Service) failover() bool {
+	s.failoverLock.Lock()
+	defer s.failoverLock.Unlock()
+
+	if len(s.failoverEndpoints) < 2 {
+		return false
+	}
+	// streams fail together, so another stream could have already moved to a healthy node
+	if err := s.checkConnection(); err == nil {
+		return true
+	}
+
+	current := s.Endpoint()
+	for _, endpoint := range failoverCandidates(s.failoverEndpoints, current) {
+		ctx, cancel := context.WithTimeout(s.ctx, identityTimeout)
+		_, _, err := s.ProbeEndpoint(ctx, endpoint)
+		cancel()
+		if err != nil {
+			log.WithError(err).WithField("vanguardEndpoint", endpoint).Warn("Vanguard fallback node is not healthy")
+			continue
+		}
+		if err := s.SetEndpoint(endpoint); err != nil {
+			log.WithError(err).WithField("vanguardEndpoint", endpoint).Warn("Could not fail over to vanguard node")
+			continue
+		}
+		failoverCounter.Inc(1)
+		log.WithField("from", current).WithField("to", endpoint).Warn("Failed over to next vanguard node")
+		return true
+	}
+	return false
+}
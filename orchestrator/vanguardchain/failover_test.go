@@ -0,0 +1,16 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestFailoverCandidates(t *testing.T) {
+	endpoints := []string{"a:4000", "b:4000", "c:4000"}
+	assert.DeepEqual(t, []string{"b:4000", "c:4000"}, failoverCandidates(endpoints, "a:4000"))
+	assert.DeepEqual(t, []string{"c:4000", "a:4000"}, failoverCandidates(endpoints, "b:4000"))
+	assert.DeepEqual(t, []string{"a:4000", "b:4000"}, failoverCandidates(endpoints, "c:4000"))
+	// endpoint which is set outside the candidates starts from the primary one
+	assert.DeepEqual(t, []string{"a:4000", "b:4000", "c:4000"}, failoverCandidates(endpoints, "d:4000"))
+}
@@ -0,0 +1,154 @@
+package vanguardchain
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+var (
+	// epochInfoConflictCounter counts epochs for which vanguard nodes sent different proposer lists
+	epochInfoConflictCounter = metrics.NewRegisteredCounter("orc_vanguard_epoch_info_conflicts_total", nil)
+)
+
+// receivedEpochInfo keeps the first accepted proposer list of an epoch
+type receivedEpochInfo struct {
+	source         string
+	validatorsHash common.Hash
+}
+
+// epochInfoFanIn merges epoch info streams of several vanguard nodes. First received epoch info wins,
+// duplicates are dropped and different proposer lists for the same epoch are reported as conflicts.
+type epochInfoFanIn struct {
+	lock     sync.Mutex
+	received map[uint64]*receivedEpochInfo
+}
+
+func newEpochInfoFanIn() *epochInfoFanIn {
+	return &epochInfoFanIn{received: make(map[uint64]*receivedEpochInfo)}
+}
+
+// validatorsHash
+func validatorsHash(validatorList []string) common.Hash {
+	return crypto.Keccak256Hash([]byte(strings.Join(validatorList, ",")))
+}
+
+// accept returns true when the epoch info is not delivered yet by any vanguard node
+func (f *epochInfoFanIn) accept(source string, consensusInfo *types.MinimalEpochConsensusInfoV2) bool {
+	hash := validatorsHash(consensusInfo.ValidatorList)
+	received, ok := f.received[consensusInfo.Epoch]
+	// re-org re-sends epoch info, so it always replaces the previous one
+	if !ok || consensusInfo.ReorgInfo != nil {
+		f.received[consensusInfo.Epoch] = &receivedEpochInfo{source: source, validatorsHash: hash}
+		return true
+	}
+	if received.validatorsHash != hash {
+		epochInfoConflictCounter.Inc(1)
+		log.WithField("epoch", consensusInfo.Epoch).WithField("source", source).
+			WithField("acceptedSource", received.source).
+			Error("Vanguard nodes disagree on proposer list of the epoch")
+	}
+	return false
+}
+
+// prune removes epochs which are older than the given epoch
+func (f *epochInfoFanIn) prune(epoch uint64) {
+	for e := range f.received {
+		if e < epoch {
+			delete(f.received, e)
+		}
+	}
+}
+
+// deliverConsensusInfo hands over epoch info from the given vanguard endpoint. When fan-in is enabled, only the
+// first copy of every epoch info is processed.
+func (s *Service) deliverConsensusInfo(ctx context.Context, source string, consensusInfo *types.MinimalEpochConsensusInfoV2) error {
+	if s.fanIn == nil {
+		return s.onNewConsensusInfo(ctx, consensusInfo)
+	}
+
+	s.fanIn.lock.Lock()
+	defer s.fanIn.lock.Unlock()
+
+	s.fanIn.prune(s.db.LatestLatestFinalizedEpoch())
+	if !s.fanIn.accept(source, consensusInfo) {
+		log.WithField("epoch", consensusInfo.Epoch).WithField("source", source).
+			Trace("Dropping already received epoch info")
+		return nil
+	}
+	if source != s.vanGRPCEndpoint {
+		log.WithField("epoch", consensusInfo.Epoch).WithField("source", source).
+			Debug("Epoch info is delivered by secondary vanguard node")
+	}
+	return s.onNewConsensusInfo(ctx, consensusInfo)
+}
+
+// subscribeFanInConsensusInfo streams epoch infos from a secondary vanguard node and keeps re-connecting until
+// the context is cancelled. Secondary nodes only provide epoch infos, re-org is still driven by the primary node.
+func (s *Service) subscribeFanInConsensusInfo(ctx context.Context, endpoint string) {
+	breaker := newBreaker("secondary vanguard connection " + endpoint)
+	for {
+		if err := s.streamFanInConsensusInfo(ctx, endpoint); err != nil {
+			breaker.Failure(err)
+			log.WithError(err).WithField("vanguardEndpoint", endpoint).WithField("backoff", breaker.Backoff()).
+				Warn("Epoch info stream of secondary vanguard node is broken, retrying")
+		}
+		if err := breaker.Wait(ctx); err != nil {
+			log.WithField("vanguardEndpoint", endpoint).
+				Info("Received cancelled context, closing secondary vanguard epoch info subscription")
+			return
+		}
+	}
+}
+
+// streamFanInConsensusInfo
+func (s *Service) streamFanInConsensusInfo(ctx context.Context, endpoint string) error {
+	conn, err := s.newConn(endpoint)
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return errDialNil
+	}
+	defer conn.Close()
+
+	// refuse to merge epoch infos of a node which belongs to another network
+	if err := s.verifyGenesisValidatorsRoot(ethpb.NewNodeClient(conn)); err != nil {
+		return err
+	}
+
+	fromEpoch := s.db.LatestLatestFinalizedEpoch()
+	stream, err := ethpb.NewBeaconChainClient(conn).StreamMinimalConsensusInfo(
+		ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: eth2Types.Epoch(fromEpoch)})
+	if err != nil {
+		return err
+	}
+	log.WithField("vanguardEndpoint", endpoint).WithField("fromEpoch", fromEpoch).
+		Info("Successfully subscribed to minimal consensus info of secondary vanguard node")
+
+	for {
+		vanMinimalConsensusInfo, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if vanMinimalConsensusInfo == nil {
+			return errConsensusInfoNil
+		}
+		if len(vanMinimalConsensusInfo.ValidatorList) < 1 {
+			return errInvalidValidatorLength
+		}
+		consensusInfo := s.toConsensusInfo(vanMinimalConsensusInfo)
+		// re-org is handled only from primary vanguard node
+		consensusInfo.ReorgInfo = nil
+		if err := s.deliverConsensusInfo(ctx, endpoint, consensusInfo); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestEpochInfoFanIn_Accept(t *testing.T) {
+	hook := logTest.NewGlobal()
+	fanIn := newEpochInfoFanIn()
+
+	epochInfo := testutil.NewMinimalConsensusInfo(5)
+	assert.Equal(t, true, fanIn.accept("primary", epochInfo))
+	// same epoch info from another node is a duplicate
+	assert.Equal(t, false, fanIn.accept("secondary", testutil.NewMinimalConsensusInfo(5)))
+	assert.LogsDoNotContain(t, hook, "Vanguard nodes disagree on proposer list of the epoch")
+
+	conflicting := testutil.NewMinimalConsensusInfo(5)
+	conflicting.ValidatorList = conflicting.ValidatorList[1:]
+	assert.Equal(t, false, fanIn.accept("secondary", conflicting))
+	assert.LogsContain(t, hook, "Vanguard nodes disagree on proposer list of the epoch")
+
+	fanIn.prune(6)
+	assert.Equal(t, true, fanIn.accept("secondary", testutil.NewMinimalConsensusInfo(5)))
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
-	eth "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/eth/v1alpha1/wrapper"
 )
 
@@ -13,6 +16,7 @@ import (
 //	- sends the new consensus info to all subscribed pandora clients
 //  - store consensus info into cache as well as into kv consensusInfoDB
 func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.MinimalEpochConsensusInfoV2) error {
+	receivedConsensusInfosCounter.Inc(1)
 	nsent := s.consensusInfoFeed.Send(consensusInfo)
 	log.WithField("nsent", nsent).Trace("Send consensus info to subscribers")
 
@@ -26,6 +30,13 @@ func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.M
 		return err
 	}
 
+	if source := s.epochInfoSource(ctx, consensusInfo.Epoch); source != nil {
+		if err := s.db.SaveConsensusInfoSource(consensusInfo.Epoch, source); err != nil {
+			log.WithError(err).Warn("failed to save consensus info source into consensusInfoDB!")
+			return err
+		}
+	}
+
 	if consensusInfo.ReorgInfo != nil {
 		nsent = s.subscriptionShutdownFeed.Send(consensusInfo.ReorgInfo)
 		log.WithField("nsent", nsent).Trace("Send reorg info to consensus service")
@@ -36,13 +47,23 @@ func (s *Service) onNewConsensusInfo(ctx context.Context, consensusInfo *types.M
 }
 
 // onNewPendingVanguardBlock
-func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *eth.StreamPendingBlockInfo) error {
+func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *ethpb.StreamPendingBlockInfo) error {
 	block := blockInfo.Block
 	blockHash, err := block.HashTreeRoot()
 	if nil != err {
 		log.WithError(err).Warn("failed to retrieve vanguard block hash from HashTreeRoot")
 		return err
 	}
+	if err := chaos.Inject(chaos.VanguardShardPoint); err != nil {
+		log.WithError(err).WithField("slot", block.Slot).Warn("Dropping vanguard block")
+		return nil
+	}
+	if s.recentRoots.seen(blockHash) {
+		duplicateBlocksCounter.Inc(1)
+		log.WithField("slot", block.Slot).WithField("blockRoot", common.Hash(blockHash)).
+			Debug("Dropping already delivered vanguard block")
+		return nil
+	}
 	wrappedPhase0Blk := wrapper.WrappedPhase0BeaconBlock(block)
 	pandoraShards := wrappedPhase0Blk.Body().PandoraShards()
 	if len(pandoraShards) < 1 {
@@ -52,6 +73,7 @@ func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *eth.
 	}
 
 	shardInfo := pandoraShards[0]
+	s.observeShard(s.vanGRPCEndpoint, block, blockHash, shardInfo)
 	cachedShardInfo := &types.VanguardShardInfo{
 		Slot:           uint64(block.Slot),
 		BlockHash:      blockHash[:],
@@ -60,14 +82,101 @@ func (s *Service) onNewPendingVanguardBlock(ctx context.Context, blockInfo *eth.
 		FinalizedEpoch: uint64(blockInfo.FinalizedEpoch),
 	}
 
+	s.latestBlockLock.Lock()
+	s.latestBlock = &types.EpochInfoSource{
+		Slot:      uint64(block.Slot),
+		BlockRoot: common.BytesToHash(blockHash[:]),
+		StateRoot: common.BytesToHash(block.StateRoot),
+	}
+	s.latestBlockLock.Unlock()
+
 	log.WithField("slot", block.Slot).WithField("panBlockNum", shardInfo.BlockNumber).
 		WithField("finalizedSlot", blockInfo.FinalizedSlot).WithField("finalizedEpoch", blockInfo.FinalizedEpoch).
 		Info("New vanguard shard info has arrived")
 
-	s.vanguardShardingInfoFeed.Send(cachedShardInfo)
+	receivedShardInfosCounter.Inc(1)
+	// nobody received the block, so it is not a duplicate when it is replayed
+	if nsent := s.vanguardShardingInfoFeed.Send(cachedShardInfo); nsent == 0 {
+		s.recentRoots.forget(blockHash)
+	}
 	return nil
 }
 
+// sourceLookbackEpochs is the number of epochs which are searched back for the dependent block of an epoch when
+// the slots before the epoch are empty
+const sourceLookbackEpochs = 2
+
+// epochInfoSource returns the vanguard block whose state the proposer list of the given epoch is derived from, which
+// is the canonical block of the last filled slot before the epoch. The block is fetched from vanguard node and the
+// source is verified. When it can not be fetched, the latest received vanguard block is returned as an unverified
+// hint if it belongs to the previous epoch. Returns nil when the source is not known.
+func (s *Service) epochInfoSource(ctx context.Context, epoch uint64) *types.EpochInfoSource {
+	if epoch == 0 {
+		return nil
+	}
+	s.latestBlockLock.RLock()
+	var inferred *types.EpochInfoSource
+	// the latest block must be of the previous epoch, an older block could miss some slots in between
+	if s.latestBlock != nil && s.latestBlock.Slot < epoch*params.SlotsPerEpoch &&
+		s.latestBlock.Slot+params.SlotsPerEpoch >= epoch*params.SlotsPerEpoch {
+		source := *s.latestBlock
+		inferred = &source
+	}
+	s.latestBlockLock.RUnlock()
+
+	source, err := s.fetchEpochInfoSource(ctx, epoch)
+	if err != nil {
+		log.WithError(err).WithField("epoch", epoch).Debug("Could not fetch dependent vanguard block of epoch")
+		return inferred
+	}
+	if source == nil {
+		return inferred
+	}
+	if inferred != nil && inferred.BlockRoot != source.BlockRoot {
+		log.WithField("epoch", epoch).WithField("inferredSlot", inferred.Slot).WithField("dependentSlot", source.Slot).
+			Warn("Latest received vanguard block is not the dependent block of epoch")
+	}
+	return source
+}
+
+// fetchEpochInfoSource lists the canonical blocks before the given epoch from vanguard node and returns the last one
+// as the verified source of the epoch. Returns nil when there is no block in the lookback epochs.
+func (s *Service) fetchEpochInfoSource(ctx context.Context, epoch uint64) (*types.EpochInfoSource, error) {
+	if s.beaconClient == nil {
+		return nil, errors.New("vanguard node is not connected")
+	}
+	for prevEpoch := epoch; prevEpoch > 0 && epoch-prevEpoch < sourceLookbackEpochs; prevEpoch-- {
+		containers, err := s.listBlocks(ctx, prevEpoch-1)
+		if err != nil {
+			return nil, err
+		}
+		if block := dependentBlock(containers, epoch); block != nil {
+			blockHash, err := block.HashTreeRoot()
+			if err != nil {
+				return nil, err
+			}
+			return &types.EpochInfoSource{
+				Slot:      uint64(block.Slot),
+				BlockRoot: common.BytesToHash(blockHash[:]),
+				StateRoot: common.BytesToHash(block.StateRoot),
+				Verified:  true,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// dependentBlock returns the canonical block of the highest slot before the given epoch or nil when there is none
+func dependentBlock(containers []*ethpb.BeaconBlockContainer, epoch uint64) *ethpb.BeaconBlock {
+	var dependent *ethpb.BeaconBlock
+	for _, container := range canonicalContainers(containers) {
+		if block := container.Block.Block; uint64(block.Slot) < epoch*params.SlotsPerEpoch {
+			dependent = block
+		}
+	}
+	return dependent
+}
+
 // ReSubscribeBlocksEvent method re-subscribe to vanguard block api.
 func (s *Service) ReSubscribeBlocksEvent() error {
 	finalizedSlot := s.db.LatestLatestFinalizedSlot()
@@ -93,6 +202,8 @@ func (s *Service) ReSubscribeBlocksEvent() error {
 
 func (s *Service) StopSubscription() {
 	defer log.Info("Stopped vanguard gRPC subscription")
+	// blocks are replayed from the finalized slot after the subscription is resumed
+	s.recentRoots.purge()
 	if s.conn != nil {
 		s.conn.Close()
 		s.conn = nil
@@ -1 +1,27 @@
 package vanguardchain
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+func TestDependentBlock(t *testing.T) {
+	container := func(slot eth2Types.Slot, canonical bool) *ethpb.BeaconBlockContainer {
+		return &ethpb.BeaconBlockContainer{
+			Block:     &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot}},
+			Canonical: canonical,
+		}
+	}
+	containers := []*ethpb.BeaconBlockContainer{
+		container(62, true),
+		container(63, false),
+		container(64, true),
+		container(60, true),
+	}
+
+	assert.Equal(t, eth2Types.Slot(62), dependentBlock(containers, 2).Slot)
+	assert.Equal(t, (*ethpb.BeaconBlock)(nil), dependentBlock(containers, 1))
+}
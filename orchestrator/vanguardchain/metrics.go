@@ -0,0 +1,16 @@
+package vanguardchain
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// receivedShardInfosCounter is the number of received vanguard shard infos
+	receivedShardInfosCounter = metrics.NewRegisteredCounter("orc_vanguard_shard_infos_total", nil)
+	// receivedConsensusInfosCounter is the number of received minimal consensus infos
+	receivedConsensusInfosCounter = metrics.NewRegisteredCounter("orc_vanguard_consensus_infos_total", nil)
+	// streamRestartsCounter is the number of times the pending block stream is restarted after a connection error
+	streamRestartsCounter = metrics.NewRegisteredCounter("orc_vanguard_stream_restarts_total", nil)
+	// failoverCounter is the number of times the service moved to a fallback vanguard node
+	failoverCounter = metrics.NewRegisteredCounter("orc_vanguard_failovers_total", nil)
+	// backfilledBlocksCounter is the number of vanguard blocks which are delivered by backfill at start
+	backfilledBlocksCounter = metrics.NewRegisteredCounter("orc_vanguard_backfilled_blocks_total", nil)
+)
@@ -0,0 +1,37 @@
+package vanguardchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Endpoint returns the primary vanguard node which the service is currently using
+func (s *Service) Endpoint() string {
+	s.processingLock.RLock()
+	defer s.processingLock.RUnlock()
+	return s.vanGRPCEndpoint
+}
+
+// ProbeEndpoint returns the head slot of the given vanguard node and the round trip of the request.
+// The round trip includes the connection setup since grpc connects lazily on the first request.
+func (s *Service) ProbeEndpoint(ctx context.Context, endpoint string) (uint64, time.Duration, error) {
+	conn, err := s.newConn(endpoint)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not dial vanguard endpoint")
+	}
+	if conn == nil {
+		return 0, 0, errors.Errorf("invalid vanguard endpoint %s", endpoint)
+	}
+	defer closeConn(conn)
+
+	start := time.Now()
+	head, err := ethpb.NewBeaconChainClient(conn).GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not retrieve chain head from vanguard endpoint")
+	}
+	return uint64(head.HeadSlot), time.Since(start), nil
+}
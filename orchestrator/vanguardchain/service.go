@@ -15,6 +15,9 @@ import (
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
 	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/taskqueue"
+	"github.com/lukso-network/lukso-orchestrator/shared/circuitbreaker"
+	"github.com/lukso-network/lukso-orchestrator/shared/retry"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 	"google.golang.org/grpc"
@@ -28,6 +31,36 @@ var (
 	errDialNil  = errors.New("failed to construct dial options")
 )
 
+// restart budget of vanguard connection. After breakerThreshold failures in breakerWindow, reconnection
+// backoff grows up to maxReConPeriod.
+var (
+	breakerThreshold = 5
+	breakerWindow    = time.Minute
+	maxReConPeriod   = time.Minute
+	reConJitter      = 0.2
+)
+
+// dialPolicy retries creating the grpc client connection. Dial does not block on the node, so it only fails
+// on invalid options or a cancelled context.
+var dialPolicy = retry.Policy{
+	MaxAttempts:  5,
+	InitialDelay: reConPeriod,
+	MaxDelay:     maxReConPeriod,
+	Multiplier:   2,
+	Jitter:       reConJitter,
+}
+
+// newBreaker creates circuit breaker for a vanguard connection
+func newBreaker(name string) *circuitbreaker.Breaker {
+	return circuitbreaker.New(name, circuitbreaker.Config{
+		Threshold:  breakerThreshold,
+		Window:     breakerWindow,
+		MinBackoff: reConPeriod,
+		MaxBackoff: maxReConPeriod,
+		Jitter:     reConJitter,
+	})
+}
+
 // Service
 // 	- maintains connection with vanguard chain
 //	- handles vanguard subscription for consensus info.
@@ -59,19 +92,62 @@ type Service struct {
 	shardingInfoCache   cache.VanguardShardCache // lru cache support
 	stopPendingBlkSubCh chan struct{}
 	stopEpochInfoSubCh  chan struct{}
+
+	// latest received vanguard block. It is the dependent block of the upcoming epoch's proposer list
+	latestBlockLock sync.RWMutex
+	latestBlock     *types.EpochInfoSource
+
+	// secondary vanguard endpoints whose epoch info streams are merged with the primary one
+	fanInEndpoints []string
+	fanIn          *epochInfoFanIn
+	// equivocations cross-checks shard infos of primary and secondary vanguard nodes
+	equivocations *equivocationDetector
+	// recentRoots drops vanguard blocks which are delivered again
+	recentRoots *recentRoots
+
+	breaker *circuitbreaker.Breaker
+
+	// tasks retries a failed backfill across restarts, failed backfills are not retried when it is nil
+	tasks taskqueue.Queue
+
+	// failoverEndpoints are the primary and fallback vanguard nodes, in failover order
+	failoverLock      sync.Mutex
+	failoverEndpoints []string
+
+	// tlsCreds secures the connections with vanguard nodes, they are insecure when it is nil
+	tlsCreds credentials.TransportCredentials
+	// token authenticates every call to vanguard nodes, nil when no token is given
+	token *tokenCredentials
+
+	// checkpointSlot is the trusted slot which an empty db is seeded from, blocks up to it are not backfilled
+	checkpointSlot uint64
 }
 
-// NewService creates new service with vanguard endpoint, vanguard namespace and consensusInfoDB
+// NewService creates new service with vanguard endpoint, vanguard namespace and consensusInfoDB.
+// Epoch infos of the optional fan-in endpoints are merged with the primary vanguard node's epoch infos.
 func NewService(
 	ctx context.Context,
 	vanGRPCEndpoint string,
 	db db.Database,
 	cache cache.VanguardShardCache,
+	fanInEndpoints ...string,
 ) (*Service, error) {
 
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
 
+	var fanIn *epochInfoFanIn
+	var equivocations *equivocationDetector
+	if len(fanInEndpoints) > 0 {
+		fanIn = newEpochInfoFanIn()
+		equivocations = newEquivocationDetector()
+	}
+
+	roots, err := newRecentRoots(recentRootsSize)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Service{
 		ctx:                 ctx,
 		cancel:              cancel,
@@ -80,6 +156,11 @@ func NewService(
 		shardingInfoCache:   cache,
 		stopPendingBlkSubCh: make(chan struct{}),
 		stopEpochInfoSubCh:  make(chan struct{}),
+		fanInEndpoints:      fanInEndpoints,
+		fanIn:               fanIn,
+		equivocations:       equivocations,
+		recentRoots:         roots,
+		breaker:             newBreaker("vanguard chain connection"),
 	}, nil
 }
 
@@ -115,6 +196,10 @@ func (s *Service) Status() error {
 	if !s.isRunning {
 		return nil
 	}
+	// degraded status after too many connection failures
+	if err := s.breaker.Err(); err != nil {
+		return err
+	}
 	// get error from run function
 	if s.runError != nil {
 		return s.runError
@@ -147,42 +232,81 @@ func (s *Service) run() {
 	}
 
 	go s.subscribeNewConsensusInfoGRPC(s.ctx, fromEpoch)
+
+	// live stream replays from the finalized slot, so blocks which are not delivered by backfill are not missed.
+	// Already delivered ones are dropped as duplicates.
+	fromSlot := s.db.LatestSavedVerifiedSlot()
+	if fromSlot < s.checkpointSlot {
+		fromSlot = s.checkpointSlot
+	}
+	if err := s.backfill(s.ctx, fromSlot); err != nil {
+		log.WithError(err).Warn("Could not backfill missed vanguard blocks, continuing with live subscription")
+		s.deferBackfill(fromSlot)
+	}
 	go s.subscribeVanNewPendingBlockHash(s.ctx, latestFinalizedSlot)
+	for _, endpoint := range s.fanInEndpoints {
+		go s.subscribeFanInConsensusInfo(s.ctx, endpoint)
+		go s.subscribeFanInPendingBlocks(s.ctx, endpoint)
+	}
 }
 
 // waitForConnection waits for a connection with vanguard chain. Until a successful with
 // vanguard chain, it retries again and again.
 func (s *Service) waitForConnection() {
-	if err := s.dialConn(); err != nil {
+	if err := retry.Do(s.ctx, dialPolicy, func(attempt int) error {
+		err := s.dialConn()
+		if err != nil {
+			log.WithError(err).WithField("vanguardEndpoint", s.vanGRPCEndpoint).WithField("attempt", attempt).
+				Warn("Could not create connection with vanguard node, retrying")
+		}
+		return err
+	}); err != nil {
 		log.WithError(err).Error("Could not create connection with vanguard node during re-subscription")
 		return
 	}
 
-	if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err == nil {
+	err := s.checkConnection()
+	// move to the next healthy vanguard node instead of waiting for the used one
+	if err != nil && s.failover() {
+		err = nil
+	}
+	if err == nil {
 		log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
 		s.connectedVanguard = true
+		s.breaker.Success()
 		return
 	}
-
-	ticker := time.NewTicker(reConPeriod)
-	defer ticker.Stop()
+	s.breaker.Failure(err)
 
 	for {
-		select {
-		case <-ticker.C:
-			if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err != nil {
-				log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Warn("Could not connect or subscribe to vanguard chain")
-				continue
-			}
-			s.connectedVanguard = true
-			s.runError = nil
-			log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
-			return
-		case <-s.ctx.Done():
+		// backoff grows while the breaker is open instead of hot-looping reconnects
+		if err := s.breaker.Wait(s.ctx); err != nil {
 			log.Info("Received cancelled context, closing existing go routine: waitForConnection")
 			return
 		}
+		if err := s.checkConnection(); err != nil && !s.failover() {
+			s.breaker.Failure(err)
+			log.WithError(err).WithField("vanguardEndpoint", s.vanGRPCEndpoint).
+				WithField("backoff", s.breaker.Backoff()).
+				Warn("Could not connect or subscribe to vanguard chain")
+			s.runError = err
+			continue
+		}
+		s.connectedVanguard = true
+		s.runError = nil
+		s.breaker.Success()
+		log.WithField("vanguardEndpoint", s.vanGRPCEndpoint).Info("Connected vanguard chain")
+		return
+	}
+}
+
+// checkConnection checks that vanguard node is reachable and belongs to the pinned network
+func (s *Service) checkConnection() error {
+	if _, err := s.beaconClient.GetChainHead(s.ctx, &emptypb.Empty{}); err != nil {
+		return err
 	}
+	// refuse to use a node which belongs to another network
+	return s.verifyGenesisValidatorsRoot(s.nodeClient)
 }
 
 // SubscribeMinConsensusInfoEvent registers a subscription of ChainHeadEvent.
@@ -207,16 +331,27 @@ func (s *Service) dialConn() error {
 		return nil
 	}
 
-	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(s.vanGRPCEndpoint, "")
-	if nil != err {
-		return nil
+	c, err := s.newConn(s.vanGRPCEndpoint)
+	if err != nil || c == nil {
+		return err
 	}
 
-	dialOpts := constructDialOptions(math.MaxInt32, "", 32, time.Minute*6)
-	if dialOpts == nil {
-		return errDialNil
+	s.conn = c
+	s.beaconClient = ethpb.NewBeaconChainClient(c)
+	s.nodeClient = ethpb.NewNodeClient(c)
+
+	return nil
+}
+
+// newConn dials to the given vanguard grpc endpoint
+func (s *Service) newConn(endpoint string) (*grpc.ClientConn, error) {
+	grpcAddress, protocol, err := resolveRpcAddressAndProtocol(endpoint, "")
+	if nil != err {
+		return nil, nil
 	}
 
+	dialOpts := constructDialOptions(math.MaxInt32, s.tlsCreds, 32, time.Minute*6, s.tokenDialOptions()...)
+
 	if "unix" == protocol {
 		dialer := func(addr string, t time.Duration) (net.Conn, error) {
 			return net.Dial(protocol, addr)
@@ -225,34 +360,20 @@ func (s *Service) dialConn() error {
 		dialOpts = append(dialOpts, grpc.WithDialer(dialer))
 	}
 
-	c, err := grpc.DialContext(s.ctx, grpcAddress, dialOpts...)
-	if err != nil {
-		return err
-	}
-
-	s.conn = c
-	s.beaconClient = ethpb.NewBeaconChainClient(c)
-	s.nodeClient = ethpb.NewNodeClient(c)
-
-	return nil
+	return grpc.DialContext(s.ctx, grpcAddress, dialOpts...)
 }
 
 // constructDialOptions constructs a list of grpc dial options
 func constructDialOptions(
 	maxCallRecvMsgSize int,
-	withCert string,
+	tlsCreds credentials.TransportCredentials,
 	grpcRetries uint,
 	grpcRetryDelay time.Duration,
 	extraOpts ...grpc.DialOption,
 ) []grpc.DialOption {
 	var transportSecurity grpc.DialOption
-	if withCert != "" {
-		creds, err := credentials.NewClientTLSFromFile(withCert, "")
-		if err != nil {
-			log.Errorf("Could not get valid credentials: %v", err)
-			return nil
-		}
-		transportSecurity = grpc.WithTransportCredentials(creds)
+	if tlsCreds != nil {
+		transportSecurity = grpc.WithTransportCredentials(tlsCreds)
 	} else {
 		transportSecurity = grpc.WithInsecure()
 		log.Warn("You are using an insecure gRPC connection. If you are running your beacon node and " +
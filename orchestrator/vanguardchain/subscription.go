@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lukso-network/lukso-orchestrator/shared/chaos"
 	"github.com/lukso-network/lukso-orchestrator/shared/types"
 	"github.com/pkg/errors"
 	eth2Types "github.com/prysmaticlabs/eth2-types"
@@ -45,24 +46,28 @@ func (s *Service) subscribeVanNewPendingBlockHash(ctx context.Context, fromSlot
 
 		default:
 			vanBlockInfo, err := stream.Recv()
+			if chaosErr := chaos.Inject(chaos.VanguardStreamPoint); err == nil && chaosErr != nil {
+				err = status.Error(codes.Unavailable, chaosErr.Error())
+			}
 			if err != nil {
 				if e, ok := status.FromError(err); ok {
 					switch e.Code() {
 					case codes.Canceled, codes.Internal, codes.Unavailable:
 						log.WithError(err).Infof("Trying to restart connection. rpc status: %v", e.Code())
+						streamRestartsCounter.Inc(1)
 						s.waitForConnection()
-						// Re-try subscription from latest finalized slot
-						latestFinalizedSlot := s.db.LatestLatestFinalizedSlot()
+						// Re-try subscription from latest verified slot, the node could be a different one after failover
+						latestVerifiedSlot := s.db.LatestSavedVerifiedSlot()
 						stream, err = s.beaconClient.StreamNewPendingBlocks(ctx,
 							&ethpb.StreamPendingBlocksRequest{
 								BlockRoot: blockRoot,
-								FromSlot:  eth2Types.Slot(latestFinalizedSlot),
+								FromSlot:  eth2Types.Slot(latestVerifiedSlot),
 							})
 						if err != nil {
 							log.WithError(err).Error("Failed to subscribe to new pending blocks stream")
 							return err
 						}
-						log.WithField("finalizedSlot", latestFinalizedSlot).Info("Successfully re-subscribed to vanguard blocks")
+						log.WithField("fromSlot", latestVerifiedSlot).Info("Successfully re-subscribed to vanguard blocks")
 						continue
 					}
 				} else {
@@ -140,27 +145,11 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 				return errInvalidValidatorLength
 			}
 
-			consensusInfo := &types.MinimalEpochConsensusInfoV2{
-				Epoch:            uint64(vanMinimalConsensusInfo.Epoch),
-				ValidatorList:    vanMinimalConsensusInfo.ValidatorList,
-				EpochStartTime:   vanMinimalConsensusInfo.EpochTimeStart,
-				SlotTimeDuration: time.Duration(vanMinimalConsensusInfo.SlotTimeDuration.Seconds),
-				FinalizedSlot:    s.db.LatestLatestFinalizedSlot(),
-			}
-
-			// if re-org happens then we get this info not nil
-			if vanMinimalConsensusInfo.ReorgInfo != nil {
-				reorgInfo := &types.Reorg{
-					VanParentHash: vanMinimalConsensusInfo.ReorgInfo.VanParentHash,
-					PanParentHash: vanMinimalConsensusInfo.ReorgInfo.PanParentHash,
-					NewSlot:       uint64(vanMinimalConsensusInfo.ReorgInfo.NewSlot),
-				}
-				consensusInfo.ReorgInfo = reorgInfo
-			}
+			consensusInfo := s.toConsensusInfo(vanMinimalConsensusInfo)
 
 			log.WithField("epoch", vanMinimalConsensusInfo.Epoch).WithField("epochInfo", fmt.Sprintf("%+v", vanMinimalConsensusInfo)).
 				Debug("Received new consensus info")
-			if err := s.onNewConsensusInfo(ctx, consensusInfo); err != nil {
+			if err := s.deliverConsensusInfo(ctx, s.vanGRPCEndpoint, consensusInfo); err != nil {
 				log.WithError(err).Error("Failed to handle consensus info. Closing epoch info subscription, Exiting go routine")
 				return err
 			}
@@ -169,3 +158,25 @@ func (s *Service) subscribeNewConsensusInfoGRPC(ctx context.Context, fromEpoch u
 
 	return nil
 }
+
+// toConsensusInfo converts vanguard minimal consensus info into orchestrator's epoch info
+func (s *Service) toConsensusInfo(vanMinimalConsensusInfo *ethpb.MinimalConsensusInfo) *types.MinimalEpochConsensusInfoV2 {
+	consensusInfo := &types.MinimalEpochConsensusInfoV2{
+		Epoch:            uint64(vanMinimalConsensusInfo.Epoch),
+		ValidatorList:    vanMinimalConsensusInfo.ValidatorList,
+		EpochStartTime:   vanMinimalConsensusInfo.EpochTimeStart,
+		SlotTimeDuration: time.Duration(vanMinimalConsensusInfo.SlotTimeDuration.Seconds),
+		FinalizedSlot:    s.db.LatestLatestFinalizedSlot(),
+	}
+
+	// if re-org happens then we get this info not nil
+	if vanMinimalConsensusInfo.ReorgInfo != nil {
+		reorgInfo := &types.Reorg{
+			VanParentHash: vanMinimalConsensusInfo.ReorgInfo.VanParentHash,
+			PanParentHash: vanMinimalConsensusInfo.ReorgInfo.PanParentHash,
+			NewSlot:       uint64(vanMinimalConsensusInfo.ReorgInfo.NewSlot),
+		}
+		consensusInfo.ReorgInfo = reorgInfo
+	}
+	return consensusInfo
+}
@@ -0,0 +1,84 @@
+package vanguardchain
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TransportSecurity is the encryption and authentication of the connections with vanguard nodes which run on a
+// separate host
+type TransportSecurity struct {
+	// TLSCert is the certificate of the vanguard node, which is trusted directly
+	TLSCert string
+	// TLSCA is the certificate authority which signed the certificate of the vanguard node
+	TLSCA string
+	// Token is sent as bearer token with every call
+	Token string
+}
+
+// SetTransportSecurity loads the certificates and token which are used by the connections with every vanguard node,
+// including fan-in and fallback nodes. It must be called before the service is started.
+func (s *Service) SetTransportSecurity(cfg *TransportSecurity) error {
+	if cfg.TLSCert != "" || cfg.TLSCA != "" {
+		creds, err := loadTLSCredentials(cfg.TLSCert, cfg.TLSCA)
+		if err != nil {
+			return err
+		}
+		s.tlsCreds = creds
+	}
+	if cfg.Token != "" {
+		if s.tlsCreds == nil {
+			log.Warn("Vanguard gRPC token is sent over an insecure connection, set --vanguard-tls-cert or --vanguard-tls-ca " +
+				"when vanguard node runs on a separate host")
+		}
+		s.token = &tokenCredentials{token: cfg.Token, secure: s.tlsCreds != nil}
+	}
+	return nil
+}
+
+// loadTLSCredentials returns client credentials which trust the certificates of the given pem files
+func loadTLSCredentials(certFile, caFile string) (credentials.TransportCredentials, error) {
+	pool := x509.NewCertPool()
+	for _, file := range []string{certFile, caFile} {
+		if file == "" {
+			continue
+		}
+		pem, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read vanguard tls certificate")
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no pem certificate found in %s", file)
+		}
+	}
+	return credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}), nil
+}
+
+// tokenDialOptions returns the dial option which attaches the token to every call
+func (s *Service) tokenDialOptions() []grpc.DialOption {
+	if s.token == nil {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithPerRPCCredentials(s.token)}
+}
+
+// tokenCredentials sends the token as bearer authorization metadata
+type tokenCredentials struct {
+	token  string
+	secure bool
+}
+
+func (t *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity refuses to send the token over an insecure connection once tls is configured
+func (t *tokenCredentials) RequireTransportSecurity() bool {
+	return t.secure
+}
@@ -0,0 +1,69 @@
+package vanguardchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+// writeSelfSignedCert writes a self-signed certificate of localhost into a pem file
+func writeSelfSignedCert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "vanguard"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "vanguard.crt")
+	require.NoError(t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return file
+}
+
+func TestService_SetTransportSecurity(t *testing.T) {
+	s := &Service{}
+	require.NoError(t, s.SetTransportSecurity(&TransportSecurity{}))
+	assert.Equal(t, true, s.tlsCreds == nil)
+	assert.Equal(t, 0, len(s.tokenDialOptions()))
+
+	// token without tls may be sent over an insecure connection, e.g. over a unix socket
+	require.NoError(t, s.SetTransportSecurity(&TransportSecurity{Token: "secret"}))
+	assert.Equal(t, false, s.token.RequireTransportSecurity())
+
+	s = &Service{}
+	require.NoError(t, s.SetTransportSecurity(&TransportSecurity{TLSCert: writeSelfSignedCert(t), Token: "secret"}))
+	assert.NotNil(t, s.tlsCreds)
+	assert.Equal(t, "tls", s.tlsCreds.Info().SecurityProtocol)
+	assert.Equal(t, true, s.token.RequireTransportSecurity())
+	assert.Equal(t, 1, len(s.tokenDialOptions()))
+	metadata, err := s.token.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", metadata["authorization"])
+}
+
+func TestService_SetTransportSecurity_InvalidCert(t *testing.T) {
+	s := &Service{}
+	err := s.SetTransportSecurity(&TransportSecurity{TLSCA: filepath.Join(t.TempDir(), "missing.crt")})
+	assert.ErrorContains(t, "could not read vanguard tls certificate", err)
+
+	notPem := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, ioutil.WriteFile(notPem, []byte("not a certificate"), 0600))
+	err = s.SetTransportSecurity(&TransportSecurity{TLSCA: notPem})
+	assert.ErrorContains(t, "no pem certificate found", err)
+}
@@ -0,0 +1,131 @@
+// Package accumulator implements an append-only merkle tree of fixed depth. Only the frontier of the tree is kept
+// in memory, so appending a leaf and calculating the root cost O(Depth) hashes.
+package accumulator
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Depth of the tree. It is enough to accumulate one leaf per slot for centuries.
+const Depth = 32
+
+var (
+	errTreeFull     = errors.New("accumulator is full")
+	errInvalidIndex = errors.New("leaf index is out of range")
+
+	// zeroHashes[i] is the root of an empty subtree of height i
+	zeroHashes [Depth + 1]common.Hash
+)
+
+func init() {
+	for i := 1; i <= Depth; i++ {
+		zeroHashes[i] = hashPair(zeroHashes[i-1], zeroHashes[i-1])
+	}
+}
+
+func hashPair(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash(left.Bytes(), right.Bytes())
+}
+
+// Accumulator
+type Accumulator struct {
+	branch [Depth]common.Hash
+	size   uint64
+}
+
+// New creates an accumulator and appends the given leaves into it
+func New(leaves []common.Hash) (*Accumulator, error) {
+	acc := new(Accumulator)
+	for _, leaf := range leaves {
+		if err := acc.Append(leaf); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+// Append adds a new leaf to the tree
+func (a *Accumulator) Append(leaf common.Hash) error {
+	if a.size >= 1<<Depth-1 {
+		return errTreeFull
+	}
+	a.size++
+	node := leaf
+	size := a.size
+	for height := 0; height < Depth; height++ {
+		if size&1 == 1 {
+			a.branch[height] = node
+			return nil
+		}
+		node = hashPair(a.branch[height], node)
+		size >>= 1
+	}
+	return nil
+}
+
+// Root returns the root of the tree where missing leaves are zero
+func (a *Accumulator) Root() common.Hash {
+	var node common.Hash
+	size := a.size
+	for height := 0; height < Depth; height++ {
+		if size&1 == 1 {
+			node = hashPair(a.branch[height], node)
+		} else {
+			node = hashPair(node, zeroHashes[height])
+		}
+		size >>= 1
+	}
+	return node
+}
+
+// Size returns the number of appended leaves
+func (a *Accumulator) Size() uint64 {
+	return a.size
+}
+
+// Proof returns the merkle branch of the leaf at the given index in the tree built from leaves
+func Proof(leaves []common.Hash, index uint64) ([]common.Hash, error) {
+	if index >= uint64(len(leaves)) {
+		return nil, errInvalidIndex
+	}
+	proof := make([]common.Hash, Depth)
+	level := leaves
+	for height := 0; height < Depth; height++ {
+		sibling := index ^ 1
+		if sibling < uint64(len(level)) {
+			proof[height] = level[sibling]
+		} else {
+			proof[height] = zeroHashes[height]
+		}
+
+		next := make([]common.Hash, (len(level)+1)/2)
+		for i := range next {
+			right := zeroHashes[height]
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			next[i] = hashPair(level[2*i], right)
+		}
+		level = next
+		index >>= 1
+	}
+	return proof, nil
+}
+
+// VerifyProof checks that the leaf is included at the given index of the tree with the given root
+func VerifyProof(root common.Hash, leaf common.Hash, index uint64, proof []common.Hash) bool {
+	if len(proof) != Depth {
+		return false
+	}
+	node := leaf
+	for height := 0; height < Depth; height++ {
+		if (index>>uint(height))&1 == 1 {
+			node = hashPair(proof[height], node)
+		} else {
+			node = hashPair(node, proof[height])
+		}
+	}
+	return node == root
+}
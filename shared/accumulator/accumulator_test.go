@@ -0,0 +1,68 @@
+package accumulator
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func generateLeaves(num int) []common.Hash {
+	leaves := make([]common.Hash, num)
+	for i := range leaves {
+		leaves[i] = crypto.Keccak256Hash([]byte{byte(i)})
+	}
+	return leaves
+}
+
+func TestAccumulator_EmptyRoot(t *testing.T) {
+	acc := new(Accumulator)
+	assert.Equal(t, zeroHashes[Depth], acc.Root())
+	assert.Equal(t, uint64(0), acc.Size())
+}
+
+func TestAccumulator_ProofAgainstRoot(t *testing.T) {
+	for _, num := range []int{1, 2, 3, 7, 8, 33} {
+		leaves := generateLeaves(num)
+		acc, err := New(leaves)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(num), acc.Size())
+
+		for i, leaf := range leaves {
+			proof, err := Proof(leaves, uint64(i))
+			require.NoError(t, err)
+			assert.Equal(t, true, VerifyProof(acc.Root(), leaf, uint64(i), proof))
+			assert.Equal(t, false, VerifyProof(acc.Root(), common.Hash{}, uint64(i), proof))
+		}
+	}
+}
+
+func TestAccumulator_InvalidIndex(t *testing.T) {
+	_, err := Proof(generateLeaves(2), 2)
+	assert.ErrorContains(t, errInvalidIndex.Error(), err)
+}
+
+func TestTree_MatchesAccumulator(t *testing.T) {
+	tree := new(Tree)
+	assert.Equal(t, zeroHashes[Depth], tree.Root())
+	leaves := generateLeaves(33)
+	for i, leaf := range leaves {
+		require.NoError(t, tree.Append(leaf))
+		acc, err := New(leaves[:i+1])
+		require.NoError(t, err)
+		assert.Equal(t, acc.Root(), tree.Root())
+		assert.Equal(t, uint64(i+1), tree.Size())
+	}
+	for i, leaf := range leaves {
+		proof, err := tree.Proof(uint64(i))
+		require.NoError(t, err)
+		expected, err := Proof(leaves, uint64(i))
+		require.NoError(t, err)
+		assert.DeepEqual(t, expected, proof)
+		assert.Equal(t, true, VerifyProof(tree.Root(), leaf, uint64(i), proof))
+	}
+	_, err := tree.Proof(33)
+	assert.ErrorContains(t, errInvalidIndex.Error(), err)
+}
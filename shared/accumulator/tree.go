@@ -0,0 +1,79 @@
+package accumulator
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tree keeps every node of the accumulator, so proofs are served with O(Depth) lookups instead of rebuilding the
+// tree from the leaves. Appending a leaf updates the nodes of its path only.
+type Tree struct {
+	// levels[0] are the leaves, levels[h] are the nodes of height h where missing right children are zero
+	levels [Depth + 1][]common.Hash
+}
+
+// NewTree creates a tree and appends the given leaves into it
+func NewTree(leaves []common.Hash) (*Tree, error) {
+	tree := new(Tree)
+	for _, leaf := range leaves {
+		if err := tree.Append(leaf); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// Append adds a new leaf to the tree
+func (t *Tree) Append(leaf common.Hash) error {
+	if t.Size() >= 1<<Depth-1 {
+		return errTreeFull
+	}
+	t.levels[0] = append(t.levels[0], leaf)
+	index := uint64(len(t.levels[0]) - 1)
+	for height := 0; height < Depth; height++ {
+		level := t.levels[height]
+		left, right := index&^1, zeroHashes[height]
+		if left+1 < uint64(len(level)) {
+			right = level[left+1]
+		}
+		node := hashPair(level[left], right)
+		parent := index >> 1
+		if parent < uint64(len(t.levels[height+1])) {
+			t.levels[height+1][parent] = node
+		} else {
+			t.levels[height+1] = append(t.levels[height+1], node)
+		}
+		index = parent
+	}
+	return nil
+}
+
+// Root returns the root of the tree where missing leaves are zero
+func (t *Tree) Root() common.Hash {
+	if len(t.levels[Depth]) == 0 {
+		return zeroHashes[Depth]
+	}
+	return t.levels[Depth][0]
+}
+
+// Size returns the number of appended leaves
+func (t *Tree) Size() uint64 {
+	return uint64(len(t.levels[0]))
+}
+
+// Proof returns the merkle branch of the leaf at the given index
+func (t *Tree) Proof(index uint64) ([]common.Hash, error) {
+	if index >= t.Size() {
+		return nil, errInvalidIndex
+	}
+	proof := make([]common.Hash, Depth)
+	for height := 0; height < Depth; height++ {
+		sibling := index ^ 1
+		if sibling < uint64(len(t.levels[height])) {
+			proof[height] = t.levels[height][sibling]
+		} else {
+			proof[height] = zeroHashes[height]
+		}
+		index >>= 1
+	}
+	return proof, nil
+}
@@ -0,0 +1,80 @@
+// Package chaos injects faults at defined points of the verification pipeline, so that recovery paths can be
+// tested systematically. Faults are only injected by the builds which are made with the chaos tag, otherwise
+// every injection point is a no-op.
+package chaos
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Points of the pipeline where faults can be injected
+const (
+	// VanguardShardPoint is passed for every received vanguard block. Delay and drop apply.
+	VanguardShardPoint = "vanguard/shard"
+	// PandoraHeaderPoint is passed for every received pandora header. Delay and drop apply.
+	PandoraHeaderPoint = "pandora/header"
+	// VerifiedSlotWritePoint is passed before verified slot info is written. Delay and fail apply.
+	VerifiedSlotWritePoint = "db/verified-slot"
+	// VanguardStreamPoint is passed after a vanguard block is received from the stream. Delay and disconnect apply.
+	VanguardStreamPoint = "vanguard/stream"
+	// PandoraStreamPoint is passed after a pandora header is received from the subscription. Delay and disconnect
+	// apply.
+	PandoraStreamPoint = "pandora/stream"
+)
+
+// Points are the known injection points
+var Points = []string{
+	VanguardShardPoint,
+	PandoraHeaderPoint,
+	VerifiedSlotWritePoint,
+	VanguardStreamPoint,
+	PandoraStreamPoint,
+}
+
+// Action is the fault which is injected at a point
+type Action string
+
+const (
+	// Delay holds the pipeline for DelayMs milliseconds
+	Delay Action = "delay"
+	// Drop skips the event like it never arrived
+	Drop Action = "drop"
+	// Fail fails the db write
+	Fail Action = "fail"
+	// Disconnect breaks the connection of the stream
+	Disconnect Action = "disconnect"
+)
+
+// ErrInjected is returned by the injection point which is set to drop, fail or disconnect
+var ErrInjected = errors.New("chaos fault injected")
+
+// Rule defines the fault of an injection point
+type Rule struct {
+	Point   string `json:"point"`
+	Action  Action `json:"action"`
+	DelayMs uint64 `json:"delayMs,omitempty"`
+	// Probability is the chance of the fault for every pass of the point. 0 means every pass.
+	Probability float64 `json:"probability,omitempty"`
+	// Remaining is the number of faults which are injected before the rule is removed. 0 means unlimited.
+	Remaining uint64 `json:"remaining,omitempty"`
+}
+
+// validate
+func (r *Rule) validate() error {
+	known := false
+	for _, point := range Points {
+		known = known || point == r.Point
+	}
+	if !known {
+		return errors.Errorf("unknown injection point %q", r.Point)
+	}
+	switch r.Action {
+	case Delay, Drop, Fail, Disconnect:
+	default:
+		return errors.Errorf("unknown chaos action %q", r.Action)
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return errors.Errorf("probability %v is not between 0 and 1", r.Probability)
+	}
+	return nil
+}
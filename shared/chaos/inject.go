@@ -0,0 +1,119 @@
+// +build chaos
+
+package chaos
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+)
+
+// Enabled is true in the builds which are made with the chaos tag
+const Enabled = true
+
+var (
+	lock  sync.Mutex
+	rules = make(map[string]*Rule)
+)
+
+// Inject applies the rule of the point. It sleeps for delay rules and returns ErrInjected for the other actions.
+func Inject(point string) error {
+	lock.Lock()
+	rule, ok := rules[point]
+	if !ok || (rule.Probability > 0 && rand.Float64() >= rule.Probability) {
+		lock.Unlock()
+		return nil
+	}
+	if rule.Remaining > 0 {
+		rule.Remaining--
+		if rule.Remaining == 0 {
+			delete(rules, point)
+		}
+	}
+	action, delay := rule.Action, time.Duration(rule.DelayMs)*time.Millisecond
+	lock.Unlock()
+
+	if action == Delay {
+		time.Sleep(delay)
+		return nil
+	}
+	return errors.Wrapf(ErrInjected, "%s at %s", action, point)
+}
+
+// Set replaces the rule of the point
+func Set(rule *Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	copied := *rule
+	lock.Lock()
+	defer lock.Unlock()
+	rules[rule.Point] = &copied
+	return nil
+}
+
+// Clear removes the rule of the point. Every rule is removed when point is empty.
+func Clear(point string) {
+	lock.Lock()
+	defer lock.Unlock()
+	if point == "" {
+		rules = make(map[string]*Rule)
+		return
+	}
+	delete(rules, point)
+}
+
+// Rules returns the active rules ordered by point
+func Rules() []*Rule {
+	lock.Lock()
+	defer lock.Unlock()
+	active := make([]*Rule, 0, len(rules))
+	for _, rule := range rules {
+		copied := *rule
+		active = append(active, &copied)
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Point < active[j].Point })
+	return active
+}
+
+// PrivateChaosAPI controls fault injection over the private rpc endpoints
+type PrivateChaosAPI struct{}
+
+// SetRule replaces the rule of the injection point
+func (api *PrivateChaosAPI) SetRule(rule *Rule) error {
+	if rule == nil {
+		return errors.New("missing chaos rule")
+	}
+	return Set(rule)
+}
+
+// ClearRule removes the rule of the injection point, or every rule when point is empty
+func (api *PrivateChaosAPI) ClearRule(point string) {
+	Clear(point)
+}
+
+// Rules returns the active rules
+func (api *PrivateChaosAPI) Rules() []*Rule {
+	return Rules()
+}
+
+// Points returns the known injection points
+func (api *PrivateChaosAPI) Points() []string {
+	return Points
+}
+
+// APIs returns the chaos rpc namespace
+func APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "chaos",
+			Version:   "1.0",
+			Service:   &PrivateChaosAPI{},
+			Public:    false,
+		},
+	}
+}
@@ -0,0 +1,18 @@
+// +build !chaos
+
+package chaos
+
+import "github.com/ethereum/go-ethereum/rpc"
+
+// Enabled is true in the builds which are made with the chaos tag
+const Enabled = false
+
+// Inject is a no-op without the chaos tag
+func Inject(point string) error {
+	return nil
+}
+
+// APIs returns no api without the chaos tag
+func APIs() []rpc.API {
+	return nil
+}
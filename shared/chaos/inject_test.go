@@ -0,0 +1,42 @@
+// +build chaos
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+func TestInject_Rules(t *testing.T) {
+	defer Clear("")
+	require.NoError(t, Inject(VanguardShardPoint))
+
+	require.NoError(t, Set(&Rule{Point: VanguardShardPoint, Action: Drop, Remaining: 2}))
+	require.NoError(t, Set(&Rule{Point: PandoraHeaderPoint, Action: Delay, DelayMs: 20}))
+	assert.Equal(t, 2, len(Rules()))
+
+	err := Inject(VanguardShardPoint)
+	assert.Equal(t, true, errors.Is(err, ErrInjected))
+	assert.ErrorContains(t, "drop at vanguard/shard", err)
+	assert.Equal(t, true, errors.Is(Inject(VanguardShardPoint), ErrInjected))
+	// rule is removed after its remaining faults
+	require.NoError(t, Inject(VanguardShardPoint))
+	assert.Equal(t, 1, len(Rules()))
+
+	start := time.Now()
+	require.NoError(t, Inject(PandoraHeaderPoint))
+	assert.Equal(t, true, time.Since(start) >= 20*time.Millisecond)
+
+	Clear(PandoraHeaderPoint)
+	assert.Equal(t, 0, len(Rules()))
+}
+
+func TestSet_Invalid(t *testing.T) {
+	assert.ErrorContains(t, "unknown injection point", Set(&Rule{Point: "unknown", Action: Drop}))
+	assert.ErrorContains(t, "unknown chaos action", Set(&Rule{Point: VanguardShardPoint, Action: "explode"}))
+	assert.ErrorContains(t, "not between 0 and 1", Set(&Rule{Point: VanguardShardPoint, Action: Drop, Probability: 2}))
+}
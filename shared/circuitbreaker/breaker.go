@@ -0,0 +1,120 @@
+// Package circuitbreaker limits how often a module restarts a failing connection. After too many failures
+// in a window the breaker opens, the module reports a degraded status and retries with capped backoff.
+package circuitbreaker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/retry"
+	"github.com/pkg/errors"
+)
+
+// Config defines the restart budget of a module
+type Config struct {
+	// Threshold is the number of failures in Window which opens the breaker
+	Threshold int
+	Window    time.Duration
+	// MinBackoff is the delay between retries while the breaker is closed
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential delay between retries while the breaker is open
+	MaxBackoff time.Duration
+	// Jitter randomizes every wait by up to the given fraction of the backoff
+	Jitter float64
+}
+
+// Breaker counts failures of a module in a sliding window
+type Breaker struct {
+	lock     sync.Mutex
+	name     string
+	cfg      Config
+	failures []time.Time
+	open     bool
+	backoff  time.Duration
+	lastErr  error
+	now      func() time.Time
+}
+
+// New creates a closed breaker with the given name which is used in the degraded status
+func New(name string, cfg Config) *Breaker {
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = cfg.MinBackoff
+	}
+	return &Breaker{
+		name:    name,
+		cfg:     cfg,
+		backoff: cfg.MinBackoff,
+		now:     time.Now,
+	}
+}
+
+// Failure records a failure. Once the breaker is open, every failure doubles the backoff up to MaxBackoff.
+func (b *Breaker) Failure(err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.now()
+	b.lastErr = err
+	b.failures = append(b.failures, now)
+	// forget failures which are out of window
+	for len(b.failures) > 0 && now.Sub(b.failures[0]) > b.cfg.Window {
+		b.failures = b.failures[1:]
+	}
+
+	if b.open {
+		b.backoff *= 2
+		if b.backoff > b.cfg.MaxBackoff {
+			b.backoff = b.cfg.MaxBackoff
+		}
+		return
+	}
+	if len(b.failures) >= b.cfg.Threshold {
+		b.open = true
+		b.backoff = b.cfg.MinBackoff * 2
+		if b.backoff > b.cfg.MaxBackoff {
+			b.backoff = b.cfg.MaxBackoff
+		}
+	}
+}
+
+// Success closes the breaker. Failures stay in the window so that a flapping connection still opens it.
+func (b *Breaker) Success() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.open = false
+	b.backoff = b.cfg.MinBackoff
+	b.lastErr = nil
+}
+
+// IsOpen
+func (b *Breaker) IsOpen() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.open
+}
+
+// Backoff returns the delay before the next retry
+func (b *Breaker) Backoff() time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.backoff
+}
+
+// Wait waits for the backoff delay. It returns context error if the context is cancelled meanwhile.
+func (b *Breaker) Wait(ctx context.Context) error {
+	return retry.Sleep(ctx, retry.Jitter(b.Backoff(), b.cfg.Jitter))
+}
+
+// Err returns the degraded status when the breaker is open, nil otherwise
+func (b *Breaker) Err() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.open {
+		return nil
+	}
+	return errors.Wrapf(b.lastErr, "%s is degraded, %d failures in %s, retrying every %s",
+		b.name, len(b.failures), b.cfg.Window, b.backoff)
+}
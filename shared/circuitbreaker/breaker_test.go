@@ -0,0 +1,48 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+)
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := New("pandora", Config{Threshold: 3, Window: time.Minute, MinBackoff: time.Second, MaxBackoff: 5 * time.Second})
+	b.now = func() time.Time { return now }
+
+	failure := errors.New("connection refused")
+	b.Failure(failure)
+	b.Failure(failure)
+	assert.Equal(t, false, b.IsOpen())
+	assert.NoError(t, b.Err())
+	assert.Equal(t, time.Second, b.Backoff())
+
+	b.Failure(failure)
+	assert.Equal(t, true, b.IsOpen())
+	assert.ErrorContains(t, "pandora is degraded", b.Err())
+	assert.ErrorContains(t, "connection refused", b.Err())
+	assert.Equal(t, 2*time.Second, b.Backoff())
+
+	// backoff is capped
+	b.Failure(failure)
+	b.Failure(failure)
+	assert.Equal(t, 5*time.Second, b.Backoff())
+
+	b.Success()
+	assert.Equal(t, false, b.IsOpen())
+	assert.Equal(t, time.Second, b.Backoff())
+}
+
+func TestBreaker_ForgetsOldFailures(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := New("vanguard", Config{Threshold: 2, Window: time.Minute, MinBackoff: time.Second, MaxBackoff: time.Minute})
+	b.now = func() time.Time { return now }
+
+	b.Failure(errors.New("unavailable"))
+	now = now.Add(2 * time.Minute)
+	b.Failure(errors.New("unavailable"))
+	assert.Equal(t, false, b.IsOpen())
+}
@@ -12,6 +12,12 @@ const (
 	DefaultHTTPPort             = 8545        // Default TCP port for the HTTP RPC server
 	DefaultWSHost               = "localhost" // Default host interface for the websocket RPC server
 	DefaultWSPort               = 8546        // Default TCP port for the websocket RPC server
+	DefaultGRPCHost             = "localhost" // Default host interface for the gRPC server
+	DefaultGRPCPort             = 4040        // Default TCP port for the gRPC server
+	DefaultWSCompressionLevel   = 1           // Default deflate level of compressed websocket frames (best speed)
+	DefaultRPCRateBurst         = 100         // Default number of requests which a rate limited RPC client may send at once
+	DefaultMetricsHost          = "localhost" // Default host interface for the metrics HTTP server
+	DefaultMetricsPort          = 6060        // Default TCP port for the metrics HTTP server
 	DefaultIpcPath              = "orchestrator.ipc"
 	DefaultVanguardGRPCEndpoint = "127.0.0.1:4000"
 	DefaultPandoraRPCEndpoint   = "http://127.0.0.1:8545"
@@ -0,0 +1,60 @@
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+	"path/filepath"
+)
+
+// FileSigner signs with a hex encoded secp256k1 key which is stored in a local file
+type FileSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// LoadOrCreateFileSigner loads the identity key from the given file. A new key is generated and saved when
+// the file does not exist yet.
+func LoadOrCreateFileSigner(path string) (*FileSigner, error) {
+	if fileutil.FileExists(path) {
+		key, err := crypto.LoadECDSA(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load identity key")
+		}
+		return NewFileSigner(key), nil
+	}
+
+	if err := fileutil.MkdirAll(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate identity key")
+	}
+	if err := crypto.SaveECDSA(path, key); err != nil {
+		return nil, errors.Wrap(err, "could not save identity key")
+	}
+	log.WithField("path", path).Info("Generated new orchestrator identity key")
+	return NewFileSigner(key), nil
+}
+
+// NewFileSigner
+func NewFileSigner(key *ecdsa.PrivateKey) *FileSigner {
+	return &FileSigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// Address
+func (s *FileSigner) Address() common.Address {
+	return s.address
+}
+
+// Sign
+func (s *FileSigner) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), s.key)
+}
@@ -0,0 +1,54 @@
+// Package identity gives the orchestrator a secp256k1 identity key. Status reports, checkpoints and proofs
+// which are exported by the orchestrator are signed with it, so consumers can authenticate them by the
+// orchestrator address. The key is either kept in a local file or held by a Web3Signer compatible remote signer.
+package identity
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+)
+
+// Signer signs data with the orchestrator identity key
+type Signer interface {
+	// Address of the identity key
+	Address() common.Address
+	// Sign returns 65 bytes [R || S || V] secp256k1 signature of keccak256(data) where V is 0 or 1
+	Sign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// Verify checks that the signature of data is created by the identity key of the given address
+func Verify(address common.Address, data []byte, signature []byte) error {
+	signer, err := recoverAddress(data, signature)
+	if err != nil {
+		return err
+	}
+	if signer != address {
+		return errors.Errorf("data is signed by %s instead of %s", signer.Hex(), address.Hex())
+	}
+	return nil
+}
+
+// recoverAddress returns the address which signed keccak256(data)
+func recoverAddress(data []byte, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, errors.Errorf("invalid signature length %d", len(signature))
+	}
+	pubKey, err := crypto.SigToPub(crypto.Keccak256(data), normalizeSignature(signature))
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "could not recover signer")
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// normalizeSignature converts V of signature from 27/28 to 0/1 which remote signers may return
+func normalizeSignature(signature []byte) []byte {
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		return signature
+	}
+	normalized := make([]byte, len(signature))
+	copy(normalized, signature)
+	normalized[crypto.RecoveryIDOffset] -= 27
+	return normalized
+}
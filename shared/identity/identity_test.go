@@ -0,0 +1,69 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSigner_LoadOrCreate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identity", "key")
+	signer, err := LoadOrCreateFileSigner(path)
+	require.NoError(t, err)
+
+	reloaded, err := LoadOrCreateFileSigner(path)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), reloaded.Address())
+
+	data := []byte("checkpoint")
+	signature, err := reloaded.Sign(context.Background(), data)
+	require.NoError(t, err)
+	assert.NoError(t, Verify(signer.Address(), data, signature))
+	assert.ErrorContains(t, "data is signed by", Verify(signer.Address(), []byte("other"), signature))
+}
+
+func TestRemoteSigner_Sign(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	publicKey := hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)[1:])
+
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signingKey := key
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/eth1/sign/"+publicKey, r.URL.Path)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		data, err := hexutil.Decode(body["data"])
+		require.NoError(t, err)
+		signature, err := crypto.Sign(crypto.Keccak256(data), signingKey)
+		require.NoError(t, err)
+		// web3signer returns V as 27 or 28
+		signature[crypto.RecoveryIDOffset] += 27
+		_, err = w.Write([]byte(hexutil.Encode(signature)))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	signer, err := NewRemoteSigner(server.URL+"/", publicKey)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	data := []byte("checkpoint")
+	signature, err := signer.Sign(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, true, signature[crypto.RecoveryIDOffset] < 2)
+	assert.NoError(t, Verify(signer.Address(), data, signature))
+
+	signingKey = otherKey
+	_, err = signer.Sign(context.Background(), data)
+	assert.ErrorContains(t, "remote signer signed with unexpected key", err)
+}
@@ -0,0 +1,5 @@
+package identity
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "identity")
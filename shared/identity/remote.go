@@ -0,0 +1,104 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// remoteSignTimeout bounds a signing request to the remote signer
+const remoteSignTimeout = 10 * time.Second
+
+// RemoteSigner signs with a key held by a Web3Signer compatible remote signer. The key never leaves the signer,
+// only the public key is configured in orchestrator.
+type RemoteSigner struct {
+	url        string
+	identifier string
+	address    common.Address
+	client     *http.Client
+}
+
+// NewRemoteSigner returns a signer which uses the eth1 sign endpoint of the remote signer at the given url.
+// Public key is the hex encoded secp256k1 public key which identifies the key in the remote signer.
+func NewRemoteSigner(url string, publicKey string) (*RemoteSigner, error) {
+	pubKey, err := parsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteSigner{
+		url:        strings.TrimSuffix(url, "/"),
+		identifier: publicKey,
+		address:    crypto.PubkeyToAddress(*pubKey),
+		client:     &http.Client{Timeout: remoteSignTimeout},
+	}, nil
+}
+
+// Address
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// Sign sends data to the remote signer and checks that the returned signature belongs to the configured key
+func (s *RemoteSigner) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	body, err := json.Marshal(map[string]string{"data": hexutil.Encode(data)})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/api/v1/eth1/sign/%s", s.url, s.identifier)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reach remote signer")
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("remote signer responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	signature, err := hexutil.Decode(strings.Trim(strings.TrimSpace(string(respBody)), "\""))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature from remote signer")
+	}
+	if err := Verify(s.address, data, signature); err != nil {
+		return nil, errors.Wrap(err, "remote signer signed with unexpected key")
+	}
+	return normalizeSignature(signature), nil
+}
+
+// parsePublicKey accepts compressed, uncompressed and uncompressed without 0x04 prefix public keys
+func parsePublicKey(publicKey string) (*ecdsa.PublicKey, error) {
+	raw, err := hexutil.Decode(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid remote signer public key")
+	}
+	switch len(raw) {
+	case 33:
+		return crypto.DecompressPubkey(raw)
+	case 64:
+		raw = append([]byte{4}, raw...)
+	}
+	pubKey, err := crypto.UnmarshalPubkey(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid remote signer public key")
+	}
+	return pubKey, nil
+}
@@ -0,0 +1,157 @@
+package logutil
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// moduleKey is the log field which tells the module of a log entry
+const moduleKey = "prefix"
+
+// moduleAliases maps package names to the prefixes which the packages log with
+var moduleAliases = map[string]string{
+	"vanguardchain": "vanguard-chain",
+	"pandorachain":  "panchain",
+	"db":            "kv",
+}
+
+// ModuleLevels are the log levels of single modules on top of a default level
+type ModuleLevels struct {
+	Default logrus.Level
+	Modules map[string]logrus.Level
+}
+
+// ParseModuleLevels parses a comma separated list of module=level pairs, e.g. "consensus=debug,db=warn". A level
+// without module overrides the default level.
+func ParseModuleLevels(defaultLevel logrus.Level, spec string) (*ModuleLevels, error) {
+	levels := &ModuleLevels{Default: defaultLevel, Modules: make(map[string]logrus.Level)}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		module, levelName := "", pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			module, levelName = strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+			if module == "" {
+				return nil, errors.Errorf("missing module in log level %q", pair)
+			}
+		}
+		level, err := logrus.ParseLevel(levelName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log level %q", pair)
+		}
+		if module == "" {
+			levels.Default = level
+			continue
+		}
+		if alias, ok := moduleAliases[module]; ok {
+			module = alias
+		}
+		levels.Modules[module] = level
+	}
+	return levels, nil
+}
+
+// Enabled returns true when the entry is at or above the level of its module
+func (m *ModuleLevels) Enabled(entry *logrus.Entry) bool {
+	level := m.Default
+	if module, ok := entry.Data[moduleKey].(string); ok {
+		if moduleLevel, ok := m.Modules[module]; ok {
+			level = moduleLevel
+		}
+	}
+	return entry.Level <= level
+}
+
+// maxLevel returns the most verbose of the levels
+func (m *ModuleLevels) maxLevel() logrus.Level {
+	level := m.Default
+	for _, moduleLevel := range m.Modules {
+		if moduleLevel > level {
+			level = moduleLevel
+		}
+	}
+	return level
+}
+
+// ConfigureModuleLevels applies the levels to the logger. The logger level is raised to the most verbose module,
+// and the formatter and hooks of the logger drop the entries which are below the level of their module.
+func ConfigureModuleLevels(logger *logrus.Logger, levels *ModuleLevels) {
+	logger.SetLevel(levels.maxLevel())
+	if len(levels.Modules) == 0 {
+		return
+	}
+	logger.SetFormatter(&moduleFormatter{levels: levels, formatter: logger.Formatter})
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range logger.Hooks {
+		for _, hook := range levelHooks {
+			hooks[level] = append(hooks[level], &moduleHook{levels: levels, hook: hook})
+		}
+	}
+	logger.ReplaceHooks(hooks)
+}
+
+// moduleFormatter formats the entries which are enabled for their module and drops the rest
+type moduleFormatter struct {
+	levels    *ModuleLevels
+	formatter logrus.Formatter
+}
+
+// Format
+func (f *moduleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !f.levels.Enabled(entry) {
+		return nil, nil
+	}
+	return f.formatter.Format(entry)
+}
+
+// moduleHook fires the hook for the entries which are enabled for their module
+type moduleHook struct {
+	levels *ModuleLevels
+	hook   logrus.Hook
+}
+
+// Levels
+func (h *moduleHook) Levels() []logrus.Level {
+	return h.hook.Levels()
+}
+
+// Fire
+func (h *moduleHook) Fire(entry *logrus.Entry) error {
+	if !h.levels.Enabled(entry) {
+		return nil
+	}
+	return h.hook.Fire(entry)
+}
+
+// NewJSONFormatter returns a formatter which writes one JSON object per entry with the module of the entry in the
+// module field, e.g. for ingestion by Loki or ELK
+func NewJSONFormatter() logrus.Formatter {
+	return &jsonFormatter{JSONFormatter: logrus.JSONFormatter{TimestampFormat: time.RFC3339Nano}}
+}
+
+// jsonFormatter renames the module field of the entries before formatting them as JSON
+type jsonFormatter struct {
+	logrus.JSONFormatter
+}
+
+// Format
+func (f *jsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	module, ok := entry.Data[moduleKey]
+	if !ok {
+		return f.JSONFormatter.Format(entry)
+	}
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		data[key] = value
+	}
+	delete(data, moduleKey)
+	data["module"] = module
+	renamed := *entry
+	renamed.Data = data
+	return f.JSONFormatter.Format(&renamed)
+}
@@ -0,0 +1,73 @@
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/sirupsen/logrus"
+)
+
+type countingHook struct {
+	fired int
+}
+
+func (h *countingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *countingHook) Fire(*logrus.Entry) error {
+	h.fired++
+	return nil
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	levels, err := ParseModuleLevels(logrus.InfoLevel, "consensus=debug, vanguardchain=warn,db=trace")
+	require.NoError(t, err)
+	assert.Equal(t, logrus.InfoLevel, levels.Default)
+	assert.DeepEqual(t, map[string]logrus.Level{
+		"consensus":      logrus.DebugLevel,
+		"vanguard-chain": logrus.WarnLevel,
+		"kv":             logrus.TraceLevel,
+	}, levels.Modules)
+
+	levels, err = ParseModuleLevels(logrus.InfoLevel, "error,rpc=debug")
+	require.NoError(t, err)
+	assert.Equal(t, logrus.ErrorLevel, levels.Default)
+	assert.Equal(t, logrus.DebugLevel, levels.Modules["rpc"])
+
+	_, err = ParseModuleLevels(logrus.InfoLevel, "consensus=loud")
+	assert.ErrorContains(t, "invalid log level", err)
+	_, err = ParseModuleLevels(logrus.InfoLevel, "=debug")
+	assert.ErrorContains(t, "missing module", err)
+}
+
+func TestConfigureModuleLevels(t *testing.T) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(NewJSONFormatter())
+	hook := &countingHook{}
+	logger.AddHook(hook)
+
+	levels, err := ParseModuleLevels(logrus.InfoLevel, "consensus=debug,pandorachain=error")
+	require.NoError(t, err)
+	ConfigureModuleLevels(logger, levels)
+	assert.Equal(t, logrus.DebugLevel, logger.GetLevel())
+
+	logger.WithField("prefix", "consensus").Debug("shown")
+	logger.WithField("prefix", "panchain").Warn("dropped")
+	logger.WithField("prefix", "rpc").Debug("dropped")
+	logger.WithField("prefix", "rpc").Info("shown")
+	assert.Equal(t, 2, hook.fired)
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Equal(t, 2, len(lines))
+	entry := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(lines[0], &entry))
+	assert.Equal(t, "consensus", entry["module"])
+	assert.Equal(t, "shown", entry["msg"])
+	assert.Equal(t, nil, entry["prefix"])
+}
@@ -0,0 +1,12 @@
+package params
+
+// SlotsPerEpoch is the number of slots in one vanguard epoch.
+const SlotsPerEpoch = 32
+
+// SecondsPerSlot is the duration of one vanguard slot in seconds.
+const SecondsPerSlot = 6
+
+// MinWeakSubjectivityEpochs is the lower bound of the weak subjectivity period, MIN_VALIDATOR_WITHDRAWABILITY_DELAY of
+// the beacon chain. The period of a network grows with its validator count and balances, so epoch infos within it
+// are kept by configuration and this is only the minimum which is accepted.
+const MinWeakSubjectivityEpochs = 256
@@ -0,0 +1,74 @@
+package params
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// DefaultNetwork is the network preset which is used without --network.
+const DefaultNetwork = "local"
+
+// NetworkConfig defines the preset of the network which the orchestrator runs on.
+type NetworkConfig struct {
+	// Name selects the preset with --network
+	Name string
+	// PandoraChainID is the chain id which the pandora nodes of the network report. When it is nil, the chain id of
+	// the first pandora node is pinned.
+	PandoraChainID *big.Int
+	// GenesisShardInfo is the slot info of the genesis slot. It seeds an empty verified db, so that the first
+	// pandora header must build on the genesis header. When it is nil, the first pandora header is accepted
+	// without a verified parent.
+	GenesisShardInfo *types.SlotInfo
+	// VanguardGRPCEndpoint and PandoraRPCEndpoint are used when the endpoint flags are not given
+	VanguardGRPCEndpoint string
+	PandoraRPCEndpoint   string
+}
+
+// networkRegistry keeps the presets of the known networks by name. A public network is added here with the
+// published values of its genesis, so that every node of the network is configured the same way.
+var networkRegistry = map[string]*NetworkConfig{
+	DefaultNetwork: {
+		Name:                 DefaultNetwork,
+		VanguardGRPCEndpoint: "127.0.0.1:4000",
+		PandoraRPCEndpoint:   "http://127.0.0.1:8545",
+	},
+}
+
+var activeNetworkConfig = networkRegistry[DefaultNetwork]
+
+// OrchestratorNetworkConfig returns the network preset of the orchestrator node.
+func OrchestratorNetworkConfig() *NetworkConfig {
+	return activeNetworkConfig
+}
+
+// UseNetwork makes the preset of the named network the one of the orchestrator node.
+func UseNetwork(name string) error {
+	config, ok := networkRegistry[name]
+	if !ok {
+		return errors.Errorf("unknown network %q, known networks are %v", name, NetworkNames())
+	}
+	activeNetworkConfig = config
+	return nil
+}
+
+// Networks returns the presets of every known network ordered by name.
+func Networks() []*NetworkConfig {
+	configs := make([]*NetworkConfig, 0, len(networkRegistry))
+	for _, config := range networkRegistry {
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+	return configs
+}
+
+// NetworkNames returns the names of every known network in order.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networkRegistry))
+	for _, config := range Networks() {
+		names = append(names, config.Name)
+	}
+	return names
+}
@@ -0,0 +1,23 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+)
+
+func TestUseNetwork(t *testing.T) {
+	defer func() {
+		require.NoError(t, UseNetwork(DefaultNetwork))
+	}()
+
+	require.ErrorContains(t, "unknown network \"unknown\"", UseNetwork("unknown"))
+	assert.Equal(t, DefaultNetwork, OrchestratorNetworkConfig().Name)
+
+	networkRegistry["test"] = &NetworkConfig{Name: "test"}
+	defer delete(networkRegistry, "test")
+	require.NoError(t, UseNetwork("test"))
+	assert.Equal(t, "test", OrchestratorNetworkConfig().Name)
+	assert.DeepEqual(t, []string{DefaultNetwork, "test"}, NetworkNames())
+}
@@ -0,0 +1,3 @@
+package orchestrator
+
+//go:generate protoc --proto_path=../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative orchestrator/v1/orchestrator.proto
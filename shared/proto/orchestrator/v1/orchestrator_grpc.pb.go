@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: orchestrator/v1/orchestrator.proto
+
+package orchestrator
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OrchestratorClient is the client API for Orchestrator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrchestratorClient interface {
+	// StreamVerifiedShardInfo sends the stored verified shard infos from from_slot and then follows the verified
+	// chain. Retracted slots are sent again with RETRACTED status when a reorg replaces them.
+	StreamVerifiedShardInfo(ctx context.Context, in *StreamVerifiedShardInfoRequest, opts ...grpc.CallOption) (Orchestrator_StreamVerifiedShardInfoClient, error)
+	// GetLatestFinalized returns the latest slot and epoch which vanguard has finalized.
+	GetLatestFinalized(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LatestFinalized, error)
+	// GetSlotStatus returns the verification status of a pandora header hash or vanguard block hash of the slot.
+	GetSlotStatus(ctx context.Context, in *SlotStatusRequest, opts ...grpc.CallOption) (*SlotStatus, error)
+}
+
+type orchestratorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrchestratorClient(cc grpc.ClientConnInterface) OrchestratorClient {
+	return &orchestratorClient{cc}
+}
+
+func (c *orchestratorClient) StreamVerifiedShardInfo(ctx context.Context, in *StreamVerifiedShardInfoRequest, opts ...grpc.CallOption) (Orchestrator_StreamVerifiedShardInfoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Orchestrator_ServiceDesc.Streams[0], "/orchestrator.v1.Orchestrator/StreamVerifiedShardInfo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &orchestratorStreamVerifiedShardInfoClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Orchestrator_StreamVerifiedShardInfoClient interface {
+	Recv() (*VerifiedShardInfo, error)
+	grpc.ClientStream
+}
+
+type orchestratorStreamVerifiedShardInfoClient struct {
+	grpc.ClientStream
+}
+
+func (x *orchestratorStreamVerifiedShardInfoClient) Recv() (*VerifiedShardInfo, error) {
+	m := new(VerifiedShardInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *orchestratorClient) GetLatestFinalized(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LatestFinalized, error) {
+	out := new(LatestFinalized)
+	err := c.cc.Invoke(ctx, "/orchestrator.v1.Orchestrator/GetLatestFinalized", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orchestratorClient) GetSlotStatus(ctx context.Context, in *SlotStatusRequest, opts ...grpc.CallOption) (*SlotStatus, error) {
+	out := new(SlotStatus)
+	err := c.cc.Invoke(ctx, "/orchestrator.v1.Orchestrator/GetSlotStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrchestratorServer is the server API for Orchestrator service.
+// All implementations must embed UnimplementedOrchestratorServer
+// for forward compatibility
+type OrchestratorServer interface {
+	// StreamVerifiedShardInfo sends the stored verified shard infos from from_slot and then follows the verified
+	// chain. Retracted slots are sent again with RETRACTED status when a reorg replaces them.
+	StreamVerifiedShardInfo(*StreamVerifiedShardInfoRequest, Orchestrator_StreamVerifiedShardInfoServer) error
+	// GetLatestFinalized returns the latest slot and epoch which vanguard has finalized.
+	GetLatestFinalized(context.Context, *emptypb.Empty) (*LatestFinalized, error)
+	// GetSlotStatus returns the verification status of a pandora header hash or vanguard block hash of the slot.
+	GetSlotStatus(context.Context, *SlotStatusRequest) (*SlotStatus, error)
+	mustEmbedUnimplementedOrchestratorServer()
+}
+
+// UnimplementedOrchestratorServer must be embedded to have forward compatible implementations.
+type UnimplementedOrchestratorServer struct {
+}
+
+func (UnimplementedOrchestratorServer) StreamVerifiedShardInfo(*StreamVerifiedShardInfoRequest, Orchestrator_StreamVerifiedShardInfoServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVerifiedShardInfo not implemented")
+}
+func (UnimplementedOrchestratorServer) GetLatestFinalized(context.Context, *emptypb.Empty) (*LatestFinalized, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLatestFinalized not implemented")
+}
+func (UnimplementedOrchestratorServer) GetSlotStatus(context.Context, *SlotStatusRequest) (*SlotStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlotStatus not implemented")
+}
+func (UnimplementedOrchestratorServer) mustEmbedUnimplementedOrchestratorServer() {}
+
+// UnsafeOrchestratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrchestratorServer will
+// result in compilation errors.
+type UnsafeOrchestratorServer interface {
+	mustEmbedUnimplementedOrchestratorServer()
+}
+
+func RegisterOrchestratorServer(s grpc.ServiceRegistrar, srv OrchestratorServer) {
+	s.RegisterService(&Orchestrator_ServiceDesc, srv)
+}
+
+func _Orchestrator_StreamVerifiedShardInfo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamVerifiedShardInfoRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrchestratorServer).StreamVerifiedShardInfo(m, &orchestratorStreamVerifiedShardInfoServer{stream})
+}
+
+type Orchestrator_StreamVerifiedShardInfoServer interface {
+	Send(*VerifiedShardInfo) error
+	grpc.ServerStream
+}
+
+type orchestratorStreamVerifiedShardInfoServer struct {
+	grpc.ServerStream
+}
+
+func (x *orchestratorStreamVerifiedShardInfoServer) Send(m *VerifiedShardInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Orchestrator_GetLatestFinalized_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).GetLatestFinalized(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orchestrator.v1.Orchestrator/GetLatestFinalized",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).GetLatestFinalized(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Orchestrator_GetSlotStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlotStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServer).GetSlotStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orchestrator.v1.Orchestrator/GetSlotStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServer).GetSlotStatus(ctx, req.(*SlotStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Orchestrator_ServiceDesc is the grpc.ServiceDesc for Orchestrator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Orchestrator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "orchestrator.v1.Orchestrator",
+	HandlerType: (*OrchestratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLatestFinalized",
+			Handler:    _Orchestrator_GetLatestFinalized_Handler,
+		},
+		{
+			MethodName: "GetSlotStatus",
+			Handler:    _Orchestrator_GetSlotStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamVerifiedShardInfo",
+			Handler:       _Orchestrator_StreamVerifiedShardInfo_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "orchestrator/v1/orchestrator.proto",
+}
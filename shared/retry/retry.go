@@ -0,0 +1,103 @@
+// Package retry runs an operation again with exponential backoff and jitter until it succeeds, the attempts
+// are exhausted or the context is cancelled.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Policy defines how often and how fast an operation is retried
+type Policy struct {
+	// MaxAttempts is the number of attempts including the first one. 0 retries until the context is cancelled
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between retries. 0 leaves the delay uncapped
+	MaxDelay time.Duration
+	// Multiplier grows the delay after every retry. Values below 1 keep the delay constant
+	Multiplier float64
+	// Jitter randomizes the delay by up to the given fraction of it, so that peers do not retry in lockstep
+	Jitter float64
+}
+
+// permanentError stops retrying
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error which must not be retried. Do returns the wrapped error.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// Delay returns the delay before the given retry. The first retry is 1.
+func (p Policy) Delay(retry int) time.Duration {
+	delay := float64(p.InitialDelay)
+	for i := 1; i < retry && p.Multiplier > 1; i++ {
+		delay *= p.Multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	return Jitter(time.Duration(delay), p.Jitter)
+}
+
+// Do calls fn until it returns nil. fn gets the attempt number starting from 1. The last error of fn is
+// returned when the attempts are exhausted or fn returns a Permanent error, the context error is returned
+// when the context is cancelled while waiting for the next attempt.
+func Do(ctx context.Context, p Policy, fn func(attempt int) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+			return err
+		}
+		if waitErr := Sleep(ctx, p.Delay(attempt)); waitErr != nil {
+			return errors.Wrap(waitErr, err.Error())
+		}
+	}
+}
+
+// Sleep waits for the given duration. It returns context error if the context is cancelled meanwhile.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Jitter randomizes d by up to +/- the given fraction of it
+func Jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	spread := float64(d) * fraction
+	return d + time.Duration(spread*(2*rand.Float64()-1))
+}
@@ -0,0 +1,75 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/pkg/errors"
+)
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	assert.Equal(t, 100*time.Millisecond, p.Delay(1))
+	assert.Equal(t, 200*time.Millisecond, p.Delay(2))
+	assert.Equal(t, 800*time.Millisecond, p.Delay(4))
+	assert.Equal(t, time.Second, p.Delay(5))
+	assert.Equal(t, time.Second, p.Delay(1000))
+
+	// constant delay without multiplier
+	p = Policy{InitialDelay: 100 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, p.Delay(10))
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := Jitter(time.Second, 0.2)
+		assert.Equal(t, true, d >= 800*time.Millisecond && d <= 1200*time.Millisecond)
+	}
+	assert.Equal(t, time.Second, Jitter(time.Second, 0))
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+	p := Policy{MaxAttempts: 3, InitialDelay: time.Millisecond}
+
+	attempts := 0
+	require.NoError(t, Do(ctx, p, func(attempt int) error {
+		attempts = attempt
+		if attempt < 2 {
+			return errors.New("busy")
+		}
+		return nil
+	}))
+	assert.Equal(t, 2, attempts)
+
+	// attempts are exhausted
+	attempts = 0
+	err := Do(ctx, p, func(attempt int) error {
+		attempts = attempt
+		return errors.New("busy")
+	})
+	assert.ErrorContains(t, "busy", err)
+	assert.Equal(t, 3, attempts)
+
+	// permanent error is not retried
+	attempts = 0
+	err = Do(ctx, p, func(attempt int) error {
+		attempts = attempt
+		return Permanent(errors.New("wrong network"))
+	})
+	assert.ErrorContains(t, "wrong network", err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDo_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := Do(ctx, Policy{InitialDelay: time.Hour}, func(attempt int) error {
+		cancel()
+		return errors.New("busy")
+	})
+	assert.Equal(t, true, errors.Is(err, context.Canceled))
+	assert.ErrorContains(t, "busy", err)
+}
@@ -4,12 +4,16 @@ package shared
 import (
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 var log = logrus.WithField("prefix", "registry")
 
+// defaultServiceTimeout is how long starting or stopping a service is waited before moving to the next one
+const defaultServiceTimeout = 10 * time.Second
+
 // Service is a struct that can be registered into a ServiceRegistry for
 // easy dependency management.
 type Service interface {
@@ -26,35 +30,63 @@ type Service interface {
 // It allows for ease of dependency management and ensures services
 // dependent on others use the same references in memory.
 type ServiceRegistry struct {
-	services     map[reflect.Type]Service // map of types to services.
-	serviceTypes []reflect.Type           // keep an ordered slice of registered service types.
+	services     map[reflect.Type]Service        // map of types to services.
+	serviceTypes []reflect.Type                  // keep an ordered slice of registered service types.
+	dependencies map[reflect.Type][]reflect.Type // declared dependencies of each service type.
+	timeouts     map[reflect.Type]time.Duration  // start and stop timeouts which override the default one.
 }
 
 // NewServiceRegistry starts a registry instance for convenience
 func NewServiceRegistry() *ServiceRegistry {
 	return &ServiceRegistry{
-		services: make(map[reflect.Type]Service),
+		services:     make(map[reflect.Type]Service),
+		dependencies: make(map[reflect.Type][]reflect.Type),
+		timeouts:     make(map[reflect.Type]time.Duration),
 	}
 }
 
-// StartAll initialized each service in order of registration.
+// StartAll starts each service in order of registration. Since dependencies must be registered
+// before their dependents, every service is started only after the services it depends on.
 func (s *ServiceRegistry) StartAll() {
 	log.Debugf("Starting %d services: %v", len(s.serviceTypes), s.serviceTypes)
 	for _, kind := range s.serviceTypes {
-		log.Debugf("Starting service type %v", kind)
-		go s.services[kind].Start()
+		log.WithField("dependencies", s.dependencies[kind]).Debugf("Starting service type %v", kind)
+		service := s.services[kind]
+		s.runWithTimeout(kind, "start", func() error {
+			service.Start()
+			return nil
+		})
 	}
 }
 
-// StopAll ends every service in reverse order of registration, logging a
-// panic if any of them fail to stop.
+// StopAll ends every service in reverse order of registration, so every service is stopped
+// before the services it depends on. A service which does not stop in time is logged and skipped.
 func (s *ServiceRegistry) StopAll() {
 	for i := len(s.serviceTypes) - 1; i >= 0; i-- {
 		kind := s.serviceTypes[i]
-		service := s.services[kind]
-		if err := service.Stop(); err != nil {
-			log.WithError(err).Errorf("Could not stop the following service: %v", kind)
+		s.runWithTimeout(kind, "stop", s.services[kind].Stop)
+	}
+}
+
+// runWithTimeout runs start or stop action of a service and waits for it until the timeout of service type
+func (s *ServiceRegistry) runWithTimeout(kind reflect.Type, action string, fn func() error) {
+	timeout, ok := s.timeouts[kind]
+	if !ok {
+		timeout = defaultServiceTimeout
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log.WithError(err).Errorf("Could not %s the following service: %v", action, kind)
 		}
+	case <-time.After(timeout):
+		log.WithField("timeout", timeout).Errorf("Timed out to %s the following service: %v", action, kind)
 	}
 }
 
@@ -69,17 +101,42 @@ func (s *ServiceRegistry) Statuses() map[reflect.Type]error {
 }
 
 // RegisterService appends a service constructor function to the service
-// registry.
-func (s *ServiceRegistry) RegisterService(service Service) error {
+// registry. Dependencies must already be registered, they are started before
+// and stopped after the service.
+func (s *ServiceRegistry) RegisterService(service Service, dependencies ...Service) error {
 	kind := reflect.TypeOf(service)
 	if _, exists := s.services[kind]; exists {
 		return fmt.Errorf("service already exists: %v", kind)
 	}
+	dependencyTypes := make([]reflect.Type, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		dependencyKind := reflect.TypeOf(dependency)
+		if registered, ok := s.services[dependencyKind]; !ok || registered != dependency {
+			return fmt.Errorf("dependency %v of service %v is not registered", dependencyKind, kind)
+		}
+		dependencyTypes = append(dependencyTypes, dependencyKind)
+	}
 	s.services[kind] = service
 	s.serviceTypes = append(s.serviceTypes, kind)
+	s.dependencies[kind] = dependencyTypes
 	return nil
 }
 
+// SetTimeout overrides how long starting and stopping of a registered service is waited.
+func (s *ServiceRegistry) SetTimeout(service Service, timeout time.Duration) error {
+	kind := reflect.TypeOf(service)
+	if _, exists := s.services[kind]; !exists {
+		return fmt.Errorf("unknown service: %v", kind)
+	}
+	s.timeouts[kind] = timeout
+	return nil
+}
+
+// Dependencies returns the declared dependencies of a registered service type.
+func (s *ServiceRegistry) Dependencies(kind reflect.Type) []reflect.Type {
+	return s.dependencies[kind]
+}
+
 // FetchService takes in a struct pointer and sets the value of that pointer
 // to a service currently stored in the service registry. This ensures the input argument is
 // set to the right pointer that refers to the originally registered service.
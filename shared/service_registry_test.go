@@ -1 +1,80 @@
 package shared
+
+import (
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type mockService struct {
+	name   string
+	events *[]string
+	block  chan struct{}
+}
+
+func (m *mockService) Start() {
+	*m.events = append(*m.events, "start "+m.name)
+}
+
+func (m *mockService) Stop() error {
+	if m.block != nil {
+		<-m.block
+	}
+	*m.events = append(*m.events, "stop "+m.name)
+	return nil
+}
+
+func (m *mockService) Status() error {
+	return nil
+}
+
+type dbService struct{ mockService }
+type consensusService struct{ mockService }
+type rpcService struct{ mockService }
+
+func TestServiceRegistry_StartStopInDependencyOrder(t *testing.T) {
+	var events []string
+	registry := NewServiceRegistry()
+	db := &dbService{mockService{name: "db", events: &events}}
+	consensus := &consensusService{mockService{name: "consensus", events: &events}}
+	rpc := &rpcService{mockService{name: "rpc", events: &events}}
+
+	require.NoError(t, registry.RegisterService(db))
+	require.NoError(t, registry.RegisterService(consensus, db))
+	require.NoError(t, registry.RegisterService(rpc, consensus))
+	assert.DeepEqual(t, []reflect.Type{reflect.TypeOf(consensus)}, registry.Dependencies(reflect.TypeOf(rpc)))
+
+	registry.StartAll()
+	registry.StopAll()
+	assert.DeepEqual(t, []string{
+		"start db", "start consensus", "start rpc",
+		"stop rpc", "stop consensus", "stop db",
+	}, events)
+}
+
+func TestServiceRegistry_UnregisteredDependency(t *testing.T) {
+	var events []string
+	registry := NewServiceRegistry()
+	db := &dbService{mockService{name: "db", events: &events}}
+	consensus := &consensusService{mockService{name: "consensus", events: &events}}
+
+	err := registry.RegisterService(consensus, db)
+	assert.ErrorContains(t, "is not registered", err)
+	assert.ErrorContains(t, "unknown service", registry.SetTimeout(consensus, time.Second))
+}
+
+func TestServiceRegistry_StopTimeout(t *testing.T) {
+	var events []string
+	registry := NewServiceRegistry()
+	db := &dbService{mockService{name: "db", events: &events}}
+	consensus := &consensusService{mockService{name: "consensus", events: &events, block: make(chan struct{})}}
+	require.NoError(t, registry.RegisterService(db))
+	require.NoError(t, registry.RegisterService(consensus, db))
+	require.NoError(t, registry.SetTimeout(consensus, 10*time.Millisecond))
+
+	// consensus never stops, db is stopped after its timeout
+	registry.StopAll()
+	assert.DeepEqual(t, []string{"stop db"}, events)
+}
@@ -0,0 +1,147 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/cache"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/consensus"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/db"
+	testDB "github.com/lukso-network/lukso-orchestrator/orchestrator/db/testing"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/pandorachain"
+	"github.com/lukso-network/lukso-orchestrator/orchestrator/vanguardchain"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+)
+
+// startOrchestrator runs pandora chain, vanguard chain and consensus services on a fresh db against the mock nodes,
+// the way the orchestrator node wires them. Services are stopped when the test finishes.
+func startOrchestrator(
+	ctx context.Context,
+	t *testing.T,
+	pandora *MockPandora,
+	vanguard *MockVanguard,
+) (*consensus.Service, db.Database) {
+
+	orcDB := testDB.SetupDB(t)
+	vanShardInfoCache := cache.NewVanShardInfoCache(1024)
+	panHeaderCache := cache.NewPanHeaderCache()
+
+	pandoraSvc, err := pandorachain.NewService(ctx, "ws://127.0.0.1:8546", PandoraNamespace, orcDB, panHeaderCache, pandora.Dial)
+	require.NoError(t, err)
+	vanguardSvc, err := vanguardchain.NewService(ctx, vanguard.Endpoint(), orcDB, vanShardInfoCache)
+	require.NoError(t, err)
+	consensusSvc := consensus.New(ctx, &consensus.Config{
+		VerifiedSlotInfoDB:           orcDB,
+		InvalidSlotInfoDB:            orcDB,
+		VanguardPendingShardingCache: vanShardInfoCache,
+		PandoraPendingHeaderCache:    panHeaderCache,
+		VanguardShardFeed:            vanguardSvc,
+		PandoraHeaderFeed:            pandoraSvc,
+	})
+
+	pandoraSvc.Start()
+	vanguardSvc.Start()
+	t.Cleanup(func() {
+		assert.NoError(t, consensusSvc.Stop())
+		assert.NoError(t, vanguardSvc.Stop())
+		assert.NoError(t, pandoraSvc.Stop())
+	})
+	return consensusSvc, orcDB
+}
+
+// waitForSlots receives verified slot events until every given slot has the status. Events of other statuses are
+// skipped, so finalized slots do not interfere.
+func waitForSlots(
+	ctx context.Context,
+	t *testing.T,
+	slotInfoCh <-chan *types.SlotInfoWithStatus,
+	status types.Status,
+	slots ...uint64,
+) map[uint64]*types.SlotInfoWithStatus {
+
+	expected := make(map[uint64]bool, len(slots))
+	for _, slot := range slots {
+		expected[slot] = true
+	}
+	received := make(map[uint64]*types.SlotInfoWithStatus, len(slots))
+	for len(received) < len(expected) {
+		select {
+		case slotInfo := <-slotInfoCh:
+			if slotInfo.Status != status {
+				continue
+			}
+			if !expected[slotInfo.Slot] {
+				t.Fatalf("unexpected %s slot %d", status, slotInfo.Slot)
+			}
+			received[slotInfo.Slot] = slotInfo
+		case <-ctx.Done():
+			t.Fatalf("%s slots are not published, got %d of %d", status, len(received), len(expected))
+		}
+	}
+	return received
+}
+
+func TestOrchestrator_VerifyAndReorg(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pandora := StartPandora(t)
+	vanguard := StartVanguard(t)
+
+	headers := []*eth1Types.Header{pandora.Genesis()}
+	blocks := []*ethpb.BeaconBlock{nil}
+	for slot := uint64(1); slot <= 4; slot++ {
+		header := NewHeader(headers[slot-1])
+		block := NewBlock(slot, header)
+		pandora.AddHeader(header)
+		vanguard.AddBlock(block)
+		headers = append(headers, header)
+		blocks = append(blocks, block)
+		// blocks after slot 2 carry slot 2 as finalized, so the reorg reverts the chain to it
+		if slot == 2 {
+			vanguard.Finalize(2, 1)
+		}
+	}
+
+	consensusSvc, orcDB := startOrchestrator(ctx, t, pandora, vanguard)
+	slotInfoCh := make(chan *types.SlotInfoWithStatus, 64)
+	sub := consensusSvc.SubscribeVerifiedSlotInfoEvent(slotInfoCh)
+	defer sub.Unsubscribe()
+	consensusSvc.Start()
+
+	verified := waitForSlots(ctx, t, slotInfoCh, types.Verified, 1, 2, 3, 4)
+	for slot := uint64(1); slot <= 4; slot++ {
+		root, err := blocks[slot].HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, headers[slot].Hash(), verified[slot].PandoraHeaderHash)
+		assert.Equal(t, common.Hash(root), verified[slot].VanguardBlockHash)
+	}
+	assert.Equal(t, uint64(4), orcDB.LatestSavedVerifiedSlot())
+	assert.Equal(t, uint64(2), orcDB.LatestLatestFinalizedSlot())
+
+	// vanguard reorgs the chain on top of slot 2 and reports it along with the epoch info
+	parentRoot, err := blocks[2].HashTreeRoot()
+	require.NoError(t, err)
+	reorgInfo := NewEpochInfo(1)
+	reorgInfo.ReorgInfo = &ethpb.Reorg{
+		VanParentHash: parentRoot[:],
+		PanParentHash: headers[2].Hash().Bytes(),
+		NewSlot:       eth2Types.Slot(3),
+	}
+	vanguard.AddEpochInfo(reorgInfo)
+
+	retracted := waitForSlots(ctx, t, slotInfoCh, types.Retracted, 3, 4)
+	for slot, slotInfo := range retracted {
+		assert.Equal(t, headers[slot].Hash(), slotInfo.PandoraHeaderHash)
+	}
+	slotInfo, err := orcDB.VerifiedSlotInfo(2)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), slotInfo.PandoraHeaderHash)
+}
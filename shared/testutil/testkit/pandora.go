@@ -0,0 +1,220 @@
+// Package testkit runs in-process mocks of pandora and vanguard nodes, so the verification and reorg flows of
+// orchestrator can be tested deterministically without docker or real clients.
+package testkit
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+)
+
+// PandoraNamespace is the rpc namespace which the mock pandora node serves its api on
+const PandoraNamespace = "eth"
+
+// MockPandora is an in-process pandora node. Headers are added by the test and are streamed to the
+// newPendingBlockHeaders subscribers in the order they are added.
+type MockPandora struct {
+	server  *rpc.Server
+	chainID *big.Int
+
+	lock      sync.RWMutex
+	headers   map[common.Hash]*eth1Types.Header
+	canonical map[uint64]*eth1Types.Header
+	pending   []*eth1Types.Header
+
+	headerFeed event.Feed
+	subscribed chan struct{}
+	once       sync.Once
+}
+
+// StartPandora starts a mock pandora node with a genesis header. The node is stopped when the test finishes.
+func StartPandora(t *testing.T) *MockPandora {
+	p := &MockPandora{
+		server:     rpc.NewServer(),
+		chainID:    big.NewInt(1),
+		headers:    make(map[common.Hash]*eth1Types.Header),
+		canonical:  make(map[uint64]*eth1Types.Header),
+		subscribed: make(chan struct{}),
+	}
+	genesis := testutil.NewEth1Header(0)
+	p.headers[genesis.Hash()] = genesis
+	p.canonical[0] = genesis
+
+	if err := p.server.RegisterName(PandoraNamespace, &pandoraAPI{node: p}); err != nil {
+		t.Fatalf("could not register mock pandora api: %v", err)
+	}
+	t.Cleanup(p.server.Stop)
+	return p
+}
+
+// Dial returns a client which is connected to the mock node in-process. The endpoint is ignored, so the function
+// can be used as the rpc dialer of pandora chain service.
+func (p *MockPandora) Dial(endpoint string) (*rpc.Client, error) {
+	client := rpc.DialInProc(p.server)
+	if client == nil {
+		return nil, errors.New("failed to create in-process client")
+	}
+	return client, nil
+}
+
+// Genesis returns the genesis header of the mock node
+func (p *MockPandora) Genesis() *eth1Types.Header {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.canonical[0]
+}
+
+// Header returns the header with the given hash or nil when the node does not have it
+func (p *MockPandora) Header(hash common.Hash) *eth1Types.Header {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.headers[hash]
+}
+
+// WaitForSubscriber blocks until the first newPendingBlockHeaders subscription is made
+func (p *MockPandora) WaitForSubscriber(ctx context.Context) error {
+	select {
+	case <-p.subscribed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// AddHeader makes the header canonical at its number and streams it to the subscribers. Canonical headers above
+// the number are dropped, so adding a header of a lower number rewinds the chain like a reorg does.
+func (p *MockPandora) AddHeader(header *eth1Types.Header) {
+	number := header.Number.Uint64()
+
+	p.lock.Lock()
+	p.headers[header.Hash()] = header
+	for n := range p.canonical {
+		if n > number {
+			delete(p.canonical, n)
+		}
+	}
+	p.canonical[number] = header
+	p.pending = append(p.pending, header)
+	p.lock.Unlock()
+
+	p.headerFeed.Send(header)
+}
+
+// NewHeader creates the next header on top of the given parent. Slot of the header's extra data is the number.
+func NewHeader(parent *eth1Types.Header) *eth1Types.Header {
+	header := testutil.NewEth1Header(parent.Number.Uint64() + 1)
+	header.ParentHash = parent.Hash()
+	return header
+}
+
+// since returns the added headers after the header with the given hash. Every added header is returned when the
+// hash is not known.
+func (p *MockPandora) since(hash common.Hash) []*eth1Types.Header {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for i, header := range p.pending {
+		if header.Hash() == hash {
+			return append([]*eth1Types.Header{}, p.pending[i+1:]...)
+		}
+	}
+	return append([]*eth1Types.Header{}, p.pending...)
+}
+
+// pandoraAPI is the rpc api of the mock pandora node
+type pandoraAPI struct {
+	node *MockPandora
+}
+
+// ChainId returns the chain id of the mock node
+func (api *pandoraAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.node.chainID)
+}
+
+// BlockNumber returns the number of the canonical head
+func (api *pandoraAPI) BlockNumber() hexutil.Uint64 {
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	var head uint64
+	for number := range api.node.canonical {
+		if number > head {
+			head = number
+		}
+	}
+	return hexutil.Uint64(head)
+}
+
+// GetBlockByHash returns the header with the given hash or nil when it is not known
+func (api *pandoraAPI) GetBlockByHash(hash common.Hash, fullTx bool) *eth1Types.Header {
+	return api.node.Header(hash)
+}
+
+// GetBlockByNumber returns the canonical header of the given number or nil when it is not known
+func (api *pandoraAPI) GetBlockByNumber(number hexutil.Uint64, fullTx bool) *eth1Types.Header {
+	api.node.lock.RLock()
+	defer api.node.lock.RUnlock()
+
+	return api.node.canonical[uint64(number)]
+}
+
+// NewPendingBlockHeaders streams the headers which are added after the header of the filter, then the newly added
+// headers
+func (api *pandoraAPI) NewPendingBlockHeaders(
+	ctx context.Context,
+	filter types.PandoraPendingHeaderFilter,
+) (*rpc.Subscription, error) {
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	subscription := notifier.CreateSubscription()
+
+	// subscribe before the replay, so no header is lost in between
+	headerCh := make(chan *eth1Types.Header, 256)
+	headerSub := api.node.headerFeed.Subscribe(headerCh)
+	replay := api.node.since(filter.FromBlockHash)
+
+	go func() {
+		defer headerSub.Unsubscribe()
+
+		sent := make(map[*eth1Types.Header]bool, len(replay))
+		for _, header := range replay {
+			if err := notifier.Notify(subscription.ID, header); err != nil {
+				return
+			}
+			sent[header] = true
+		}
+		api.node.once.Do(func() { close(api.node.subscribed) })
+
+		for {
+			select {
+			case header := <-headerCh:
+				if sent[header] {
+					continue
+				}
+				if err := notifier.Notify(subscription.ID, header); err != nil {
+					return
+				}
+			case <-subscription.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return subscription, nil
+}
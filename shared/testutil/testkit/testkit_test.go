@@ -0,0 +1,126 @@
+package testkit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/require"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestMockPandora_NewPendingBlockHeaders(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pandora := StartPandora(t)
+	client, err := pandora.Dial("")
+	require.NoError(t, err)
+	defer client.Close()
+
+	first := NewHeader(pandora.Genesis())
+	pandora.AddHeader(first)
+
+	headerCh := make(chan *eth1Types.Header, 2)
+	filter := types.PandoraPendingHeaderFilter{FromBlockHash: pandora.Genesis().Hash()}
+	sub, err := client.Subscribe(ctx, PandoraNamespace, headerCh, "newPendingBlockHeaders", filter)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	require.NoError(t, pandora.WaitForSubscriber(ctx))
+
+	second := NewHeader(first)
+	pandora.AddHeader(second)
+
+	for _, expected := range []*eth1Types.Header{first, second} {
+		select {
+		case header := <-headerCh:
+			assert.Equal(t, expected.Hash(), header.Hash())
+		case <-ctx.Done():
+			t.Fatal("header was not streamed")
+		}
+	}
+
+	// adding a header of a lower number drops the canonical headers above it
+	fork := NewHeader(pandora.Genesis())
+	fork.Time++
+	pandora.AddHeader(fork)
+
+	var number hexutil.Uint64
+	require.NoError(t, client.CallContext(ctx, &number, PandoraNamespace+"_blockNumber"))
+	assert.Equal(t, hexutil.Uint64(1), number)
+	var canonical *eth1Types.Header
+	require.NoError(t, client.CallContext(ctx, &canonical, PandoraNamespace+"_getBlockByNumber", hexutil.Uint64(1), false))
+	assert.Equal(t, fork.Hash(), canonical.Hash())
+}
+
+func TestMockVanguard_StreamNewPendingBlocks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	vanguard := StartVanguard(t)
+	conn, err := grpc.DialContext(ctx, vanguard.Endpoint(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := ethpb.NewBeaconChainClient(conn)
+
+	header := NewHeader(testutil.NewEth1Header(0))
+	vanguard.AddBlock(NewBlock(1, header))
+	vanguard.Finalize(1, 0)
+
+	stream, err := client.StreamNewPendingBlocks(ctx, &ethpb.StreamPendingBlocksRequest{FromSlot: 1})
+	require.NoError(t, err)
+	blockInfo, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Slot(1), blockInfo.Block.Slot)
+	assert.DeepEqual(t, header.Hash().Bytes(), blockInfo.Block.Body.PandoraShard[0].Hash)
+
+	vanguard.AddBlock(NewBlock(2, NewHeader(header)))
+	blockInfo, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Slot(2), blockInfo.Block.Slot)
+	assert.Equal(t, eth2Types.Slot(1), blockInfo.FinalizedSlot)
+
+	head, err := client.GetChainHead(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Slot(2), head.HeadSlot)
+
+	blocks, err := client.ListBlocks(ctx, &ethpb.ListBlocksRequest{QueryFilter: &ethpb.ListBlocksRequest_Epoch{Epoch: 0}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(blocks.BlockContainers))
+
+	genesis, err := ethpb.NewNodeClient(conn).GetGenesis(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, make([]byte, 32), genesis.GenesisValidatorsRoot)
+}
+
+func TestMockVanguard_StreamMinimalConsensusInfo(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	vanguard := StartVanguard(t)
+	conn, err := grpc.DialContext(ctx, vanguard.Endpoint(), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	vanguard.AddEpochInfo(NewEpochInfo(0))
+	vanguard.AddEpochInfo(NewEpochInfo(1))
+
+	stream, err := ethpb.NewBeaconChainClient(conn).StreamMinimalConsensusInfo(ctx, &ethpb.MinimalConsensusInfoRequest{FromEpoch: 1})
+	require.NoError(t, err)
+	epochInfo, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Epoch(1), epochInfo.Epoch)
+
+	vanguard.AddEpochInfo(NewEpochInfo(2))
+	epochInfo, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, eth2Types.Epoch(2), epochInfo.Epoch)
+}
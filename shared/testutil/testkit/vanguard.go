@@ -0,0 +1,299 @@
+package testkit
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lukso-network/lukso-orchestrator/shared/params"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil"
+	eth2Types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MockVanguard is a vanguard node which serves the beacon chain and node gRPC apis on a local port. Blocks and epoch
+// infos are added by the test and are streamed to the subscribers in the order they are added.
+type MockVanguard struct {
+	server   *grpc.Server
+	listener net.Listener
+
+	lock                  sync.RWMutex
+	genesisValidatorsRoot []byte
+	blocks                []*ethpb.StreamPendingBlockInfo
+	canonical             map[eth2Types.Slot]*ethpb.BeaconBlock
+	epochInfos            []*ethpb.MinimalConsensusInfo
+	finalizedSlot         eth2Types.Slot
+	finalizedEpoch        eth2Types.Epoch
+
+	blockFeed     event.Feed
+	epochInfoFeed event.Feed
+}
+
+// StartVanguard starts a mock vanguard node on a free local port. The node is stopped when the test finishes.
+func StartVanguard(t *testing.T) *MockVanguard {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen for mock vanguard node: %v", err)
+	}
+	v := &MockVanguard{
+		server:                grpc.NewServer(),
+		listener:              listener,
+		genesisValidatorsRoot: make([]byte, 32),
+		canonical:             make(map[eth2Types.Slot]*ethpb.BeaconBlock),
+	}
+	ethpb.RegisterBeaconChainServer(v.server, &beaconChainServer{node: v})
+	ethpb.RegisterNodeServer(v.server, &nodeServer{node: v})
+
+	go func() {
+		if err := v.server.Serve(listener); err != nil {
+			t.Logf("mock vanguard node stopped: %v", err)
+		}
+	}()
+	t.Cleanup(v.server.Stop)
+	return v
+}
+
+// Endpoint returns the gRPC endpoint of the mock node
+func (v *MockVanguard) Endpoint() string {
+	return v.listener.Addr().String()
+}
+
+// SetGenesisValidatorsRoot changes the genesis validators root which the node reports, so it looks like a node of
+// another network
+func (v *MockVanguard) SetGenesisValidatorsRoot(root []byte) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.genesisValidatorsRoot = root
+}
+
+// Finalize sets the finalized checkpoint which is sent along with the blocks added after it
+func (v *MockVanguard) Finalize(slot uint64, epoch uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.finalizedSlot = eth2Types.Slot(slot)
+	v.finalizedEpoch = eth2Types.Epoch(epoch)
+}
+
+// AddBlock makes the block canonical at its slot and streams it to the subscribers. Canonical blocks above the slot
+// are dropped, so adding a block of a lower slot rewinds the chain like a reorg does.
+func (v *MockVanguard) AddBlock(block *ethpb.BeaconBlock) {
+	v.lock.Lock()
+	for slot := range v.canonical {
+		if slot > block.Slot {
+			delete(v.canonical, slot)
+		}
+	}
+	v.canonical[block.Slot] = block
+	blockInfo := &ethpb.StreamPendingBlockInfo{
+		Block:          block,
+		FinalizedSlot:  v.finalizedSlot,
+		FinalizedEpoch: v.finalizedEpoch,
+	}
+	v.blocks = append(v.blocks, blockInfo)
+	v.lock.Unlock()
+
+	v.blockFeed.Send(blockInfo)
+}
+
+// AddEpochInfo streams the epoch info to the subscribers. Reorg info of the epoch info is sent as it is.
+func (v *MockVanguard) AddEpochInfo(epochInfo *ethpb.MinimalConsensusInfo) {
+	v.lock.Lock()
+	v.epochInfos = append(v.epochInfos, epochInfo)
+	v.lock.Unlock()
+
+	v.epochInfoFeed.Send(epochInfo)
+}
+
+// NewBlock creates a vanguard block of the slot which links the given pandora header
+func NewBlock(slot uint64, header *eth1Types.Header) *ethpb.BeaconBlock {
+	block := testutil.NewBeaconBlock(slot)
+	block.Body.PandoraShard = []*ethpb.PandoraShard{testutil.NewPandoraShard(header)}
+	return block
+}
+
+// NewEpochInfo creates the epoch info of the epoch as vanguard node sends it
+func NewEpochInfo(epoch uint64) *ethpb.MinimalConsensusInfo {
+	epochInfo := testutil.NewMinimalConsensusInfo(epoch)
+	return &ethpb.MinimalConsensusInfo{
+		Epoch:            eth2Types.Epoch(epochInfo.Epoch),
+		ValidatorList:    epochInfo.ValidatorList,
+		EpochTimeStart:   epochInfo.EpochStartTime,
+		SlotTimeDuration: &durationpb.Duration{Seconds: int64(epochInfo.SlotTimeDuration)},
+	}
+}
+
+// head returns the canonical head block or nil when there is no block yet
+func (v *MockVanguard) head() *ethpb.BeaconBlock {
+	var head *ethpb.BeaconBlock
+	for _, block := range v.canonical {
+		if head == nil || block.Slot > head.Slot {
+			head = block
+		}
+	}
+	return head
+}
+
+// beaconChainServer is the beacon chain api of the mock vanguard node
+type beaconChainServer struct {
+	ethpb.UnimplementedBeaconChainServer
+	node *MockVanguard
+}
+
+// GetChainHead returns the slot of the canonical head and the finalized checkpoint
+func (s *beaconChainServer) GetChainHead(ctx context.Context, _ *emptypb.Empty) (*ethpb.ChainHead, error) {
+	s.node.lock.RLock()
+	defer s.node.lock.RUnlock()
+
+	chainHead := &ethpb.ChainHead{
+		HeadBlockRoot:      make([]byte, 32),
+		FinalizedSlot:      s.node.finalizedSlot,
+		FinalizedEpoch:     s.node.finalizedEpoch,
+		FinalizedBlockRoot: make([]byte, 32),
+	}
+	if head := s.node.head(); head != nil {
+		root, err := head.HashTreeRoot()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		chainHead.HeadSlot = head.Slot
+		chainHead.HeadEpoch = eth2Types.Epoch(uint64(head.Slot) / params.SlotsPerEpoch)
+		chainHead.HeadBlockRoot = root[:]
+	}
+	return chainHead, nil
+}
+
+// ListBlocks returns the canonical blocks of an epoch in a single page. Other filters are not supported.
+func (s *beaconChainServer) ListBlocks(ctx context.Context, req *ethpb.ListBlocksRequest) (*ethpb.ListBlocksResponse, error) {
+	filter, ok := req.QueryFilter.(*ethpb.ListBlocksRequest_Epoch)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "mock vanguard node only lists blocks by epoch")
+	}
+
+	s.node.lock.RLock()
+	defer s.node.lock.RUnlock()
+
+	containers := make([]*ethpb.BeaconBlockContainer, 0)
+	for slot, block := range s.node.canonical {
+		if uint64(slot)/params.SlotsPerEpoch != uint64(filter.Epoch) {
+			continue
+		}
+		root, err := block.HashTreeRoot()
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		containers = append(containers, &ethpb.BeaconBlockContainer{
+			Block:     &ethpb.SignedBeaconBlock{Block: block, Signature: make([]byte, 96)},
+			BlockRoot: root[:],
+			Canonical: true,
+		})
+	}
+	return &ethpb.ListBlocksResponse{BlockContainers: containers, TotalSize: int32(len(containers))}, nil
+}
+
+// StreamNewPendingBlocks streams the added blocks from the requested slot, then the newly added blocks
+func (s *beaconChainServer) StreamNewPendingBlocks(
+	req *ethpb.StreamPendingBlocksRequest,
+	stream ethpb.BeaconChain_StreamNewPendingBlocksServer,
+) error {
+
+	// subscribe before the replay, so no block is lost in between
+	blockCh := make(chan *ethpb.StreamPendingBlockInfo, 256)
+	sub := s.node.blockFeed.Subscribe(blockCh)
+	defer sub.Unsubscribe()
+
+	s.node.lock.RLock()
+	replay := append([]*ethpb.StreamPendingBlockInfo{}, s.node.blocks...)
+	s.node.lock.RUnlock()
+
+	sent := make(map[*ethpb.StreamPendingBlockInfo]bool, len(replay))
+	for _, blockInfo := range replay {
+		if blockInfo.Block.Slot < req.FromSlot {
+			continue
+		}
+		if err := stream.Send(blockInfo); err != nil {
+			return err
+		}
+		sent[blockInfo] = true
+	}
+	for {
+		select {
+		case blockInfo := <-blockCh:
+			if sent[blockInfo] {
+				continue
+			}
+			if err := stream.Send(blockInfo); err != nil {
+				return err
+			}
+		case <-sub.Err():
+			return nil
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream context canceled")
+		}
+	}
+}
+
+// StreamMinimalConsensusInfo streams the added epoch infos from the requested epoch, then the newly added epoch infos
+func (s *beaconChainServer) StreamMinimalConsensusInfo(
+	req *ethpb.MinimalConsensusInfoRequest,
+	stream ethpb.BeaconChain_StreamMinimalConsensusInfoServer,
+) error {
+
+	// subscribe before the replay, so no epoch info is lost in between
+	epochInfoCh := make(chan *ethpb.MinimalConsensusInfo, 256)
+	sub := s.node.epochInfoFeed.Subscribe(epochInfoCh)
+	defer sub.Unsubscribe()
+
+	s.node.lock.RLock()
+	replay := append([]*ethpb.MinimalConsensusInfo{}, s.node.epochInfos...)
+	s.node.lock.RUnlock()
+
+	sent := make(map[*ethpb.MinimalConsensusInfo]bool, len(replay))
+	for _, epochInfo := range replay {
+		if epochInfo.Epoch < req.FromEpoch {
+			continue
+		}
+		if err := stream.Send(epochInfo); err != nil {
+			return err
+		}
+		sent[epochInfo] = true
+	}
+	for {
+		select {
+		case epochInfo := <-epochInfoCh:
+			if sent[epochInfo] {
+				continue
+			}
+			if err := stream.Send(epochInfo); err != nil {
+				return err
+			}
+		case <-sub.Err():
+			return nil
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream context canceled")
+		}
+	}
+}
+
+// nodeServer is the node api of the mock vanguard node
+type nodeServer struct {
+	ethpb.UnimplementedNodeServer
+	node *MockVanguard
+}
+
+// GetGenesis returns the genesis validators root of the mock node
+func (s *nodeServer) GetGenesis(ctx context.Context, _ *emptypb.Empty) (*ethpb.Genesis, error) {
+	s.node.lock.RLock()
+	defer s.node.lock.RUnlock()
+
+	return &ethpb.Genesis{GenesisValidatorsRoot: s.node.genesisValidatorsRoot}, nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
 	eth2Types "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
@@ -31,12 +32,99 @@ type MinimalEpochConsensusInfo struct {
 	SlotTimeDuration time.Duration `json:"slotTimeDuration"`
 }
 
+// EpochInfoSource points to the vanguard block whose state the proposer list of an epoch is derived from
+type EpochInfoSource struct {
+	Slot      uint64      `json:"slot"`
+	BlockRoot common.Hash `json:"blockRoot"`
+	StateRoot common.Hash `json:"stateRoot"`
+	// Verified is true when vanguard node reported the block as the canonical block of the last filled slot before
+	// the epoch. It is false when the block is inferred from the order of the vanguard block and epoch info streams,
+	// unverified sources are only a hint.
+	Verified bool `json:"verified"`
+}
+
+// EpochInfoWithSource
+type EpochInfoWithSource struct {
+	*MinimalEpochConsensusInfo
+	Source *EpochInfoSource `json:"source"`
+}
+
+// SlotProposer is the validator which is expected to seal the shard header of the slot
+type SlotProposer struct {
+	Slot      uint64 `json:"slot"`
+	Epoch     uint64 `json:"epoch"`
+	PublicKey string `json:"publicKey"`
+}
+
 type BlockStatus struct {
 	Hash          common.Hash `json:"hash"`
 	Status        Status      `json:"status"`
 	FinalizedSlot uint64      `json:"finalizedSlot"`
 }
 
+// BlockRetraction is sent on the confirmation stream when a reorg orphans a previously verified pandora block, so
+// that pandora can un-finalize or re-queue it. ReplacedBy is the pandora parent hash of the new chain.
+type BlockRetraction struct {
+	Hash       common.Hash  `json:"hash"`
+	Slot       uint64       `json:"slot"`
+	Status     Status       `json:"status"`
+	ReplacedBy *common.Hash `json:"replacedBy"`
+}
+
+// JournaledConfirmation is a confirmation or retraction which was published to pandora, as kept by the
+// confirmation journal. Time is the unix time when it was published.
+type JournaledConfirmation struct {
+	Slot          uint64       `json:"slot"`
+	Hash          common.Hash  `json:"hash"`
+	Status        Status       `json:"status"`
+	FinalizedSlot uint64       `json:"finalizedSlot"`
+	ReplacedBy    *common.Hash `json:"replacedBy,omitempty"`
+	Time          uint64       `json:"time"`
+}
+
+// SlotHeaderStatus is the slim confirmation tuple which is streamed to light clients
+type SlotHeaderStatus struct {
+	Slot              uint64      `json:"slot"`
+	PandoraHeaderHash common.Hash `json:"panHeaderHash"`
+	VanguardBlockRoot common.Hash `json:"vanBlockRoot"`
+	Status            Status      `json:"status"`
+	StepId            *uint64     `json:"stepId,omitempty"`
+	// ResumeToken is an opaque position which is passed to slotHeadersResume after reconnecting
+	ResumeToken string `json:"resumeToken,omitempty"`
+}
+
+// EpochInfoPage is a page of epoch infos. Next is the first epoch of the next page and it is nil on the last page.
+type EpochInfoPage struct {
+	EpochInfos []*MinimalEpochConsensusInfoV2 `json:"epochInfos"`
+	Next       *uint64                        `json:"next"`
+}
+
+// VerifiedSlotPage is a page of verified slots. Next is the first slot of the next page and it is nil on the last
+// page.
+type VerifiedSlotPage struct {
+	Slots []*SlotHeaderStatus `json:"slots"`
+	Next  *uint64             `json:"next"`
+}
+
+// ReorgCursor is the position of a reorg in the reorg history. More than one reorg may be triggered at a slot, so
+// reorgs of a slot are ordered by their id.
+type ReorgCursor struct {
+	Slot uint64 `json:"slot"`
+	Id   uint64 `json:"id"`
+}
+
+// ReorgHistoryPage is a page of reorgs. Next is the first reorg of the next page and it is nil on the last page.
+type ReorgHistoryPage struct {
+	Reorgs []*ReorgRecord `json:"reorgs"`
+	Next   *ReorgCursor   `json:"next"`
+}
+
+// SlotHeaderWithPayload is a verified slot with its full pandora block as returned by the execution node
+type SlotHeaderWithPayload struct {
+	*SlotHeaderStatus
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
 // PandoraPendingHeaderFilter
 type PandoraPendingHeaderFilter struct {
 	FromBlockHash common.Hash `json:"fromBlockHash"`
@@ -71,8 +159,13 @@ type BlsSignatureBytes [BLSSignatureSize]byte
 
 // SlotInfo
 type SlotInfoWithStatus struct {
+	Slot              uint64
 	VanguardBlockHash common.Hash
 	PandoraHeaderHash common.Hash
+	// StepId is the position of the slot in the verified chain. It is nil when slot is not accumulated
+	StepId *uint64
+	// ReplacedBy is the pandora parent hash of the new chain. It is only set when the slot is retracted
+	ReplacedBy *common.Hash
 	Status
 }
 
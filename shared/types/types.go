@@ -1,10 +1,15 @@
 package types
 
 import (
+	"encoding/binary"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth1Types "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	eth2Types "github.com/prysmaticlabs/prysm/proto/eth/v1alpha1"
 )
 
 type Status string
@@ -15,6 +20,10 @@ const (
 	Invalid  Status = "Invalid"
 	Skipped  Status = "Skipped"
 	Unknown  Status = "Unknown"
+	// Retracted is sent for a previously verified slot which is orphaned by a reorg
+	Retracted Status = "Retracted"
+	// Finalized is sent for a previously verified slot once it is at or below the latest finalized slot of vanguard
+	Finalized Status = "Finalized"
 )
 
 // ExtraData
@@ -30,6 +39,288 @@ type SlotInfo struct {
 	PandoraHeaderHash common.Hash
 }
 
+// Root returns the hash which commits to both vanguard block hash and pandora header hash
+func (info *SlotInfo) Root() common.Hash {
+	return crypto.Keccak256Hash(info.VanguardBlockHash.Bytes(), info.PandoraHeaderHash.Bytes())
+}
+
+// TrustedCheckpoint is a trusted finalized slot whose shard info root is known from another source. It seeds an
+// empty verified db instead of the whole history.
+type TrustedCheckpoint struct {
+	Slot      uint64
+	ShardRoot common.Hash
+}
+
+// AccumulatorStep is the state of verified-chain accumulator right after the slot got verified
+type AccumulatorStep struct {
+	Slot      uint64      `json:"slot"`
+	LeafIndex uint64      `json:"leafIndex"`
+	Leaf      common.Hash `json:"leaf"`
+	Root      common.Hash `json:"root"`
+}
+
+// BatchedSlot is a verified slot info with its pandora block number and accumulator step, which is nil when
+// accumulator is disabled
+type BatchedSlot struct {
+	Slot        uint64
+	BlockNumber uint64
+	SlotInfo    *SlotInfo
+	Step        *AccumulatorStep
+}
+
+// VerifiedSlotBatch is a range of verified slots in ascending order which is written in a single db transaction
+type VerifiedSlotBatch struct {
+	Slots          []*BatchedSlot
+	FinalizedSlot  uint64
+	FinalizedEpoch uint64
+}
+
+// EpochSummary is the outcome of the slots of an epoch which is persisted once the epoch is over
+type EpochSummary struct {
+	Epoch         uint64 `json:"epoch"`
+	VerifiedSlots uint64 `json:"verifiedSlots"`
+	InvalidSlots  uint64 `json:"invalidSlots"`
+	SkippedSlots  uint64 `json:"skippedSlots"`
+	Reorgs        uint64 `json:"reorgs"`
+	// AverageConfirmationLatency is the average time in milliseconds between pandora header time and verification
+	AverageConfirmationLatency uint64 `json:"averageConfirmationLatency"`
+}
+
+// HeaderVerification is the result of checking a pandora header against the vanguard shard info of its slot
+type HeaderVerification struct {
+	Hash   common.Hash `json:"hash"`
+	Slot   uint64      `json:"slot"`
+	Status Status      `json:"status"`
+	// Reason explains why the header is invalid or still pending
+	Reason string `json:"reason,omitempty"`
+}
+
+// LifetimeStats counts slots, reorgs and uptime over all runs of the orchestrator
+type LifetimeStats struct {
+	VerifiedSlots uint64 `json:"verifiedSlots"`
+	InvalidSlots  uint64 `json:"invalidSlots"`
+	Reorgs        uint64 `json:"reorgs"`
+	// Uptime is the total running time in seconds
+	Uptime uint64 `json:"uptime"`
+}
+
+// AccumulatorProof proves that the verified slot info is included in the accumulator with the given root
+type AccumulatorProof struct {
+	AccumulatorStep
+	AccumulatorRoot common.Hash   `json:"accumulatorRoot"`
+	AccumulatorSize uint64        `json:"accumulatorSize"`
+	Proof           []common.Hash `json:"proof"`
+}
+
+// SigningData returns the data which is signed by orchestrator identity key when the proof is exported.
+// Proof hashes are not signed since they are derived from the signed root.
+func (proof *AccumulatorProof) SigningData() []byte {
+	data := make([]byte, 8*3+common.HashLength*2)
+	binary.BigEndian.PutUint64(data[0:], proof.Slot)
+	binary.BigEndian.PutUint64(data[8:], proof.LeafIndex)
+	binary.BigEndian.PutUint64(data[16:], proof.AccumulatorSize)
+	copy(data[24:], proof.Leaf.Bytes())
+	copy(data[24+common.HashLength:], proof.AccumulatorRoot.Bytes())
+	return data
+}
+
+// SignedAccumulatorProof is accumulator inclusion proof authenticated by the orchestrator identity
+type SignedAccumulatorProof struct {
+	*AccumulatorProof
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// ShardEquivocationSide is one of the conflicting shard infos of a proposer
+type ShardEquivocationSide struct {
+	// Source is the vanguard endpoint which delivered the block
+	Source    string           `json:"source"`
+	BlockRoot common.Hash      `json:"blockRoot"`
+	Shard     *ShardInfoFields `json:"shard"`
+}
+
+// ShardEquivocation is the evidence of a proposer which signed different shard infos for the same slot
+type ShardEquivocation struct {
+	Slot          uint64                 `json:"slot"`
+	ProposerIndex uint64                 `json:"proposerIndex"`
+	First         *ShardEquivocationSide `json:"first"`
+	Second        *ShardEquivocationSide `json:"second"`
+}
+
+// ShardConflict is the evidence of a verified slot which received a different pandora header without a vanguard
+// reorg. The verified slot info is kept and the conflicting one is refused, since the linked validator equivocated.
+type ShardConflict struct {
+	Slot        uint64    `json:"slot"`
+	Verified    *SlotInfo `json:"verified"`
+	Conflicting *SlotInfo `json:"conflicting"`
+	// Shard is the conflicting shard info of the vanguard block
+	Shard      *ShardInfoFields `json:"shard"`
+	DetectedAt int64            `json:"detectedAt"`
+}
+
+// Checkpoint is the finalized verified slot which is published for bootstrapping new orchestrators
+type Checkpoint struct {
+	Slot              uint64      `json:"slot"`
+	Epoch             uint64      `json:"epoch"`
+	VanguardBlockHash common.Hash `json:"vanguardBlockHash"`
+	PandoraHeaderHash common.Hash `json:"pandoraHeaderHash"`
+	// ShardInfoRoot commits to both vanguard block hash and pandora header hash
+	ShardInfoRoot common.Hash `json:"shardInfoRoot"`
+}
+
+// SigningData returns the data which is signed by orchestrator identity key when the checkpoint is published
+func (checkpoint *Checkpoint) SigningData() []byte {
+	data := make([]byte, 8*2+common.HashLength)
+	binary.BigEndian.PutUint64(data[0:], checkpoint.Slot)
+	binary.BigEndian.PutUint64(data[8:], checkpoint.Epoch)
+	copy(data[16:], checkpoint.ShardInfoRoot.Bytes())
+	return data
+}
+
+// SignedCheckpoint is checkpoint authenticated by the orchestrator identity
+type SignedCheckpoint struct {
+	*Checkpoint
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// PandoraChainIdentity
+type PandoraChainIdentity struct {
+	ChainID     *big.Int    `json:"chainId"`
+	GenesisHash common.Hash `json:"genesisHash"`
+}
+
+// ShardInfoFields are the fields compared between pandora header and vanguard shard info
+type ShardInfoFields struct {
+	BlockNumber uint64        `json:"blockNumber"`
+	Hash        common.Hash   `json:"hash"`
+	ParentHash  common.Hash   `json:"parentHash"`
+	StateRoot   common.Hash   `json:"stateRoot"`
+	TxHash      common.Hash   `json:"txHash"`
+	ReceiptHash common.Hash   `json:"receiptHash"`
+	Signature   hexutil.Bytes `json:"signature"`
+}
+
+// NewShardInfoFields copies the compared fields of vanguard shard info
+func NewShardInfoFields(vs *eth2Types.PandoraShard) *ShardInfoFields {
+	return &ShardInfoFields{
+		BlockNumber: vs.BlockNumber,
+		Hash:        common.BytesToHash(vs.GetHash()),
+		ParentHash:  common.BytesToHash(vs.GetParentHash()),
+		StateRoot:   common.BytesToHash(vs.GetStateRoot()),
+		TxHash:      common.BytesToHash(vs.GetTxHash()),
+		ReceiptHash: common.BytesToHash(vs.GetReceiptHash()),
+		Signature:   common.CopyBytes(vs.GetSignature()),
+	}
+}
+
+// ShardDisagreement keeps both sides of a slot whose pandora header does not match with vanguard shard info
+type ShardDisagreement struct {
+	Slot uint64 `json:"slot"`
+	// Field is the first mismatched field
+	Field    string           `json:"field"`
+	Pandora  *ShardInfoFields `json:"pandora"`
+	Vanguard *ShardInfoFields `json:"vanguard"`
+	// PandoraExtraData is the raw extra data of pandora header, useful to debug encoding mismatches
+	PandoraExtraData hexutil.Bytes `json:"pandoraExtraData"`
+}
+
+// ReorgRecord is a reorg which orchestrator detected and the verified chain it reverted
+type ReorgRecord struct {
+	Id uint64 `json:"id"`
+	// Slot is the new vanguard slot which triggered the reorg
+	Slot          uint64        `json:"slot"`
+	VanParentHash hexutil.Bytes `json:"vanParentHash"`
+	PanParentHash hexutil.Bytes `json:"panParentHash"`
+	// OldHeadSlot and OldHeadHash are the verified head before the reorg
+	OldHeadSlot uint64      `json:"oldHeadSlot"`
+	OldHeadHash common.Hash `json:"oldHeadHash"`
+	// RevertSlot is the finalized slot which the verified chain is reverted to
+	RevertSlot uint64 `json:"revertSlot"`
+	// NewParentStepId is the accumulator step of the revert slot, nil when the slot is not accumulated
+	NewParentStepId *uint64 `json:"newParentStepId,omitempty"`
+	Depth           uint64  `json:"depth"`
+	DetectedAt      int64   `json:"detectedAt"`
+	// ResolvedAt is zero when the verified chain could not be reverted
+	ResolvedAt int64 `json:"resolvedAt"`
+}
+
+// DeferredTask is work which is kept in the persistent task queue and retried until it succeeds
+type DeferredTask struct {
+	Id   uint64 `json:"id"`
+	Kind string `json:"kind"`
+	// FromSlot and ToSlot are the slot range which the task covers
+	FromSlot uint64 `json:"fromSlot"`
+	ToSlot   uint64 `json:"toSlot"`
+	Attempts uint64 `json:"attempts"`
+	// NotBefore is the unix time before which the task is not run
+	NotBefore int64  `json:"notBefore"`
+	LastError string `json:"lastError,omitempty"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
+// HistoricalSlot is the verified entry which orchestrator considered current at a past point. ReplacedBy is the
+// reorg which later reverted the entry, nil when the entry is still in the verified chain.
+type HistoricalSlot struct {
+	Slot              uint64       `json:"slot"`
+	PandoraHeaderHash common.Hash  `json:"panHeaderHash"`
+	VanguardBlockRoot common.Hash  `json:"vanBlockRoot"`
+	StepId            *uint64      `json:"stepId,omitempty"`
+	ReplacedBy        *ReorgRecord `json:"replacedBy,omitempty"`
+}
+
+// EndpointScore is the latest probe result of a configured chain endpoint. Lower score is better.
+type EndpointScore struct {
+	Chain    string `json:"chain"`
+	Endpoint string `json:"endpoint"`
+	// Selected is true for the endpoint which the chain service is currently using
+	Selected bool `json:"selected"`
+	Healthy  bool `json:"healthy"`
+	// LatencyMs is the moving average of probe round trips
+	LatencyMs int64 `json:"latencyMs"`
+	// Head is the latest block number or slot reported by the endpoint, Lag is its distance to the best head
+	Head     uint64    `json:"head"`
+	Lag      uint64    `json:"lag"`
+	Score    int64     `json:"score"`
+	Error    string    `json:"error,omitempty"`
+	ProbedAt time.Time `json:"probedAt"`
+}
+
+// DiskPressure is raised when the disk of the db is forecast to be full soon or its free space is below the floor
+type DiskPressure struct {
+	DBSize    int64  `json:"dbSize"`
+	FreeSpace uint64 `json:"freeSpace"`
+	// GrowthPerHour is the db growth in bytes per hour over the recent window
+	GrowthPerHour int64 `json:"growthPerHour"`
+	// TimeToFull is the forecast time until the disk is full. Zero when db does not grow.
+	TimeToFull time.Duration `json:"timeToFull"`
+	BelowFloor bool          `json:"belowFloor"`
+}
+
+// StartupReport describes the setup of a started node in one artifact
+type StartupReport struct {
+	Version string `json:"version"`
+	// ConfigDigest is the sha256 of every flag value of the node, secrets excluded
+	ConfigDigest    common.Hash         `json:"configDigest"`
+	Network         *StartupNetwork     `json:"network"`
+	DataDir         string              `json:"dataDir"`
+	SchemaVersion   uint64              `json:"schemaVersion"`
+	Endpoints       map[string][]string `json:"endpoints"`
+	Features        []string            `json:"features"`
+	ListenAddresses map[string]string   `json:"listenAddresses"`
+	StartedAt       time.Time           `json:"startedAt"`
+}
+
+// StartupNetwork is the network which the node follows. Chain identities are nil until they are pinned on the
+// first connection with the nodes.
+type StartupNetwork struct {
+	Name                          string                `json:"name"`
+	PandoraChainIdentity          *PandoraChainIdentity `json:"pandoraChainIdentity,omitempty"`
+	VanguardGenesisValidatorsRoot hexutil.Bytes         `json:"vanguardGenesisValidatorsRoot,omitempty"`
+	GenesisShardInfo              *SlotInfo             `json:"genesisShardInfo,omitempty"`
+	Checkpoint                    *TrustedCheckpoint    `json:"checkpoint,omitempty"`
+}
+
 // CopyHeader creates a deep copy of a block header to prevent side effects from
 // modifying a header variable.
 func CopyHeader(h *eth1Types.Header) *eth1Types.Header {
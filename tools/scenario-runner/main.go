@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout    = 30 * time.Minute
+	defaultTxInterval = time.Second
+	pollInterval      = 6 * time.Second
+	// maxRange is the slot range which an orchestrator serves in one call
+	maxRange = 4096
+)
+
+var (
+	scenarioFile = flag.String(
+		"scenario-file",
+		"",
+		"Path to scenario.json which lists the client combinations, the workload and the compared slot window",
+	)
+	reportFile = flag.String(
+		"report-file",
+		"",
+		"Path of the divergence report. Report is printed to stdout when it is empty",
+	)
+)
+
+// run executes the workload and compares what orchestrators of the combinations verified in the scenario window
+func run(ctx context.Context, scenario *Scenario) (*Report, error) {
+	timeout, err := scenario.timeout()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := runWorkload(ctx, scenario.Workload, scenario.Combinations); err != nil {
+		return nil, err
+	}
+
+	views := make([]map[uint64]*slotView, len(scenario.Combinations))
+	errs := make([]error, len(scenario.Combinations))
+	var wg sync.WaitGroup
+	for i, combination := range scenario.Combinations {
+		wg.Add(1)
+		go func(i int, combination *Combination) {
+			defer wg.Done()
+			views[i], errs[i] = collectSlots(ctx, combination, scenario.FromSlot, scenario.toSlot())
+		}(i, combination)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{Scenario: scenario.Name, FromSlot: scenario.FromSlot, ToSlot: scenario.toSlot()}
+	byName := make(map[string]map[uint64]*slotView, len(views))
+	for i, combination := range scenario.Combinations {
+		report.Combinations = append(report.Combinations, combination.Name)
+		byName[combination.Name] = views[i]
+	}
+	report.ComparedSlots = int(scenario.Slots)
+	report.Divergences = compare(scenario.FromSlot, scenario.toSlot(), byName)
+	return report, nil
+}
+
+func main() {
+	flag.Parse()
+	if *scenarioFile == "" {
+		log.Fatalf("Missed the scenario.json file path!")
+	}
+	scenario, err := loadScenario(*scenarioFile)
+	if err != nil {
+		log.WithError(err).Fatalf("Failed to read scenario")
+	}
+
+	log.WithField("scenario", scenario.Name).WithField("combinations", len(scenario.Combinations)).
+		WithField("fromSlot", scenario.FromSlot).WithField("toSlot", scenario.toSlot()).Info("Running scenario")
+	report, err := run(context.Background(), scenario)
+	if err != nil {
+		log.WithError(err).Fatalf("Failed to run scenario")
+	}
+
+	enc, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.WithError(err).Fatalf("Failed to encode report")
+	}
+	if *reportFile == "" {
+		os.Stdout.Write(append(enc, '\n'))
+	} else if err := ioutil.WriteFile(*reportFile, enc, 0644); err != nil {
+		log.WithError(err).Fatalf("Failed to write report")
+	}
+
+	if len(report.Divergences) > 0 {
+		log.WithField("divergences", len(report.Divergences)).Error("Client combinations verified the scenario window differently")
+		os.Exit(1)
+	}
+	log.WithField("comparedSlots", report.ComparedSlots).Info("Client combinations verified the scenario window the same way")
+}
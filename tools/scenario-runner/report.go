@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+// slotView is what one combination's orchestrator verified for a slot
+type slotView struct {
+	PandoraHeaderHash common.Hash  `json:"panHeaderHash"`
+	VanguardBlockRoot common.Hash  `json:"vanBlockRoot"`
+	Status            types.Status `json:"status"`
+}
+
+// Divergence is a slot which was not verified the same way by every combination. Combinations which did not
+// verify the slot are listed in Missing.
+type Divergence struct {
+	Slot    uint64               `json:"slot"`
+	Views   map[string]*slotView `json:"views"`
+	Missing []string             `json:"missing,omitempty"`
+}
+
+// Report is the result of a scenario run
+type Report struct {
+	Scenario      string        `json:"scenario"`
+	FromSlot      uint64        `json:"fromSlot"`
+	ToSlot        uint64        `json:"toSlot"`
+	Combinations  []string      `json:"combinations"`
+	ComparedSlots int           `json:"comparedSlots"`
+	Divergences   []*Divergence `json:"divergences"`
+}
+
+// collectSlots polls the orchestrator of the combination until it verified the last slot of the window
+// and returns its verified slots.
+func collectSlots(ctx context.Context, combination *Combination, fromSlot, toSlot uint64) (map[uint64]*slotView, error) {
+	client, err := rpc.DialContext(ctx, combination.OrchestratorEndpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not dial orchestrator of combination %s", combination.Name)
+	}
+	defer client.Close()
+
+	views := make(map[uint64]*slotView)
+	next := fromSlot
+	for next <= toSlot {
+		var slots []*types.SlotHeaderStatus
+		if err := client.CallContext(ctx, &slots, "orc_getVerifiedSlotRange", next, toSlot); err != nil {
+			return nil, errors.Wrapf(err, "could not get verified slots of combination %s", combination.Name)
+		}
+		for _, slot := range slots {
+			views[slot.Slot] = &slotView{
+				PandoraHeaderHash: slot.PandoraHeaderHash,
+				VanguardBlockRoot: slot.VanguardBlockRoot,
+				Status:            slot.Status,
+			}
+		}
+		if len(slots) > 0 && slots[len(slots)-1].Slot >= toSlot {
+			return views, nil
+		}
+		// range is capped by the orchestrator, continue after the last returned slot
+		if len(slots) > 0 && slots[len(slots)-1].Slot-next+1 >= maxRange {
+			next = slots[len(slots)-1].Slot + 1
+			continue
+		}
+
+		log.WithField("combination", combination.Name).WithField("verifiedSlots", len(views)).
+			WithField("toSlot", toSlot).Debug("Waiting for orchestrator to verify the scenario window")
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "combination %s did not verify slot %d in time", combination.Name, toSlot)
+		case <-time.After(pollInterval):
+		}
+	}
+	return views, nil
+}
+
+// compare returns the slots of [fromSlot, toSlot] which combinations verified differently, ordered by slot
+func compare(fromSlot, toSlot uint64, views map[string]map[uint64]*slotView) []*Divergence {
+	names := make([]string, 0, len(views))
+	for name := range views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	divergences := make([]*Divergence, 0)
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		divergence := &Divergence{Slot: slot, Views: make(map[string]*slotView)}
+		var reference *slotView
+		diverged := false
+		for _, name := range names {
+			view := views[name][slot]
+			if view == nil {
+				divergence.Missing = append(divergence.Missing, name)
+				continue
+			}
+			divergence.Views[name] = view
+			if reference == nil {
+				reference = view
+			} else if *reference != *view {
+				diverged = true
+			}
+		}
+		// slot which no combination verified is a gap of the chain, not a divergence
+		if diverged || (reference != nil && len(divergence.Missing) > 0) {
+			divergences = append(divergences, divergence)
+		}
+	}
+	return divergences
+}
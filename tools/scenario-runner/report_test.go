@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lukso-network/lukso-orchestrator/shared/testutil/assert"
+	"github.com/lukso-network/lukso-orchestrator/shared/types"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	view := func(hash string) *slotView {
+		return &slotView{
+			PandoraHeaderHash: common.HexToHash(hash),
+			VanguardBlockRoot: common.HexToHash(hash),
+			Status:            types.Verified,
+		}
+	}
+	views := map[string]map[uint64]*slotView{
+		"catalyst+prysm":  {1: view("0x1"), 2: view("0x2"), 3: view("0x3")},
+		"catalyst+teku":   {1: view("0x1"), 2: view("0x22"), 3: view("0x3")},
+		"geth+lighthouse": {1: view("0x1"), 2: view("0x2")},
+	}
+
+	divergences := compare(1, 4, views)
+	assert.Equal(t, 2, len(divergences))
+	assert.Equal(t, uint64(2), divergences[0].Slot)
+	assert.Equal(t, 0, len(divergences[0].Missing))
+	assert.DeepEqual(t, view("0x22"), divergences[0].Views["catalyst+teku"])
+	assert.Equal(t, uint64(3), divergences[1].Slot)
+	assert.DeepEqual(t, []string{"geth+lighthouse"}, divergences[1].Missing)
+}
+
+func TestScenario_Validate(t *testing.T) {
+	scenario := &Scenario{
+		Combinations: []*Combination{
+			{ExecutionClient: "catalyst", ConsensusClient: "prysm", OrchestratorEndpoint: "ws://127.0.0.1:7877"},
+		},
+		Slots: 64,
+	}
+	assert.ErrorContains(t, "at least two client combinations", scenario.validate())
+
+	scenario.Combinations = append(scenario.Combinations,
+		&Combination{ExecutionClient: "catalyst", ConsensusClient: "prysm", OrchestratorEndpoint: "ws://127.0.0.1:7878"})
+	assert.ErrorContains(t, "duplicate combination catalyst+prysm", scenario.validate())
+
+	scenario.Combinations[1].ConsensusClient = "teku"
+	assert.NoError(t, scenario.validate())
+	assert.Equal(t, uint64(63), scenario.toSlot())
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"time"
+)
+
+// Combination is one execution and consensus client pair of the devnet with the orchestrator which verifies it
+type Combination struct {
+	Name                 string `json:"name"`
+	ExecutionClient      string `json:"executionClient"`
+	ConsensusClient      string `json:"consensusClient"`
+	OrchestratorEndpoint string `json:"orchestratorEndpoint"`
+	PandoraEndpoint      string `json:"pandoraEndpoint"`
+}
+
+// Workload is the scripted traffic which is sent to the pandora endpoints of all combinations in turn
+type Workload struct {
+	Transactions     int    `json:"transactions"`
+	TxInterval       string `json:"txInterval"`
+	KeystoreFile     string `json:"keystoreFile"`
+	KeystorePassword string `json:"keystorePassword"`
+	ChainID          int64  `json:"chainId"`
+}
+
+// Scenario describes the combinations which are compared and the slot window of the comparison
+type Scenario struct {
+	Name         string         `json:"name"`
+	Combinations []*Combination `json:"combinations"`
+	Workload     *Workload      `json:"workload,omitempty"`
+	// FromSlot is the first compared slot, Slots is the size of the compared window
+	FromSlot uint64 `json:"fromSlot"`
+	Slots    uint64 `json:"slots"`
+	// Timeout bounds the wait until every orchestrator has verified the window
+	Timeout string `json:"timeout"`
+}
+
+// loadScenario
+func loadScenario(path string) (*Scenario, error) {
+	expanded, err := fileutil.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := ioutil.ReadFile(expanded)
+	if err != nil {
+		return nil, err
+	}
+	scenario := new(Scenario)
+	if err := json.Unmarshal(enc, scenario); err != nil {
+		return nil, errors.Wrap(err, "invalid scenario file")
+	}
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// validate
+func (s *Scenario) validate() error {
+	if len(s.Combinations) < 2 {
+		return errors.New("scenario needs at least two client combinations to compare")
+	}
+	names := make(map[string]bool, len(s.Combinations))
+	for _, c := range s.Combinations {
+		if c.Name == "" {
+			c.Name = c.ExecutionClient + "+" + c.ConsensusClient
+		}
+		if names[c.Name] {
+			return errors.Errorf("duplicate combination %s", c.Name)
+		}
+		names[c.Name] = true
+		if c.OrchestratorEndpoint == "" {
+			return errors.Errorf("missing orchestrator endpoint of combination %s", c.Name)
+		}
+		if s.Workload != nil && s.Workload.Transactions > 0 && c.PandoraEndpoint == "" {
+			return errors.Errorf("missing pandora endpoint of combination %s", c.Name)
+		}
+	}
+	if s.Slots == 0 {
+		return errors.New("scenario compares no slots")
+	}
+	if _, err := s.timeout(); err != nil {
+		return err
+	}
+	if s.Workload != nil {
+		if _, err := s.Workload.interval(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toSlot returns the last compared slot
+func (s *Scenario) toSlot() uint64 {
+	return s.FromSlot + s.Slots - 1
+}
+
+// timeout
+func (s *Scenario) timeout() (time.Duration, error) {
+	if s.Timeout == "" {
+		return defaultTimeout, nil
+	}
+	return time.ParseDuration(s.Timeout)
+}
+
+// interval
+func (w *Workload) interval() (time.Duration, error) {
+	if w.TxInterval == "" {
+		return defaultTxInterval, nil
+	}
+	return time.ParseDuration(w.TxInterval)
+}
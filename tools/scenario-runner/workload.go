@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/lukso-network/lukso-orchestrator/shared/fileutil"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"math/big"
+	"time"
+)
+
+const transferGasLimit = 21000
+
+// runWorkload sends self transfers to the pandora endpoints of the combinations in turn, so every execution
+// client of the scenario produces blocks with transactions which it received itself.
+func runWorkload(ctx context.Context, workload *Workload, combinations []*Combination) error {
+	if workload == nil || workload.Transactions == 0 {
+		return nil
+	}
+	interval, err := workload.interval()
+	if err != nil {
+		return err
+	}
+	keystorePath, err := fileutil.ExpandPath(workload.KeystoreFile)
+	if err != nil {
+		return err
+	}
+	keystoreBytes, err := ioutil.ReadFile(keystorePath)
+	if err != nil {
+		return err
+	}
+	txOps, err := bind.NewTransactorWithChainID(bytes.NewReader(keystoreBytes), workload.KeystorePassword, big.NewInt(workload.ChainID))
+	if err != nil {
+		return err
+	}
+
+	clients := make([]*ethclient.Client, len(combinations))
+	for i, c := range combinations {
+		if clients[i], err = ethclient.DialContext(ctx, c.PandoraEndpoint); err != nil {
+			return errors.Wrapf(err, "could not dial pandora of combination %s", c.Name)
+		}
+		defer clients[i].Close()
+	}
+
+	nonce, err := clients[0].PendingNonceAt(ctx, txOps.From)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < workload.Transactions; i++ {
+		client := clients[i%len(clients)]
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		tx, err := txOps.Signer(txOps.From, types.NewTransaction(nonce, txOps.From, big.NewInt(0), transferGasLimit, gasPrice, nil))
+		if err != nil {
+			return err
+		}
+		if err := client.SendTransaction(ctx, tx); err != nil {
+			return errors.Wrapf(err, "could not send transaction to combination %s", combinations[i%len(clients)].Name)
+		}
+		log.WithField("txHash", tx.Hash()).WithField("nonce", nonce).
+			WithField("combination", combinations[i%len(clients)].Name).Info("Sent workload transaction")
+		nonce++
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return nil
+}